@@ -0,0 +1,122 @@
+package raiden
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BulkInsertMaxParams is maximum bind parameter of single insert statement,
+// postgres doesn't accept statement with more than 65535 parameter
+var BulkInsertMaxParams = 65535
+
+// BulkInsertStatement is multi row insert statement with positional parameter ($1, $2, ...)
+type BulkInsertStatement struct {
+	Query string
+	Args  []any
+	Rows  int
+}
+
+// BuildBulkInsert build multi row insert statement of total rows, rows is chunked so every
+// statement stay under BulkInsertMaxParams. Returning column (e.g primary key) is returned by
+// statement, so statement is executed as query to get inserted key (e.g db.Query(s.Query, s.Args...))
+func BuildBulkInsert(schema, table string, columns []string, returning []string, total int, valuesFn func(i int) []any) ([]BulkInsertStatement, error) {
+	return buildBulkInsert(schema, table, columns, "", returning, total, valuesFn)
+}
+
+// BuildUpsert build multi row insert statement that update existing row when inserted row conflict
+// on conflict column (primary key or unique constraint), update column is set from inserted row
+// and conflicting row is skipped when update is empty. Postgres can't update the same row twice
+// in single statement, so rows must not contain duplicate conflict value
+func BuildUpsert(schema, table string, columns, conflict, update, returning []string, total int, valuesFn func(i int) []any) ([]BulkInsertStatement, error) {
+	if len(conflict) == 0 {
+		return nil, fmt.Errorf("upsert to %s.%s doesn't have conflict column", schema, table)
+	}
+
+	onConflict := fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", quoteIdentifiers(conflict))
+	if len(update) > 0 {
+		set := make([]string, 0, len(update))
+		for _, c := range update {
+			set = append(set, fmt.Sprintf("%s = EXCLUDED.%s", quoteIdentifier(c), quoteIdentifier(c)))
+		}
+		onConflict = fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", quoteIdentifiers(conflict), strings.Join(set, ", "))
+	}
+	return buildBulkInsert(schema, table, columns, onConflict, returning, total, valuesFn)
+}
+
+// CheckUpsertKey return error when conflict column of any row is nil, conflict column that defaulted
+// by database (e.g uuid primary key) is inserted as null when it is not set and the row never conflict
+func CheckUpsertKey(schema, table, column string, total int, valueFn func(i int) any) error {
+	for i := 0; i < total; i++ {
+		value := valueFn(i)
+		if rv := reflect.ValueOf(value); value == nil || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+			return fmt.Errorf("upsert to %s.%s row %d doesn't have value of conflict column %s", schema, table, i, column)
+		}
+	}
+	return nil
+}
+
+func buildBulkInsert(schema, table string, columns []string, onConflict string, returning []string, total int, valuesFn func(i int) []any) ([]BulkInsertStatement, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("bulk insert to %s.%s doesn't have column", schema, table)
+	}
+
+	batchSize := BulkInsertMaxParams / len(columns)
+	if batchSize == 0 {
+		return nil, fmt.Errorf("bulk insert to %s.%s have more column than maximum parameter", schema, table)
+	}
+
+	insertInto := fmt.Sprintf("INSERT INTO %s.%s (%s) VALUES ", quoteIdentifier(schema), quoteIdentifier(table), quoteIdentifiers(columns))
+
+	var returningClause string
+	if len(returning) > 0 {
+		returningClause = " RETURNING " + quoteIdentifiers(returning)
+	}
+
+	statements := make([]BulkInsertStatement, 0, (total+batchSize-1)/batchSize)
+	for start := 0; start < total; start += batchSize {
+		end := min(start+batchSize, total)
+
+		var query strings.Builder
+		query.WriteString(insertInto)
+
+		args := make([]any, 0, (end-start)*len(columns))
+		for i := start; i < end; i++ {
+			values := valuesFn(i)
+			if len(values) != len(columns) {
+				return nil, fmt.Errorf("bulk insert to %s.%s row %d have %d value, expected %d", schema, table, i, len(values), len(columns))
+			}
+
+			if i > start {
+				query.WriteString(", ")
+			}
+
+			query.WriteString("(")
+			for j := range values {
+				if j > 0 {
+					query.WriteString(", ")
+				}
+				fmt.Fprintf(&query, "$%d", len(args)+j+1)
+			}
+			query.WriteString(")")
+			args = append(args, values...)
+		}
+		query.WriteString(onConflict)
+		query.WriteString(returningClause)
+
+		statements = append(statements, BulkInsertStatement{Query: query.String(), Args: args, Rows: end - start})
+	}
+	return statements, nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, n := range names {
+		quoted = append(quoted, quoteIdentifier(n))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,86 @@
+package raiden_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBulkInsert(t *testing.T) {
+	maxParams := raiden.BulkInsertMaxParams
+	t.Cleanup(func() { raiden.BulkInsertMaxParams = maxParams })
+	raiden.BulkInsertMaxParams = 3000
+
+	type orderItem struct {
+		OrderId int64
+		Sku     string
+		Qty     int
+	}
+	rows := make([]orderItem, 2500)
+	for i := range rows {
+		rows[i] = orderItem{OrderId: int64(i / 10), Sku: fmt.Sprintf("sku-%d", i), Qty: i % 5}
+	}
+
+	statements, err := raiden.BuildBulkInsert("public", "order_items", []string{"order_id", "sku", "qty"}, []string{"id"}, len(rows), func(i int) []any {
+		return []any{rows[i].OrderId, rows[i].Sku, rows[i].Qty}
+	})
+	assert.NoError(t, err)
+
+	// 3 column is 1000 rows per statement
+	assert.Len(t, statements, 3)
+	var inserted int
+	for i, expectedRows := range []int{1000, 1000, 500} {
+		s := statements[i]
+		assert.Equal(t, expectedRows, s.Rows)
+		assert.Len(t, s.Args, expectedRows*3)
+		assert.LessOrEqual(t, len(s.Args), raiden.BulkInsertMaxParams)
+		assert.True(t, strings.HasPrefix(s.Query, `INSERT INTO "public"."order_items" ("order_id", "sku", "qty") VALUES ($1, $2, $3), ($4, $5, $6)`))
+		assert.True(t, strings.HasSuffix(s.Query, fmt.Sprintf("($%d, $%d, $%d) RETURNING \"id\"", expectedRows*3-2, expectedRows*3-1, expectedRows*3)))
+		assert.Equal(t, expectedRows-1, strings.Count(s.Query, "), ("))
+
+		// parameter of statement is value of next rows
+		assert.Equal(t, []any{rows[inserted].OrderId, rows[inserted].Sku, rows[inserted].Qty}, s.Args[:3])
+		inserted += s.Rows
+	}
+	assert.Equal(t, len(rows), inserted)
+
+	// statement without returning column
+	statements, err = raiden.BuildBulkInsert("public", "logs", []string{"message"}, nil, 1, func(i int) []any { return []any{"hello"} })
+	assert.NoError(t, err)
+	assert.Equal(t, []raiden.BulkInsertStatement{{Query: `INSERT INTO "public"."logs" ("message") VALUES ($1)`, Args: []any{"hello"}, Rows: 1}}, statements)
+
+	_, err = raiden.BuildBulkInsert("public", "logs", []string{"message", "level"}, nil, 1, func(i int) []any { return []any{"hello"} })
+	assert.EqualError(t, err, "bulk insert to public.logs row 0 have 1 value, expected 2")
+}
+
+func TestBuildUpsert(t *testing.T) {
+	values := func(i int) []any { return []any{int64(i + 1), fmt.Sprintf("sku-%d", i), i} }
+
+	statements, err := raiden.BuildUpsert("public", "products", []string{"id", "sku", "stock"}, []string{"sku"}, []string{"stock"}, []string{"id"}, 2, values)
+	assert.NoError(t, err)
+	assert.Len(t, statements, 1)
+	assert.Equal(t, `INSERT INTO "public"."products" ("id", "sku", "stock") VALUES ($1, $2, $3), ($4, $5, $6) ON CONFLICT ("sku") DO UPDATE SET "stock" = EXCLUDED."stock" RETURNING "id"`, statements[0].Query)
+	assert.Equal(t, []any{int64(1), "sku-0", 0, int64(2), "sku-1", 1}, statements[0].Args)
+
+	// row that conflict is skipped when there is no column to update
+	statements, err = raiden.BuildUpsert("public", "product_tags", []string{"product_id", "tag"}, []string{"product_id", "tag"}, nil, nil, 1, func(i int) []any { return []any{1, "new"} })
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "public"."product_tags" ("product_id", "tag") VALUES ($1, $2) ON CONFLICT ("product_id", "tag") DO NOTHING`, statements[0].Query)
+
+	_, err = raiden.BuildUpsert("public", "products", []string{"sku"}, nil, nil, nil, 1, func(i int) []any { return []any{"sku"} })
+	assert.EqualError(t, err, "upsert to public.products doesn't have conflict column")
+}
+
+func TestCheckUpsertKey(t *testing.T) {
+	id := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	keys := []*string{&id, nil}
+
+	err := raiden.CheckUpsertKey("public", "devices", "id", 1, func(i int) any { return keys[i] })
+	assert.NoError(t, err)
+
+	err = raiden.CheckUpsertKey("public", "devices", "id", 2, func(i int) any { return keys[i] })
+	assert.EqualError(t, err, "upsert to public.devices row 1 doesn't have value of conflict column id")
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/sev-2/raiden/pkg/cli"
 	"github.com/sev-2/raiden/pkg/cli/configure"
 	init_cmd "github.com/sev-2/raiden/pkg/cli/init"
+	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -42,7 +43,7 @@ func InitCommand() *cobra.Command {
 				return
 			}
 
-			if err = init_cmd.Run(&f.Init, currentDir, utils.ToGoModuleName(config.ProjectName)); err != nil {
+			if err = init_cmd.Run(&f.Init, currentDir, generator.NewProjectPackage(config).GetModulePath()); err != nil {
 				init_cmd.InitLogger.Error(err.Error())
 			}
 		},
@@ -9,6 +9,7 @@ import (
 	"github.com/sev-2/raiden/pkg/cli/generate"
 	"github.com/sev-2/raiden/pkg/cli/imports"
 	init_cmd "github.com/sev-2/raiden/pkg/cli/init"
+	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/logger"
 	"github.com/sev-2/raiden/pkg/utils"
 	"github.com/spf13/cobra"
@@ -68,7 +69,7 @@ func StartCommand() *cobra.Command {
 			}
 
 			// 3. running init
-			if executeErr := init_cmd.Run(&f.Init, projectPath, utils.ToGoModuleName(promptConfig.ProjectName)); executeErr != nil {
+			if executeErr := init_cmd.Run(&f.Init, projectPath, generator.NewProjectPackage(&promptConfig.Config).GetModulePath()); executeErr != nil {
 				StartLogger.Error(executeErr.Error())
 				return
 			}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/cli"
+	"github.com/sev-2/raiden/pkg/cli/configure"
+	"github.com/sev-2/raiden/pkg/cli/validate"
+	"github.com/sev-2/raiden/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type ValidateFlags struct {
+	cli.LogFlags
+	Validate validate.Flags
+}
+
+func ValidateCommand() *cobra.Command {
+	var f ValidateFlags
+
+	cmd := &cobra.Command{
+		Use:    "validate",
+		Short:  "Validate generated resource",
+		Long:   "Check generated file against local state and report drifted file without contacting supabase",
+		PreRun: PreRun(&f.LogFlags, validate.PreRun),
+		Run: func(cmd *cobra.Command, args []string) {
+			f.CheckAndActivateDebug(cmd)
+
+			// get current directory
+			currentDir, errCurDir := utils.GetCurrentDirectory()
+			if errCurDir != nil {
+				validate.ValidateLogger.Error(errCurDir.Error())
+				return
+			}
+
+			// load config
+			validate.ValidateLogger.Info("load configuration")
+			configFilePath := configure.GetConfigFilePath(currentDir)
+			validate.ValidateLogger.Debug("config file information", "path", configFilePath)
+			config, err := raiden.LoadConfig(&configFilePath)
+			if err != nil {
+				validate.ValidateLogger.Error(err.Error())
+				return
+			}
+
+			if err = validate.Run(&f.Validate, config, currentDir); err != nil {
+				validate.ValidateLogger.Error(err.Error())
+			}
+		},
+	}
+
+	f.Validate.Bind(cmd)
+	return cmd
+}
@@ -22,6 +22,7 @@ func main() {
 		commands.RunCommand(),
 		commands.ServeCommand(),
 		commands.StartCommand(),
+		commands.ValidateCommand(),
 		commands.VersionCommand(),
 	)
 
@@ -1,6 +1,7 @@
 package raiden
 
 import (
+	"context"
 	"path/filepath"
 
 	"github.com/ory/viper"
@@ -15,28 +16,105 @@ const (
 	DeploymentTargetSelfHosted DeploymentTarget = "self_hosted"
 )
 
+// JsonColumnType define go struct used as type of json / jsonb column when import,
+// column is written as schema.table.column (e.g public.orders.payload)
+type JsonColumnType struct {
+	Column string `mapstructure:"COLUMN"`
+	Type   string `mapstructure:"TYPE"`
+}
+
+// PivotRule is threshold to determine table as many to many pivot table on import,
+// foreign key threshold that not set is two (classic join table)
+type PivotRule struct {
+	MinForeignKey  int `mapstructure:"MIN_FOREIGN_KEY"`
+	MaxForeignKey  int `mapstructure:"MAX_FOREIGN_KEY"`
+	MaxExtraColumn int `mapstructure:"MAX_EXTRA_COLUMN"`
+}
+
+// ManyToManyEdge is inferred many to many relation from source table to target table through
+// pivot table, table is written as table or schema.table (e.g public.teacher)
+type ManyToManyEdge struct {
+	Through string `mapstructure:"THROUGH"`
+	Source  string `mapstructure:"SOURCE"`
+	Target  string `mapstructure:"TARGET"`
+}
+
+// ImportSource is supabase project that imported together with the other source into one
+// codebase, connection that not set is inherited from config. Schema of every source must
+// not overlap, table and function of the same schema can't be loaded from two source.
+// Import source is only used by import, apply refuse to run when it is set
+type ImportSource struct {
+	Name                string           `mapstructure:"NAME"`
+	DeploymentTarget    DeploymentTarget `mapstructure:"DEPLOYMENT_TARGET"`
+	ProjectId           string           `mapstructure:"PROJECT_ID"`
+	AccessToken         string           `mapstructure:"ACCESS_TOKEN"`
+	AnonKey             string           `mapstructure:"ANON_KEY"`
+	ServiceKey          string           `mapstructure:"SERVICE_KEY"`
+	SupabaseApiUrl      string           `mapstructure:"SUPABASE_API_URL"`
+	SupabaseApiBasePath string           `mapstructure:"SUPABASE_API_BASE_PATH"`
+	SupabasePublicUrl   string           `mapstructure:"SUPABASE_PUBLIC_URL"`
+	Schemas             []string         `mapstructure:"SCHEMAS"`
+}
+
 type Config struct {
-	AccessToken            string           `mapstructure:"ACCESS_TOKEN"`
-	AnonKey                string           `mapstructure:"ANON_KEY"`
-	BreakerEnable          bool             `mapstructure:"BREAKER_ENABLE"`
-	CorsAllowedOrigins     string           `mapstructure:"CORS_ALLOWED_ORIGINS"`
-	CorsAllowedMethods     string           `mapstructure:"CORS_ALLOWED_METHODS"`
-	CorsAllowedHeaders     string           `mapstructure:"CORS_ALLOWED_HEADERS"`
-	CorsAllowCredentials   bool             `mapstructure:"CORS_ALLOWED_CREDENTIALS"`
-	DeploymentTarget       DeploymentTarget `mapstructure:"DEPLOYMENT_TARGET"`
-	Environment            string           `mapstructure:"ENVIRONMENT"`
-	ProjectId              string           `mapstructure:"PROJECT_ID"`
-	ProjectName            string           `mapstructure:"PROJECT_NAME"`
-	ServiceKey             string           `mapstructure:"SERVICE_KEY"`
-	ServerHost             string           `mapstructure:"SERVER_HOST"`
-	ServerPort             string           `mapstructure:"SERVER_PORT"`
-	SupabaseApiUrl         string           `mapstructure:"SUPABASE_API_URL"`
-	SupabaseApiBasePath    string           `mapstructure:"SUPABASE_API_BASE_PATH"`
-	SupabasePublicUrl      string           `mapstructure:"SUPABASE_PUBLIC_URL"`
-	TraceEnable            bool             `mapstructure:"TRACE_ENABLE"`
-	TraceCollector         string           `mapstructure:"TRACE_COLLECTOR"`
-	TraceCollectorEndpoint string           `mapstructure:"TRACE_COLLECTOR_ENDPOINT"`
-	Version                string           `mapstructure:"VERSION"`
+	AccessToken            string            `mapstructure:"ACCESS_TOKEN"`
+	AnonKey                string            `mapstructure:"ANON_KEY"`
+	BreakerEnable          bool              `mapstructure:"BREAKER_ENABLE"`
+	BulkInsertHelpers      bool              `mapstructure:"BULK_INSERT_HELPERS"`
+	CorsAllowedOrigins     string            `mapstructure:"CORS_ALLOWED_ORIGINS"`
+	CorsAllowedMethods     string            `mapstructure:"CORS_ALLOWED_METHODS"`
+	CorsAllowedHeaders     string            `mapstructure:"CORS_ALLOWED_HEADERS"`
+	CorsAllowCredentials   bool              `mapstructure:"CORS_ALLOWED_CREDENTIALS"`
+	DeploymentTarget       DeploymentTarget  `mapstructure:"DEPLOYMENT_TARGET"`
+	Environment            string            `mapstructure:"ENVIRONMENT"`
+	ExcludeColumns         []string          `mapstructure:"EXCLUDE_COLUMNS"`
+	GenerateControllers    bool              `mapstructure:"GENERATE_CONTROLLERS"`
+	GenerateMigration      bool              `mapstructure:"GENERATE_MIGRATION"`
+	GenerateTypeScript     bool              `mapstructure:"GENERATE_TYPESCRIPT"`
+	GormTags               bool              `mapstructure:"GORM_TAGS"`
+	ImportForeignTables    bool              `mapstructure:"IMPORT_FOREIGN_TABLES"`
+	ImportPartitions       bool              `mapstructure:"IMPORT_PARTITIONS"`
+	ImportRetries          int               `mapstructure:"IMPORT_RETRIES"`
+	ImportTimeout          int               `mapstructure:"IMPORT_TIMEOUT"`
+	ImportSchemas          []string          `mapstructure:"IMPORT_SCHEMAS"`
+	ImportSources          []ImportSource    `mapstructure:"IMPORT_SOURCES"`
+	ImportViews            bool              `mapstructure:"IMPORT_VIEWS"`
+	JsonCase               string            `mapstructure:"JSON_CASE"`
+	JsonColumnTypes        []JsonColumnType  `mapstructure:"JSON_COLUMN_TYPES"`
+	ModelBaseColumns       []string          `mapstructure:"MODEL_BASE_COLUMNS"`
+	ModelsPackage          string            `mapstructure:"MODELS_PACKAGE"`
+	ModulePath             string            `mapstructure:"MODULE_PATH"`
+	PaginationHelpers      bool              `mapstructure:"PAGINATION_HELPERS"`
+	PivotRule              PivotRule         `mapstructure:"PIVOT_RULE"`
+	ProjectId              string            `mapstructure:"PROJECT_ID"`
+	ProjectName            string            `mapstructure:"PROJECT_NAME"`
+	RequirePrimaryKey      bool              `mapstructure:"REQUIRE_PRIMARY_KEY"`
+	SchemaPackages         bool              `mapstructure:"SCHEMA_PACKAGES"`
+	SchemaSuffixOnConflict bool              `mapstructure:"SCHEMA_SUFFIX_ON_CONFLICT"`
+	SeedTables             []string          `mapstructure:"SEED_TABLES"`
+	SoftDeleteColumn       string            `mapstructure:"SOFT_DELETE_COLUMN"`
+	ServiceKey             string            `mapstructure:"SERVICE_KEY"`
+	ServerHost             string            `mapstructure:"SERVER_HOST"`
+	ServerPort             string            `mapstructure:"SERVER_PORT"`
+	StrictRelations        bool              `mapstructure:"STRICT_RELATIONS"`
+	SuppressManyToMany     []ManyToManyEdge  `mapstructure:"SUPPRESS_MANY_TO_MANY"`
+	StripColumnPrefixes    []string          `mapstructure:"STRIP_COLUMN_PREFIXES"`
+	StripTablePrefixes     []string          `mapstructure:"STRIP_TABLE_PREFIXES"`
+	SupabaseApiUrl         string            `mapstructure:"SUPABASE_API_URL"`
+	SupabaseApiBasePath    string            `mapstructure:"SUPABASE_API_BASE_PATH"`
+	SupabasePublicUrl      string            `mapstructure:"SUPABASE_PUBLIC_URL"`
+	TraceEnable            bool              `mapstructure:"TRACE_ENABLE"`
+	TraceCollector         string            `mapstructure:"TRACE_COLLECTOR"`
+	TraceCollectorEndpoint string            `mapstructure:"TRACE_COLLECTOR_ENDPOINT"`
+	TypeOverrides          map[string]string `mapstructure:"TYPE_OVERRIDES"`
+	UpsertHelpers          bool              `mapstructure:"UPSERT_HELPERS"`
+	UuidType               string            `mapstructure:"UUID_TYPE"`
+	ValueRelations         bool              `mapstructure:"VALUE_RELATIONS"`
+	Version                string            `mapstructure:"VERSION"`
+	VersionColumn          string            `mapstructure:"VERSION_COLUMN"`
+
+	// context of request to supabase, see WithContext
+	ctx context.Context
 }
 
 // The function `LoadConfig` loads a configuration file based on the provided path or uses default
@@ -90,3 +168,65 @@ func LoadConfig(path *string) (*Config, error) {
 
 	return &config, nil
 }
+
+// WithContext return copy of config that bound to ctx,
+// request to supabase with the config is cancelled when ctx is done
+func (c *Config) WithContext(ctx context.Context) *Config {
+	cfg := *c
+	cfg.ctx = ctx
+	return &cfg
+}
+
+// Context return context of config, background context is returned when not set
+func (c *Config) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// SourceConfig return copy of config that connect to import source
+func (c *Config) SourceConfig(source ImportSource) *Config {
+	cfg := *c
+	cfg.ImportSources = nil
+
+	if source.DeploymentTarget != "" {
+		cfg.DeploymentTarget = source.DeploymentTarget
+	}
+
+	if source.ProjectId != "" {
+		cfg.ProjectId = source.ProjectId
+	}
+
+	if source.AccessToken != "" {
+		cfg.AccessToken = source.AccessToken
+	}
+
+	if source.AnonKey != "" {
+		cfg.AnonKey = source.AnonKey
+	}
+
+	if source.ServiceKey != "" {
+		cfg.ServiceKey = source.ServiceKey
+	}
+
+	if source.SupabaseApiUrl != "" {
+		cfg.SupabaseApiUrl = source.SupabaseApiUrl
+	}
+
+	if source.SupabaseApiBasePath != "" {
+		cfg.SupabaseApiBasePath = source.SupabaseApiBasePath
+		if cfg.SupabaseApiBasePath[0] != '/' {
+			cfg.SupabaseApiBasePath = "/" + cfg.SupabaseApiBasePath
+		}
+	}
+
+	if source.SupabasePublicUrl != "" {
+		cfg.SupabasePublicUrl = source.SupabasePublicUrl
+	}
+
+	if len(source.Schemas) > 0 {
+		cfg.ImportSchemas = source.Schemas
+	}
+	return &cfg
+}
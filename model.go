@@ -1,8 +1,11 @@
 package raiden
 
 import (
+	"reflect"
 	"regexp"
 	"strings"
+
+	"github.com/sev-2/raiden/pkg/utils"
 )
 
 type (
@@ -11,6 +14,8 @@ type (
 
 	// definition of column tag, example :
 	// column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false;unique;default:now()"
+	// column:"name:id;type:bigint;primaryKey;autoIncrement;readOnly"
+	// column:"name:id;type:uuid;primaryKey;nullable:false;default:gen_random_uuid();serverDefault"
 	ColumnTag struct {
 		Name          string
 		Type          string
@@ -19,17 +24,34 @@ type (
 		Nullable      bool
 		Default       any
 		Unique        bool
+		ReadOnly      bool
+
+		// value is generated by database when not set (e.g gen_random_uuid()),
+		// column can be omitted on insert
+		ServerDefault bool
+
+		// postgres domain of column in format schema.name,
+		// type contain the base type of the domain
+		Domain string
+
+		// postgres composite type of column in format schema.name
+		Composite string
 	}
 
 	// definition of join tag, example:
-	// - join:"joinType:hasOne;primaryKey:id;foreignKey:candidate_id"
+	// - join:"joinType:belongsTo;primaryKey:id;foreignKey:candidate_id"
+	// - join:"joinType:hasOne;primaryKey:id;foreignKey:user_id"
 	// - join:"joinType:hasMany;primaryKey:id;foreignKey:scouter_id"
-	// - join:"joinType:manyToMany;through:submission;sourcePrimaryKey:id;sourceForeignKey:candidate_id;targetPrimaryKey:id;targetForeign:candidate_id"
+	// - join:"joinType:manyToMany;through:submission;sourcePrimaryKey:id;sourceForeignKey:candidate_id;targetPrimaryKey:id;targetForeign:scouter_id"
+	// - join:"joinType:belongsTo;primaryKey:id;foreignKey:order_id;primaryKeys:id,tenant_id;foreignKeys:order_id,tenant_id"
 	JoinTag struct {
 		JoinType   RelationType
 		PrimaryKey string
 		ForeignKey string
 
+		PrimaryKeys []string
+		ForeignKeys []string
+
 		Through          string
 		SourcePrimaryKey string
 		SourceForeignKey string
@@ -38,10 +60,41 @@ type (
 		TargetForeignKey string
 	}
 
+	// definition of index tag, column and partial index predicate is written
+	// as separate tag because expression may contain `;`, `:` and `,`, example :
+	// PostsTagsIdx string `json:"-" index:"name:posts_tags_idx;method:gin" indexColumns:"tags" indexWhere:"deleted_at IS NULL"`
+	// UsersEmailIdx string `json:"-" index:"name:users_email_idx;unique" indexColumns:"lower(email)"`
+	IndexTag struct {
+		Name   string
+		Method string
+		Unique bool
+	}
+
+	// ModelColumn is column metadata of generated model, so column value
+	// can be accessed by its go field without reflection
+	ModelColumn struct {
+		Name       string
+		Field      string
+		PrimaryKey bool
+
+		// value is generated by database (identity or generated column)
+		Generated bool
+	}
+
+	// TableModel is implemented by every generated table and view model
+	TableModel interface {
+		ModelColumns() []ModelColumn
+	}
+
 	RelationType string
 )
 
 var (
+	// model hold the foreign key and refer to single row of related table
+	RelationTypeBelongsTo RelationType = "belongsTo"
+
+	// related table hold the foreign key with unique constraint, or model hold
+	// the foreign key for relation that declared before belongs to is introduced
 	RelationTypeHasOne     RelationType = "hasOne"
 	RelationTypeHasMany    RelationType = "hasMany"
 	RelationTypeManyToMany RelationType = "manyToMany"
@@ -58,12 +111,9 @@ func UnmarshalColumnTag(tag string) ColumnTag {
 	tagSplit := strings.Split(tag, ";")
 	tagMap := make(map[string]string)
 	for _, c := range tagSplit {
-		cSplit := strings.Split(c, ":")
-		if len(cSplit) == 2 {
-			tagMap[cSplit[0]] = cSplit[1]
-		} else if len(cSplit) == 1 {
-			tagMap[cSplit[0]] = ""
-		}
+		// value may contain `:`, example : default:nextval('orders_id_seq'::regclass)
+		key, value, _ := strings.Cut(c, ":")
+		tagMap[key] = value
 	}
 
 	defaultValue := "default"
@@ -89,6 +139,14 @@ func UnmarshalColumnTag(tag string) ColumnTag {
 			}
 		case "unique":
 			columnTag.Unique = true
+		case "readOnly":
+			columnTag.ReadOnly = true
+		case "serverDefault":
+			columnTag.ServerDefault = true
+		case "domain":
+			columnTag.Domain = value
+		case "composite":
+			columnTag.Composite = value
 		}
 	}
 
@@ -120,6 +178,10 @@ func UnmarshalJoinTag(tag string) JoinTag {
 			joinTag.PrimaryKey = value
 		case "foreignKey":
 			joinTag.ForeignKey = value
+		case "primaryKeys":
+			joinTag.PrimaryKeys = strings.Split(value, ",")
+		case "foreignKeys":
+			joinTag.ForeignKeys = strings.Split(value, ",")
 		case "through":
 			joinTag.Through = value
 		case "sourcePrimaryKey":
@@ -135,3 +197,99 @@ func UnmarshalJoinTag(tag string) JoinTag {
 
 	return joinTag
 }
+
+func UnmarshalIndexTag(tag string) IndexTag {
+	indexTag := IndexTag{}
+	for _, c := range strings.Split(tag, ";") {
+		key, value, _ := strings.Cut(c, ":")
+		switch strings.TrimSpace(key) {
+		case "name":
+			indexTag.Name = value
+		case "method":
+			indexTag.Method = value
+		case "unique":
+			indexTag.Unique = value == "" || value == "true"
+		}
+	}
+	return indexTag
+}
+
+// SplitIndexColumns split value of indexColumns tag by comma,
+// comma inside parentheses or quote is part of expression, example :
+// "lower(email), coalesce(code, '-')" will be ["lower(email)", "coalesce(code, '-')"]
+func SplitIndexColumns(columns string) (result []string) {
+	var depth int
+	var quote rune
+	var current strings.Builder
+
+	for _, r := range columns {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			if c := strings.TrimSpace(current.String()); c != "" {
+				result = append(result, c)
+			}
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+
+	if c := strings.TrimSpace(current.String()); c != "" {
+		result = append(result, c)
+	}
+	return
+}
+
+// GetTableName return table name of model type, table name is taken from
+// tableName tag in Metadata field (set when struct name is not derived from table name)
+// and fallback to snake case of struct name
+func GetTableName(modelType reflect.Type) string {
+	for modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice {
+		modelType = modelType.Elem()
+	}
+
+	if modelType.Kind() == reflect.Struct {
+		if metadataField, isExist := modelType.FieldByName("Metadata"); isExist {
+			if tableName := metadataField.Tag.Get("tableName"); len(tableName) > 0 {
+				return tableName
+			}
+		}
+	}
+	return utils.ToSnakeCase(modelType.Name())
+}
+
+// GetSoftDeleteColumn return soft delete column of model type from softDelete tag in Metadata
+// field, empty string is returned when model is not soft deleted. Query of soft deleted model
+// should exclude row where the column is not null, see SoftDeleteFilter
+func GetSoftDeleteColumn(modelType reflect.Type) string {
+	for modelType.Kind() == reflect.Ptr || modelType.Kind() == reflect.Slice {
+		modelType = modelType.Elem()
+	}
+
+	if modelType.Kind() == reflect.Struct {
+		if metadataField, isExist := modelType.FieldByName("Metadata"); isExist {
+			return metadataField.Tag.Get("softDelete")
+		}
+	}
+	return ""
+}
+
+// SoftDeleteFilter return rest query filter that exclude soft deleted row of model,
+// example : deleted_at=is.null, empty string is returned when model is not soft deleted
+func SoftDeleteFilter(modelType reflect.Type) string {
+	column := GetSoftDeleteColumn(modelType)
+	if column == "" {
+		return ""
+	}
+	return column + "=is.null"
+}
@@ -1,6 +1,7 @@
 package raiden_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/sev-2/raiden"
@@ -16,3 +17,65 @@ func TestMarshallColumnTag(t *testing.T) {
 	assert.Equal(t, "varchar(10)", column.Type)
 	assert.Equal(t, true, column.Nullable)
 }
+
+func TestUnmarshalColumnTag_ServerDefault(t *testing.T) {
+	column := raiden.UnmarshalColumnTag("name:id;type:uuid;primaryKey;nullable:false;default:gen_random_uuid();serverDefault")
+	assert.Equal(t, "id", column.Name)
+	assert.True(t, column.PrimaryKey)
+	assert.True(t, column.ServerDefault)
+
+	column = raiden.UnmarshalColumnTag("name:id;type:bigint;primaryKey;autoIncrement")
+	assert.False(t, column.ServerDefault)
+}
+
+func TestUnmarshalJoinTag_CompositeKey(t *testing.T) {
+	tag := "joinType:hasOne;primaryKey:id;foreignKey:order_id;primaryKeys:id,tenant_id;foreignKeys:order_id,tenant_id"
+
+	join := raiden.UnmarshalJoinTag(tag)
+
+	assert.Equal(t, raiden.RelationTypeHasOne, join.JoinType)
+	assert.Equal(t, "id", join.PrimaryKey)
+	assert.Equal(t, "order_id", join.ForeignKey)
+	assert.Equal(t, []string{"id", "tenant_id"}, join.PrimaryKeys)
+	assert.Equal(t, []string{"order_id", "tenant_id"}, join.ForeignKeys)
+}
+
+func TestUnmarshalIndexTag(t *testing.T) {
+	index := raiden.UnmarshalIndexTag("name:users_email_idx;method:hash;unique")
+
+	assert.Equal(t, "users_email_idx", index.Name)
+	assert.Equal(t, "hash", index.Method)
+	assert.True(t, index.Unique)
+}
+
+func TestSplitIndexColumns(t *testing.T) {
+	assert.Equal(t, []string{"tenant_id", "lower(email)", "coalesce(code, ', ')"}, raiden.SplitIndexColumns("tenant_id, lower(email), coalesce(code, ', ')"))
+	assert.Equal(t, []string{`"Name" DESC`}, raiden.SplitIndexColumns(`"Name" DESC`))
+	assert.Nil(t, raiden.SplitIndexColumns(""))
+}
+
+func TestUnmarshalColumnTag_DefaultWithColon(t *testing.T) {
+	column := raiden.UnmarshalColumnTag("name:id;type:bigserial;primaryKey;nullable:false;default:nextval('orders_id_seq'::regclass)")
+	assert.Equal(t, "bigserial", column.Type)
+	defaultValue, isString := column.Default.(*string)
+	assert.True(t, isString)
+	assert.Equal(t, "nextval('orders_id_seq'::regclass)", *defaultValue)
+	assert.False(t, column.Nullable)
+}
+
+type softDeleteOrders struct {
+	Id        int64   `json:"id,omitempty" column:"name:id;type:bigint;primaryKey"`
+	DeletedAt *string `json:"deleted_at,omitempty" column:"name:deleted_at;type:timestamptz;nullable"`
+
+	Metadata string `json:"-" schema:"public" tableName:"orders" softDelete:"deleted_at"`
+}
+
+func TestSoftDeleteFilter(t *testing.T) {
+	assert.Equal(t, "deleted_at", raiden.GetSoftDeleteColumn(reflect.TypeOf(&softDeleteOrders{})))
+	assert.Equal(t, "deleted_at=is.null", raiden.SoftDeleteFilter(reflect.TypeOf([]softDeleteOrders{})))
+
+	type items struct {
+		Metadata string `json:"-" schema:"public"`
+	}
+	assert.Equal(t, "", raiden.SoftDeleteFilter(reflect.TypeOf(items{})))
+}
@@ -0,0 +1,145 @@
+package raiden
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageLimit is used when limit of page query is not set
+const DefaultPageLimit = 50
+
+type (
+	// PageQuery is pagination of list query, keyset pagination is used when cursor
+	// is set and offset pagination is used otherwise. Order column of keyset
+	// pagination must be not null, primary key is used as tie breaker
+	PageQuery struct {
+		Limit  int
+		Offset int
+		Cursor string
+		Desc   bool
+	}
+
+	// Page is single page of list, next cursor and next offset is empty on the last page
+	Page[T any] struct {
+		Rows       []T    `json:"rows"`
+		NextCursor string `json:"next_cursor,omitempty"`
+		NextOffset int    `json:"next_offset,omitempty"`
+	}
+)
+
+var ErrInvalidPageCursor = errors.New("invalid page cursor")
+
+func (q PageQuery) limit() int {
+	if q.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	return q.Limit
+}
+
+// BuildPageQuery return rest query of paginated list ordered by order column and primary key,
+// one more row than limit is fetched so NewPage can tell if there is next page, example :
+// order=created_at.asc,id.asc&limit=21&or=(created_at.gt."2024-01-01",and(created_at.eq."2024-01-01",id.gt."5"))
+func BuildPageQuery(primaryKey string, orderColumn string, q PageQuery) (url.Values, error) {
+	direction, operator := "asc", "gt"
+	if q.Desc {
+		direction, operator = "desc", "lt"
+	}
+
+	values := url.Values{}
+	values.Set("limit", strconv.Itoa(q.limit()+1))
+
+	isPrimaryKeyOrder := orderColumn == "" || orderColumn == primaryKey
+	if isPrimaryKeyOrder {
+		values.Set("order", fmt.Sprintf("%s.%s", primaryKey, direction))
+	} else {
+		values.Set("order", fmt.Sprintf("%s.%s,%s.%s", orderColumn, direction, primaryKey, direction))
+	}
+
+	if q.Cursor == "" {
+		if q.Offset > 0 {
+			values.Set("offset", strconv.Itoa(q.Offset))
+		}
+		return values, nil
+	}
+
+	cursor, err := decodePageCursor(q.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if isPrimaryKeyOrder {
+		if len(cursor) != 1 {
+			return nil, ErrInvalidPageCursor
+		}
+		values.Set(primaryKey, fmt.Sprintf("%s.%s", operator, cursor[0]))
+		return values, nil
+	}
+
+	if len(cursor) != 2 {
+		return nil, ErrInvalidPageCursor
+	}
+
+	orderValue, keyValue := quotePageValue(cursor[0]), quotePageValue(cursor[1])
+	values.Set("or", fmt.Sprintf(
+		"(%s.%s.%s,and(%s.eq.%s,%s.%s.%s))",
+		orderColumn, operator, orderValue, orderColumn, orderValue, primaryKey, operator, keyValue,
+	))
+	return values, nil
+}
+
+// NewPage create page from rows that fetched with BuildPageQuery, cursorFn return value
+// of order column and primary key of the row or only primary key when ordered by primary key
+func NewPage[T any](rows []T, q PageQuery, cursorFn func(row T) []any) (Page[T], error) {
+	limit := q.limit()
+	if len(rows) <= limit {
+		return Page[T]{Rows: rows}, nil
+	}
+
+	page := Page[T]{Rows: rows[:limit]}
+	cursor, err := json.Marshal(cursorFn(rows[limit-1]))
+	if err != nil {
+		return page, err
+	}
+	page.NextCursor = base64.RawURLEncoding.EncodeToString(cursor)
+
+	if q.Cursor == "" {
+		page.NextOffset = q.Offset + limit
+	}
+	return page, nil
+}
+
+func decodePageCursor(cursor string) ([]string, error) {
+	content, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidPageCursor
+	}
+
+	// number is kept as is, so bigint value doesn't lose precision
+	var values []any
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, ErrInvalidPageCursor
+	}
+
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == nil {
+			return nil, ErrInvalidPageCursor
+		}
+		result = append(result, fmt.Sprint(v))
+	}
+	return result, nil
+}
+
+// quotePageValue quote value of logical filter, so value that contain comma or parenthesis is kept as is
+func quotePageValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
@@ -0,0 +1,70 @@
+package raiden_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+type pageOrders struct {
+	Id        uuid.UUID
+	CreatedAt time.Time
+}
+
+func TestPageQuery_Keyset(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []pageOrders{
+		{Id: uuid.MustParse("0b4e6d4a-1c1f-4a55-9e59-1b1a4b7b0a01"), CreatedAt: createdAt},
+		{Id: uuid.MustParse("0b4e6d4a-1c1f-4a55-9e59-1b1a4b7b0a02"), CreatedAt: createdAt},
+		{Id: uuid.MustParse("0b4e6d4a-1c1f-4a55-9e59-1b1a4b7b0a03"), CreatedAt: createdAt.Add(time.Hour)},
+	}
+	byPrimaryKey := func(row pageOrders) []any { return []any{row.Id} }
+
+	query := raiden.PageQuery{Limit: 2}
+	values, err := raiden.BuildPageQuery("id", "id", query)
+	assert.NoError(t, err)
+	assert.Equal(t, "limit=3&order=id.asc", values.Encode())
+
+	// one more row than limit is fetched, so the page have next cursor
+	page, err := raiden.NewPage(rows, query, byPrimaryKey)
+	assert.NoError(t, err)
+	assert.Equal(t, rows[:2], page.Rows)
+	assert.NotEmpty(t, page.NextCursor)
+	assert.Equal(t, 2, page.NextOffset)
+
+	query.Cursor = page.NextCursor
+	values, err = raiden.BuildPageQuery("id", "id", query)
+	assert.NoError(t, err)
+	assert.Equal(t, "gt.0b4e6d4a-1c1f-4a55-9e59-1b1a4b7b0a02", values.Get("id"))
+	assert.Empty(t, values.Get("offset"))
+
+	// last page doesn't have next cursor
+	page, err = raiden.NewPage(rows[2:], query, byPrimaryKey)
+	assert.NoError(t, err)
+	assert.Equal(t, rows[2:], page.Rows)
+	assert.Empty(t, page.NextCursor)
+	assert.Zero(t, page.NextOffset)
+
+	// primary key is tie breaker of order column
+	query = raiden.PageQuery{Limit: 1, Desc: true}
+	page, err = raiden.NewPage(rows, query, func(row pageOrders) []any { return []any{row.CreatedAt, row.Id} })
+	assert.NoError(t, err)
+
+	query.Cursor = page.NextCursor
+	values, err = raiden.BuildPageQuery("id", "created_at", query)
+	assert.NoError(t, err)
+	assert.Equal(t, "created_at.desc,id.desc", values.Get("order"))
+	assert.Equal(t, `(created_at.lt."2024-01-01T00:00:00Z",and(created_at.eq."2024-01-01T00:00:00Z",id.lt."0b4e6d4a-1c1f-4a55-9e59-1b1a4b7b0a01"))`, values.Get("or"))
+
+	_, err = raiden.BuildPageQuery("id", "id", raiden.PageQuery{Cursor: "invalid"})
+	assert.ErrorIs(t, err, raiden.ErrInvalidPageCursor)
+}
+
+func TestPageQuery_Offset(t *testing.T) {
+	values, err := raiden.BuildPageQuery("id", "created_at", raiden.PageQuery{Offset: 40})
+	assert.NoError(t, err)
+	assert.Equal(t, "limit=51&offset=40&order=created_at.asc%2Cid.asc", values.Encode())
+}
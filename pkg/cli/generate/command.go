@@ -108,24 +108,24 @@ func Run(flags *Flags, config *raiden.Config, projectPath string, initialize boo
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			GenerateLogger.Debug("start generate routes")
+			GenerateLogger.Debug("start generate", "phase", "routes")
 			if initialize {
 				// generate example controller
-				GenerateLogger.Info("start generate hello word controller")
+				GenerateLogger.Info("start generate", "phase", "hello-world-controller")
 				if err := generator.GenerateHelloWordController(projectPath, generator.Generate); err != nil {
 					errChan <- err
 					return
 				}
-				GenerateLogger.Info("finish generate hello word controller")
+				GenerateLogger.Info("finish generate", "phase", "hello-world-controller")
 			}
 
 			// generate route base on controllers
-			if err := generator.GenerateRoute(projectPath, config.ProjectName, generator.Generate); err != nil {
+			if err := generator.GenerateRouteWithPackage(projectPath, generator.NewProjectPackage(config), generator.Generate); err != nil {
 				errChan <- err
 				return
 			}
 			errChan <- nil
-			GenerateLogger.Info("finish generate routes")
+			GenerateLogger.Info("finish generate", "phase", "routes")
 		}()
 	}
 
@@ -135,13 +135,13 @@ func Run(flags *Flags, config *raiden.Config, projectPath string, initialize boo
 			defer wg.Done()
 
 			// generate main function
-			GenerateLogger.Info("start generate main function")
+			GenerateLogger.Info("start generate", "phase", "main-function")
 			if err := generator.GenerateMainFunction(projectPath, config, generator.Generate); err != nil {
 				errChan <- err
 			} else {
 				errChan <- nil
 			}
-			GenerateLogger.Info("finish generate main function")
+			GenerateLogger.Info("finish generate", "phase", "main-function")
 		}()
 	}
 
@@ -150,49 +150,63 @@ func Run(flags *Flags, config *raiden.Config, projectPath string, initialize boo
 		defer wg.Done()
 
 		// generate rpc register
-		GenerateLogger.Debug("start generate rpc register file")
-		if err := generator.GenerateRpcRegister(projectPath, config.ProjectName, generator.Generate); err != nil {
+		GenerateLogger.Debug("start generate", "phase", "rpc-register")
+		if err := generator.GenerateRpcRegisterWithPackage(projectPath, generator.NewProjectPackage(config), generator.Generate); err != nil {
 			errChan <- err
 		}
-		GenerateLogger.Debug("finish generate rpc register file")
+		GenerateLogger.Debug("finish generate", "phase", "rpc-register")
 
 		// generate role register
-		GenerateLogger.Debug("start generate role register file")
-		if err := generator.GenerateRoleRegister(projectPath, config.ProjectName, generator.Generate); err != nil {
+		GenerateLogger.Debug("start generate", "phase", "role-register")
+		if err := generator.GenerateRoleRegisterWithPackage(projectPath, generator.NewProjectPackage(config), generator.Generate); err != nil {
 			errChan <- err
 		}
-		GenerateLogger.Debug("finish generate role register file")
+		GenerateLogger.Debug("finish generate", "phase", "role-register")
 
 		// generate model register
-		GenerateLogger.Debug("start generate model register file")
-		if err := generator.GenerateModelRegister(projectPath, config.ProjectName, generator.Generate); err != nil {
+		GenerateLogger.Debug("start generate", "phase", "model-register")
+		if err := generator.GenerateModelRegisterWithPackage(projectPath, generator.NewProjectPackage(config), generator.Generate); err != nil {
 			errChan <- err
 		}
-		GenerateLogger.Debug("finish generate role register file")
+		GenerateLogger.Debug("finish generate", "phase", "model-register")
 
 		// generate storage register
-		GenerateLogger.Debug("start generate storages register file")
-		if err := generator.GenerateStoragesRegister(projectPath, config.ProjectName, generator.Generate); err != nil {
+		GenerateLogger.Debug("start generate", "phase", "storage-register")
+		if err := generator.GenerateStoragesRegisterWithPackage(projectPath, generator.NewProjectPackage(config), generator.Generate); err != nil {
 			errChan <- err
 		}
-		GenerateLogger.Debug("finish generate storages register file")
+		GenerateLogger.Debug("finish generate", "phase", "storage-register")
+
+		// generate trigger register
+		GenerateLogger.Debug("start generate", "phase", "trigger-register")
+		if err := generator.GenerateTriggerRegisterWithPackage(projectPath, generator.NewProjectPackage(config), generator.Generate); err != nil {
+			errChan <- err
+		}
+		GenerateLogger.Debug("finish generate", "phase", "trigger-register")
+
+		// generate publication register
+		GenerateLogger.Debug("start generate", "phase", "publication-register")
+		if err := generator.GeneratePublicationRegisterWithPackage(projectPath, generator.NewProjectPackage(config), generator.Generate); err != nil {
+			errChan <- err
+		}
+		GenerateLogger.Debug("finish generate", "phase", "publication-register")
 
 		if initialize {
 			// generate import main function
-			GenerateLogger.Debug("start generate import main function file")
+			GenerateLogger.Debug("start generate", "phase", "import-main-function")
 			if err := generator.GenerateImportMainFunction(projectPath, config, generator.Generate); err != nil {
 				errChan <- err
 			}
-			GenerateLogger.Debug("finish generate import main function file")
+			GenerateLogger.Debug("finish generate", "phase", "import-main-function")
 
-			// generate import main function
-			GenerateLogger.Debug("start generate apply main function file")
+			// generate apply main function
+			GenerateLogger.Debug("start generate", "phase", "apply-main-function")
 			if err := generator.GenerateApplyMainFunction(projectPath, config, generator.Generate); err != nil {
 				errChan <- err
 			} else {
 				errChan <- nil
 			}
-			GenerateLogger.Debug("finish generate import main function file")
+			GenerateLogger.Debug("finish generate", "phase", "apply-main-function")
 		} else {
 			errChan <- nil
 		}
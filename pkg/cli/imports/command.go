@@ -28,6 +28,20 @@ type Flags struct {
 	StoragesOnly  bool
 	AllowedSchema string
 	DryRun        bool
+	ModelStub     bool
+	SingleFile    bool
+	Incremental   bool
+	RelationsOnly bool
+	Graph         string
+	OpenApi       string
+	Force         bool
+	NoClobber     bool
+	Conflict      string
+	Since         string
+	Seed          string
+	IncludeTables string
+	ExcludeTables string
+	Report        string
 }
 
 func (f *Flags) Bind(cmd *cobra.Command) {
@@ -37,7 +51,20 @@ func (f *Flags) Bind(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&f.StoragesOnly, "storages-only", "", false, "import storage only")
 	cmd.Flags().StringVarP(&f.AllowedSchema, "schema", "s", "", "set allowed schema to import, use coma separator for multiple schema")
 	cmd.Flags().BoolVar(&f.DryRun, "dry-run", false, "run import in simulate mode without actual import resource as code")
-
+	cmd.Flags().BoolVar(&f.ModelStub, "model-stub", false, "generate model to <table>_gen.go and keep custom method in <table>.go")
+	cmd.Flags().BoolVar(&f.SingleFile, "single-file", false, "generate all model to single models_gen.go file")
+	cmd.Flags().BoolVar(&f.Incremental, "incremental", false, "only regenerate model for changed table")
+	cmd.Flags().BoolVar(&f.RelationsOnly, "relations-only", false, "only regenerate relation of existing model and keep the rest of model file")
+	cmd.Flags().StringVar(&f.Graph, "graph", "", "write table relation diagram in mermaid format to file path")
+	cmd.Flags().StringVar(&f.OpenApi, "openapi", "", "write openapi document of table and rpc to file path, use .json extension for json format")
+	cmd.Flags().BoolVar(&f.Force, "force", false, "overwrite all generated file even when content is unchanged")
+	cmd.Flags().BoolVar(&f.NoClobber, "no-clobber", false, "skip generate file that already exist")
+	cmd.Flags().StringVar(&f.Conflict, "conflict", "", "check generated file that modified after previous import, value is prompt or fail")
+	cmd.Flags().StringVar(&f.Since, "since", "", "only regenerate resource that changed after date (2006-01-02) or RFC3339 time")
+	cmd.Flags().StringVar(&f.Seed, "seed", "", "generate seed data from rows of table, use coma separator for multiple table")
+	cmd.Flags().StringVar(&f.IncludeTables, "include", "", "only import table that match glob pattern or re:<regex>, use coma separator for multiple pattern")
+	cmd.Flags().StringVar(&f.ExcludeTables, "exclude", "", "skip import table that match glob pattern or re:<regex>, use coma separator for multiple pattern")
+	cmd.Flags().StringVar(&f.Report, "report", "", "write import result in json format to file path")
 }
 
 func (f *Flags) LoadAll() bool {
@@ -116,13 +143,69 @@ func Run(logFlags *cli.LogFlags, flags *Flags, projectPath string) error {
 	}
 
 	if flags.AllowedSchema != "" {
-		args = append(args, "--schema "+flags.AllowedSchema)
+		args = append(args, "--schema", flags.AllowedSchema)
 	}
 
 	if flags.DryRun {
 		args = append(args, "--dry-run")
 	}
 
+	if flags.ModelStub {
+		args = append(args, "--model-stub")
+	}
+
+	if flags.SingleFile {
+		args = append(args, "--single-file")
+	}
+
+	if flags.Incremental {
+		args = append(args, "--incremental")
+	}
+
+	if flags.RelationsOnly {
+		args = append(args, "--relations-only")
+	}
+
+	if flags.Graph != "" {
+		args = append(args, "--graph", flags.Graph)
+	}
+
+	if flags.OpenApi != "" {
+		args = append(args, "--openapi", flags.OpenApi)
+	}
+
+	if flags.Force {
+		args = append(args, "--force")
+	}
+
+	if flags.NoClobber {
+		args = append(args, "--no-clobber")
+	}
+
+	if flags.Conflict != "" {
+		args = append(args, "--conflict", flags.Conflict)
+	}
+
+	if flags.Since != "" {
+		args = append(args, "--since", flags.Since)
+	}
+
+	if flags.Seed != "" {
+		args = append(args, "--seed", flags.Seed)
+	}
+
+	if flags.IncludeTables != "" {
+		args = append(args, "--include", flags.IncludeTables)
+	}
+
+	if flags.ExcludeTables != "" {
+		args = append(args, "--exclude", flags.ExcludeTables)
+	}
+
+	if flags.Report != "" {
+		args = append(args, "--report", flags.Report)
+	}
+
 	if logFlags.DebugMode {
 		args = append(args, "--debug")
 	} else if logFlags.TraceMode {
@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/cli/configure"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/resource"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/spf13/cobra"
+)
+
+var ValidateLogger hclog.Logger = logger.HcLog().Named("validate")
+
+type Flags struct {
+	Build bool
+}
+
+func (f *Flags) Bind(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.Build, "build", false, "compile models package to check error of manually edited model")
+}
+
+func PreRun(projectPath string) error {
+	if !configure.IsConfigExist(projectPath) {
+		return errors.New("missing config file (./configs/app.yaml), run `raiden configure` first for generate configuration file")
+	}
+
+	return nil
+}
+
+// Run compare generated file with local state and list every drifted file,
+// supabase is not contacted so validate can be run offline (e.g in CI)
+func Run(flags *Flags, config *raiden.Config, projectPath string) error {
+	ValidateLogger.Info("load resource from local state")
+	localState, err := state.Load()
+	if err != nil {
+		return err
+	}
+
+	drifts, err := resource.Validate(&resource.Flags{ProjectPath: projectPath}, config, localState)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range drifts {
+		ValidateLogger.Warn("generated file is drifted from local state", "path", d.Path, "reason", d.Reason)
+	}
+
+	if flags.Build {
+		ValidateLogger.Info("build models package")
+		if err := resource.ValidateBuild(projectPath); err != nil {
+			return err
+		}
+	}
+
+	if len(drifts) > 0 {
+		return fmt.Errorf("%d generated file is drifted from local state, run import to regenerate it", len(drifts))
+	}
+
+	ValidateLogger.Info("generated file match local state")
+	return nil
+}
@@ -63,6 +63,8 @@ func main() {
 			bootstrap.RegisterRoles()
 			bootstrap.RegisterModels()
 			bootstrap.RegisterStorages()
+			bootstrap.RegisterTriggers()
+			bootstrap.RegisterPublications()
 			
 			if err = resource.Apply(&f, config); err != nil {
 				apply.ApplyLogger.Error(err.Error())
@@ -118,7 +120,7 @@ func GenerateApplyMainFunction(basePath string, config *raiden.Config, generateF
 		fmt.Sprintf("%q", "github.com/sev-2/raiden/pkg/utils"),
 		fmt.Sprintf("%q", "github.com/spf13/cobra"),
 	}
-	rpcImportPath := fmt.Sprintf("\"%s/internal/bootstrap\"", utils.ToGoModuleName(config.ProjectName))
+	rpcImportPath := fmt.Sprintf("%q", NewProjectPackage(config).GetImportPath(RouterDir))
 	importPaths = append(importPaths, rpcImportPath)
 	data := GenerateApplyMainFunctionData{
 		Package: "main",
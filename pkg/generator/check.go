@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// postgres normalize `status in ('a', 'b')` check to one of :
+	// - (status = ANY (ARRAY['a'::text, 'b'::text]))
+	// - ((status)::text = ANY ((ARRAY['a'::character varying, 'b'::character varying])::text[]))
+	checkInRegex      = regexp.MustCompile(`^\(*\s*\(?"?(\w+)"?\)?(?:::[\w ]+)?\s*=\s*ANY\s*\(\(?ARRAY\[(.+?)\]\)?(?:::[\w\[\] ]+)?\)\s*\)*$`)
+	checkValueRegex   = regexp.MustCompile(`^'((?:[^']|'')*)'(?:::[\w ]+)?$`)
+	checkElementRegex = regexp.MustCompile(`'(?:[^']|'')*'(?:::[\w ]+)?|[^,\s][^,]*`)
+)
+
+// ParseCheckInValues extract allowed value of enum style check constraint,
+// only check that compare column with list of string literal is supported
+func ParseCheckInValues(column, check string) ([]string, bool) {
+	matches := checkInRegex.FindStringSubmatch(strings.TrimSpace(check))
+	if len(matches) != 3 || matches[1] != column {
+		return nil, false
+	}
+
+	var values []string
+	for _, e := range checkElementRegex.FindAllString(matches[2], -1) {
+		valueMatches := checkValueRegex.FindStringSubmatch(strings.TrimSpace(e))
+		if len(valueMatches) != 2 {
+			return nil, false
+		}
+		values = append(values, strings.ReplaceAll(valueMatches[1], "''", "'"))
+	}
+
+	return values, len(values) > 0
+}
+
+// buildCheckValidateTag build validate tag that understood by go-playground validator,
+// empty string is returned when value can't be written as oneof param
+func buildCheckValidateTag(values []string) string {
+	for _, v := range values {
+		if v == "" || strings.ContainsAny(v, " ,|'\"`") {
+			return ""
+		}
+	}
+	return fmt.Sprintf("validate:%q", "omitempty,oneof="+strings.Join(values, " "))
+}
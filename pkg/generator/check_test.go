@@ -0,0 +1,27 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCheckInValues(t *testing.T) {
+	values, ok := generator.ParseCheckInValues("status", "(status = ANY (ARRAY['pending'::text, 'paid'::text, 'can''t'::text]))")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"pending", "paid", "can't"}, values)
+
+	values, ok = generator.ParseCheckInValues("status", "((status)::text = ANY ((ARRAY['a'::character varying, 'b'::character varying])::text[]))")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, values)
+
+	_, ok = generator.ParseCheckInValues("price", "(price > (0)::numeric)")
+	assert.False(t, ok)
+
+	_, ok = generator.ParseCheckInValues("level", "(level = ANY (ARRAY[1, 2, 3]))")
+	assert.False(t, ok)
+
+	_, ok = generator.ParseCheckInValues("kind", "(status = ANY (ARRAY['a'::text]))")
+	assert.False(t, ok)
+}
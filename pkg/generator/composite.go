@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var CompositeLogger hclog.Logger = logger.HcLog().Named("generator.composite")
+
+// ----- Define type, variable and constant -----
+type GenerateCompositeField struct {
+	Name      string
+	Attribute string
+	Type      string
+}
+
+type GenerateCompositeData struct {
+	Package string
+	Imports []string
+	Name    string
+	Schema  string
+	Type    string
+	Fields  []GenerateCompositeField
+}
+
+const (
+	CompositeFileSuffix = "_composite"
+	CompositeTemplate   = `package {{ .Package }}
+{{- if gt (len .Imports) 0 }}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{- end }}
+
+// {{ .Type }} represent postgres composite type {{ .Schema }}.{{ .Name }}
+type {{ .Type }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .Type }} ` + "`json:\"{{ .Attribute }},omitempty\"`" + `
+{{- end }}
+}
+`
+)
+
+// GenerateComposites generate struct of composite type, types is used to resolve
+// attribute that typed as other user defined type (e.g enum or nested composite type)
+func GenerateComposites(ctx context.Context, basePath string, packageName string, composites []objects.Type, types []objects.Type, generateFn GenerateFn) (err error) {
+	folderPath := filepath.Join(basePath, ModelDir)
+	CompositeLogger.Trace("create models folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	mapTypes := make(map[int]objects.Type, len(types)+len(composites))
+	for _, t := range types {
+		mapTypes[t.ID] = t
+	}
+	for _, t := range composites {
+		mapTypes[t.ID] = t
+	}
+
+	for _, t := range composites {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !t.IsComposite() {
+			continue
+		}
+
+		if err := GenerateComposite(folderPath, packageName, t, mapTypes, generateFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func GenerateComposite(folderPath string, packageName string, compositeType objects.Type, mapTypes map[int]objects.Type, generateFn GenerateFn) error {
+	if packageName == "" {
+		packageName = ModelsPackage
+	}
+
+	// define file path
+	filePath := filepath.Join(folderPath, fmt.Sprintf("%s%s.%s", utils.ToSnakeCase(compositeType.Name), CompositeFileSuffix, "go"))
+
+	// attribute of composite type can't be declared as not null, so every field is nullable
+	importsMap := make(map[string]bool)
+	fields := make([]GenerateCompositeField, 0, len(compositeType.Attributes))
+	for _, a := range compositeType.Attributes {
+		goType := getCompositeAttributeGoType(a.TypeID, mapTypes)
+		if importPath := getGoTypeImportPath(goType); importPath != "" {
+			importsMap[importPath] = true
+		}
+		if strings.HasPrefix(goType, "*raiden.") {
+			importsMap["github.com/sev-2/raiden"] = true
+		}
+
+		fields = append(fields, GenerateCompositeField{
+			Name:      toEnumIdentifier(a.Name),
+			Attribute: a.Name,
+			Type:      goType,
+		})
+	}
+
+	data := GenerateCompositeData{
+		Package: packageName,
+		Name:    compositeType.Name,
+		Schema:  compositeType.Schema,
+		Type:    toEnumIdentifier(compositeType.Name),
+		Fields:  fields,
+	}
+
+	for importPath := range importsMap {
+		data.Imports = append(data.Imports, importPath)
+	}
+	sort.Strings(data.Imports)
+
+	input := GenerateInput{
+		BindData:     data,
+		Template:     CompositeTemplate,
+		TemplateName: "compositeTemplate",
+		OutputPath:   filePath,
+	}
+
+	CompositeLogger.Debug("generate composite", "path", input.OutputPath)
+	return generateFn(input, nil)
+}
+
+// getCompositeAttributeGoType map attribute type to nullable go type, built in type is resolved by its
+// fixed oid and user defined type by generated type. Type that can't be resolved is kept as raw json
+func getCompositeAttributeGoType(typeID int, mapTypes map[int]objects.Type) string {
+	if dataType, format, found := postgres.GetBuiltinType(typeID); found {
+		if dataType == postgres.ArrayType {
+			return postgres.ToGoArrayType(format)
+		}
+		return postgres.ToGoType(dataType, true)
+	}
+
+	if t, exist := mapTypes[typeID]; exist && (t.IsEnum() || t.IsDomain() || t.IsComposite()) {
+		return "*" + toEnumIdentifier(t.Name)
+	}
+	return "json.RawMessage"
+}
@@ -0,0 +1,88 @@
+package generator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateComposites(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	// attribute type is referred by oid, 25 is text and 3910 is tstzrange
+	types := []objects.Type{
+		{ID: 100, Name: "country", Schema: "public", Enums: []string{"id", "sg"}},
+		{ID: 101, Name: "geo_point", Schema: "public", Attributes: []objects.TypeAttribute{
+			{Name: "lat", TypeID: 701},
+			{Name: "lng", TypeID: 701},
+		}},
+	}
+	composites := []objects.Type{
+		{ID: 102, Name: "address", Schema: "public", Attributes: []objects.TypeAttribute{
+			{Name: "street", TypeID: 25},
+			{Name: "country", TypeID: 100},
+			{Name: "location", TypeID: 101},
+			{Name: "open_hours", TypeID: 3910},
+			{Name: "tags", TypeID: 1009},
+			{Name: "extra", TypeID: 99999},
+		}},
+		{ID: 100, Name: "country", Schema: "public", Enums: []string{"id", "sg"}},
+	}
+
+	err := generator.GenerateComposites(context.Background(), dir, "", composites, types, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.ModelDir, "address_composite.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "// Address represent postgres composite type public.address")
+	assert.Contains(t, string(content), "Street *string `json:\"street,omitempty\"`")
+	assert.Contains(t, string(content), "Country *Country `json:\"country,omitempty\"`")
+	assert.Contains(t, string(content), "Location *GeoPoint `json:\"location,omitempty\"`")
+	assert.Contains(t, string(content), "OpenHours *raiden.Range[time.Time] `json:\"open_hours,omitempty\"`")
+	assert.Contains(t, string(content), "Tags []string `json:\"tags,omitempty\"`")
+	assert.Contains(t, string(content), "Extra json.RawMessage `json:\"extra,omitempty\"`")
+	assert.Contains(t, string(content), "\"encoding/json\"\n\t\"github.com/sev-2/raiden\"\n\t\"time\"")
+
+	assert.NoFileExists(t, filepath.Join(dir, generator.ModelDir, "country_composite.go"))
+}
+
+func TestGenerateModel_RangeAndCompositeColumn(t *testing.T) {
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "stores",
+			Schema: "sales",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint", Format: "int8"},
+				{Name: "open_period", DataType: "tstzrange", Format: "tstzrange"},
+				{Name: "capacity", DataType: "int4range", Format: "int4range", IsNullable: true},
+				{Name: "address", DataType: "USER-DEFINED", Format: "address", IsNullable: true},
+				{Name: "metadata", DataType: "USER-DEFINED", Format: "hstore", IsNullable: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		ProjectName:    "shop",
+		SchemaPackage:  true,
+		CompositeTypes: map[string]string{"address": "public.address"},
+	}
+
+	dir := t.TempDir()
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "stores.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"shop/internal/models\"")
+	assert.Contains(t, string(content), "\"time\"")
+	assert.Contains(t, string(content), "OpenPeriod raiden.Range[time.Time] `json:\"open_period,omitempty\" column:\"name:open_period;type:tstzrange;nullable:false\"`")
+	assert.Contains(t, string(content), "Capacity *raiden.Range[int32] `json:\"capacity,omitempty\" column:\"name:capacity;type:int4range;nullable\"`")
+	assert.Contains(t, string(content), "Address *models.Address `json:\"address,omitempty\" column:\"name:address;composite:public.address;nullable\"`")
+
+	// user defined type that is not composite type is kept as is
+	assert.Contains(t, string(content), "Metadata interface{} `json:\"metadata,omitempty\" column:\"name:metadata;nullable\"`")
+}
@@ -44,6 +44,44 @@ TRACE_COLLECTOR_ENDPOINT: {{ .TraceCollectorEndpoint }}
 CORS_ALLOWED_ORIGINS:
 CORS_ALLOWED_METHODS:
 CORS_ALLOWED_HEADERS:
+
+IMPORT_SCHEMAS:
+IMPORT_SOURCES:
+IMPORT_VIEWS: false
+IMPORT_PARTITIONS: false
+IMPORT_FOREIGN_TABLES: false
+IMPORT_RETRIES: 3
+IMPORT_TIMEOUT: 0
+REQUIRE_PRIMARY_KEY: false
+SCHEMA_PACKAGES: false
+SCHEMA_SUFFIX_ON_CONFLICT: false
+JSON_CASE: snake
+JSON_COLUMN_TYPES:
+EXCLUDE_COLUMNS:
+MODEL_BASE_COLUMNS:
+MODELS_PACKAGE: models
+MODULE_PATH:
+STRICT_RELATIONS: false
+STRIP_COLUMN_PREFIXES:
+STRIP_TABLE_PREFIXES:
+SUPPRESS_MANY_TO_MANY:
+PIVOT_RULE:
+  MIN_FOREIGN_KEY: 2
+  MAX_FOREIGN_KEY: 2
+  MAX_EXTRA_COLUMN: 0
+VALUE_RELATIONS: false
+GENERATE_CONTROLLERS: false
+GENERATE_TYPESCRIPT: false
+GENERATE_MIGRATION: false
+GORM_TAGS: false
+PAGINATION_HELPERS: false
+BULK_INSERT_HELPERS: false
+UPSERT_HELPERS: false
+UUID_TYPE: uuid.UUID
+TYPE_OVERRIDES:
+SEED_TABLES:
+SOFT_DELETE_COLUMN:
+VERSION_COLUMN:
 `
 )
 
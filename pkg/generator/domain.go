@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var DomainLogger hclog.Logger = logger.HcLog().Named("generator.domain")
+
+// ----- Define type, variable and constant -----
+type GenerateDomainData struct {
+	Package  string
+	Imports  []string
+	Name     string
+	Schema   string
+	BaseType string
+	Type     string
+	GoType   string
+	IsAlias  bool
+	Check    string
+}
+
+const (
+	DomainFileSuffix = "_domain"
+	DomainTemplate   = `package {{ .Package }}
+{{- if gt (len .Imports) 0 }}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{- end }}
+
+// {{ .Type }} represent postgres domain {{ .Schema }}.{{ .Name }} over {{ .BaseType }}
+{{- if .IsAlias }}
+type {{ .Type }} = {{ .GoType }}
+{{- else }}
+type {{ .Type }} {{ .GoType }}
+{{- end }}
+{{- if .Check }}
+
+// {{ .Type }}Check is check constraint of the domain, value is validated by database
+const {{ .Type }}Check = {{ .Check | printf "%q" }}
+{{- end }}
+`
+)
+
+func GenerateDomains(ctx context.Context, basePath string, packageName string, types []objects.Type, generateFn GenerateFn) (err error) {
+	folderPath := filepath.Join(basePath, ModelDir)
+	DomainLogger.Trace("create models folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range types {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !t.IsDomain() {
+			continue
+		}
+
+		if err := GenerateDomain(folderPath, packageName, t, generateFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func GenerateDomain(folderPath string, packageName string, domainType objects.Type, generateFn GenerateFn) error {
+	if packageName == "" {
+		packageName = ModelsPackage
+	}
+
+	// define file path
+	filePath := filepath.Join(folderPath, fmt.Sprintf("%s%s.%s", utils.ToSnakeCase(domainType.Name), DomainFileSuffix, "go"))
+
+	goType, importPath := getDomainGoType(domainType.BaseType)
+
+	// defined type of struct from other package lose the method (e.g json marshaller of time.Time),
+	// so domain over that type is generated as alias
+	data := GenerateDomainData{
+		Package:  packageName,
+		Name:     domainType.Name,
+		Schema:   domainType.Schema,
+		BaseType: domainType.BaseType,
+		Type:     toEnumIdentifier(domainType.Name),
+		GoType:   goType,
+		IsAlias:  importPath != "",
+		Check:    domainType.Check,
+	}
+
+	if importPath != "" {
+		data.Imports = []string{importPath}
+	}
+
+	input := GenerateInput{
+		BindData:     data,
+		Template:     DomainTemplate,
+		TemplateName: "domainTemplate",
+		OutputPath:   filePath,
+	}
+
+	DomainLogger.Debug("generate domain", "path", input.OutputPath)
+	return generateFn(input, nil)
+}
+
+// getDomainGoType map base type of domain to go type and the import path,
+// type modifier of base type is not needed for go type
+func getDomainGoType(baseType string) (goType string, importPath string) {
+	dataType, _ := postgres.ParseTypeModifier(baseType)
+	goType = postgres.ToGoType(postgres.GetPgDataTypeName(postgres.DataType(dataType), false), false)
+
+	if pkg, _, found := strings.Cut(goType, "."); found {
+		switch pkg {
+		case "time":
+			importPath = "time"
+		case "uuid":
+			importPath = "github.com/google/uuid"
+		case "json":
+			importPath = "encoding/json"
+		}
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package generator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateDomains(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	types := []objects.Type{
+		{Name: "email", Schema: "public", BaseType: "text", Check: "VALUE ~~ '%@%'::text"},
+		{Name: "event_time", Schema: "public", BaseType: "timestamp with time zone"},
+		{Name: "order_status", Schema: "public", Enums: []string{"pending"}},
+	}
+
+	err := generator.GenerateDomains(context.Background(), dir, "", types, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.ModelDir, "email_domain.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "type Email string")
+	assert.Contains(t, string(content), `const EmailCheck = "VALUE ~~ '%@%'::text"`)
+
+	content, err = os.ReadFile(filepath.Join(dir, generator.ModelDir, "event_time_domain.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"time\"")
+	assert.Contains(t, string(content), "type EventTime = time.Time")
+	assert.NotContains(t, string(content), "EventTimeCheck")
+
+	assert.NoFileExists(t, filepath.Join(dir, generator.ModelDir, "order_status_domain.go"))
+}
+
+func TestGenerateModel_DomainColumn(t *testing.T) {
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "customers",
+			Schema: "sales",
+			Columns: []objects.Column{
+				{Name: "email", DataType: "text", Format: "text", Domain: "email", DomainSchema: "public"},
+				{Name: "backup_email", DataType: "text", Format: "text", Domain: "email", DomainSchema: "public", IsNullable: true},
+			},
+		},
+		ProjectName:   "shop",
+		SchemaPackage: true,
+	}
+
+	dir := t.TempDir()
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "customers.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"shop/internal/models\"")
+	assert.Contains(t, string(content), "Email models.Email `json:\"email,omitempty\" column:\"name:email;type:text;domain:public.email;nullable:false\"`")
+	assert.Contains(t, string(content), "BackupEmail *models.Email `json:\"backup_email,omitempty\" column:\"name:backup_email;type:text;domain:public.email;nullable\"`")
+}
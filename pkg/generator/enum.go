@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"unicode"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var EnumLogger hclog.Logger = logger.HcLog().Named("generator.enum")
+
+// ----- Define type, variable and constant -----
+type GenerateEnumValue struct {
+	Name  string
+	Value string
+}
+
+type GenerateEnumData struct {
+	Package string
+	Name    string
+	Schema  string
+	Type    string
+	Values  []GenerateEnumValue
+}
+
+const (
+	EnumFileSuffix = "_enum"
+	EnumTemplate   = `package {{ .Package }}
+
+// {{ .Type }} represent postgres enum type {{ .Schema }}.{{ .Name }}
+type {{ .Type }} string
+{{- if gt (len .Values) 0 }}
+
+const (
+{{- range .Values }}
+	{{ .Name }} {{ $.Type }} = {{ .Value | printf "%q" }}
+{{- end }}
+)
+{{- end }}
+`
+)
+
+var nonAlphanumericRegex = regexp.MustCompile("[^a-zA-Z0-9]+")
+
+// EnumConstantNames is constant name of enum value, keyed by enum type name and value
+type EnumConstantNames map[string]map[string]string
+
+func GenerateEnums(basePath string, types []objects.Type, generateFn GenerateFn) (err error) {
+	return GenerateEnumsWithContext(context.Background(), basePath, ModelsPackage, types, nil, generateFn)
+}
+
+// GenerateEnumsWithContext generate enum type to models package and stop when context is cancelled,
+// constant name is built from types when constant names is not set
+func GenerateEnumsWithContext(ctx context.Context, basePath string, packageName string, types []objects.Type, constantNames EnumConstantNames, generateFn GenerateFn) (err error) {
+	if constantNames == nil {
+		constantNames = BuildEnumConstantNames(types)
+	}
+
+	folderPath := filepath.Join(basePath, ModelDir)
+	EnumLogger.Trace("create models folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range types {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !t.IsEnum() {
+			continue
+		}
+
+		if err := GenerateEnum(folderPath, packageName, t, constantNames, generateFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func GenerateEnum(folderPath string, packageName string, enumType objects.Type, constantNames EnumConstantNames, generateFn GenerateFn) error {
+	if packageName == "" {
+		packageName = ModelsPackage
+	}
+
+	// define file path
+	filePath := filepath.Join(folderPath, fmt.Sprintf("%s%s.%s", utils.ToSnakeCase(enumType.Name), EnumFileSuffix, "go"))
+
+	// constant is named by enum value, see BuildEnumConstantNames
+	typeName := GetEnumTypeName(enumType)
+	values := make([]GenerateEnumValue, 0, len(enumType.Enums))
+	for _, v := range enumType.Enums {
+		values = append(values, GenerateEnumValue{
+			Name:  constantNames.Get(typeName, v),
+			Value: v,
+		})
+	}
+
+	data := GenerateEnumData{
+		Package: packageName,
+		Name:    enumType.Name,
+		Schema:  enumType.Schema,
+		Type:    typeName,
+		Values:  values,
+	}
+
+	input := GenerateInput{
+		BindData:     data,
+		Template:     EnumTemplate,
+		TemplateName: "enumTemplate",
+		OutputPath:   filePath,
+	}
+
+	EnumLogger.Debug("generate enum", "path", input.OutputPath)
+	return generateFn(input, nil)
+}
+
+// toEnumIdentifier convert enum name or value to go identifier,
+// character that not allowed in go identifier is treated as word separator
+func toEnumIdentifier(value string) string {
+	return utils.SnakeCaseToPascalCase(nonAlphanumericRegex.ReplaceAllString(value, "_"))
+}
+
+// GetEnumTypeName return go type name of enum type
+func GetEnumTypeName(enumType objects.Type) string {
+	return toEnumIdentifier(enumType.Name)
+}
+
+// GetEnumConstantName return constant name of enum value (e.g in_progress become InProgress),
+// value that can't be go identifier (e.g 1st) is prefixed with type name
+func GetEnumConstantName(typeName, value string) string {
+	name := toEnumIdentifier(value)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		return typeName + name
+	}
+	return name
+}
+
+// BuildEnumConstantNames name constant of every enum value by the value. Every enum is generated
+// in models package, so constant that collide with constant of other enum, enum type or reserved
+// name (e.g model struct) is prefixed with type name (e.g OrderStatusActive)
+func BuildEnumConstantNames(types []objects.Type, reservedNames ...string) EnumConstantNames {
+	mapCount := make(map[string]int)
+	for _, n := range reservedNames {
+		mapCount[n]++
+	}
+
+	for _, t := range types {
+		if !t.IsEnum() {
+			continue
+		}
+
+		typeName := GetEnumTypeName(t)
+		mapCount[typeName]++
+		for _, v := range t.Enums {
+			mapCount[GetEnumConstantName(typeName, v)]++
+		}
+	}
+
+	names := make(EnumConstantNames)
+	for _, t := range types {
+		if !t.IsEnum() {
+			continue
+		}
+
+		typeName := GetEnumTypeName(t)
+		values := make(map[string]string, len(t.Enums))
+		for _, v := range t.Enums {
+			name := GetEnumConstantName(typeName, v)
+			if mapCount[name] > 1 && name == toEnumIdentifier(v) {
+				name = typeName + name
+			}
+			values[v] = name
+		}
+		names[typeName] = values
+	}
+	return names
+}
+
+// Get return constant name of enum value, value that not exist use name from GetEnumConstantName
+func (n EnumConstantNames) Get(typeName, value string) string {
+	if name, exist := n[typeName][value]; exist {
+		return name
+	}
+	return GetEnumConstantName(typeName, value)
+}
@@ -0,0 +1,89 @@
+package generator_test
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateEnums(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	types := []objects.Type{
+		{Name: "order_status", Schema: "public", Enums: []string{"pending", "in_progress", "done-ok"}},
+		{Name: "address", Schema: "public", Attributes: []objects.TypeAttribute{{Name: "street"}}},
+	}
+
+	err := generator.GenerateEnums(dir, types, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.ModelDir, "order_status_enum.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "type OrderStatus string")
+	assert.Contains(t, string(content), `Pending OrderStatus = "pending"`)
+	assert.Contains(t, string(content), `InProgress OrderStatus = "in_progress"`)
+	assert.Contains(t, string(content), `DoneOk OrderStatus = "done-ok"`)
+
+	assert.NoFileExists(t, filepath.Join(dir, generator.ModelDir, "address_enum.go"))
+}
+
+func TestGenerateEnums_SameValue(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	enumTypes := []objects.Type{
+		{Name: "order_status", Schema: "public", Enums: []string{"active", "done"}},
+		{Name: "user_status", Schema: "public", Enums: []string{"active", "blocked", "users"}},
+	}
+
+	// users value collide with model struct of users table
+	constantNames := generator.BuildEnumConstantNames(enumTypes, "Users")
+	err := generator.GenerateEnumsWithContext(context.Background(), dir, generator.ModelsPackage, enumTypes, constantNames, generator.Generate)
+	assert.NoError(t, err)
+
+	modelFile := filepath.Join(dir, generator.ModelDir, "users.go")
+	assert.NoError(t, os.WriteFile(modelFile, []byte("package models\n\ntype Users struct{}\n"), 0644))
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.ModelDir, "user_status_enum.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `UserStatusActive UserStatus = "active"`)
+	assert.Contains(t, string(content), `Blocked UserStatus = "blocked"`)
+	assert.Contains(t, string(content), `UserStatusUsers UserStatus = "users"`)
+
+	// generated package is type checked
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, filepath.Join(dir, generator.ModelDir), nil, 0)
+	assert.NoError(t, err)
+
+	var files []*ast.File
+	for _, f := range pkgs[generator.ModelsPackage].Files {
+		files = append(files, f)
+	}
+	_, err = (&types.Config{}).Check(generator.ModelsPackage, fset, files, nil)
+	assert.NoError(t, err)
+}
+
+func TestMapTableAttributes_EnumColumn(t *testing.T) {
+	table := objects.Table{
+		Name:   "orders",
+		Schema: "public",
+		Columns: []objects.Column{
+			{Name: "status", DataType: "USER-DEFINED", Format: "order_status", Enums: []string{"pending"}},
+			{Name: "prev_status", DataType: "USER-DEFINED", Format: "order_status", Enums: []string{"pending"}, IsNullable: true},
+		},
+	}
+
+	columns, _ := generator.MapTableAttributes(table)
+	assert.Equal(t, "OrderStatus", columns[0].Type)
+	assert.Equal(t, "*OrderStatus", columns[1].Type)
+}
@@ -58,13 +58,22 @@ func main() {
 			bootstrap.RegisterRoles()
 			bootstrap.RegisterModels()
 			bootstrap.RegisterStorages()
+			bootstrap.RegisterTriggers()
+			bootstrap.RegisterPublications()
 
 			if err = generate.Run(&f.Generate, config, f.ProjectPath, false); err != nil {
 				imports.ImportLogger.Error(err.Error())
 			}
 
-			if err := resource.Import(&f, config); err != nil {
+			// stop import when interrupted, file that already written is kept
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if err := resource.ImportWithContext(ctx, &f, config, nil); err != nil {
 				imports.ImportLogger.Error(err.Error())
+				if ctx.Err() != nil {
+					return
+				}
 			}
 
 			if !f.DryRun {
@@ -86,6 +95,20 @@ func main() {
 	cmd.Flags().BoolVarP(&f.StoragesOnly, "storages-only", "", false, "import storages only")
 	cmd.Flags().StringVarP(&f.AllowedSchema, "schema", "s", "", "set allowed schema to import, use coma separator for multiple schema")
 	cmd.Flags().BoolVar(&f.DryRun, "dry-run", false, "run import in simulate mode without actual import resource as code")
+	cmd.Flags().BoolVar(&f.ModelStub, "model-stub", false, "generate model to <table>_gen.go and keep custom method in <table>.go")
+	cmd.Flags().BoolVar(&f.SingleFile, "single-file", false, "generate all model to single models_gen.go file")
+	cmd.Flags().BoolVar(&f.Incremental, "incremental", false, "only regenerate model for changed table")
+	cmd.Flags().BoolVar(&f.RelationsOnly, "relations-only", false, "only regenerate relation of existing model and keep the rest of model file")
+	cmd.Flags().StringVar(&f.Graph, "graph", "", "write table relation diagram in mermaid format to file path")
+	cmd.Flags().StringVar(&f.OpenApi, "openapi", "", "write openapi document of table and rpc to file path, use .json extension for json format")
+	cmd.Flags().BoolVar(&f.Force, "force", false, "overwrite all generated file even when content is unchanged")
+	cmd.Flags().BoolVar(&f.NoClobber, "no-clobber", false, "skip generate file that already exist")
+	cmd.Flags().StringVar(&f.Conflict, "conflict", "", "check generated file that modified after previous import, value is prompt or fail")
+	cmd.Flags().StringVar(&f.Since, "since", "", "only regenerate resource that changed after date (2006-01-02) or RFC3339 time")
+	cmd.Flags().StringVar(&f.Seed, "seed", "", "generate seed data from rows of table, use coma separator for multiple table")
+	cmd.Flags().StringVar(&f.IncludeTables, "include", "", "only import table that match glob pattern or re:<regex>, use coma separator for multiple pattern")
+	cmd.Flags().StringVar(&f.ExcludeTables, "exclude", "", "skip import table that match glob pattern or re:<regex>, use coma separator for multiple pattern")
+	cmd.Flags().StringVar(&f.Report, "report", "", "write import result in json format to file path")
 
 	f.Generate.Bind(cmd)
 
@@ -119,6 +142,11 @@ func GenerateImportMainFunction(basePath string, config *raiden.Config, generate
 
 	// setup import path
 	importPaths := []string{
+		fmt.Sprintf("%q", "context"),
+		fmt.Sprintf("%q", "os"),
+		fmt.Sprintf("%q", "os/signal"),
+		fmt.Sprintf("%q", "syscall"),
+		"",
 		fmt.Sprintf("%q", "github.com/sev-2/raiden"),
 		fmt.Sprintf("%q", "github.com/sev-2/raiden/pkg/cli/generate"),
 		fmt.Sprintf("%q", "github.com/sev-2/raiden/pkg/cli/imports"),
@@ -126,7 +154,7 @@ func GenerateImportMainFunction(basePath string, config *raiden.Config, generate
 		fmt.Sprintf("%q", "github.com/sev-2/raiden/pkg/utils"),
 		fmt.Sprintf("%q", "github.com/spf13/cobra"),
 	}
-	rpcImportPath := fmt.Sprintf("\"%s/internal/bootstrap\"", utils.ToGoModuleName(config.ProjectName))
+	rpcImportPath := fmt.Sprintf("%q", NewProjectPackage(config).GetImportPath(RouterDir))
 	importPaths = append(importPaths, rpcImportPath)
 	data := GenerateImportMainFunctionData{
 		Package: "main",
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// JsonCase is casing of json key in generated model, column tag always keep the real column name
+type JsonCase string
+
+const (
+	JsonCaseSnake  JsonCase = "snake"
+	JsonCaseCamel  JsonCase = "camel"
+	JsonCasePascal JsonCase = "pascal"
+)
+
+// ToJsonKey convert column or relation name to json key with configured casing,
+// snake case is used when casing is empty or not supported, example for first_name :
+// - snake  : first_name
+// - camel  : firstName
+// - pascal : FirstName
+func ToJsonKey(name string, jsonCase JsonCase) string {
+	snake := utils.ToSnakeCase(name)
+
+	switch JsonCase(strings.ToLower(string(jsonCase))) {
+	case JsonCaseCamel:
+		pascal := []rune(utils.SnakeCaseToPascalCase(snake))
+		if len(pascal) > 0 {
+			pascal[0] = unicode.ToLower(pascal[0])
+		}
+		return string(pascal)
+	case JsonCasePascal:
+		return utils.SnakeCaseToPascalCase(snake)
+	default:
+		return snake
+	}
+}
+
+// IsValidJsonCase check if json case is supported, empty json case is valid and use snake case
+func IsValidJsonCase(jsonCase JsonCase) bool {
+	switch JsonCase(strings.ToLower(string(jsonCase))) {
+	case "", JsonCaseSnake, JsonCaseCamel, JsonCasePascal:
+		return true
+	default:
+		return false
+	}
+}
@@ -77,7 +77,7 @@ func GenerateMainFunction(basePath string, config *raiden.Config, generateFn Gen
 	importPaths := []string{
 		fmt.Sprintf("%q", "github.com/sev-2/raiden"),
 	}
-	routeImportPath := fmt.Sprintf("\"%s/internal/bootstrap\"", utils.ToGoModuleName(config.ProjectName))
+	routeImportPath := fmt.Sprintf("%q", NewProjectPackage(config).GetImportPath(RouterDir))
 	importPaths = append(importPaths, routeImportPath)
 	data := GenerateMainFunctionData{
 		Package: "main",
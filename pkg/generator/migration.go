@@ -0,0 +1,325 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/lib/pq"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var MigrationLogger hclog.Logger = logger.HcLog().Named("generator.migration")
+
+// ----- Define type, variable and constant -----
+type (
+	GenerateMigrationInput struct {
+		Roles    []objects.Role
+		Types    []objects.Type
+		Tables   []objects.Table
+		Policies []objects.Policy
+	}
+
+	GenerateMigrationStatement struct {
+		Comment string
+		Query   string
+	}
+
+	GenerateMigrationData struct {
+		Statements []GenerateMigrationStatement
+	}
+)
+
+const (
+	MigrationDir      = "migrations"
+	MigrationFile     = "baseline.sql"
+	MigrationTemplate = `-- Code generated by raiden-cli; DO NOT EDIT.
+-- Baseline of imported schema, apply to empty database to recreate imported resource.
+{{- range .Statements }}
+
+-- {{ .Comment }}
+{{ .Query }}
+{{- end }}
+`
+)
+
+// GenerateMigration write ddl of imported role, type, table, relation and policy
+// to <basePath>/migrations/baseline.sql
+func GenerateMigration(basePath string, input GenerateMigrationInput, generateFn GenerateFn) error {
+	folderPath := filepath.Join(basePath, MigrationDir)
+	MigrationLogger.Trace("create migrations folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	data, err := BuildMigrationData(input)
+	if err != nil {
+		return err
+	}
+
+	generateInput := GenerateInput{
+		BindData:     data,
+		Template:     MigrationTemplate,
+		TemplateName: "migrationTemplate",
+		OutputPath:   filepath.Join(folderPath, MigrationFile),
+	}
+
+	MigrationLogger.Debug("generate migration", "path", generateInput.OutputPath)
+	return generateFn(generateInput, nil)
+}
+
+// BuildMigrationData render resource as ordered ddl statement, every object is created
+// after object that it depend on (e.g role before policy, referenced table before relation)
+func BuildMigrationData(input GenerateMigrationInput) (data GenerateMigrationData, err error) {
+	add := func(comment, q string) {
+		data.Statements = append(data.Statements, GenerateMigrationStatement{Comment: comment, Query: strings.TrimSpace(q)})
+	}
+
+	tables := sortTablesByRelation(input.Tables)
+
+	mapSchemas := map[string]bool{"public": true}
+	var schemas []string
+	for _, t := range input.Types {
+		if !mapSchemas[t.Schema] {
+			mapSchemas[t.Schema] = true
+			schemas = append(schemas, t.Schema)
+		}
+	}
+	for _, t := range tables {
+		if !mapSchemas[t.Schema] {
+			mapSchemas[t.Schema] = true
+			schemas = append(schemas, t.Schema)
+		}
+	}
+	sort.Strings(schemas)
+	for _, s := range schemas {
+		add("schema "+s, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;", s))
+	}
+
+	roles := make([]objects.Role, len(input.Roles))
+	copy(roles, input.Roles)
+	sort.SliceStable(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	for _, r := range roles {
+		add("role "+r.Name, query.BuildCreateRoleQuery(r))
+	}
+
+	typeQueries, err := buildMigrationTypeQueries(input.Types)
+	if err != nil {
+		return data, err
+	}
+	for _, q := range typeQueries {
+		add(q.Comment, q.Query)
+	}
+
+	// relation is created right after source table when target table already exist (including
+	// self reference), relation of circular dependency is created after all table is created
+	created := make(map[string]bool)
+	var deferred []objects.TablesRelationship
+	for _, t := range tables {
+		q, err := query.BuildCreateTableQuery(t)
+		if err != nil {
+			return data, fmt.Errorf("build migration of table %s.%s : %w", t.Schema, t.Name, err)
+		}
+		add(fmt.Sprintf("table %s.%s", t.Schema, t.Name), q)
+		created[t.Schema+"."+t.Name] = true
+
+		for _, r := range getTableForeignKeys(t) {
+			if !created[r.TargetTableSchema+"."+r.TargetTableName] {
+				deferred = append(deferred, r)
+				continue
+			}
+
+			q, err := query.BuildFkQuery(objects.UpdateRelationCreate, &r)
+			if err != nil {
+				return data, err
+			}
+			add(fmt.Sprintf("relation %s.%s.%s", r.SourceSchema, r.SourceTableName, r.ConstraintName), q)
+		}
+	}
+
+	for _, r := range deferred {
+		// relation to table that not imported can't be created
+		if !created[r.TargetTableSchema+"."+r.TargetTableName] {
+			MigrationLogger.Warn("skip relation, target table is not imported", "constraint", r.ConstraintName, "target", r.TargetTableSchema+"."+r.TargetTableName)
+			continue
+		}
+
+		q, err := query.BuildFkQuery(objects.UpdateRelationCreate, &r)
+		if err != nil {
+			return data, err
+		}
+		add(fmt.Sprintf("relation %s.%s.%s", r.SourceSchema, r.SourceTableName, r.ConstraintName), q)
+	}
+
+	policies := make([]objects.Policy, 0, len(input.Policies))
+	for _, p := range input.Policies {
+		if created[p.Schema+"."+p.Table] {
+			policies = append(policies, p)
+		}
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		if policies[i].Schema+"."+policies[i].Table != policies[j].Schema+"."+policies[j].Table {
+			return policies[i].Schema+"."+policies[i].Table < policies[j].Schema+"."+policies[j].Table
+		}
+		return policies[i].Name < policies[j].Name
+	})
+	for _, p := range policies {
+		add(fmt.Sprintf("policy %s on %s.%s", p.Name, p.Schema, p.Table), query.BuildCreatePolicyQuery(p))
+	}
+
+	return data, nil
+}
+
+// sortTablesByRelation order table so referenced table is placed before table that refer it,
+// table is sorted by name first so the output is stable and circular relation is ignored
+func sortTablesByRelation(tables []objects.Table) []objects.Table {
+	sorted := make([]objects.Table, len(tables))
+	copy(sorted, tables)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Schema != sorted[j].Schema {
+			return sorted[i].Schema < sorted[j].Schema
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	mapTable := make(map[string]objects.Table, len(sorted))
+	for _, t := range sorted {
+		mapTable[t.Schema+"."+t.Name] = t
+	}
+
+	visited := make(map[string]bool, len(sorted))
+	result := make([]objects.Table, 0, len(sorted))
+
+	var visit func(t objects.Table)
+	visit = func(t objects.Table) {
+		visited[t.Schema+"."+t.Name] = true
+		for _, r := range getTableForeignKeys(t) {
+			target, exist := mapTable[r.TargetTableSchema+"."+r.TargetTableName]
+			if exist && !visited[r.TargetTableSchema+"."+r.TargetTableName] {
+				visit(target)
+			}
+		}
+		result = append(result, t)
+	}
+
+	for _, t := range sorted {
+		if !visited[t.Schema+"."+t.Name] {
+			visit(t)
+		}
+	}
+	return result
+}
+
+// getTableForeignKeys return relation that owned by table, relationship of table
+// also contain relation from other table that refer to it
+func getTableForeignKeys(table objects.Table) (relations []objects.TablesRelationship) {
+	exist := make(map[string]bool)
+	for _, r := range table.Relationships {
+		if r.SourceSchema != table.Schema || r.SourceTableName != table.Name || exist[r.ConstraintName] {
+			continue
+		}
+		exist[r.ConstraintName] = true
+		relations = append(relations, r)
+	}
+	return
+}
+
+// buildMigrationTypeQueries create enum and domain type before composite type,
+// composite type that used as attribute is created before the composite that use it
+func buildMigrationTypeQueries(types []objects.Type) (statements []GenerateMigrationStatement, err error) {
+	sorted := make([]objects.Type, len(types))
+	copy(sorted, types)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Schema != sorted[j].Schema {
+			return sorted[i].Schema < sorted[j].Schema
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	mapTypes := make(map[int]objects.Type, len(sorted))
+	for _, t := range sorted {
+		mapTypes[t.ID] = t
+	}
+
+	for _, t := range sorted {
+		name := fmt.Sprintf("%s.%s", t.Schema, t.Name)
+		switch {
+		case t.IsEnum():
+			values := make([]string, 0, len(t.Enums))
+			for _, v := range t.Enums {
+				values = append(values, pq.QuoteLiteral(v))
+			}
+			statements = append(statements, GenerateMigrationStatement{
+				Comment: "type " + name,
+				Query:   fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);", name, strings.Join(values, ", ")),
+			})
+		case t.IsDomain():
+			var check string
+			if t.Check != "" {
+				check = fmt.Sprintf(" CHECK (%s)", t.Check)
+			}
+			statements = append(statements, GenerateMigrationStatement{
+				Comment: "type " + name,
+				Query:   fmt.Sprintf("CREATE DOMAIN %s AS %s%s;", name, t.BaseType, check),
+			})
+		}
+	}
+
+	created := make(map[int]bool)
+	var visit func(t objects.Type) error
+	visit = func(t objects.Type) error {
+		created[t.ID] = true
+
+		attributes := make([]string, 0, len(t.Attributes))
+		for _, a := range t.Attributes {
+			if nested, exist := mapTypes[a.TypeID]; exist && nested.IsComposite() && !created[a.TypeID] {
+				if err := visit(nested); err != nil {
+					return err
+				}
+			}
+
+			attributeType, err := getMigrationAttributeType(a.TypeID, mapTypes)
+			if err != nil {
+				return fmt.Errorf("build migration of type %s.%s : %w", t.Schema, t.Name, err)
+			}
+			attributes = append(attributes, fmt.Sprintf("%s %s", a.Name, attributeType))
+		}
+
+		statements = append(statements, GenerateMigrationStatement{
+			Comment: fmt.Sprintf("type %s.%s", t.Schema, t.Name),
+			Query:   fmt.Sprintf("CREATE TYPE %s.%s AS (%s);", t.Schema, t.Name, strings.Join(attributes, ", ")),
+		})
+		return nil
+	}
+
+	for _, t := range sorted {
+		if t.IsComposite() && !created[t.ID] {
+			if err := visit(t); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return statements, nil
+}
+
+func getMigrationAttributeType(typeID int, mapTypes map[int]objects.Type) (string, error) {
+	if dataType, format, found := postgres.GetBuiltinType(typeID); found {
+		if dataType == postgres.ArrayType {
+			return strings.TrimPrefix(format, "_") + "[]", nil
+		}
+		return string(dataType), nil
+	}
+
+	if t, exist := mapTypes[typeID]; exist {
+		return fmt.Sprintf("%s.%s", t.Schema, t.Name), nil
+	}
+	return "", fmt.Errorf("type of attribute with oid %d is not imported", typeID)
+}
@@ -1,8 +1,10 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -21,13 +23,30 @@ var ModelLogger hclog.Logger = logger.HcLog().Named("generator.model")
 // ----- Define type, variable and constant -----
 type (
 	GenerateModelColumn struct {
-		Name string
-		Type string
-		Tag  string
+		Name        string
+		Type        string
+		Tag         string
+		IsIdentity  bool
+		IsGenerated bool
+		Comments    []string
+
+		// allowed value of enum style check constraint
+		CheckValues []string
+
+		// column have database default (e.g now(), nextval(...)),
+		// generated as pointer so unset field is omitted on insert
+		HasDefault bool
+	}
+
+	// GenerateModelIndex is index declaration field, field is named by index name
+	GenerateModelIndex struct {
+		Field string
+		Tag   string
 	}
 
 	GenerateModelData struct {
 		Columns    []GenerateModelColumn
+		Indexes    []GenerateModelIndex
 		Imports    []string
 		Package    string
 		Relations  []state.Relation
@@ -36,18 +55,186 @@ type (
 		RlsForced  bool
 		StructName string
 		Schema     string
+		Generated  bool
+		Comments   []string
+
+		// table is partitioned parent table, data stored in partition table
+		Partitioned bool
+
+		// table doesn't have primary key, row can't be targeted for update and delete
+		ReadOnly bool
+
+		// table is foreign table, data is stored in foreign server and never migrated
+		Foreign bool
+
+		// soft delete column of table, empty when table is not soft deleted
+		SoftDelete string
+
+		// primary key of soft deleted table, used by single row filter
+		SoftDeleteKeys []string
+
+		// coma separated column that omitted from struct
+		ExcludeColumns string
+
+		// only set when struct name is not derived from table name
+		TableName string
+
+		// only used by view model
+		Materialized bool
+
+		// constant of allowed value from column check constraint
+		Constants []GenerateEnumValue
+
+		// embedded model base type, empty when model doesn't embed model base
+		Base string
+
+		// schema qualified table name for gorm, only set when gorm tag is generated
+		GormTableName string
+
+		// list query helper, only set when pagination helper is generated
+		Pagination *GenerateModelPagination
+
+		// metadata of model column and model base column, see raiden.TableModel
+		ColumnMetadata []raiden.ModelColumn
+
+		// bulk insert helper, only set when bulk insert helper is generated
+		BulkInsert *GenerateModelBulkInsert
+
+		// upsert helper of primary key and every unique constraint,
+		// only set when upsert helper is generated
+		Upserts []GenerateModelUpsert
+
+		// optimistic locking update helper, only set when version column is configured
+		// and table have the column
+		VersionedUpdate *GenerateModelVersionedUpdate
+	}
+
+	// GenerateModelBulkInsert is table and column of bulk insert statement, column that
+	// generated or defaulted by database is not inserted and primary key is returned
+	GenerateModelBulkInsert struct {
+		Table     string
+		Columns   []string
+		Returning []string
+	}
+
+	// GenerateModelUpsert is multi row upsert statement that conflict on primary key or unique
+	// constraint, conflict column is inserted even when it is defaulted by database so
+	// defaulted conflict column must be set in every row, see raiden.CheckUpsertKey
+	GenerateModelUpsert struct {
+		Name          string
+		Table         string
+		Columns       []string
+		Conflict      []string
+		DefaultedKeys []string
+		Update        []string
+		Returning     []string
+	}
+
+	// GenerateModelVersionedUpdate is single row update by primary key that guarded by version
+	// column, version column is incremented by database instead of set from model
+	GenerateModelVersionedUpdate struct {
+		Table   string
+		Columns []string
+		Keys    []string
+		Version string
+	}
+
+	// GenerateModelPagination is primary key and column that can be used to order
+	// paginated list, primary key is always the first column
+	GenerateModelPagination struct {
+		PrimaryKey string
+		Columns    []string
 	}
 
 	GenerateModelInput struct {
 		Table     objects.Table
 		Relations []state.Relation
 		Policies  objects.Policies
+
+		// split generated model to <table>_gen.go and <table>.go stub,
+		// stub file is only created if not exist and can be used for custom method
+		WithStub bool
+
+		// map column name to go struct used as json / jsonb column type,
+		// struct must be declared in models package
+		JsonTypes map[string]string
+
+		// generate model to schema subpackage (internal/models/<schema>),
+		// project name is used for build import path of model in other schema
+		SchemaPackage bool
+		ProjectName   string
+
+		// go module path and package name of generated model, used when module path
+		// is different from project name, see GetModelsImportPath and GetModelsPackage
+		ModulePath    string
+		ModelsPackage string
+
+		// map table, column and relation name to go identifier,
+		// DefaultNameTransformer is used when not set
+		NameTransformer NameTransformer
+
+		// override struct name of table by <schema>.<table> key, used for
+		// resolve conflicting struct name of table in different schema
+		StructNames map[string]string
+
+		// casing of json key in struct tag, snake case is used when not set
+		JsonCase JsonCase
+
+		// shared struct that embedded by model, column of model base is not
+		// declared in model, see ResolveModelBase
+		Base *GenerateModelBase
+
+		// go type of uuid column, uuid.UUID is used when not set
+		UuidType UuidType
+
+		// map postgres type to go type with import path, consulted before
+		// built in type mapping, see ParseTypeOverride
+		TypeOverrides map[string]string
+
+		// composite type (schema.name) keyed by type name, column of
+		// composite type is typed as generated composite struct
+		CompositeTypes map[string]string
+
+		// column that mark row as deleted (e.g deleted_at), model is flagged
+		// as soft deleted when table have the column, see GetSoftDeleteColumn
+		SoftDeleteColumn string
+
+		// generate gorm tag and TableName method in addition to raiden tag,
+		// so the model can be used by gorm in other service
+		GormTags bool
+
+		// generate typed offset and keyset pagination helper of list query
+		PaginationHelpers bool
+
+		// generate multi row insert statement builder, see raiden.BuildBulkInsert
+		BulkInsertHelpers bool
+
+		// generate upsert statement builder of primary key and every
+		// unique constraint, see raiden.BuildUpsert
+		UpsertHelpers bool
+
+		// integer column used for optimistic locking (e.g version), update helper
+		// is generated when table have the column, see raiden.BuildVersionedUpdate
+		VersionColumn string
+
+		// column that omitted from struct, column still exist in database
+		// and listed in metadata tag so it is not dropped by apply
+		ExcludeColumns []string
+	}
+
+	GenerateModelStubData struct {
+		Package    string
+		StructName string
 	}
 )
 
 const (
-	ModelDir      = "internal/models"
-	ModelTemplate = `package {{ .Package }}
+	ModelDir           = "internal/models"
+	ModelsPackage      = "models"
+	ModelGenFileSuffix = "_gen"
+	ModelTemplate      = `{{- if .Generated }}// Code generated by raiden-cli; DO NOT EDIT.
+{{ end -}}
+package {{ .Package }}
 {{- if gt (len .Imports) 0 }}
 
 import (
@@ -56,31 +243,240 @@ import (
 {{- end}}
 )
 {{- end }}
-
+{{ range .Comments }}
+{{ . }}
+{{- end }}
 type {{ .StructName }} struct {
 	raiden.ModelBase
+{{- if .Base }}
+	{{ .Base }}
+{{- end }}
 {{- range .Columns }}
-	{{ .Name | ToGoIdentifier }} {{ .Type }} ` + "`{{ .Tag }}`" + `
+{{- range .Comments }}
+	{{ . }}
+{{- end }}
+	{{ .Name | ToColumnIdentifier }} {{ .Type }} ` + "`{{ .Tag }}`" + `
 {{- end }}
 
 	// Table information
-	Metadata string ` + "`json:\"-\" schema:\"{{ .Schema}}\" rlsEnable:\"{{ .RlsEnable }}\" rlsForced:\"{{ .RlsForced }}\"`" + `
+	Metadata string ` + "`json:\"-\" schema:\"{{ .Schema}}\"{{ if .TableName }} tableName:\"{{ .TableName }}\"{{ end }} rlsEnable:\"{{ .RlsEnable }}\" rlsForced:\"{{ .RlsForced }}\"{{ if .Partitioned }} partitioned:\"true\"{{ end }}{{ if .Foreign }} foreign:\"true\"{{ end }}{{ if .SoftDelete }} softDelete:\"{{ .SoftDelete }}\"{{ end }}{{ if .ExcludeColumns }} excludeColumns:\"{{ .ExcludeColumns }}\"{{ end }}{{ if .ReadOnly }} readOnly:\"true\"{{ end }}{{ if .GormTableName }} gorm:\"-\"{{ end }}`" + `
 
 	// Access control
-	Acl string ` + "`json:\"-\" {{ .RlsTag }}`" + `
+	Acl string ` + "`json:\"-\" {{ .RlsTag }}{{ if .GormTableName }} gorm:\"-\"{{ end }}`" + `
 	
-{{- if gt (len .Relations) 0 }}
+{{- if gt (len .Indexes) 0 }}
 
-	// Relations
+	// Indexes
+{{- end }}
+{{- range .Indexes }}
+	{{ .Field }} string ` + "`{{ .Tag }}{{ if $.GormTableName }} gorm:\"-\"{{ end }}`" + `
 {{- end }}
+
+
+	// Relations
+	` + ModelRelationsBeginMarker + `
 {{- range .Relations }}
-	{{ .Table | ToGoIdentifier }} {{ .Type }} ` + "`{{ .Tag }}`" + `
+{{- if .Comment }}
+	// {{ .Comment }}
+{{- end }}
+{{- if .Nullable }}
+	// optional, nil when {{ if .ForeignKeys }}{{ range $i, $k := .ForeignKeys }}{{ if $i }}, {{ end }}{{ $k }}{{ end }}{{ else }}{{ .ForeignKey }}{{ end }} is null
+{{- end }}
+	{{ .Table | ToRelationIdentifier }} {{ .Type }} ` + "`{{ .Tag }}`" + `
+{{- end }}
+	` + ModelRelationsEndMarker + `
+}
+{{- if gt (len .Constants) 0 }}
+
+const (
+{{- range .Constants }}
+	{{ .Name }} = {{ .Value | printf "%q" }}
+{{- end }}
+)
+{{- end }}
+
+var modelColumns{{ .StructName }} = []raiden.ModelColumn{
+{{- range .ColumnMetadata }}
+	{Name: {{ .Name | printf "%q" }}, Field: {{ .Field | printf "%q" }}, PrimaryKey: {{ .PrimaryKey }}, Generated: {{ .Generated }}},
+{{- end }}
+}
+
+// ModelColumns return column metadata of {{ .StructName }}, returned slice must not be modified
+func ({{ .StructName }}) ModelColumns() []raiden.ModelColumn {
+	return modelColumns{{ .StructName }}
+}
+{{- if .SoftDelete }}
+
+// List{{ .StructName }}Filter return rest filter of {{ .StructName }} list, soft deleted row is excluded
+func List{{ .StructName }}Filter() url.Values {
+	values := url.Values{}
+	values.Set({{ .SoftDelete | printf "%q" }}, "is.null")
+	return values
+}
+{{- if .SoftDeleteKeys }}
+
+// Get{{ .StructName }}Filter return rest filter of single {{ .StructName }} by primary key, soft deleted row is excluded
+func Get{{ .StructName }}Filter(row {{ .StructName }}) url.Values {
+	values := List{{ .StructName }}Filter()
+{{- range .SoftDeleteKeys }}
+	values.Set({{ . | printf "%q" }}, fmt.Sprintf("eq.%v", row.{{ . | ToColumnIdentifier }}))
+{{- end }}
+	return values
+}
+{{- end }}
+{{- end }}
+{{- if .Pagination }}
+
+// {{ .StructName }}OrderColumn is column that can be used to order paginated {{ .StructName }} list
+type {{ .StructName }}OrderColumn string
+
+const (
+{{- range .Pagination.Columns }}
+	{{ $.StructName }}OrderBy{{ . | ToColumnIdentifier }} {{ $.StructName }}OrderColumn = {{ . | printf "%q" }}
+{{- end }}
+)
+
+// List{{ .StructName }}Query build rest query of paginated {{ .StructName }} list,
+// primary key is used as tie breaker of order column
+{{- if .SoftDelete }}, soft deleted row is excluded{{ end }}
+func List{{ .StructName }}Query(orderBy {{ .StructName }}OrderColumn, query raiden.PageQuery) (url.Values, error) {
+{{- if .SoftDelete }}
+	q, err := raiden.BuildPageQuery({{ .Pagination.PrimaryKey | printf "%q" }}, string(orderBy), query)
+	if err != nil {
+		return nil, err
+	}
+	q.Set({{ .SoftDelete | printf "%q" }}, "is.null")
+	return q, nil
+{{- else }}
+	return raiden.BuildPageQuery({{ .Pagination.PrimaryKey | printf "%q" }}, string(orderBy), query)
+{{- end }}
+}
+
+// New{{ .StructName }}Page create page and next cursor from rows that fetched with List{{ .StructName }}Query
+func New{{ .StructName }}Page(rows []{{ .StructName }}, orderBy {{ .StructName }}OrderColumn, query raiden.PageQuery) (raiden.Page[{{ .StructName }}], error) {
+	return raiden.NewPage(rows, query, func(row {{ .StructName }}) []any {
+		switch orderBy {
+{{- range slice .Pagination.Columns 1 }}
+		case {{ $.StructName }}OrderBy{{ . | ToColumnIdentifier }}:
+			return []any{row.{{ . | ToColumnIdentifier }}, row.{{ $.Pagination.PrimaryKey | ToColumnIdentifier }}}
+{{- end }}
+		}
+		return []any{row.{{ .Pagination.PrimaryKey | ToColumnIdentifier }}}
+	})
+}
+{{- end }}
+{{- if .BulkInsert }}
+
+// BulkInsert{{ .StructName }} build multi row insert statement of {{ .StructName }}, column that
+// generated or defaulted by database is not inserted and inserted primary key is returned
+func BulkInsert{{ .StructName }}(rows []{{ .StructName }}) ([]raiden.BulkInsertStatement, error) {
+	columns := []string{ {{- range $i, $c := .BulkInsert.Columns }}{{ if $i }}, {{ end }}{{ $c | printf "%q" }}{{ end -}} }
+	returning := []string{ {{- range $i, $c := .BulkInsert.Returning }}{{ if $i }}, {{ end }}{{ $c | printf "%q" }}{{ end -}} }
+	return raiden.BuildBulkInsert({{ .Schema | printf "%q" }}, {{ .BulkInsert.Table | printf "%q" }}, columns, returning, len(rows), func(i int) []any {
+		return []any{ {{- range $i, $c := .BulkInsert.Columns }}{{ if $i }}, {{ end }}rows[i].{{ $c | ToColumnIdentifier }}{{ end -}} }
+	})
+}
+{{- end }}
+{{- range .Upserts }}
+
+// {{ .Name }} build multi row upsert statement of {{ $.StructName }}, row that conflict on
+// {{ range $i, $c := .Conflict }}{{ if $i }}, {{ end }}{{ $c }}{{ end }} update the other inserted column
+func {{ .Name }}(rows []{{ $.StructName }}) ([]raiden.BulkInsertStatement, error) {
+	columns := []string{ {{- range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ $c | printf "%q" }}{{ end -}} }
+	conflict := []string{ {{- range $i, $c := .Conflict }}{{ if $i }}, {{ end }}{{ $c | printf "%q" }}{{ end -}} }
+	update := []string{ {{- range $i, $c := .Update }}{{ if $i }}, {{ end }}{{ $c | printf "%q" }}{{ end -}} }
+	returning := []string{ {{- range $i, $c := .Returning }}{{ if $i }}, {{ end }}{{ $c | printf "%q" }}{{ end -}} }
+{{- $table := .Table }}
+{{- range .DefaultedKeys }}
+	if err := raiden.CheckUpsertKey({{ $.Schema | printf "%q" }}, {{ $table | printf "%q" }}, {{ . | printf "%q" }}, len(rows), func(i int) any { return rows[i].{{ . | ToColumnIdentifier }} }); err != nil {
+		return nil, err
+	}
+{{- end }}
+	return raiden.BuildUpsert({{ $.Schema | printf "%q" }}, {{ .Table | printf "%q" }}, columns, conflict, update, returning, len(rows), func(i int) []any {
+		return []any{ {{- range $i, $c := .Columns }}{{ if $i }}, {{ end }}rows[i].{{ $c | ToColumnIdentifier }}{{ end -}} }
+	})
+}
+{{- end }}
+{{- with .VersionedUpdate }}
+
+// Update{{ $.StructName }} build update statement of {{ $.StructName }} by primary key that only applied when
+// {{ .Version }} is not changed since row is read, {{ .Version }} is incremented by the statement
+func Update{{ $.StructName }}(row {{ $.StructName }}) (raiden.VersionedUpdateStatement, error) {
+	columns := []string{ {{- range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ $c | printf "%q" }}{{ end -}} }
+	keys := []string{ {{- range $i, $c := .Keys }}{{ if $i }}, {{ end }}{{ $c | printf "%q" }}{{ end -}} }
+	values := []any{ {{- range $i, $c := .Columns }}{{ if $i }}, {{ end }}row.{{ $c | ToColumnIdentifier }}{{ end -}} }
+	keyValues := []any{ {{- range $i, $c := .Keys }}{{ if $i }}, {{ end }}row.{{ $c | ToColumnIdentifier }}{{ end -}} }
+	return raiden.BuildVersionedUpdate({{ $.Schema | printf "%q" }}, {{ .Table | printf "%q" }}, columns, values, keys, keyValues, {{ .Version | printf "%q" }}, row.{{ .Version | ToColumnIdentifier }})
+}
+{{- end }}
+{{- if .GormTableName }}
+
+// TableName return table name that used by gorm
+func ({{ .StructName }}) TableName() string {
+	return "{{ .GormTableName }}"
+}
+{{- end }}
+`
+	ModelViewTemplate = `{{- if .Generated }}// Code generated by raiden-cli; DO NOT EDIT.
+{{ end -}}
+package {{ .Package }}
+{{- if gt (len .Imports) 0 }}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{- end }}
+{{ range .Comments }}
+{{ . }}
+{{- end }}
+type {{ .StructName }} struct {
+	raiden.ModelBase
+{{- range .Columns }}
+{{- range .Comments }}
+	{{ . }}
+{{- end }}
+	{{ .Name | ToColumnIdentifier }} {{ .Type }} ` + "`{{ .Tag }}`" + `
+{{- end }}
+
+	// View information
+	Metadata string ` + "`json:\"-\" schema:\"{{ .Schema}}\"{{ if .TableName }} tableName:\"{{ .TableName }}\"{{ end }} view:\"true\"{{ if .Materialized }} materialized:\"true\"{{ end }}{{ if .GormTableName }} gorm:\"-\"{{ end }}`" + `
+}
+
+var modelColumns{{ .StructName }} = []raiden.ModelColumn{
+{{- range .ColumnMetadata }}
+	{Name: {{ .Name | printf "%q" }}, Field: {{ .Field | printf "%q" }}, PrimaryKey: {{ .PrimaryKey }}, Generated: {{ .Generated }}},
 {{- end }}
 }
+
+// ModelColumns return column metadata of {{ .StructName }}, returned slice must not be modified
+func ({{ .StructName }}) ModelColumns() []raiden.ModelColumn {
+	return modelColumns{{ .StructName }}
+}
+{{- if .GormTableName }}
+
+// TableName return view name that used by gorm
+func ({{ .StructName }}) TableName() string {
+	return "{{ .GormTableName }}"
+}
+{{- end }}
+`
+	ModelStubTemplate = `package {{ .Package }}
+
+// This file is created once by raiden-cli and never overwritten,
+// put custom method for {{ .StructName }} model in this file.
 `
 )
 
+// GenerateModels generate model of every table to internal/models, see GenerateModelsWithContext
 func GenerateModels(basePath string, tables []*GenerateModelInput, generateFn GenerateFn) (err error) {
+	return GenerateModelsWithContext(context.Background(), basePath, tables, generateFn, false)
+}
+
+// GenerateModelsWithContext generate model of every table to internal/models, all model
+// is generated to models_gen.go when single file is set, see GenerateModelsFile
+func GenerateModelsWithContext(ctx context.Context, basePath string, tables []*GenerateModelInput, generateFn GenerateFn, singleFile bool) (err error) {
 	folderPath := filepath.Join(basePath, ModelDir)
 	ModelLogger.Trace("create models folder if not exist", "path", folderPath)
 	if exist := utils.IsFolderExists(folderPath); !exist {
@@ -89,9 +485,48 @@ func GenerateModels(basePath string, tables []*GenerateModelInput, generateFn Ge
 		}
 	}
 
+	// model base is shared by model, so it only written once
+	var base *GenerateModelBase
+	for i := range tables {
+		if tables[i].Base != nil {
+			base = tables[i].Base
+			break
+		}
+	}
+
+	if base != nil {
+		if err := GenerateModelBaseFile(folderPath, base, generateFn); err != nil {
+			return err
+		}
+	}
+
+	if singleFile {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return GenerateModelsFile(folderPath, tables, generateFn)
+	}
+
 	for i := range tables {
+		// stop before write the next file when generate is cancelled
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		t := tables[i]
-		if err := GenerateModel(folderPath, t, generateFn); err != nil {
+
+		modelFolderPath := folderPath
+		if t.SchemaPackage {
+			modelFolderPath = filepath.Join(folderPath, ToSchemaPackageName(t.Table.Schema))
+			if exist := utils.IsFolderExists(modelFolderPath); !exist {
+				ModelLogger.Trace("create schema models folder", "path", modelFolderPath)
+				if err := utils.CreateFolder(modelFolderPath); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := GenerateModel(modelFolderPath, t, generateFn); err != nil {
 			return err
 		}
 	}
@@ -100,27 +535,84 @@ func GenerateModels(basePath string, tables []*GenerateModelInput, generateFn Ge
 }
 
 func GenerateModel(folderPath string, input *GenerateModelInput, generateFn GenerateFn) error {
+	nameTransformer := input.GetNameTransformer()
+
 	// define binding func
 	funcMaps := []template.FuncMap{
-		{"ToGoIdentifier": utils.SnakeCaseToPascalCase},
+		{"ToColumnIdentifier": nameTransformer.Column},
+		{"ToRelationIdentifier": nameTransformer.Relation},
 		{"ToSnakeCase": utils.ToSnakeCase},
 	}
 
+	modelTable := input.Table
+	if len(input.ExcludeColumns) > 0 {
+		modelTable.Columns = nil
+		for _, c := range input.Table.Columns {
+			if !input.IsExcludedColumn(c.Name) {
+				modelTable.Columns = append(modelTable.Columns, c)
+			}
+		}
+	}
+
+	// column of model base is declared in embedded model base
+	table := modelTable
+	if input.Base != nil {
+		table.Columns = nil
+		for _, c := range modelTable.Columns {
+			if !input.Base.HasColumn(c.Name) {
+				table.Columns = append(table.Columns, c)
+			}
+		}
+	}
+
 	// map column data
-	columns, importsPath := MapTableAttributes(input.Table)
+	columns, importsPath := mapTableAttributes(table, input.JsonTypes, input.TypeOverrides, input.CompositeTypes, input.JsonCase, input.UuidType)
+	if input.GormTags {
+		appendGormColumnTags(columns, table)
+	}
 	rlsTag := BuildRlsTag(input.Policies, input.Table.Name, supabase.RlsTypeModel)
 	raidenPath := "github.com/sev-2/raiden"
 	importsPath = append(importsPath, raidenPath)
 
+	packageName := input.GetModelsPackage()
+	modelsImportPath := input.GetModelsImportPath()
+	if input.SchemaPackage {
+		packageName = ToSchemaPackageName(input.Table.Schema)
+
+		// enum, domain and composite type is generated in models package
+		for i, c := range table.Columns {
+			if (len(c.Enums) == 0 && c.Domain == "" && getColumnCompositeType(c, input.CompositeTypes) == "") || i >= len(columns) {
+				continue
+			}
+
+			dataType := postgres.DataType(c.DataType)
+			if c.Domain != "" || dataType == postgres.UserDefinedType || dataType == postgres.ArrayType {
+				columns[i].Type = qualifyTypeName(columns[i].Type, input.GetModelsPackage())
+				importsPath = appendImportPath(importsPath, modelsImportPath)
+			}
+		}
+	}
+
 	// define file path
-	filePath := filepath.Join(folderPath, fmt.Sprintf("%s.%s", input.Table.Name, "go"))
+	fileName := input.GetFileName()
+	filePath := filepath.Join(folderPath, fmt.Sprintf("%s.%s", fileName, "go"))
+	if input.WithStub {
+		filePath = filepath.Join(folderPath, fmt.Sprintf("%s%s.%s", fileName, ModelGenFileSuffix, "go"))
+	}
 
 	// build relation tag
 	mapRelationName := make(map[string]bool)
+	mapRelationTarget := countRelationTarget(input.Relations)
 	relation := make([]state.Relation, 0)
 
 	for i := range input.Relations {
 		r := input.Relations[i]
+		r.Type = input.transformRelationType(r)
+
+		// table that refer to the same table more than once is named by foreign key
+		if r.RelationType != raiden.RelationTypeManyToMany && mapRelationTarget[getRelationTargetKey(r)] > 1 {
+			r.Table = GetForeignKeyRelationName(r.ForeignKey, r.Table, r.RelationType)
+		}
 
 		if r.RelationType == raiden.RelationTypeManyToMany {
 			key := fmt.Sprintf("%s_%s", input.Table.Name, r.Table)
@@ -132,21 +624,85 @@ func GenerateModel(folderPath string, input *GenerateModelInput, generateFn Gene
 			}
 		}
 
-		r.Tag = BuildJoinTag(&r)
+		r.Tag = buildJoinTag(&r, input.JsonCase)
+		if gormTag := buildGormJoinTag(&r); input.GormTags && gormTag != "" {
+			r.Tag += " " + gormTag
+		}
+
+		// relation to model in other schema refer to schema subpackage
+		if input.SchemaPackage && r.Schema != "" && r.Schema != input.Table.Schema {
+			relationPackage := ToSchemaPackageName(r.Schema)
+			r.Type = qualifyTypeName(r.Type, relationPackage)
+			importsPath = appendImportPath(importsPath, fmt.Sprintf("%s/%s", modelsImportPath, relationPackage))
+		}
 		relation = append(relation, r)
 	}
 
 	// set data
 	data := GenerateModelData{
-		Package:    "models",
+		Package:    packageName,
 		Imports:    importsPath,
-		StructName: utils.SnakeCaseToPascalCase(input.Table.Name),
+		StructName: input.GetStructName(),
 		Columns:    columns,
 		Schema:     input.Table.Schema,
 		RlsTag:     rlsTag,
 		RlsEnable:  input.Table.RLSEnabled,
 		RlsForced:  input.Table.RLSForced,
 		Relations:  relation,
+		Generated:  input.WithStub,
+		Comments:   toCommentLines(input.Table.Comment),
+
+		Partitioned: input.Table.IsPartitioned,
+		ReadOnly:    IsReadOnlyTable(input.Table),
+		Foreign:     input.Table.IsForeign,
+		SoftDelete:  input.GetSoftDeleteColumn(),
+
+		ExcludeColumns: strings.Join(input.ExcludeColumns, ","),
+	}
+	indexFieldColumns := columns
+	if input.Base != nil {
+		var importPath string
+		data.Base, importPath = getModelBaseType(input)
+		if importPath != "" {
+			data.Imports = appendImportPath(data.Imports, importPath)
+		}
+		indexFieldColumns = append(append([]GenerateModelColumn{}, columns...), input.Base.Columns...)
+	}
+	data.Indexes = buildModelIndexes(input.Table.Indexes, indexFieldColumns, relation, nameTransformer)
+	data.ColumnMetadata = buildModelColumnMetadata(indexFieldColumns, input.Table.PrimaryKeys, nameTransformer)
+	data.Constants = buildCheckConstants(data.StructName, columns, nameTransformer)
+
+	if data.StructName != utils.SnakeCaseToPascalCase(input.Table.Name) {
+		data.TableName = input.Table.Name
+	}
+
+	if input.GormTags {
+		data.GormTableName = fmt.Sprintf("%s.%s", input.Table.Schema, input.Table.Name)
+	}
+
+	if input.BulkInsertHelpers && !input.Table.IsView {
+		data.BulkInsert = buildModelBulkInsert(input.Table, indexFieldColumns)
+	}
+
+	if input.UpsertHelpers && !input.Table.IsView {
+		data.Upserts = buildModelUpserts(data.StructName, modelTable, indexFieldColumns, nameTransformer)
+	}
+
+	if input.VersionColumn != "" && !input.Table.IsView {
+		data.VersionedUpdate = buildModelVersionedUpdate(modelTable, indexFieldColumns, input.VersionColumn)
+	}
+
+	if data.SoftDelete != "" {
+		data.Imports = appendImportPath(data.Imports, "net/url")
+		if data.SoftDeleteKeys = getModelKeyColumns(modelTable, indexFieldColumns); len(data.SoftDeleteKeys) > 0 {
+			data.Imports = appendImportPath(data.Imports, "fmt")
+		}
+	}
+
+	if input.PaginationHelpers {
+		if data.Pagination = buildModelPagination(modelTable); data.Pagination != nil {
+			data.Imports = appendImportPath(data.Imports, "net/url")
+		}
 	}
 
 	// setup generate input param
@@ -158,12 +714,285 @@ func GenerateModel(folderPath string, input *GenerateModelInput, generateFn Gene
 		OutputPath:   filePath,
 	}
 
+	// view is read only, generated without access control and relation
+	if input.Table.IsView {
+		data.Materialized = input.Table.IsMaterialized
+		data.Relations = make([]state.Relation, 0)
+		generateInput.BindData = data
+		generateInput.Template = ModelViewTemplate
+		generateInput.TemplateName = "modelViewTemplate"
+	}
+
 	ModelLogger.Debug("generate model", "path", generateInput.OutputPath)
+	if err := generateFn(generateInput, nil); err != nil {
+		return err
+	}
+
+	if input.WithStub {
+		return GenerateModelStub(folderPath, packageName, data.StructName, fileName, generateFn)
+	}
+	return nil
+}
+
+// buildModelColumnMetadata build metadata of every generated column field
+func buildModelColumnMetadata(columns []GenerateModelColumn, primaryKeys []objects.PrimaryKey, nameTransformer NameTransformer) []raiden.ModelColumn {
+	mapPrimaryKey := make(map[string]bool)
+	for _, pk := range primaryKeys {
+		mapPrimaryKey[pk.Name] = true
+	}
+
+	metadata := make([]raiden.ModelColumn, 0, len(columns))
+	for _, c := range columns {
+		metadata = append(metadata, raiden.ModelColumn{
+			Name:       c.Name,
+			Field:      nameTransformer.Column(c.Name),
+			PrimaryKey: mapPrimaryKey[c.Name],
+			Generated:  c.IsIdentity || c.IsGenerated,
+		})
+	}
+	return metadata
+}
+
+// buildCheckConstants build constant for every allowed value of column check constraint,
+// constant is prefixed with struct and column name, example : OrdersStatusPending
+func buildCheckConstants(structName string, columns []GenerateModelColumn, nameTransformer NameTransformer) (constants []GenerateEnumValue) {
+	mapName := make(map[string]bool)
+	for _, c := range columns {
+		for _, v := range c.CheckValues {
+			valueIdentifier := toEnumIdentifier(v)
+			if valueIdentifier == "" {
+				continue
+			}
+
+			name := structName + nameTransformer.Column(c.Name) + valueIdentifier
+			if mapName[name] {
+				continue
+			}
+			mapName[name] = true
+			constants = append(constants, GenerateEnumValue{Name: name, Value: v})
+		}
+	}
+	return
+}
+
+// buildModelIndexes build index declaration field, field that conflict
+// with column or relation field is suffixed with Index
+func buildModelIndexes(indexes []objects.TableIndex, columns []GenerateModelColumn, relations []state.Relation, nameTransformer NameTransformer) (result []GenerateModelIndex) {
+	mapField := map[string]bool{"Metadata": true, "Acl": true}
+	for _, c := range columns {
+		mapField[nameTransformer.Column(c.Name)] = true
+	}
+
+	for _, r := range relations {
+		mapField[nameTransformer.Relation(r.Table)] = true
+	}
+
+	for _, idx := range indexes {
+		if idx.Name == "" || len(idx.Columns) == 0 {
+			continue
+		}
+
+		field := utils.SnakeCaseToPascalCase(nonAlphanumericRegex.ReplaceAllString(idx.Name, "_"))
+		if mapField[field] {
+			field += "Index"
+		}
+		mapField[field] = true
+
+		result = append(result, GenerateModelIndex{Field: field, Tag: BuildIndexTag(idx)})
+	}
+	return
+}
+
+// BuildIndexTag build struct tag of index declaration, btree method is not written
+func BuildIndexTag(idx objects.TableIndex) string {
+	indexTags := []string{fmt.Sprintf("name:%s", idx.Name)}
+	if method := idx.GetMethod(); method != "btree" {
+		indexTags = append(indexTags, "method:"+method)
+	}
+
+	if idx.IsUnique {
+		indexTags = append(indexTags, "unique")
+	}
+
+	tags := []string{
+		`json:"-"`,
+		fmt.Sprintf("index:%q", strings.Join(indexTags, ";")),
+		fmt.Sprintf("indexColumns:%q", strings.Join(idx.Columns, ", ")),
+	}
+
+	if idx.Predicate != "" {
+		tags = append(tags, fmt.Sprintf("indexWhere:%q", idx.Predicate))
+	}
+	return strings.Join(tags, " ")
+}
+
+// IsReadOnlyTable check if table can only be selected, table without primary key
+// can't be targeted by update and delete and foreign table is managed by foreign server
+func IsReadOnlyTable(table objects.Table) bool {
+	return table.IsForeign || (!table.IsView && len(table.PrimaryKeys) == 0)
+}
+
+// GetNameTransformer return configured name transformer or default transformer
+func (input *GenerateModelInput) GetNameTransformer() NameTransformer {
+	if input.NameTransformer == nil {
+		return DefaultNameTransformer{}
+	}
+	return input.NameTransformer
+}
+
+// GetProjectPackage return module path and models package of project that model generated to
+func (input *GenerateModelInput) GetProjectPackage() ProjectPackage {
+	return ProjectPackage{
+		ProjectName:   input.ProjectName,
+		ModulePath:    input.ModulePath,
+		ModelsPackage: input.ModelsPackage,
+	}
+}
+
+// GetModelsPackage return package name of generated model, models is used when not set
+func (input *GenerateModelInput) GetModelsPackage() string {
+	return input.GetProjectPackage().GetModelsPackage()
+}
+
+// GetModelsImportPath return import path of models folder, see ProjectPackage.GetModulePath
+func (input *GenerateModelInput) GetModelsImportPath() string {
+	return input.GetProjectPackage().GetModelsImportPath()
+}
+
+// GetModelType return model type qualified with package name and import path of the package,
+// model in schema subpackage is placed in internal/models/<schema>
+func (input *GenerateModelInput) GetModelType() (modelType string, importPath string) {
+	packageName, importPath := input.GetModelsPackage(), input.GetModelsImportPath()
+	if input.SchemaPackage {
+		packageName = ToSchemaPackageName(input.Table.Schema)
+		importPath = fmt.Sprintf("%s/%s", importPath, packageName)
+	}
+	return qualifyTypeName(input.GetStructName(), packageName), importPath
+}
+
+// GetSoftDeleteColumn return configured soft delete column when table have the column,
+// view is read only so view is never flagged as soft deleted
+func (input *GenerateModelInput) GetSoftDeleteColumn() string {
+	if input.SoftDeleteColumn == "" || input.Table.IsView {
+		return ""
+	}
+
+	for _, c := range input.Table.Columns {
+		if c.Name == input.SoftDeleteColumn {
+			return c.Name
+		}
+	}
+	return ""
+}
+
+// IsExcludedColumn check if column is omitted from generated struct
+func (input *GenerateModelInput) IsExcludedColumn(name string) bool {
+	for _, c := range input.ExcludeColumns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStructName return go struct name of generated model
+func (input *GenerateModelInput) GetStructName() string {
+	return input.getTableStructName(input.Table.Schema, input.Table.Name)
+}
+
+// GetFileName return generated model file name without extension, table name is used
+// unless struct name is overridden for avoid conflict with table in other schema
+func (input *GenerateModelInput) GetFileName() string {
+	if _, exist := input.StructNames[fmt.Sprintf("%s.%s", input.Table.Schema, input.Table.Name)]; exist {
+		return utils.ToSnakeCase(input.GetStructName())
+	}
+	return input.Table.Name
+}
+
+func (input *GenerateModelInput) getTableStructName(schema, name string) string {
+	if structName, exist := input.StructNames[fmt.Sprintf("%s.%s", schema, name)]; exist {
+		return structName
+	}
+	return input.GetNameTransformer().Table(name)
+}
+
+// transformRelationType apply table name transformer to relation type, relation type is
+// build from related table name (or model table name for self relation) as pascal case
+func (input *GenerateModelInput) transformRelationType(r state.Relation) string {
+	typePrefix := r.Type[:len(r.Type)-len(strings.TrimLeft(r.Type, "[]*"))]
+	typeName := strings.TrimPrefix(r.Type, typePrefix)
+
+	schema := r.Schema
+	if schema == "" {
+		schema = input.Table.Schema
+	}
+
+	switch typeName {
+	case utils.SnakeCaseToPascalCase(r.Table):
+		return typePrefix + input.getTableStructName(schema, r.Table)
+	case utils.SnakeCaseToPascalCase(input.Table.Name):
+		return typePrefix + input.GetStructName()
+	default:
+		return r.Type
+	}
+}
+
+// GenerateModelStub create <table>.go file for custom model method if not exist,
+// file generated with previous layout (contain model struct) is replaced with stub
+// for avoid redeclared model struct in <table>_gen.go
+func GenerateModelStub(folderPath string, packageName string, structName string, fileName string, generateFn GenerateFn) error {
+	filePath := filepath.Join(folderPath, fmt.Sprintf("%s.%s", fileName, "go"))
+	if utils.IsFileExists(filePath) {
+		declaredStruct, err := getStructByBaseName(filePath, "ModelBase")
+		if err != nil {
+			return err
+		}
+
+		if !utils.Contains(declaredStruct, structName) {
+			ModelLogger.Trace("skip generate model stub, file already exist", "path", filePath)
+			return nil
+		}
+	}
+
+	generateInput := GenerateInput{
+		BindData: GenerateModelStubData{
+			Package:    packageName,
+			StructName: structName,
+		},
+		Template:     ModelStubTemplate,
+		TemplateName: "modelStubTemplate",
+		OutputPath:   filePath,
+	}
+
+	ModelLogger.Debug("generate model stub", "path", generateInput.OutputPath)
 	return generateFn(generateInput, nil)
 }
 
+// ToSchemaPackageName convert schema name to go package name
+func ToSchemaPackageName(schema string) string {
+	return strings.ToLower(nonAlphanumericRegex.ReplaceAllString(schema, ""))
+}
+
+// qualifyTypeName add package name to type and keep slice / pointer prefix,
+// example : []*Users will be []*auth.Users
+func qualifyTypeName(typeName string, packageName string) string {
+	name := strings.TrimLeft(typeName, "[]*")
+	return typeName[:len(typeName)-len(name)] + packageName + "." + name
+}
+
+func appendImportPath(importsPath []string, path string) []string {
+	if utils.Contains(importsPath, path) {
+		return importsPath
+	}
+	return append(importsPath, path)
+}
+
 // map table to column, map pg type to go type and get dependency import path
 func MapTableAttributes(table objects.Table) (columns []GenerateModelColumn, importsPath []string) {
+	return mapTableAttributes(table, nil, nil, nil, JsonCaseSnake, UuidTypeUuid)
+}
+
+func mapTableAttributes(table objects.Table, jsonTypes map[string]string, typeOverrides map[string]string, compositeTypes map[string]string, jsonCase JsonCase, uuidType UuidType) (columns []GenerateModelColumn, importsPath []string) {
 	importsMap := make(map[string]any)
 	mapPrimaryKey := map[string]bool{}
 	for _, k := range table.PrimaryKeys {
@@ -171,29 +1000,81 @@ func MapTableAttributes(table objects.Table) (columns []GenerateModelColumn, imp
 	}
 
 	for _, c := range table.Columns {
+		compositeType := getColumnCompositeType(c, compositeTypes)
+		tag := buildColumnTag(c, mapPrimaryKey, compositeType, jsonCase)
+		if table.IsView {
+			tag = buildViewColumnTag(c, jsonCase)
+		}
+
 		column := GenerateModelColumn{
-			Name: c.Name,
-			Tag:  buildColumnTag(c, mapPrimaryKey),
-			Type: postgres.ToGoType(postgres.DataType(c.DataType), c.IsNullable),
+			Name:        c.Name,
+			Tag:         tag,
+			IsIdentity:  c.IsIdentity,
+			IsGenerated: c.IsGenerated,
+			Comments:    toCommentLines(c.Comment),
+			HasDefault:  !table.IsView && hasColumnDefault(c),
+		}
+
+		// zero value of non pointer field is sent as is and override database default
+		isPointer := c.IsNullable || column.HasDefault
+		column.Type = postgres.ToGoType(postgres.DataType(c.DataType), isPointer)
+
+		if values, isEnumCheck := ParseCheckInValues(c.Name, c.GetCheck()); isEnumCheck && !table.IsView {
+			column.CheckValues = values
+		}
+
+		if overrideType, importPath, isOverride := getTypeOverride(c, typeOverrides, isPointer); isOverride {
+			column.Type = overrideType
+			if importPath != "" {
+				importsMap[importPath] = true
+			}
+			columns = append(columns, column)
+			continue
 		}
 
-		splitType := strings.Split(column.Type, ".")
-		if len(splitType) > 1 {
-			importPackage := splitType[0]
-			if c.IsNullable {
-				importPackage = strings.TrimLeft(importPackage, "*")
+		switch postgres.DataType(c.DataType) {
+		case postgres.UserDefinedType:
+			// column backed by enum is typed as generated enum type
+			if len(c.Enums) > 0 {
+				column.Type = toEnumIdentifier(c.Format)
+				if isPointer {
+					column.Type = "*" + column.Type
+				}
 			}
 
-			var importPackageName string
-			switch importPackage {
-			case "time":
-				importPackageName = importPackage
-			case "uuid":
-				importPackageName = "github.com/google/uuid"
-			case "json":
-				importPackageName = "encoding/json"
+			// column backed by composite type is typed as generated composite struct
+			if compositeType != "" {
+				column.Type = toEnumIdentifier(c.Format)
+				if isPointer {
+					column.Type = "*" + column.Type
+				}
+			}
+		case postgres.JsonType, postgres.JsonbType:
+			if jsonType, exist := jsonTypes[c.Name]; exist && jsonType != "" {
+				column.Type = "*" + jsonType
+			}
+		case postgres.UuidType:
+			if uuidType == UuidTypeString {
+				column.Type = postgres.ToGoType(postgres.TextType, isPointer)
+			}
+		case postgres.ArrayType:
+			// nil slice already represent null value, so array is not generated as pointer
+			column.Type = postgres.ToGoArrayType(c.Format)
+			if len(c.Enums) > 0 {
+				column.Type = "[]" + toEnumIdentifier(postgres.GetArrayElementType(c.Format))
 			}
-			importsMap[importPackageName] = true
+		}
+
+		// column backed by domain is typed as generated domain type
+		if c.Domain != "" {
+			column.Type = toEnumIdentifier(c.Domain)
+			if isPointer {
+				column.Type = "*" + column.Type
+			}
+		}
+
+		if importPath := getGoTypeImportPath(column.Type); importPath != "" {
+			importsMap[importPath] = true
 		}
 
 		columns = append(columns, column)
@@ -202,15 +1083,103 @@ func MapTableAttributes(table objects.Table) (columns []GenerateModelColumn, imp
 	for key := range importsMap {
 		importsPath = append(importsPath, key)
 	}
+	sort.Strings(importsPath)
 
 	return
 }
 
-func buildColumnTag(c objects.Column, mapPk map[string]bool) string {
+// getColumnCompositeType return composite type of column in format schema.name, composite
+// types is keyed by type name because column only have information about type name
+func getColumnCompositeType(c objects.Column, compositeTypes map[string]string) string {
+	if postgres.DataType(c.DataType) != postgres.UserDefinedType || len(c.Enums) > 0 {
+		return ""
+	}
+	return compositeTypes[c.Format]
+}
+
+// getGoTypeImportPath return import path of package used by go type, raiden is not returned
+// because it is always imported by model, so element type is checked for range (e.g raiden.Range[time.Time])
+func getGoTypeImportPath(goType string) string {
+	goType = strings.TrimLeft(goType, "*[]")
+	if elemType, isRange := strings.CutPrefix(goType, "raiden.Range["); isRange {
+		goType = strings.TrimSuffix(elemType, "]")
+	}
+
+	pkg, _, found := strings.Cut(goType, ".")
+	if !found {
+		return ""
+	}
+
+	switch pkg {
+	case "time":
+		return "time"
+	case "uuid":
+		return "github.com/google/uuid"
+	case "json":
+		return "encoding/json"
+	}
+	return ""
+}
+
+// toCommentLines convert table or column comment to go comment lines
+func toCommentLines(comment any) (lines []string) {
+	commentStr, isString := comment.(string)
+	if !isString || strings.TrimSpace(commentStr) == "" {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(commentStr), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			lines = append(lines, "//")
+			continue
+		}
+		lines = append(lines, "// "+line)
+	}
+	return
+}
+
+func buildColumnTypeTag(c objects.Column) string {
+	// serial column is declared as serial so sequence is created with the column on apply
+	if serialType := c.GetSerialType(); serialType != "" {
+		return "type:" + serialType
+	}
+
+	if postgres.IsValidDataType(c.DataType) {
+		pdType := postgres.GetPgDataTypeName(postgres.DataType(c.DataType), true)
+		return "type:" + string(pdType) + c.GetTypeModifier()
+	}
+
+	if postgres.DataType(c.DataType) == postgres.ArrayType {
+		return "type:" + postgres.GetArrayElementType(c.Format) + "[]"
+	}
+	return ""
+}
+
+// buildViewColumnTag only contain information for read the column,
+// view column can't be inserted, updated or migrated
+func buildViewColumnTag(c objects.Column, jsonCase JsonCase) string {
+	columnTags := []string{
+		fmt.Sprintf("name:%s", c.Name),
+	}
+
+	if typeTag := buildColumnTypeTag(c); typeTag != "" {
+		columnTags = append(columnTags, typeTag)
+	}
+
+	if c.IsNullable {
+		columnTags = append(columnTags, "nullable")
+	}
+
+	jsonTag := fmt.Sprintf("json:%q", ToJsonKey(c.Name, jsonCase)+",omitempty")
+	return fmt.Sprintf("%s column:%q", jsonTag, strings.Join(columnTags, ";"))
+}
+
+func buildColumnTag(c objects.Column, mapPk map[string]bool, compositeType string, jsonCase JsonCase) string {
 	var tags []string
 
 	// append json tag
-	jsonTag := fmt.Sprintf("json:%q", utils.ToSnakeCase(c.Name)+",omitempty")
+	jsonTag := fmt.Sprintf("json:%q", ToJsonKey(c.Name, jsonCase)+",omitempty")
 	tags = append(tags, jsonTag)
 
 	// append column tag
@@ -218,9 +1187,16 @@ func buildColumnTag(c objects.Column, mapPk map[string]bool) string {
 		fmt.Sprintf("name:%s", c.Name),
 	}
 
-	if postgres.IsValidDataType(c.DataType) {
-		pdType := postgres.GetPgDataTypeName(postgres.DataType(c.DataType), true)
-		columnTags = append(columnTags, "type:"+string(pdType))
+	if typeTag := buildColumnTypeTag(c); typeTag != "" {
+		columnTags = append(columnTags, typeTag)
+	}
+
+	if domain := c.GetDomain(); domain != "" {
+		columnTags = append(columnTags, "domain:"+domain)
+	}
+
+	if compositeType != "" {
+		columnTags = append(columnTags, "composite:"+compositeType)
 	}
 
 	_, exist := mapPk[c.Name]
@@ -234,16 +1210,25 @@ func buildColumnTag(c objects.Column, mapPk map[string]bool) string {
 		}
 	}
 
+	// generated column and `GENERATED ALWAYS AS IDENTITY` column is not writable,
+	// `GENERATED BY DEFAULT AS IDENTITY` column still accept user value
+	if isReadOnlyColumn(c) {
+		columnTags = append(columnTags, "readOnly")
+	}
+
 	if c.IsNullable {
 		columnTags = append(columnTags, "nullable")
 	} else {
 		columnTags = append(columnTags, "nullable:false")
 	}
 
-	if c.DefaultValue != "" {
-		defaultStr, isString := c.DefaultValue.(string)
-		if isString {
-			columnTags = append(columnTags, "default:"+defaultStr)
+	// default is written as is, so boolean and timestamp default (e.g true and now()) is applied back unchanged
+	if defaultStr := getColumnDefault(c); defaultStr != "" {
+		columnTags = append(columnTags, "default:"+defaultStr)
+
+		// random uuid is filled by database, so key can be omitted on insert
+		if postgres.DataType(c.DataType) == postgres.UuidType && postgres.IsUuidGenerateFunc(defaultStr) {
+			columnTags = append(columnTags, "serverDefault")
 		}
 	}
 
@@ -253,15 +1238,367 @@ func buildColumnTag(c objects.Column, mapPk map[string]bool) string {
 
 	tags = append(tags, fmt.Sprintf("column:%q", strings.Join(columnTags, ";")))
 
+	// check expression may contain `;` and `:`, so it is written as separate tag
+	if check := c.GetCheck(); check != "" {
+		tags = append(tags, fmt.Sprintf("check:%q", check))
+
+		if values, isEnumCheck := ParseCheckInValues(c.Name, check); isEnumCheck {
+			if validateTag := buildCheckValidateTag(values); validateTag != "" {
+				tags = append(tags, validateTag)
+			}
+		}
+	}
+
+	if grantTag := buildColumnGrantTag(c); grantTag != "" {
+		tags = append(tags, fmt.Sprintf("grant:%q", grantTag))
+	}
+
 	return strings.Join(tags, " ")
 }
 
+// buildColumnGrantTag build column privilege grouped by privilege type,
+// example : select:anon,authenticated;update:authenticated
+func buildColumnGrantTag(c objects.Column) string {
+	var privilegeTypes []string
+	mapGrantee := make(map[string][]string)
+	for _, p := range c.GetPrivileges() {
+		privilegeType := strings.ToLower(p.PrivilegeType)
+		if _, exist := mapGrantee[privilegeType]; !exist {
+			privilegeTypes = append(privilegeTypes, privilegeType)
+		}
+		mapGrantee[privilegeType] = append(mapGrantee[privilegeType], p.Grantee)
+	}
+
+	grants := make([]string, 0, len(privilegeTypes))
+	for _, pt := range privilegeTypes {
+		grants = append(grants, fmt.Sprintf("%s:%s", pt, strings.Join(mapGrantee[pt], ",")))
+	}
+	return strings.Join(grants, ";")
+}
+
+// hasColumnDefault check if column value is filled by database when not set on insert
+func hasColumnDefault(c objects.Column) bool {
+	if c.IsGenerated {
+		return false
+	}
+
+	return getColumnDefault(c) != ""
+}
+
+// getColumnDefault return default expression of column, empty when column doesn't have default
+func getColumnDefault(c objects.Column) string {
+	switch v := c.DefaultValue.(type) {
+	case string:
+		return v
+	case *string:
+		if v != nil {
+			return *v
+		}
+	}
+	return ""
+}
+
+func isReadOnlyColumn(c objects.Column) bool {
+	if c.IsGenerated {
+		return true
+	}
+
+	identityStr, isString := c.IdentityGeneration.(string)
+	return isString && strings.EqualFold(identityStr, "ALWAYS")
+}
+
+func getRelationTargetKey(r state.Relation) string {
+	return fmt.Sprintf("%s.%s", r.Schema, r.Table)
+}
+
+// countRelationTarget count relation to the same table, many to many relation
+// is not counted because it already named by pivot table when conflict
+func countRelationTarget(relations []state.Relation) map[string]int {
+	mapCount := make(map[string]int)
+	for _, r := range relations {
+		if r.RelationType != raiden.RelationTypeManyToMany {
+			mapCount[getRelationTargetKey(r)]++
+		}
+	}
+	return mapCount
+}
+
+// mapForeignKeyRelationVerb is past form of common foreign key name,
+// used for name has many relation from the side of referred table
+var mapForeignKeyRelationVerb = map[string]string{
+	"sender":    "sent",
+	"recipient": "received",
+	"receiver":  "received",
+}
+
+// GetForeignKeyRelationName derive relation name from foreign key column when table refer to
+// the same table more than once, example : messages.sender_id -> users will produce `sender`
+// for belongs to and `sent_messages` for has many relation in users
+func GetForeignKeyRelationName(foreignKey string, table string, relationType raiden.RelationType) string {
+	name := strings.TrimSuffix(foreignKey, "_id")
+	if name == foreignKey {
+		// avoid conflict with column field name
+		name += "_ref"
+	}
+
+	if relationType == raiden.RelationTypeBelongsTo {
+		return name
+	}
+
+	if verb, exist := mapForeignKeyRelationVerb[name]; exist {
+		name = verb
+	}
+	return fmt.Sprintf("%s_%s", name, table)
+}
+
+// buildModelPagination return orderable column of paginated list, keyset pagination need single
+// column primary key as tie breaker and not null order column, so nil is returned for view and
+// table with composite primary key
+func buildModelPagination(table objects.Table) *GenerateModelPagination {
+	if table.IsView || len(table.PrimaryKeys) != 1 {
+		return nil
+	}
+
+	pagination := &GenerateModelPagination{PrimaryKey: table.PrimaryKeys[0].Name}
+	pagination.Columns = append(pagination.Columns, pagination.PrimaryKey)
+	for _, c := range table.Columns {
+		if c.Name == pagination.PrimaryKey || c.IsNullable {
+			continue
+		}
+
+		switch postgres.DataType(c.DataType) {
+		case postgres.JsonType, postgres.JsonbType, postgres.ArrayType:
+			continue
+		}
+		pagination.Columns = append(pagination.Columns, c.Name)
+	}
+	return pagination
+}
+
+// buildModelBulkInsert return nil when every column is generated or defaulted by database
+func buildModelBulkInsert(table objects.Table, columns []GenerateModelColumn) *GenerateModelBulkInsert {
+	bulkInsert := &GenerateModelBulkInsert{Table: table.Name}
+	for _, c := range columns {
+		if c.IsIdentity || c.IsGenerated || c.HasDefault {
+			continue
+		}
+		bulkInsert.Columns = append(bulkInsert.Columns, c.Name)
+	}
+
+	if len(bulkInsert.Columns) == 0 {
+		return nil
+	}
+
+	for _, pk := range table.PrimaryKeys {
+		bulkInsert.Returning = append(bulkInsert.Returning, pk.Name)
+	}
+	return bulkInsert
+}
+
+// buildModelUpserts build upsert of primary key and then unique constraint ordered by name, constraint
+// that has column not declared in model or column that always generated by database is skipped
+func buildModelUpserts(structName string, table objects.Table, columns []GenerateModelColumn, nameTransformer NameTransformer) (upserts []GenerateModelUpsert) {
+	mapColumn := make(map[string]GenerateModelColumn, len(columns))
+	for _, c := range columns {
+		mapColumn[c.Name] = c
+	}
+
+	mapAlwaysIdentity := make(map[string]bool)
+	for _, c := range table.Columns {
+		if c.IsIdentity && fmt.Sprint(c.IdentityGeneration) == "ALWAYS" {
+			mapAlwaysIdentity[c.Name] = true
+		}
+	}
+
+	var primaryKeys, returning []string
+	for _, pk := range table.PrimaryKeys {
+		primaryKeys = append(primaryKeys, pk.Name)
+		returning = append(returning, pk.Name)
+	}
+
+	uniqueIndexes := make([]objects.TableUniqueIndex, len(table.UniqueIndexes))
+	copy(uniqueIndexes, table.UniqueIndexes)
+	sort.SliceStable(uniqueIndexes, func(i, j int) bool { return uniqueIndexes[i].Name < uniqueIndexes[j].Name })
+
+	// primary key is reported as unique index too
+	targets := [][]string{primaryKeys}
+	for _, idx := range uniqueIndexes {
+		targets = append(targets, idx.Columns)
+	}
+
+	mapTarget := make(map[string]bool)
+	for _, conflict := range targets {
+		key := strings.Join(conflict, ",")
+		if len(conflict) == 0 || mapTarget[key] {
+			continue
+		}
+		mapTarget[key] = true
+
+		mapConflict := make(map[string]bool)
+		isValid := true
+		for _, c := range conflict {
+			if column, exist := mapColumn[c]; !exist || column.IsGenerated || mapAlwaysIdentity[c] {
+				isValid = false
+				break
+			}
+			mapConflict[c] = true
+		}
+		if !isValid {
+			continue
+		}
+
+		upsert := GenerateModelUpsert{Table: table.Name, Conflict: conflict, Returning: returning}
+		for _, c := range columns {
+			if !mapConflict[c.Name] && (c.IsIdentity || c.IsGenerated || c.HasDefault) {
+				continue
+			}
+
+			upsert.Columns = append(upsert.Columns, c.Name)
+			if !mapConflict[c.Name] {
+				upsert.Update = append(upsert.Update, c.Name)
+			} else if (c.IsIdentity || c.HasDefault) && strings.HasPrefix(c.Type, "*") {
+				upsert.DefaultedKeys = append(upsert.DefaultedKeys, c.Name)
+			}
+		}
+
+		identifiers := make([]string, 0, len(conflict))
+		for _, c := range conflict {
+			identifiers = append(identifiers, nameTransformer.Column(c))
+		}
+		upsert.Name = fmt.Sprintf("Upsert%sBy%s", structName, strings.Join(identifiers, "And"))
+		upserts = append(upserts, upsert)
+	}
+	return
+}
+
+// getModelKeyColumns return primary key of table, nil is returned when one of primary key is not generated
+func getModelKeyColumns(table objects.Table, columns []GenerateModelColumn) (keys []string) {
+	mapColumn := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		mapColumn[c.Name] = true
+	}
+
+	for _, pk := range table.PrimaryKeys {
+		if !mapColumn[pk.Name] {
+			return nil
+		}
+		keys = append(keys, pk.Name)
+	}
+	return
+}
+
+// buildModelVersionedUpdate return nil when table doesn't have version column or primary key,
+// primary key, version and column that always generated by database is not updated
+func buildModelVersionedUpdate(table objects.Table, columns []GenerateModelColumn, version string) *GenerateModelVersionedUpdate {
+	mapColumn := make(map[string]GenerateModelColumn, len(columns))
+	for _, c := range columns {
+		mapColumn[c.Name] = c
+	}
+
+	if _, exist := mapColumn[version]; !exist || len(table.PrimaryKeys) == 0 {
+		return nil
+	}
+
+	mapAlwaysIdentity := make(map[string]bool)
+	for _, c := range table.Columns {
+		if c.IsIdentity && fmt.Sprint(c.IdentityGeneration) == "ALWAYS" {
+			mapAlwaysIdentity[c.Name] = true
+		}
+	}
+
+	update := &GenerateModelVersionedUpdate{Table: table.Name, Version: version}
+	mapKey := make(map[string]bool, len(table.PrimaryKeys))
+	for _, pk := range table.PrimaryKeys {
+		if _, exist := mapColumn[pk.Name]; !exist {
+			return nil
+		}
+		mapKey[pk.Name] = true
+		update.Keys = append(update.Keys, pk.Name)
+	}
+
+	for _, c := range columns {
+		if mapKey[c.Name] || c.Name == version || c.IsGenerated || mapAlwaysIdentity[c.Name] {
+			continue
+		}
+		update.Columns = append(update.Columns, c.Name)
+	}
+	return update
+}
+
+// appendGormColumnTags add gorm tag to mapped columns, columns is mapped
+// from table columns in the same order
+func appendGormColumnTags(columns []GenerateModelColumn, table objects.Table) {
+	mapPrimaryKey := map[string]bool{}
+	for _, k := range table.PrimaryKeys {
+		mapPrimaryKey[k.Name] = true
+	}
+
+	for i, c := range table.Columns {
+		if i >= len(columns) {
+			break
+		}
+
+		tags := []string{"column:" + c.Name}
+		if mapPrimaryKey[c.Name] {
+			tags = append(tags, "primaryKey")
+		}
+
+		if identityStr, isString := c.IdentityGeneration.(string); isString && len(identityStr) > 0 {
+			tags = append(tags, "autoIncrement")
+		}
+
+		// let database fill the default value, gorm doesn't send nil value of column that have default
+		if columns[i].HasDefault {
+			tags = append(tags, "default:(-)")
+		}
+
+		if table.IsView || isReadOnlyColumn(c) {
+			tags = append(tags, "->")
+		}
+		columns[i].Tag += fmt.Sprintf(" gorm:%q", strings.Join(tags, ";"))
+	}
+}
+
+// buildGormJoinTag build gorm association tag of relation, foreign key and references
+// is written as column name that resolved by gorm to model field
+func buildGormJoinTag(r *state.Relation) string {
+	var tags []string
+	if r.RelationType == raiden.RelationTypeManyToMany {
+		if r.JoinRelation == nil {
+			return ""
+		}
+
+		tags = append(tags,
+			"many2many:"+r.Through,
+			"foreignKey:"+r.SourcePrimaryKey,
+			"joinForeignKey:"+r.JoinsSourceForeignKey,
+			"references:"+r.TargetPrimaryKey,
+			"joinReferences:"+r.JoinTargetForeignKey,
+		)
+		return fmt.Sprintf("gorm:%q", strings.Join(tags, ";"))
+	}
+
+	foreignKey, references := r.ForeignKey, r.PrimaryKey
+	if len(r.PrimaryKeys) > 1 || len(r.ForeignKeys) > 1 {
+		foreignKey, references = strings.Join(r.ForeignKeys, ","), strings.Join(r.PrimaryKeys, ",")
+	}
+
+	if foreignKey == "" || references == "" {
+		return ""
+	}
+	return fmt.Sprintf("gorm:\"foreignKey:%s;references:%s\"", foreignKey, references)
+}
+
 func BuildJoinTag(r *state.Relation) string {
+	return buildJoinTag(r, JsonCaseSnake)
+}
+
+func buildJoinTag(r *state.Relation, jsonCase JsonCase) string {
 	var tags []string
 	var joinTags []string
 
 	// append json tag
-	jsonTag := fmt.Sprintf("json:%q", utils.ToSnakeCase(r.Table)+",omitempty")
+	jsonTag := fmt.Sprintf("json:%q", ToJsonKey(r.Table, jsonCase)+",omitempty")
 	tags = append(tags, jsonTag)
 
 	// append relation type tag
@@ -280,6 +1617,13 @@ func BuildJoinTag(r *state.Relation) string {
 		joinTags = append(joinTags, fk)
 	}
 
+	// append composite key tag
+	if len(r.PrimaryKeys) > 1 || len(r.ForeignKeys) > 1 {
+		pks := fmt.Sprintf("primaryKeys:%s", strings.Join(r.PrimaryKeys, ","))
+		fks := fmt.Sprintf("foreignKeys:%s", strings.Join(r.ForeignKeys, ","))
+		joinTags = append(joinTags, pks, fks)
+	}
+
 	if r.RelationType == raiden.RelationTypeManyToMany && r.JoinRelation != nil {
 		th := fmt.Sprintf("through:%s", r.Through)
 		joinTags = append(joinTags, th)
@@ -297,7 +1641,7 @@ func BuildJoinTag(r *state.Relation) string {
 		joinTags = append(joinTags, tpk)
 
 		// append join target foreign key
-		jtpk := fmt.Sprintf("targetForeign:%s", r.JoinsSourceForeignKey)
+		jtpk := fmt.Sprintf("targetForeign:%s", r.JoinTargetForeignKey)
 		joinTags = append(joinTags, jtpk)
 	}
 	tags = append(tags, fmt.Sprintf("join:%q", strings.Join(joinTags, ";")))
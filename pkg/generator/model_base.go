@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"path/filepath"
+	"text/template"
+)
+
+// ----- Define type, variable and constant -----
+type (
+	// GenerateModelBase is struct that contain shared column, model that has
+	// all the column embed this struct instead of declare the column
+	GenerateModelBase struct {
+		StructName      string
+		Columns         []GenerateModelColumn
+		Imports         []string
+		NameTransformer NameTransformer
+		Package         string
+	}
+
+	GenerateModelBaseData struct {
+		Package    string
+		Imports    []string
+		StructName string
+		Columns    []GenerateModelColumn
+	}
+)
+
+const (
+	ModelBaseStructName = "Model"
+	ModelBaseFile       = "model_base.go"
+	ModelBaseTemplate   = `// Code generated by raiden-cli; DO NOT EDIT.
+
+package {{ .Package }}
+{{- if gt (len .Imports) 0 }}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{- end }}
+
+// {{ .StructName }} contain column that shared by model, only model
+// that has all the column with the same definition embed this struct
+type {{ .StructName }} struct {
+{{- range .Columns }}
+{{- range .Comments }}
+	{{ . }}
+{{- end }}
+	{{ .Name | ToColumnIdentifier }} {{ .Type }} ` + "`{{ .Tag }}`" + `
+{{- end }}
+}
+`
+)
+
+// ResolveModelBase build model base from the given column and set it to model input that can embed it,
+// column definition is taken from the first table that has all the column. Table that lack one
+// of the column or has different definition is generated with normal field.
+func ResolveModelBase(inputs []*GenerateModelInput, baseColumns []string) *GenerateModelBase {
+	if len(baseColumns) == 0 {
+		return nil
+	}
+
+	var base *GenerateModelBase
+	for _, input := range inputs {
+		if input == nil || input.Table.IsView {
+			continue
+		}
+
+		columns, imports, isComplete := mapModelBaseColumns(input, baseColumns)
+		if !isComplete {
+			ModelLogger.Debug("table doesn't have all model base column, generate without model base", "schema", input.Table.Schema, "table", input.Table.Name)
+			continue
+		}
+
+		if base == nil {
+			base = &GenerateModelBase{
+				StructName:      ModelBaseStructName,
+				Columns:         columns,
+				Imports:         imports,
+				NameTransformer: input.GetNameTransformer(),
+				Package:         input.GetModelsPackage(),
+			}
+		} else if !isSameModelBaseColumns(base.Columns, columns) {
+			ModelLogger.Warn("model base column definition is different, generate without model base", "schema", input.Table.Schema, "table", input.Table.Name)
+			continue
+		}
+		input.Base = base
+	}
+	return base
+}
+
+// mapModelBaseColumns map base column of table in base column order
+func mapModelBaseColumns(input *GenerateModelInput, baseColumns []string) (columns []GenerateModelColumn, imports []string, isComplete bool) {
+	table := input.Table
+	table.Columns = nil
+
+	for _, name := range baseColumns {
+		var found bool
+		for _, c := range input.Table.Columns {
+			if c.Name == name && !input.IsExcludedColumn(c.Name) {
+				table.Columns = append(table.Columns, c)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, nil, false
+		}
+	}
+
+	columns, imports = mapTableAttributes(table, input.JsonTypes, input.TypeOverrides, input.CompositeTypes, input.JsonCase, input.UuidType)
+	if input.GormTags {
+		appendGormColumnTags(columns, table)
+	}
+	return columns, imports, true
+}
+
+func isSameModelBaseColumns(a, b []GenerateModelColumn) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Type != b[i].Type || a[i].Tag != b[i].Tag {
+			return false
+		}
+	}
+	return true
+}
+
+// GetPackage return package name of model base, models is used when not set
+func (base *GenerateModelBase) GetPackage() string {
+	if base.Package == "" {
+		return ModelsPackage
+	}
+	return base.Package
+}
+
+// HasColumn check if column is declared in model base
+func (base *GenerateModelBase) HasColumn(name string) bool {
+	for _, c := range base.Columns {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateModelBaseFile write model base to <folderPath>/model_base.go,
+// model base always placed in models package
+func GenerateModelBaseFile(folderPath string, base *GenerateModelBase, generateFn GenerateFn) error {
+	nameTransformer := base.NameTransformer
+	if nameTransformer == nil {
+		nameTransformer = DefaultNameTransformer{}
+	}
+
+	generateInput := GenerateInput{
+		BindData: GenerateModelBaseData{
+			Package:    base.GetPackage(),
+			Imports:    base.Imports,
+			StructName: base.StructName,
+			Columns:    base.Columns,
+		},
+		FuncMap:      []template.FuncMap{{"ToColumnIdentifier": nameTransformer.Column}},
+		Template:     ModelBaseTemplate,
+		TemplateName: "modelBaseTemplate",
+		OutputPath:   filepath.Join(folderPath, ModelBaseFile),
+	}
+
+	ModelLogger.Debug("generate model base", "path", generateInput.OutputPath)
+	return generateFn(generateInput, nil)
+}
+
+// getModelBaseType return embedded type of model base, model in schema
+// subpackage refer to model base in models package
+func getModelBaseType(input *GenerateModelInput) (typeName string, importPath string) {
+	if !input.SchemaPackage {
+		return input.Base.StructName, ""
+	}
+
+	return qualifyTypeName(input.Base.StructName, input.GetModelsPackage()), input.GetModelsImportPath()
+}
@@ -0,0 +1,81 @@
+package generator_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func newModelBaseInput(name string, columns ...objects.Column) *generator.GenerateModelInput {
+	return &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:        name,
+			Schema:      "public",
+			Columns:     columns,
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+}
+
+func TestGenerateModels_ModelBase(t *testing.T) {
+	id := objects.Column{Name: "id", DataType: "bigint", IdentityGeneration: "BY DEFAULT"}
+	createdAt := objects.Column{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: "now()"}
+	updatedAt := objects.Column{Name: "updated_at", DataType: "timestamp with time zone", IsNullable: true}
+
+	users := newModelBaseInput("users", id, objects.Column{Name: "name", DataType: "text"}, createdAt, updatedAt)
+	posts := newModelBaseInput("posts", id, objects.Column{Name: "title", DataType: "text"}, createdAt, updatedAt)
+	logs := newModelBaseInput("logs", id, objects.Column{Name: "message", DataType: "text"}, createdAt)
+	inputs := []*generator.GenerateModelInput{users, posts, logs}
+
+	base := generator.ResolveModelBase(inputs, []string{"id", "created_at", "updated_at"})
+	assert.NotNil(t, base)
+	assert.Equal(t, 3, len(base.Columns))
+	assert.Equal(t, base, users.Base)
+	assert.Equal(t, base, posts.Base)
+
+	// logs doesn't have updated_at
+	assert.Nil(t, logs.Base)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	err := generator.GenerateModels(dir, inputs, generator.Generate)
+	assert.NoError(t, err)
+
+	modelDir := filepath.Join(dir, generator.ModelDir)
+	baseContent, err := os.ReadFile(filepath.Join(modelDir, generator.ModelBaseFile))
+	assert.NoError(t, err)
+	assert.Contains(t, string(baseContent), "type Model struct {")
+	assert.Contains(t, string(baseContent), "\"time\"")
+	assert.Equal(t, 1, strings.Count(string(baseContent), "column:\"name:updated_at;"))
+
+	usersContent, err := os.ReadFile(filepath.Join(modelDir, "users.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(usersContent), "\traiden.ModelBase\n\tModel\n")
+	assert.Contains(t, string(usersContent), "column:\"name:name;")
+	assert.NotContains(t, string(usersContent), "column:\"name:created_at;")
+	assert.NotContains(t, string(usersContent), "\"time\"")
+
+	logsContent, err := os.ReadFile(filepath.Join(modelDir, "logs.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(logsContent), "\tModel\n")
+	assert.Contains(t, string(logsContent), "column:\"name:id;")
+	assert.Contains(t, string(logsContent), "column:\"name:created_at;")
+}
+
+func TestResolveModelBase_DifferentDefinition(t *testing.T) {
+	users := newModelBaseInput("users", objects.Column{Name: "id", DataType: "bigint"})
+	sessions := newModelBaseInput("sessions", objects.Column{Name: "id", DataType: "uuid"})
+
+	base := generator.ResolveModelBase([]*generator.GenerateModelInput{users, sessions}, []string{"id"})
+	assert.NotNil(t, base)
+	assert.Equal(t, base, users.Base)
+	assert.Nil(t, sessions.Base)
+
+	assert.Nil(t, generator.ResolveModelBase([]*generator.GenerateModelInput{users}, nil))
+}
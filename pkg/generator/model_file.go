@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ----- Define type, variable and constant -----
+type GenerateModelsFileData struct {
+	Package string
+	Imports []string
+	Models  []string
+	Body    string
+}
+
+const (
+	// ModelsFileName is file name of all model when model is generated to single file
+	ModelsFileName     = "models_gen.go"
+	ModelsFileTemplate = `// Code generated by raiden-cli; DO NOT EDIT.
+package {{ .Package }}
+{{- if gt (len .Imports) 0 }}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{- end }}
+
+{{ .Body }}
+`
+)
+
+// ErrSingleFileModel is returned when single file is combined with option that
+// generate model to more than one file or package
+var ErrSingleFileModel = errors.New("single file model can't be used with model stub or schema package")
+
+// GenerateModelsFile generate all model to single file, every model is rendered with
+// model template, sorted by struct name and merged with single package and import clause
+func GenerateModelsFile(folderPath string, tables []*GenerateModelInput, generateFn GenerateFn) error {
+	// capture model input and render it after sorted
+	var inputs []GenerateInput
+	captureFn := func(input GenerateInput, writer io.Writer) error {
+		inputs = append(inputs, input)
+		return nil
+	}
+
+	models := make([]string, 0, len(tables))
+	for i := range tables {
+		t := tables[i]
+		if t.WithStub || t.SchemaPackage {
+			return ErrSingleFileModel
+		}
+
+		if err := GenerateModel(folderPath, t, captureFn); err != nil {
+			return err
+		}
+	}
+
+	sort.SliceStable(inputs, func(i, j int) bool {
+		return getModelStructName(inputs[i]) < getModelStructName(inputs[j])
+	})
+
+	data := GenerateModelsFileData{Package: ModelsPackage}
+	if len(tables) > 0 {
+		data.Package = tables[0].GetModelsPackage()
+	}
+	mapImport := make(map[string]bool)
+	bodies := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		var buff bytes.Buffer
+		if err := Generate(input, &buff); err != nil {
+			return err
+		}
+
+		packageName, imports, body, err := splitModelSource(buff.Bytes())
+		if err != nil {
+			return fmt.Errorf("failed parse model %s : %v", getModelStructName(input), err)
+		}
+		data.Package = packageName
+
+		for _, i := range imports {
+			if !mapImport[i] {
+				mapImport[i] = true
+				data.Imports = append(data.Imports, i)
+			}
+		}
+		bodies = append(bodies, body)
+		models = append(models, getModelStructName(input))
+	}
+	sort.Strings(data.Imports)
+	data.Models = models
+
+	// body is formatted so the generated file is gofmt clean
+	body, err := format.Source([]byte(strings.Join(bodies, "\n\n")))
+	if err != nil {
+		return fmt.Errorf("failed format %s : %v", ModelsFileName, err)
+	}
+	data.Body = strings.TrimSpace(string(body))
+
+	generateInput := GenerateInput{
+		BindData:     data,
+		Template:     ModelsFileTemplate,
+		TemplateName: "modelsFileTemplate",
+		OutputPath:   filepath.Join(folderPath, ModelsFileName),
+	}
+
+	ModelLogger.Debug("generate models file", "path", generateInput.OutputPath)
+	return generateFn(generateInput, nil)
+}
+
+func getModelStructName(input GenerateInput) string {
+	if data, ok := input.BindData.(GenerateModelData); ok {
+		return data.StructName
+	}
+	return ""
+}
+
+// splitModelSource split rendered model to package name, import path and declaration
+func splitModelSource(src []byte) (packageName string, imports []string, body string, err error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return
+	}
+
+	packageName = file.Name.Name
+	bodyPos := file.Name.End()
+	for _, i := range file.Imports {
+		path, err := strconv.Unquote(i.Path.Value)
+		if err != nil {
+			return packageName, nil, "", err
+		}
+		imports = append(imports, path)
+	}
+
+	for _, d := range file.Decls {
+		if d.End() > bodyPos {
+			bodyPos = d.End()
+		}
+	}
+
+	body = strings.TrimSpace(string(src[fset.Position(bodyPos).Offset:]))
+	return
+}
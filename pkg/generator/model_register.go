@@ -37,7 +37,7 @@ import (
 func RegisterModels() {
 	resource.RegisterModels(
 		{{- range .Models}}
-		&models.{{.}}{},
+		&{{.}}{},
 		{{- end}}
 	)
 }
@@ -45,6 +45,12 @@ func RegisterModels() {
 )
 
 func GenerateModelRegister(basePath string, projectName string, generateFn GenerateFn) error {
+	return GenerateModelRegisterWithPackage(basePath, ProjectPackage{ProjectName: projectName}, generateFn)
+}
+
+// GenerateModelRegisterWithPackage generate model register, root model is qualified
+// with configured models package instead of models
+func GenerateModelRegisterWithPackage(basePath string, project ProjectPackage, generateFn GenerateFn) error {
 	modelRegisterDir := filepath.Join(basePath, ModelRegisterDir)
 	ModelRegisterLogger.Trace("create bootstrap folder if not exist", "path", modelRegisterDir)
 	if exist := utils.IsFolderExists(modelRegisterDir); !exist {
@@ -62,12 +68,12 @@ func GenerateModelRegister(basePath string, projectName string, generateFn Gener
 	}
 
 	// scan all controller
-	modelList, err := WalkScanModel(modelDir)
+	modelList, err := walkScanModel(modelDir, project.GetModelsPackage())
 	if err != nil {
 		return err
 	}
 
-	input, err := createModelRegisterInput(projectName, modelRegisterDir, modelList)
+	input, err := createModelRegisterInput(project, modelRegisterDir, modelList)
 	if err != nil {
 		return err
 	}
@@ -76,7 +82,7 @@ func GenerateModelRegister(basePath string, projectName string, generateFn Gener
 	return generateFn(input, nil)
 }
 
-func createModelRegisterInput(projectName string, modelRegisterDir string, modelList []string) (input GenerateInput, err error) {
+func createModelRegisterInput(project ProjectPackage, modelRegisterDir string, modelList []string) (input GenerateInput, err error) {
 	// set file path
 	filePath := filepath.Join(modelRegisterDir, ModelRegisterFilename)
 
@@ -85,9 +91,20 @@ func createModelRegisterInput(projectName string, modelRegisterDir string, model
 		fmt.Sprintf("%q", "github.com/sev-2/raiden/pkg/resource"),
 	}
 
-	if len(modelList) > 0 {
-		rolesImportPath := fmt.Sprintf("%s/internal/models", utils.ToGoModuleName(projectName))
-		imports = append(imports, fmt.Sprintf("%q", rolesImportPath))
+	// model in schema subpackage is qualified with subpackage name
+	modelsImportPath := project.GetModelsImportPath()
+	mapImport := make(map[string]bool)
+	for _, m := range modelList {
+		packageName := strings.Split(m, ".")[0]
+		importPath := modelsImportPath
+		if packageName != project.GetModelsPackage() {
+			importPath = fmt.Sprintf("%s/%s", modelsImportPath, packageName)
+		}
+
+		if !mapImport[importPath] {
+			mapImport[importPath] = true
+			imports = append(imports, fmt.Sprintf("%q", importPath))
+		}
 	}
 
 	// set passed parameter
@@ -107,7 +124,13 @@ func createModelRegisterInput(projectName string, modelRegisterDir string, model
 	return
 }
 
+// WalkScanModel return list of model qualified with package name,
+// model in schema subpackage (internal/models/<schema>) qualified with schema package name
 func WalkScanModel(modelDir string) ([]string, error) {
+	return walkScanModel(modelDir, ModelsPackage)
+}
+
+func walkScanModel(modelDir string, modelsPackage string) ([]string, error) {
 	ModelRegisterLogger.Trace("scan registered all models", "path", modelDir)
 
 	roles := make([]string, 0)
@@ -119,8 +142,14 @@ func WalkScanModel(modelDir string) ([]string, error) {
 				return e
 			}
 
-			roles = append(roles, rs...)
+			packageName := modelsPackage
+			if dir := filepath.Dir(path); filepath.Clean(dir) != filepath.Clean(modelDir) {
+				packageName = filepath.Base(dir)
+			}
 
+			for _, r := range rs {
+				roles = append(roles, packageName+"."+r)
+			}
 		}
 		return nil
 	})
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	ModelRelationsBeginMarker = "// raiden:relations:begin"
+	ModelRelationsEndMarker   = "// raiden:relations:end"
+)
+
+// ErrModelRelationsMarker is returned when model file doesn't have relation marker,
+// model that generated before the marker is introduced must be regenerated once
+var ErrModelRelationsMarker = errors.New("relation marker is not found, run import without --relations-only to regenerate the model")
+
+// WithModelRelationsOnly decorate generate func so existing model file only get its relation
+// block (between relation marker) replaced, everything else in the file is kept as is.
+// Model that doesn't have file yet is generated in full and view is never changed.
+func WithModelRelationsOnly(generateFn GenerateFn) GenerateFn {
+	return func(input GenerateInput, writer io.Writer) error {
+		if input.TemplateName != "modelTemplate" && input.TemplateName != "modelViewTemplate" {
+			return generateFn(input, writer)
+		}
+
+		current, err := os.ReadFile(input.OutputPath)
+		if errors.Is(err, os.ErrNotExist) {
+			ModelLogger.Debug("model file is not exist, generate full model", "path", input.OutputPath)
+			return generateFn(input, writer)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		source := current
+		if input.TemplateName == "modelTemplate" {
+			var buff bytes.Buffer
+			if err := Generate(input, &buff); err != nil {
+				return err
+			}
+
+			if source, err = ReplaceModelRelations(current, buff.Bytes()); err != nil {
+				return fmt.Errorf("failed replace relation of %s : %w", input.OutputPath, err)
+			}
+		}
+
+		// bind data is kept, so generated file is still matched to imported table
+		input.Template = "{{ ModelSource }}"
+		input.TemplateName = "modelRelationsTemplate"
+		input.FuncMap = []template.FuncMap{
+			{"ModelSource": func() string { return string(source) }},
+		}
+		return generateFn(input, writer)
+	}
+}
+
+// ReplaceModelRelations replace relation block of current model source with relation block
+// of generated model source, relation that refer to package not imported by current source
+// can't be written without regenerate the import so it returned as error
+func ReplaceModelRelations(current, generated []byte) ([]byte, error) {
+	currentLines := strings.Split(string(current), "\n")
+	begin, end, err := findModelRelationsBlock(currentLines)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedLines := strings.Split(string(generated), "\n")
+	generatedBegin, generatedEnd, err := findModelRelationsBlock(generatedLines)
+	if err != nil {
+		return nil, err
+	}
+	relations := generatedLines[generatedBegin+1 : generatedEnd]
+
+	missingImports, err := getMissingImports(current, generated)
+	if err != nil {
+		return nil, err
+	}
+
+	block := strings.Join(relations, "\n")
+	for _, importPath := range missingImports {
+		if strings.Contains(block, path.Base(importPath)+".") {
+			return nil, fmt.Errorf("relation use package %q that not imported, run import without --relations-only to regenerate the model", importPath)
+		}
+	}
+
+	lines := make([]string, 0, len(currentLines)-(end-begin)+len(relations))
+	lines = append(lines, currentLines[:begin+1]...)
+	lines = append(lines, relations...)
+	lines = append(lines, currentLines[end:]...)
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// findModelRelationsBlock return line index of begin and end relation marker
+func findModelRelationsBlock(lines []string) (begin int, end int, err error) {
+	begin, end = -1, -1
+	for i, l := range lines {
+		switch strings.TrimSpace(l) {
+		case ModelRelationsBeginMarker:
+			if begin >= 0 {
+				return 0, 0, fmt.Errorf("relation marker is declared more than once")
+			}
+			begin = i
+		case ModelRelationsEndMarker:
+			if end >= 0 {
+				return 0, 0, fmt.Errorf("relation marker is declared more than once")
+			}
+			end = i
+		}
+	}
+
+	if begin < 0 || end < 0 || end < begin {
+		return 0, 0, ErrModelRelationsMarker
+	}
+	return begin, end, nil
+}
+
+// getMissingImports return import path of generated source that not imported by current source
+func getMissingImports(current, generated []byte) ([]string, error) {
+	currentImports, err := getSourceImports(current)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedImports, err := getSourceImports(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	mapCurrentImport := make(map[string]bool, len(currentImports))
+	for _, i := range currentImports {
+		mapCurrentImport[i] = true
+	}
+
+	var missing []string
+	for _, i := range generatedImports {
+		if !mapCurrentImport[i] {
+			missing = append(missing, i)
+		}
+	}
+	return missing, nil
+}
+
+func getSourceImports(source []byte) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", source, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make([]string, 0, len(file.Imports))
+	for _, i := range file.Imports {
+		importPath, err := strconv.Unquote(i.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		imports = append(imports, importPath)
+	}
+	return imports, nil
+}
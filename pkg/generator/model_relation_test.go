@@ -0,0 +1,80 @@
+package generator_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithModelRelationsOnly(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:        "orders",
+			Schema:      "public",
+			Columns:     []objects.Column{{Name: "id", DataType: "bigint"}, {Name: "user_id", DataType: "bigint"}},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		Relations: []state.Relation{
+			{Table: "users", Schema: "public", Type: "*Users", RelationType: raiden.RelationTypeBelongsTo, PrimaryKey: "id", ForeignKey: "user_id"},
+		},
+	}
+
+	// model file that doesn't exist is generated in full
+	require.NoError(t, generator.GenerateModel(dir, input, generator.WithModelRelationsOnly(generator.Generate)))
+
+	filePath := filepath.Join(dir, "orders.go")
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), generator.ModelRelationsBeginMarker)
+	assert.Contains(t, string(content), "Users *Users")
+
+	// edit outside relation block, including column that no longer match the table
+	edited := strings.Replace(string(content), "type Orders struct {", "// Orders is edited by hand\ntype Orders struct {", 1)
+	edited = strings.Replace(edited, "UserId", "CustomerId", 1)
+	require.NoError(t, os.WriteFile(filePath, []byte(edited), 0644))
+
+	input.Table.Columns = append(input.Table.Columns, objects.Column{Name: "note", DataType: "text"})
+	input.Relations = []state.Relation{
+		{Table: "customers", Schema: "public", Type: "*Customers", RelationType: raiden.RelationTypeBelongsTo, PrimaryKey: "id", ForeignKey: "user_id"},
+	}
+	require.NoError(t, generator.GenerateModel(dir, input, generator.WithModelRelationsOnly(generator.Generate)))
+
+	content, err = os.ReadFile(filePath)
+	require.NoError(t, err)
+	result := string(content)
+	assert.Contains(t, result, "// Orders is edited by hand")
+	assert.Contains(t, result, "CustomerId int64")
+	assert.NotContains(t, result, "Note ")
+	assert.Contains(t, result, "Customers *Customers")
+	assert.NotContains(t, result, "Users *Users")
+	assert.Equal(t, strings.Replace(edited, "Users *Users `json:\"users,omitempty\" join:\"joinType:belongsTo;primaryKey:id;foreignKey:user_id\"`", "Customers *Customers `json:\"customers,omitempty\" join:\"joinType:belongsTo;primaryKey:id;foreignKey:user_id\"`", 1), result)
+}
+
+func TestWithModelRelationsOnly_MissingMarker(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "orders.go")
+	require.NoError(t, os.WriteFile(filePath, []byte("package models\n\ntype Orders struct {\n\traiden.ModelBase\n}\n"), 0644))
+
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{Name: "orders", Schema: "public", Columns: []objects.Column{{Name: "id", DataType: "bigint"}}},
+	}
+	err := generator.GenerateModel(dir, input, generator.WithModelRelationsOnly(generator.Generate))
+	assert.ErrorIs(t, err, generator.ErrModelRelationsMarker)
+}
+
+func TestReplaceModelRelations_MissingImport(t *testing.T) {
+	current := "package public\n\nimport (\n\t\"github.com/sev-2/raiden\"\n)\n\ntype Profile struct {\n\traiden.ModelBase\n\t" + generator.ModelRelationsBeginMarker + "\n\t" + generator.ModelRelationsEndMarker + "\n}\n"
+	generated := "package public\n\nimport (\n\t\"github.com/sev-2/raiden\"\n\t\"my_app/internal/models/auth\"\n)\n\ntype Profile struct {\n\traiden.ModelBase\n\t" + generator.ModelRelationsBeginMarker + "\n\tUsers *auth.Users\n\t" + generator.ModelRelationsEndMarker + "\n}\n"
+
+	_, err := generator.ReplaceModelRelations([]byte(current), []byte(generated))
+	assert.ErrorContains(t, err, "my_app/internal/models/auth")
+}
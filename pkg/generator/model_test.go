@@ -0,0 +1,1335 @@
+package generator_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapTableAttributes_ReadOnlyColumn(t *testing.T) {
+	table := objects.Table{
+		Name:   "orders",
+		Schema: "public",
+		Columns: []objects.Column{
+			{Name: "id", DataType: "bigint", IsIdentity: true, IdentityGeneration: "ALWAYS"},
+			{Name: "code", DataType: "bigint", IsIdentity: true, IdentityGeneration: "BY DEFAULT"},
+			{Name: "total", DataType: "numeric", IsGenerated: true, IsNullable: true},
+			{Name: "note", DataType: "text", IsNullable: true},
+		},
+		PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+	}
+
+	columns, _ := generator.MapTableAttributes(table)
+	assert.Equal(t, 4, len(columns))
+
+	assert.True(t, columns[0].IsIdentity)
+	assert.Contains(t, columns[0].Tag, "readOnly")
+
+	assert.True(t, columns[1].IsIdentity)
+	assert.NotContains(t, columns[1].Tag, "readOnly")
+
+	assert.True(t, columns[2].IsGenerated)
+	assert.Contains(t, columns[2].Tag, "readOnly")
+
+	assert.NotContains(t, columns[3].Tag, "readOnly")
+}
+
+func TestGenerateModel_WithStub(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:    "orders",
+			Schema:  "public",
+			Columns: []objects.Column{{Name: "id", DataType: "bigint"}},
+		},
+		WithStub: true,
+	}
+
+	var outputPaths []string
+	err := generator.GenerateModel(dir, input, func(input generator.GenerateInput, writer io.Writer) error {
+		outputPaths = append(outputPaths, input.OutputPath)
+		return generator.Generate(input, nil)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "orders_gen.go"), filepath.Join(dir, "orders.go")}, outputPaths)
+
+	// stub is not overwritten when exist
+	stubPath := filepath.Join(dir, "orders.go")
+	assert.NoError(t, os.WriteFile(stubPath, []byte("package models\n\nfunc (o *Orders) Custom() {}\n"), 0644))
+
+	outputPaths = nil
+	err = generator.GenerateModel(dir, input, func(input generator.GenerateInput, writer io.Writer) error {
+		outputPaths = append(outputPaths, input.OutputPath)
+		return generator.Generate(input, nil)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "orders_gen.go")}, outputPaths)
+
+	content, err := os.ReadFile(stubPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Custom()")
+}
+
+func TestMapTableAttributes_ArrayColumn(t *testing.T) {
+	table := objects.Table{
+		Name:   "articles",
+		Schema: "public",
+		Columns: []objects.Column{
+			{Name: "tags", DataType: "ARRAY", Format: "_text", IsNullable: true},
+			{Name: "scores", DataType: "ARRAY", Format: "_int8"},
+			{Name: "reviewer_ids", DataType: "ARRAY", Format: "_uuid"},
+			{Name: "points", DataType: "ARRAY", Format: "_point"},
+			{Name: "statuses", DataType: "ARRAY", Format: "_article_status", Enums: []string{"draft"}},
+		},
+	}
+
+	columns, imports := generator.MapTableAttributes(table)
+	assert.Equal(t, "[]string", columns[0].Type)
+	assert.Contains(t, columns[0].Tag, "type:text[]")
+	assert.Equal(t, "[]int64", columns[1].Type)
+	assert.Contains(t, columns[1].Tag, "type:int8[]")
+	assert.Equal(t, "[]uuid.UUID", columns[2].Type)
+	assert.Equal(t, "json.RawMessage", columns[3].Type)
+	assert.Equal(t, "[]ArticleStatus", columns[4].Type)
+	assert.Equal(t, []string{"encoding/json", "github.com/google/uuid"}, imports)
+}
+
+func TestGenerateModel_JsonColumn(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "attributes", DataType: "jsonb", IsNullable: true},
+				{Name: "payload", DataType: "jsonb", IsNullable: true},
+			},
+		},
+		JsonTypes: map[string]string{"payload": "OrderPayload"},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"encoding/json"`)
+	assert.Contains(t, string(content), "Attributes json.RawMessage `json:\"attributes,omitempty\" column:\"name:attributes;type:jsonb;nullable\"`")
+	assert.Contains(t, string(content), "Payload *OrderPayload `json:\"payload,omitempty\" column:\"name:payload;type:jsonb;nullable\"`")
+}
+
+func TestGenerateModel_WithComment(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:    "orders",
+			Schema:  "public",
+			Comment: "list of customer order",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "note", DataType: "text", Comment: "note from customer\nvisible to admin"},
+			},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), ")\n\n// list of customer order\ntype Orders struct {")
+	assert.Contains(t, string(content), "\t// note from customer\n\t// visible to admin\n\tNote string")
+}
+
+func TestGenerateModel_View(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:           "order_summaries",
+			Schema:         "public",
+			IsView:         true,
+			IsMaterialized: true,
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint", DefaultValue: "1", IsUnique: true},
+				{Name: "total", DataType: "numeric", IsNullable: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "order_summaries.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Id int64 `json:\"id,omitempty\" column:\"name:id;type:bigint\"`")
+	assert.Contains(t, string(content), "Total *float64 `json:\"total,omitempty\" column:\"name:total;type:numeric;nullable\"`")
+	assert.Contains(t, string(content), "Metadata string `json:\"-\" schema:\"public\" view:\"true\" materialized:\"true\"`")
+	assert.NotContains(t, string(content), "Acl string")
+	assert.NotContains(t, string(content), "primaryKey")
+}
+
+func TestGenerateModel_Partitioned(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:          "events",
+			Schema:        "analytics",
+			IsPartitioned: true,
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "events.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Metadata string `json:\"-\" schema:\"analytics\" rlsEnable:\"false\" rlsForced:\"false\" partitioned:\"true\"`")
+}
+
+func TestGenerateModel_ForeignTable(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:      "exchange_rates",
+			Schema:    "public",
+			IsForeign: true,
+			Columns: []objects.Column{
+				{Name: "currency", DataType: "text"},
+				{Name: "rate", DataType: "numeric", IsNullable: true},
+			},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "exchange_rates.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Metadata string `json:\"-\" schema:\"public\" rlsEnable:\"false\" rlsForced:\"false\" foreign:\"true\" readOnly:\"true\"`")
+}
+
+func TestGenerateModel_SoftDelete(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "deleted_at", DataType: "timestamp with time zone", IsNullable: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		SoftDeleteColumn: "deleted_at",
+	}
+	assert.Equal(t, "deleted_at", input.GetSoftDeleteColumn())
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "DeletedAt *time.Time `json:\"deleted_at,omitempty\"")
+	assert.Contains(t, string(content), "Metadata string `json:\"-\" schema:\"public\" rlsEnable:\"false\" rlsForced:\"false\" softDelete:\"deleted_at\"`")
+
+	// query helper exclude soft deleted row by default
+	assert.Contains(t, string(content), "func ListOrdersFilter() url.Values {\n\tvalues := url.Values{}\n\tvalues.Set(\"deleted_at\", \"is.null\")\n\treturn values\n}")
+	assert.Contains(t, string(content), "func GetOrdersFilter(row Orders) url.Values {\n\tvalues := ListOrdersFilter()\n\tvalues.Set(\"id\", fmt.Sprintf(\"eq.%v\", row.Id))\n\treturn values\n}")
+	assert.Contains(t, string(content), "\"net/url\"")
+
+	_, err = parser.ParseFile(token.NewFileSet(), "", content, 0)
+	assert.NoError(t, err)
+
+	// table without soft delete column is not flagged
+	input.Table.Columns = input.Table.Columns[:1]
+	assert.Equal(t, "", input.GetSoftDeleteColumn())
+
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "softDelete")
+	assert.NotContains(t, string(content), "ListOrdersFilter")
+}
+
+func TestGenerateModel_TypeOverrides(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "customers",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "email", DataType: "USER-DEFINED", Format: "citext"},
+				{Name: "balance", DataType: "numeric", Format: "numeric", IsNullable: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		TypeOverrides: map[string]string{
+			"citext":  "string",
+			"numeric": "github.com/shopspring/decimal.Decimal",
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "customers.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"github.com/shopspring/decimal\"")
+	assert.Contains(t, string(content), "Email string `json:\"email,omitempty\"")
+	assert.Contains(t, string(content), "Balance *decimal.Decimal `json:\"balance,omitempty\"")
+}
+
+func TestParseTypeOverride(t *testing.T) {
+	goType, importPath, err := generator.ParseTypeOverride("[]github.com/jackc/pgx/v5/pgtype.Point")
+	assert.NoError(t, err)
+	assert.Equal(t, "[]pgtype.Point", goType)
+	assert.Equal(t, "github.com/jackc/pgx/v5/pgtype", importPath)
+
+	goType, importPath, err = generator.ParseTypeOverride("github.com/jackc/pgx/v5.Tx")
+	assert.NoError(t, err)
+	assert.Equal(t, "pgx.Tx", goType)
+	assert.Equal(t, "github.com/jackc/pgx/v5", importPath)
+
+	_, _, err = generator.ParseTypeOverride("github.com/shopspring/decimal")
+	assert.Error(t, err)
+}
+
+func TestGenerateModel_RelationComment(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:        "orders",
+			Schema:      "public",
+			Columns:     []objects.Column{{Name: "id", DataType: "bigint"}, {Name: "user_id", DataType: "bigint"}},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		Relations: []state.Relation{
+			{Table: "users", Schema: "public", Type: "*Users", RelationType: raiden.RelationTypeBelongsTo, PrimaryKey: "id", ForeignKey: "user_id", Comment: "fk: orders.user_id -> users.id"},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\t// fk: orders.user_id -> users.id\n\tUsers *Users `json:\"users,omitempty\"")
+}
+
+func TestGenerateModel_NullableRelation(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "customer_id", DataType: "bigint"},
+				{Name: "coupon_id", DataType: "bigint", IsNullable: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		Relations: []state.Relation{
+			{Table: "customers", Schema: "public", Type: "*Customers", RelationType: raiden.RelationTypeBelongsTo, PrimaryKey: "id", ForeignKey: "customer_id"},
+			{Table: "coupons", Schema: "public", Type: "*Coupons", RelationType: raiden.RelationTypeBelongsTo, PrimaryKey: "id", ForeignKey: "coupon_id", Nullable: true},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\t// optional, nil when coupon_id is null\n\tCoupons *Coupons `json:\"coupons,omitempty\"")
+	assert.Contains(t, string(content), generator.ModelRelationsBeginMarker+"\n\tCustomers *Customers `json:\"customers,omitempty\"")
+}
+
+func TestGenerateModel_GormTags(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "users",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint", IdentityGeneration: "ALWAYS"},
+				{Name: "name", DataType: "text"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		Relations: []state.Relation{
+			{Table: "orders", Schema: "public", Type: "[]*Orders", RelationType: raiden.RelationTypeHasMany, PrimaryKey: "id", ForeignKey: "user_id"},
+			{
+				Table: "roles", Schema: "public", Type: "[]*Roles", RelationType: raiden.RelationTypeManyToMany,
+				JoinRelation: &state.JoinRelation{
+					SourcePrimaryKey:      "id",
+					JoinsSourceForeignKey: "user_id",
+					TargetPrimaryKey:      "id",
+					JoinTargetForeignKey:  "role_id",
+					Through:               "user_roles",
+				},
+			},
+		},
+		GormTags: true,
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "users.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "gorm:\"column:id;primaryKey;autoIncrement;->\"`")
+	assert.Contains(t, string(content), "gorm:\"column:name\"`")
+	assert.Contains(t, string(content), "gorm:\"foreignKey:user_id;references:id\"`")
+	assert.Contains(t, string(content), "gorm:\"many2many:user_roles;foreignKey:id;joinForeignKey:user_id;references:id;joinReferences:role_id\"`")
+	assert.Contains(t, string(content), "rlsForced:\"false\" gorm:\"-\"`")
+	assert.Contains(t, string(content), "func (Users) TableName() string {\n\treturn \"public.users\"\n}")
+
+	// gorm tag is not generated by default
+	input.GormTags = false
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "users.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "gorm:")
+	assert.NotContains(t, string(content), "TableName()")
+}
+
+func TestGenerateModel_PaginationHelpers(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "uuid", DefaultValue: "gen_random_uuid()"},
+				{Name: "created_at", DataType: "timestamp with time zone"},
+				{Name: "note", DataType: "text", IsNullable: true},
+				{Name: "meta", DataType: "jsonb"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		PaginationHelpers: true,
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"net/url\"")
+	assert.Contains(t, string(content), "OrdersOrderById OrdersOrderColumn = \"id\"")
+	assert.Contains(t, string(content), "OrdersOrderByCreatedAt OrdersOrderColumn = \"created_at\"")
+	assert.Contains(t, string(content), "func ListOrdersQuery(orderBy OrdersOrderColumn, query raiden.PageQuery) (url.Values, error) {")
+	assert.Contains(t, string(content), "return raiden.BuildPageQuery(\"id\", string(orderBy), query)")
+	assert.Contains(t, string(content), "case OrdersOrderByCreatedAt:\n\t\t\treturn []any{row.CreatedAt, row.Id}")
+
+	// nullable and json column can't be used as keyset order column
+	assert.NotContains(t, string(content), "OrdersOrderByNote")
+	assert.NotContains(t, string(content), "OrdersOrderByMeta")
+	assert.NotContains(t, string(content), "is.null")
+
+	// soft deleted row is excluded from paginated list
+	input.Table.Columns = append(input.Table.Columns, objects.Column{Name: "deleted_at", DataType: "timestamp with time zone", IsNullable: true})
+	input.SoftDeleteColumn = "deleted_at"
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "q, err := raiden.BuildPageQuery(\"id\", string(orderBy), query)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tq.Set(\"deleted_at\", \"is.null\")\n\treturn q, nil")
+
+	_, err = parser.ParseFile(token.NewFileSet(), "", content, 0)
+	assert.NoError(t, err)
+
+	// helper is not generated for table without single primary key
+	input.Table.PrimaryKeys = nil
+	input.SoftDeleteColumn = ""
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "ListOrdersQuery")
+	assert.NotContains(t, string(content), "net/url")
+}
+
+func TestGenerateModel_ColumnMetadata(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "order_items",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "order_id", DataType: "bigint"},
+				{Name: "line_no", DataType: "integer", IdentityGeneration: "ALWAYS", IsIdentity: true},
+				{Name: "price", DataType: "numeric"},
+				{Name: "total", DataType: "numeric", IsGenerated: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "order_id"}, {Name: "line_no"}},
+		},
+	}
+
+	var data generator.GenerateModelData
+	err := generator.GenerateModel(dir, input, func(input generator.GenerateInput, writer io.Writer) error {
+		data = input.BindData.(generator.GenerateModelData)
+		return generator.Generate(input, writer)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []raiden.ModelColumn{
+		{Name: "order_id", Field: "OrderId", PrimaryKey: true},
+		{Name: "line_no", Field: "LineNo", PrimaryKey: true, Generated: true},
+		{Name: "price", Field: "Price"},
+		{Name: "total", Field: "Total", Generated: true},
+	}, data.ColumnMetadata)
+
+	// every metadata refer to struct field with the same column name
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join(dir, "order_items.go"), nil, 0)
+	assert.NoError(t, err)
+
+	fieldColumns := make(map[string]string)
+	ast.Inspect(file, func(n ast.Node) bool {
+		ts, isType := n.(*ast.TypeSpec)
+		if !isType || ts.Name.Name != "OrderItems" {
+			return true
+		}
+		for _, f := range ts.Type.(*ast.StructType).Fields.List {
+			if len(f.Names) == 0 || f.Tag == nil {
+				continue
+			}
+			if column := reflect.StructTag(strings.Trim(f.Tag.Value, "`")).Get("column"); column != "" {
+				fieldColumns[f.Names[0].Name] = strings.TrimPrefix(strings.Split(column, ";")[0], "name:")
+			}
+		}
+		return false
+	})
+	assert.Len(t, fieldColumns, len(data.ColumnMetadata))
+	for _, c := range data.ColumnMetadata {
+		assert.Equal(t, c.Name, fieldColumns[c.Field])
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "order_items.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "func (OrderItems) ModelColumns() []raiden.ModelColumn {")
+	assert.Contains(t, string(content), `{Name: "line_no", Field: "LineNo", PrimaryKey: true, Generated: true},`)
+}
+
+func TestGenerateModel_BulkInsertHelpers(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "order_items",
+			Schema: "sales",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint", IdentityGeneration: "ALWAYS", IsIdentity: true},
+				{Name: "order_id", DataType: "bigint"},
+				{Name: "note", DataType: "text", IsNullable: true},
+				{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: "now()"},
+				{Name: "total", DataType: "numeric", IsGenerated: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		BulkInsertHelpers: true,
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	// identity, generated and defaulted column is not inserted
+	content, err := os.ReadFile(filepath.Join(dir, "order_items.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "func BulkInsertOrderItems(rows []OrderItems) ([]raiden.BulkInsertStatement, error) {")
+	assert.Contains(t, string(content), "columns := []string{\"order_id\", \"note\"}")
+	assert.Contains(t, string(content), "returning := []string{\"id\"}")
+	assert.Contains(t, string(content), "return raiden.BuildBulkInsert(\"sales\", \"order_items\", columns, returning, len(rows), func(i int) []any {")
+	assert.Contains(t, string(content), "return []any{rows[i].OrderId, rows[i].Note}")
+
+	// generated source is valid go
+	_, err = parser.ParseFile(token.NewFileSet(), "", content, 0)
+	assert.NoError(t, err)
+
+	// helper is not generated by default
+	input.BulkInsertHelpers = false
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "order_items.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "BulkInsert")
+}
+
+func TestGenerateModel_UpsertHelpers(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "products",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint", IdentityGeneration: "BY DEFAULT", IsIdentity: true},
+				{Name: "tenant_id", DataType: "bigint"},
+				{Name: "sku", DataType: "text"},
+				{Name: "stock", DataType: "integer"},
+				{Name: "search", DataType: "tsvector", IsGenerated: true},
+				{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: "now()"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+			UniqueIndexes: []objects.TableUniqueIndex{
+				{Name: "products_tenant_id_sku_key", Columns: []string{"tenant_id", "sku"}},
+				{Name: "products_pkey", Columns: []string{"id"}},
+			},
+		},
+		UpsertHelpers: true,
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "products.go"))
+	assert.NoError(t, err)
+	result := string(content)
+
+	// primary key is inserted for conflict target, generated and defaulted column is omitted
+	assert.Contains(t, result, "func UpsertProductsById(rows []Products) ([]raiden.BulkInsertStatement, error) {")
+	assert.Contains(t, result, "columns := []string{\"id\", \"tenant_id\", \"sku\", \"stock\"}\n\tconflict := []string{\"id\"}\n\tupdate := []string{\"tenant_id\", \"sku\", \"stock\"}")
+	assert.Contains(t, result, "return []any{rows[i].Id, rows[i].TenantId, rows[i].Sku, rows[i].Stock}")
+
+	assert.Contains(t, result, "func UpsertProductsByTenantIdAndSku(rows []Products) ([]raiden.BulkInsertStatement, error) {")
+	assert.Contains(t, result, "columns := []string{\"tenant_id\", \"sku\", \"stock\"}\n\tconflict := []string{\"tenant_id\", \"sku\"}\n\tupdate := []string{\"stock\"}\n\treturning := []string{\"id\"}")
+	assert.Contains(t, result, `return raiden.BuildUpsert("public", "products", columns, conflict, update, returning, len(rows), func(i int) []any {`)
+	assert.Equal(t, 2, strings.Count(result, "raiden.BuildUpsert("))
+
+	_, err = parser.ParseFile(token.NewFileSet(), "", content, 0)
+	assert.NoError(t, err)
+
+	// identity column that always generated can't be conflict target
+	input.Table.Columns[0].IdentityGeneration = "ALWAYS"
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "products.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "UpsertProductsById(")
+	assert.Contains(t, string(content), "UpsertProductsByTenantIdAndSku(")
+}
+
+func TestGenerateModel_UpsertDefaultedKey(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "devices",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "uuid", DefaultValue: "gen_random_uuid()"},
+				{Name: "serial", DataType: "text"},
+			},
+			PrimaryKeys:   []objects.PrimaryKey{{Name: "id"}},
+			UniqueIndexes: []objects.TableUniqueIndex{{Name: "devices_serial_key", Columns: []string{"serial"}}},
+		},
+		UpsertHelpers: true,
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "devices.go"))
+	assert.NoError(t, err)
+	result := string(content)
+
+	// defaulted primary key is inserted as null when not set, row without key is rejected
+	assert.Contains(t, result, "Id *uuid.UUID")
+	assert.Contains(t, result, `if err := raiden.CheckUpsertKey("public", "devices", "id", len(rows), func(i int) any { return rows[i].Id }); err != nil {`)
+	assert.Equal(t, 1, strings.Count(result, "raiden.CheckUpsertKey("))
+
+	_, err = parser.ParseFile(token.NewFileSet(), "", content, 0)
+	assert.NoError(t, err)
+}
+
+func TestGenerateModel_VersionColumn(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint", IdentityGeneration: "ALWAYS", IsIdentity: true},
+				{Name: "status", DataType: "text"},
+				{Name: "total", DataType: "numeric"},
+				{Name: "search", DataType: "tsvector", IsGenerated: true},
+				{Name: "number", DataType: "bigint", IdentityGeneration: "BY DEFAULT", IsIdentity: true},
+				{Name: "seq", DataType: "bigint", IdentityGeneration: "ALWAYS", IsIdentity: true},
+				{Name: "is_active", DataType: "boolean", DefaultValue: "true"},
+				{Name: "version", DataType: "integer"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		VersionColumn: "version",
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	result := string(content)
+
+	// version column is still a normal field, generated and always identity column is not updated
+	assert.Contains(t, result, "Version int32")
+	assert.Contains(t, result, "func UpdateOrders(row Orders) (raiden.VersionedUpdateStatement, error) {")
+	assert.Contains(t, result, "columns := []string{\"status\", \"total\", \"number\", \"is_active\"}\n\tkeys := []string{\"id\"}")
+	assert.Contains(t, result, "values := []any{row.Status, row.Total, row.Number, row.IsActive}\n\tkeyValues := []any{row.Id}")
+	assert.Contains(t, result, `return raiden.BuildVersionedUpdate("public", "orders", columns, values, keys, keyValues, "version", row.Version)`)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "", content, 0)
+	assert.NoError(t, err)
+
+	// table without version column doesn't have update helper
+	input.Table.Columns = input.Table.Columns[:6]
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "UpdateOrders(")
+}
+
+func TestGenerateModel_ExcludeColumns(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "_audit_created_by", DataType: "uuid", IsNullable: true},
+				{Name: "total", DataType: "numeric"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		ExcludeColumns:    []string{"_audit_created_by"},
+		BulkInsertHelpers: true,
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "AuditCreatedBy")
+	assert.NotContains(t, string(content), "name:_audit_created_by")
+	assert.Contains(t, string(content), `excludeColumns:"_audit_created_by"`)
+	assert.Contains(t, string(content), "columns := []string{\"id\", \"total\"}")
+}
+
+func TestGenerateModels_SchemaPackage(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	inputs := []*generator.GenerateModelInput{
+		{
+			Table: objects.Table{
+				Name:    "users",
+				Schema:  "auth",
+				Columns: []objects.Column{{Name: "id", DataType: "uuid"}},
+			},
+			SchemaPackage: true,
+			ProjectName:   "my_app",
+		},
+		{
+			Table: objects.Table{
+				Name:   "profile",
+				Schema: "public",
+				Columns: []objects.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "user_id", DataType: "uuid"},
+					{Name: "status", DataType: "USER-DEFINED", Format: "profile_status", Enums: []string{"active"}},
+				},
+			},
+			Relations: []state.Relation{
+				{Table: "users", Schema: "auth", Type: "*Users", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "user_id"},
+			},
+			SchemaPackage: true,
+			ProjectName:   "my_app",
+		},
+	}
+
+	err := generator.GenerateModels(dir, inputs, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.ModelDir, "public", "profile.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "package public")
+	assert.Contains(t, string(content), `"myapp/internal/models"`)
+	assert.Contains(t, string(content), `"myapp/internal/models/auth"`)
+	assert.Contains(t, string(content), "Status models.ProfileStatus")
+	assert.Contains(t, string(content), "Users *auth.Users")
+
+	content, err = os.ReadFile(filepath.Join(dir, generator.ModelDir, "auth", "users.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "package auth")
+
+	models, err := generator.WalkScanModel(filepath.Join(dir, generator.ModelDir))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"auth.Users", "public.Profile"}, models)
+}
+
+func TestGenerateModels_ModulePath(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	inputs := []*generator.GenerateModelInput{
+		{
+			Table: objects.Table{
+				Name:    "users",
+				Schema:  "public",
+				Columns: []objects.Column{{Name: "id", DataType: "uuid"}},
+			},
+			ProjectName:   "my_app",
+			ModulePath:    "github.com/acme/shop/",
+			ModelsPackage: "store",
+		},
+		{
+			Table: objects.Table{
+				Name:   "profile",
+				Schema: "auth",
+				Columns: []objects.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "status", DataType: "USER-DEFINED", Format: "profile_status", Enums: []string{"active"}},
+				},
+			},
+			SchemaPackage: true,
+			ProjectName:   "my_app",
+			ModulePath:    "github.com/acme/shop",
+			ModelsPackage: "store",
+		},
+	}
+
+	err := generator.GenerateModels(dir, inputs, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.ModelDir, "users.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "package store")
+
+	content, err = os.ReadFile(filepath.Join(dir, generator.ModelDir, "auth", "profile.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "package auth")
+	assert.Contains(t, string(content), `"github.com/acme/shop/internal/models"`)
+	assert.Contains(t, string(content), "Status store.ProfileStatus")
+}
+
+// parseModelDecls format declaration and import path of every go file in model folder
+func parseModelDecls(t *testing.T, dir string) (decls []string, imports []string) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	assert.NoError(t, err)
+
+	mapImport := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, i := range file.Imports {
+				mapImport[i.Path.Value] = true
+			}
+
+			for _, d := range file.Decls {
+				if gd, isGen := d.(*ast.GenDecl); isGen && gd.Tok == token.IMPORT {
+					continue
+				}
+
+				var buff bytes.Buffer
+				assert.NoError(t, format.Node(&buff, fset, d))
+				decls = append(decls, buff.String())
+			}
+		}
+	}
+
+	for i := range mapImport {
+		imports = append(imports, i)
+	}
+	sort.Strings(decls)
+	sort.Strings(imports)
+	return
+}
+
+func TestGenerateModels_SingleFile(t *testing.T) {
+	newInputs := func() []*generator.GenerateModelInput {
+		return []*generator.GenerateModelInput{
+			{
+				Table: objects.Table{
+					Name:    "users",
+					Schema:  "public",
+					Comment: "registered user",
+					Columns: []objects.Column{
+						{Name: "id", DataType: "uuid"},
+						{Name: "created_at", DataType: "timestamp with time zone"},
+					},
+					PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "users"}},
+				},
+				Relations: []state.Relation{
+					{Table: "orders", Schema: "public", Type: "[]*Orders", RelationType: raiden.RelationTypeHasMany, PrimaryKey: "id", ForeignKey: "user_id"},
+				},
+			},
+			{
+				Table: objects.Table{
+					Name:   "orders",
+					Schema: "public",
+					Columns: []objects.Column{
+						{Name: "id", DataType: "bigint"},
+						{Name: "user_id", DataType: "uuid"},
+						{Name: "detail", DataType: "jsonb", IsNullable: true},
+					},
+					PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "orders"}},
+				},
+				Relations: []state.Relation{
+					{Table: "users", Schema: "public", Type: "*Users", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "user_id"},
+				},
+			},
+		}
+	}
+
+	perFileDir, singleFileDir := t.TempDir(), t.TempDir()
+	for _, dir := range []string{perFileDir, singleFileDir} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+	}
+
+	assert.NoError(t, generator.GenerateModels(perFileDir, newInputs(), generator.Generate))
+	assert.NoError(t, generator.GenerateModelsWithContext(context.Background(), singleFileDir, newInputs(), generator.Generate, true))
+
+	entries, err := os.ReadDir(filepath.Join(singleFileDir, generator.ModelDir))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, generator.ModelsFileName, entries[0].Name())
+
+	content, err := os.ReadFile(filepath.Join(singleFileDir, generator.ModelDir, generator.ModelsFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(content), "package models"))
+	assert.Less(t, strings.Index(string(content), "type Orders struct"), strings.Index(string(content), "type Users struct"))
+
+	// generated file is gofmt clean
+	formatted, err := format.Source(content)
+	assert.NoError(t, err)
+	assert.Equal(t, string(formatted), string(content))
+
+	// single file declare the same model and import as model file
+	perFileDecls, perFileImports := parseModelDecls(t, filepath.Join(perFileDir, generator.ModelDir))
+	singleFileDecls, singleFileImports := parseModelDecls(t, filepath.Join(singleFileDir, generator.ModelDir))
+	assert.Equal(t, perFileDecls, singleFileDecls)
+	assert.Equal(t, perFileImports, singleFileImports)
+
+	// single file can't be split to schema package
+	inputs := newInputs()
+	inputs[0].SchemaPackage = true
+	err = generator.GenerateModelsWithContext(context.Background(), singleFileDir, inputs, generator.Generate, true)
+	assert.ErrorIs(t, err, generator.ErrSingleFileModel)
+}
+
+func TestGenerateModel_NameTransformer(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "tbl_profiles",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "col_id", DataType: "bigint"},
+				{Name: "col_first_name", DataType: "text", IsNullable: true},
+			},
+		},
+		Relations: []state.Relation{
+			{Table: "tbl_users", Schema: "public", Type: "*TblUsers", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "col_user_id"},
+		},
+		NameTransformer: generator.PrefixNameTransformer{
+			ColumnPrefixes: []string{"col_"},
+			TablePrefixes:  []string{"tbl_"},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "tbl_profiles.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "type Profiles struct")
+	assert.Contains(t, string(content), "FirstName *string `json:\"col_first_name,omitempty\" column:\"name:col_first_name;type:text;nullable\"`")
+	assert.Contains(t, string(content), "tableName:\"tbl_profiles\"")
+	assert.Contains(t, string(content), "Users *Users `json:\"tbl_users,omitempty\"")
+}
+
+func TestGenerateModel_StructNames(t *testing.T) {
+	dir := t.TempDir()
+	structNames := map[string]string{"auth.users": "UsersAuth"}
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "users",
+			Schema: "auth",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "uuid"},
+			},
+		},
+		Relations: []state.Relation{
+			{Table: "users", Schema: "public", Type: "*Users", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "id"},
+		},
+		StructNames: structNames,
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "users_auth.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "type UsersAuth struct")
+	assert.Contains(t, string(content), "tableName:\"users\"")
+	assert.Contains(t, string(content), "Users *Users `json:\"users,omitempty\"")
+
+	input = &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "users",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "uuid"},
+			},
+		},
+		Relations: []state.Relation{
+			{Table: "users", Schema: "auth", Type: "*Users", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "id"},
+		},
+		StructNames: structNames,
+	}
+
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "users.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "type Users struct")
+	assert.Contains(t, string(content), "Users *UsersAuth `json:\"users,omitempty\"")
+}
+
+func TestGenerateModel_JsonCase(t *testing.T) {
+	cases := []struct {
+		jsonCase    generator.JsonCase
+		columnKey   string
+		relationKey string
+	}{
+		{jsonCase: "", columnKey: "first_name", relationKey: "user_roles"},
+		{jsonCase: generator.JsonCaseSnake, columnKey: "first_name", relationKey: "user_roles"},
+		{jsonCase: generator.JsonCaseCamel, columnKey: "firstName", relationKey: "userRoles"},
+		{jsonCase: generator.JsonCasePascal, columnKey: "FirstName", relationKey: "UserRoles"},
+	}
+
+	for _, c := range cases {
+		dir := t.TempDir()
+		input := &generator.GenerateModelInput{
+			Table: objects.Table{
+				Name:   "users",
+				Schema: "public",
+				Columns: []objects.Column{
+					{Name: "first_name", DataType: "text", IsNullable: true},
+				},
+			},
+			Relations: []state.Relation{
+				{Table: "user_roles", Type: "[]*UserRoles", RelationType: raiden.RelationTypeHasMany, PrimaryKey: "id", ForeignKey: "user_id"},
+			},
+			JsonCase: c.jsonCase,
+		}
+
+		err := generator.GenerateModel(dir, input, generator.Generate)
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "users.go"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), fmt.Sprintf("`json:\"%s,omitempty\" column:\"name:first_name;", c.columnKey))
+		assert.Contains(t, string(content), fmt.Sprintf("`json:\"%s,omitempty\" join:\"joinType:hasMany;", c.relationKey))
+	}
+}
+
+func TestToJsonKey(t *testing.T) {
+	assert.Equal(t, "id", generator.ToJsonKey("id", generator.JsonCaseCamel))
+	assert.Equal(t, "Id", generator.ToJsonKey("id", generator.JsonCasePascal))
+	assert.Equal(t, "createdAt", generator.ToJsonKey("created_at", "CAMEL"))
+	assert.Equal(t, "created_at", generator.ToJsonKey("created_at", "kebab"))
+
+	assert.True(t, generator.IsValidJsonCase(""))
+	assert.True(t, generator.IsValidJsonCase(generator.JsonCasePascal))
+	assert.False(t, generator.IsValidJsonCase("kebab"))
+}
+
+func TestGenerateModel_WithoutPrimaryKey(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "audit_logs",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "action", DataType: "text"},
+				{Name: "created_at", DataType: "timestamp with time zone"},
+			},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "audit_logs.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Metadata string `json:\"-\" schema:\"public\" rlsEnable:\"false\" rlsForced:\"false\" readOnly:\"true\"`")
+
+	// view is always read only and doesn't need the tag
+	input.Table.IsView = true
+	err = generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "audit_logs.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "readOnly:\"true\"")
+}
+
+func TestGenerateModel_CheckConstraint(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "status", DataType: "text", Check: "(status = ANY (ARRAY['pending'::text, 'in_progress'::text, 'done'::text]))"},
+				{Name: "price", DataType: "numeric", Check: "(price > (0)::numeric)"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "check:\"(status = ANY (ARRAY['pending'::text, 'in_progress'::text, 'done'::text]))\" validate:\"omitempty,oneof=pending in_progress done\"`")
+	assert.Contains(t, string(content), "check:\"(price > (0)::numeric)\"`")
+	assert.Contains(t, string(content), "OrdersStatusPending = \"pending\"")
+	assert.Contains(t, string(content), "OrdersStatusInProgress = \"in_progress\"")
+	assert.Contains(t, string(content), "OrdersStatusDone = \"done\"")
+}
+
+func TestGenerateModel_ColumnGrant(t *testing.T) {
+	// column as returned by column query after `GRANT SELECT (email) ON public.customers TO support`
+	columnsJson := `[
+		{"schema":"public","table":"customers","name":"id","data_type":"bigint","format":"int8","is_nullable":false,"privileges":[]},
+		{"schema":"public","table":"customers","name":"email","data_type":"text","format":"text","is_nullable":true,"privileges":[{"grantee":"support","privilege_type":"SELECT"}]}
+	]`
+
+	var columns []objects.Column
+	assert.NoError(t, json.Unmarshal([]byte(columnsJson), &columns))
+
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:        "customers",
+			Schema:      "public",
+			Columns:     columns,
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "customers.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "column:\"name:email;type:text;nullable\" grant:\"select:support\"`")
+	assert.Equal(t, 1, strings.Count(string(content), "grant:"))
+}
+
+func TestGenerateModel_DefaultValue(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint", DefaultValue: "nextval('orders_id_seq'::regclass)"},
+				{Name: "note", DataType: "text"},
+				{Name: "is_paid", DataType: "boolean", DefaultValue: "false"},
+				{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: "now()"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Id *int64 `json:\"id,omitempty\" column:\"name:id;type:bigint;primaryKey;nullable:false;default:nextval('orders_id_seq'::regclass)\"`")
+	assert.Contains(t, string(content), "Note string `json:\"note,omitempty\" column:\"name:note;type:text;nullable:false\"`")
+	assert.Contains(t, string(content), "IsPaid *bool `json:\"is_paid,omitempty\" column:\"name:is_paid;type:boolean;nullable:false;default:false\"`")
+	assert.Contains(t, string(content), "CreatedAt *time.Time `json:\"created_at,omitempty\" column:\"name:created_at;type:timestampz;nullable:false;default:now()\"`")
+}
+
+func TestGenerateModel_Index(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "posts",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "tags", DataType: "ARRAY", Format: "_text"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+			Indexes: []objects.TableIndex{
+				{Name: "posts_tags_idx", Columns: []string{"tags"}, Method: "gin", Predicate: "deleted_at IS NULL"},
+				{Name: "tags", Columns: []string{"lower(title)", "id DESC"}, IsUnique: true, Method: "btree"},
+			},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "posts.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "// Indexes\n")
+	assert.Contains(t, string(content), "PostsTagsIdx string `json:\"-\" index:\"name:posts_tags_idx;method:gin\" indexColumns:\"tags\" indexWhere:\"deleted_at IS NULL\"`")
+	assert.Contains(t, string(content), "TagsIndex string `json:\"-\" index:\"name:tags;unique\" indexColumns:\"lower(title), id DESC\"`")
+}
+
+func TestGenerateModel_TypeModifier(t *testing.T) {
+	dir := t.TempDir()
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "products",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "code", DataType: "character varying", Length: 50},
+				{Name: "price", DataType: "numeric", Precision: 12, Scale: 2, IsNullable: true},
+				{Name: "name", DataType: "character varying"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "products.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "column:\"name:code;type:varchar(50)")
+	assert.Contains(t, string(content), "column:\"name:price;type:numeric(12,2)")
+	assert.Contains(t, string(content), "column:\"name:name;type:varchar;")
+}
+
+func TestGenerateModel_UuidPrimaryKey(t *testing.T) {
+	newInput := func(uuidType generator.UuidType) *generator.GenerateModelInput {
+		return &generator.GenerateModelInput{
+			Table: objects.Table{
+				Name:   "devices",
+				Schema: "public",
+				Columns: []objects.Column{
+					{Name: "id", DataType: "uuid", DefaultValue: "gen_random_uuid()"},
+					{Name: "owner_id", DataType: "uuid", DefaultValue: "extensions.uuid_generate_v4()"},
+					{Name: "token", DataType: "uuid", IsNullable: true},
+				},
+				PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+			},
+			UuidType: uuidType,
+		}
+	}
+
+	dir := t.TempDir()
+	err := generator.GenerateModel(dir, newInput(""), generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "devices.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"github.com/google/uuid\"")
+	assert.Contains(t, string(content), "Id *uuid.UUID `json:\"id,omitempty\" column:\"name:id;type:uuid;primaryKey;nullable:false;default:gen_random_uuid();serverDefault\"`")
+	assert.Contains(t, string(content), "column:\"name:owner_id;type:uuid;nullable:false;default:extensions.uuid_generate_v4();serverDefault\"")
+	assert.Contains(t, string(content), "Token *uuid.UUID `json:\"token,omitempty\" column:\"name:token;type:uuid;nullable\"`")
+
+	dir = t.TempDir()
+	err = generator.GenerateModel(dir, newInput(generator.UuidTypeString), generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filepath.Join(dir, "devices.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "\"github.com/google/uuid\"")
+	assert.Contains(t, string(content), "Id *string `json:\"id,omitempty\" column:\"name:id;type:uuid;primaryKey;nullable:false;default:gen_random_uuid();serverDefault\"`")
+}
+
+func TestGenerateModel_BooleanAndTimestampDefault(t *testing.T) {
+	createdAt := "CURRENT_TIMESTAMP"
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "accounts",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "is_active", DataType: "boolean", DefaultValue: "true"},
+				{Name: "updated_at", DataType: "timestamp with time zone", DefaultValue: "now()"},
+				{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: &createdAt},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+
+	dir := t.TempDir()
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "accounts.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "column:\"name:is_active;type:boolean;nullable:false;default:true\"")
+	assert.Contains(t, string(content), "column:\"name:updated_at;type:timestampz;nullable:false;default:now()\"")
+	assert.Contains(t, string(content), "column:\"name:created_at;type:timestampz;nullable:false;default:CURRENT_TIMESTAMP\"")
+}
+
+func TestGenerateModel_SerialPrimaryKey(t *testing.T) {
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "invoices",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", Table: "invoices", DataType: "bigint", DefaultValue: "nextval('invoices_id_seq'::regclass)", Sequence: "public.invoices_id_seq"},
+				{Name: "number", Table: "invoices", DataType: "bigint", DefaultValue: "nextval('document_number_seq'::regclass)"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+	}
+
+	dir := t.TempDir()
+	err := generator.GenerateModel(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "invoices.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "column:\"name:id;type:bigserial;primaryKey;nullable:false;default:nextval('invoices_id_seq'::regclass)\"")
+	assert.Contains(t, string(content), "column:\"name:number;type:bigint;nullable:false;default:nextval('document_number_seq'::regclass)\"")
+}
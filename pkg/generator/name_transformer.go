@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// NameTransformer map database name to go identifier used in generated model,
+// database side tag (column, join, table name) always keep the real name
+type NameTransformer interface {
+	Column(name string) string
+	Table(name string) string
+	Relation(name string) string
+}
+
+// DefaultNameTransformer convert name to pascal case without any modification
+type DefaultNameTransformer struct{}
+
+func (DefaultNameTransformer) Column(name string) string {
+	return utils.SnakeCaseToPascalCase(name)
+}
+
+func (DefaultNameTransformer) Table(name string) string {
+	return utils.SnakeCaseToPascalCase(name)
+}
+
+func (DefaultNameTransformer) Relation(name string) string {
+	return utils.SnakeCaseToPascalCase(name)
+}
+
+// PrefixNameTransformer strip configured prefix before convert name to pascal case,
+// relation is named by related table so table prefix is used for relation name
+type PrefixNameTransformer struct {
+	ColumnPrefixes []string
+	TablePrefixes  []string
+}
+
+func (t PrefixNameTransformer) Column(name string) string {
+	return utils.SnakeCaseToPascalCase(trimNamePrefix(name, t.ColumnPrefixes))
+}
+
+func (t PrefixNameTransformer) Table(name string) string {
+	return utils.SnakeCaseToPascalCase(trimNamePrefix(name, t.TablePrefixes))
+}
+
+func (t PrefixNameTransformer) Relation(name string) string {
+	return utils.SnakeCaseToPascalCase(trimNamePrefix(name, t.TablePrefixes))
+}
+
+// trimNamePrefix remove first matched prefix, name is returned as is
+// when nothing left after prefix is removed
+func trimNamePrefix(name string, prefixes []string) string {
+	for _, p := range prefixes {
+		if p == "" || !strings.HasPrefix(name, p) {
+			continue
+		}
+
+		if trimmed := strings.TrimPrefix(name, p); trimmed != "" {
+			return trimmed
+		}
+	}
+	return name
+}
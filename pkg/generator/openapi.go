@@ -0,0 +1,421 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+var OpenApiLogger hclog.Logger = logger.HcLog().Named("generator.openapi")
+
+// ----- Define type, variable and constant -----
+type (
+	OpenApiDocument struct {
+		OpenApi    string                      `json:"openapi" yaml:"openapi"`
+		Info       OpenApiInfo                 `json:"info" yaml:"info"`
+		Servers    []OpenApiServer             `json:"servers,omitempty" yaml:"servers,omitempty"`
+		Paths      map[string]*OpenApiPathItem `json:"paths" yaml:"paths"`
+		Components OpenApiComponents           `json:"components" yaml:"components"`
+	}
+
+	OpenApiInfo struct {
+		Title   string `json:"title" yaml:"title"`
+		Version string `json:"version" yaml:"version"`
+	}
+
+	OpenApiServer struct {
+		Url string `json:"url" yaml:"url"`
+	}
+
+	OpenApiPathItem struct {
+		Get    *OpenApiOperation `json:"get,omitempty" yaml:"get,omitempty"`
+		Post   *OpenApiOperation `json:"post,omitempty" yaml:"post,omitempty"`
+		Patch  *OpenApiOperation `json:"patch,omitempty" yaml:"patch,omitempty"`
+		Delete *OpenApiOperation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	}
+
+	OpenApiOperation struct {
+		OperationId string                      `json:"operationId" yaml:"operationId"`
+		Summary     string                      `json:"summary,omitempty" yaml:"summary,omitempty"`
+		Tags        []string                    `json:"tags,omitempty" yaml:"tags,omitempty"`
+		Parameters  []OpenApiParameter          `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		RequestBody *OpenApiRequestBody         `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+		Responses   map[string]*OpenApiResponse `json:"responses" yaml:"responses"`
+	}
+
+	OpenApiParameter struct {
+		Name        string         `json:"name" yaml:"name"`
+		In          string         `json:"in" yaml:"in"`
+		Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+		Required    bool           `json:"required,omitempty" yaml:"required,omitempty"`
+		Schema      *OpenApiSchema `json:"schema" yaml:"schema"`
+	}
+
+	OpenApiRequestBody struct {
+		Required bool                        `json:"required,omitempty" yaml:"required,omitempty"`
+		Content  map[string]OpenApiMediaType `json:"content" yaml:"content"`
+	}
+
+	OpenApiResponse struct {
+		Description string                      `json:"description" yaml:"description"`
+		Content     map[string]OpenApiMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+	}
+
+	OpenApiMediaType struct {
+		Schema *OpenApiSchema `json:"schema" yaml:"schema"`
+	}
+
+	OpenApiComponents struct {
+		Schemas map[string]*OpenApiSchema `json:"schemas" yaml:"schemas"`
+	}
+
+	OpenApiSchema struct {
+		Ref        string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+		Type       string                    `json:"type,omitempty" yaml:"type,omitempty"`
+		Format     string                    `json:"format,omitempty" yaml:"format,omitempty"`
+		Nullable   bool                      `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+		ReadOnly   bool                      `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+		Enum       []string                  `json:"enum,omitempty" yaml:"enum,omitempty"`
+		Items      *OpenApiSchema            `json:"items,omitempty" yaml:"items,omitempty"`
+		Properties map[string]*OpenApiSchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+		Required   []string                  `json:"required,omitempty" yaml:"required,omitempty"`
+	}
+)
+
+const (
+	OpenApiVersion     = "3.0.3"
+	OpenApiServerUrl   = "/rest/v1"
+	OpenApiContentType = "application/json"
+)
+
+// GenerateOpenApi write openapi document of table and rpc function to file path,
+// document is written as json when file extension is .json and yaml for other extension
+func GenerateOpenApi(filePath string, title string, modelsPackage string, tables []objects.Table, functions []objects.Function) error {
+	doc := BuildOpenApiDocument(title, modelsPackage, tables, functions)
+
+	var content []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		content, err = json.MarshalIndent(doc, "", "  ")
+	} else {
+		content, err = yaml.Marshal(doc)
+	}
+	if err != nil {
+		return fmt.Errorf("failed encode openapi document : %v", err)
+	}
+
+	OpenApiLogger.Debug("generate openapi", "path", filePath)
+	return os.WriteFile(filePath, content, 0644)
+}
+
+// BuildOpenApiDocument build postgrest style openapi document, every table has crud operation
+// in /<table> path and every rpc function has post operation in /rpc/<function> path.
+// Schema is derived with the same go type mapping that used by generated model and rpc,
+// rpc that return model of models package refer to schema of the table.
+func BuildOpenApiDocument(title string, modelsPackage string, tables []objects.Table, functions []objects.Function) *OpenApiDocument {
+	project := ProjectPackage{ModelsPackage: modelsPackage}
+
+	doc := &OpenApiDocument{
+		OpenApi: OpenApiVersion,
+		Info:    OpenApiInfo{Title: title, Version: "1.0.0"},
+		Servers: []OpenApiServer{{Url: OpenApiServerUrl}},
+		Paths:   make(map[string]*OpenApiPathItem),
+		Components: OpenApiComponents{
+			Schemas: make(map[string]*OpenApiSchema),
+		},
+	}
+
+	sortedTables := make([]objects.Table, len(tables))
+	copy(sortedTables, tables)
+	sort.SliceStable(sortedTables, func(i, j int) bool {
+		return fmt.Sprintf("%s.%s", sortedTables[i].Schema, sortedTables[i].Name) < fmt.Sprintf("%s.%s", sortedTables[j].Schema, sortedTables[j].Name)
+	})
+
+	for i := range sortedTables {
+		t := sortedTables[i]
+		path := "/" + t.Name
+		if _, exist := doc.Paths[path]; exist {
+			OpenApiLogger.Warn("skip openapi path, table with the same name is already declared", "schema", t.Schema, "table", t.Name)
+			continue
+		}
+
+		schemaName := toOpenApiSchemaName(t.Schema, t.Name)
+		doc.Components.Schemas[schemaName] = buildOpenApiTableSchema(t)
+		doc.Paths[path] = buildOpenApiTablePath(t, schemaName)
+	}
+
+	for i := range functions {
+		fn := functions[i]
+		path := "/rpc/" + fn.Name
+		if _, exist := doc.Paths[path]; exist {
+			OpenApiLogger.Warn("skip openapi path, rpc with the same name is already declared", "schema", fn.Schema, "name", fn.Name)
+			continue
+		}
+
+		item, err := buildOpenApiRpcPath(&fn, project, doc.Components.Schemas)
+		if err != nil {
+			OpenApiLogger.Warn("skip openapi path, failed extract rpc", "schema", fn.Schema, "name", fn.Name, "reason", err.Error())
+			continue
+		}
+		doc.Paths[path] = item
+	}
+
+	return doc
+}
+
+// toOpenApiSchemaName return component name of table,
+// table in public schema use the same name as generated model
+func toOpenApiSchemaName(schema, table string) string {
+	if schema == "" || schema == "public" {
+		return utils.SnakeCaseToPascalCase(table)
+	}
+	return utils.SnakeCaseToPascalCase(schema) + utils.SnakeCaseToPascalCase(table)
+}
+
+func buildOpenApiTableSchema(table objects.Table) *OpenApiSchema {
+	columns, _ := MapTableAttributes(table)
+
+	schema := &OpenApiSchema{
+		Type:       "object",
+		Properties: make(map[string]*OpenApiSchema),
+	}
+
+	for i, c := range table.Columns {
+		if i >= len(columns) {
+			break
+		}
+
+		property := goTypeToOpenApiSchema(strings.TrimPrefix(columns[i].Type, "*"), ModelsPackage)
+		property.Nullable = c.IsNullable
+		property.ReadOnly = isReadOnlyColumn(c)
+
+		if len(c.Enums) > 0 {
+			property.Type, property.Format = "string", ""
+			property.Enum = c.Enums
+		} else if values, isEnumCheck := ParseCheckInValues(c.Name, c.GetCheck()); isEnumCheck {
+			property.Enum = values
+		}
+
+		if !table.IsView && !c.IsNullable && !c.IsIdentity && !hasColumnDefault(c) && !property.ReadOnly {
+			schema.Required = append(schema.Required, c.Name)
+		}
+		schema.Properties[c.Name] = property
+	}
+
+	return schema
+}
+
+func buildOpenApiTablePath(table objects.Table, schemaName string) *OpenApiPathItem {
+	ref := &OpenApiSchema{Ref: "#/components/schemas/" + schemaName}
+	tags := []string{schemaName}
+
+	var readParams, writeParams []OpenApiParameter
+	if table.Schema != "" && table.Schema != "public" {
+		readParams = append(readParams, OpenApiParameter{
+			Name: "Accept-Profile", In: "header", Required: true,
+			Schema: &OpenApiSchema{Type: "string", Enum: []string{table.Schema}},
+		})
+		writeParams = append(writeParams, OpenApiParameter{
+			Name: "Content-Profile", In: "header", Required: true,
+			Schema: &OpenApiSchema{Type: "string", Enum: []string{table.Schema}},
+		})
+	}
+
+	listParams := append([]OpenApiParameter{
+		{Name: "select", In: "query", Description: "column to return, example : id,name", Schema: &OpenApiSchema{Type: "string"}},
+	}, readParams...)
+
+	item := &OpenApiPathItem{
+		Get: &OpenApiOperation{
+			OperationId: "list" + schemaName,
+			Summary:     fmt.Sprintf("list %s.%s", table.Schema, table.Name),
+			Tags:        tags,
+			Parameters:  listParams,
+			Responses: map[string]*OpenApiResponse{
+				"200": {
+					Description: "OK",
+					Content:     map[string]OpenApiMediaType{OpenApiContentType: {Schema: &OpenApiSchema{Type: "array", Items: ref}}},
+				},
+			},
+		},
+	}
+
+	// view and table without primary key is read only, row can't be targeted for mutation
+	if table.IsView || IsReadOnlyTable(table) {
+		return item
+	}
+
+	var keyParams []OpenApiParameter
+	for _, pk := range table.PrimaryKeys {
+		keyParams = append(keyParams, OpenApiParameter{
+			Name: pk.Name, In: "query",
+			Description: fmt.Sprintf("filter by %s, example : eq.1", pk.Name),
+			Schema:      &OpenApiSchema{Type: "string"},
+		})
+	}
+	keyParams = append(keyParams, writeParams...)
+
+	item.Post = &OpenApiOperation{
+		OperationId: "create" + schemaName,
+		Summary:     fmt.Sprintf("create %s.%s", table.Schema, table.Name),
+		Tags:        tags,
+		Parameters:  writeParams,
+		RequestBody: &OpenApiRequestBody{
+			Required: true,
+			Content:  map[string]OpenApiMediaType{OpenApiContentType: {Schema: ref}},
+		},
+		Responses: map[string]*OpenApiResponse{"201": {Description: "Created"}},
+	}
+
+	item.Patch = &OpenApiOperation{
+		OperationId: "update" + schemaName,
+		Summary:     fmt.Sprintf("update %s.%s", table.Schema, table.Name),
+		Tags:        tags,
+		Parameters:  keyParams,
+		RequestBody: &OpenApiRequestBody{
+			Required: true,
+			Content:  map[string]OpenApiMediaType{OpenApiContentType: {Schema: ref}},
+		},
+		Responses: map[string]*OpenApiResponse{"204": {Description: "No Content"}},
+	}
+
+	item.Delete = &OpenApiOperation{
+		OperationId: "delete" + schemaName,
+		Summary:     fmt.Sprintf("delete %s.%s", table.Schema, table.Name),
+		Tags:        tags,
+		Parameters:  keyParams,
+		Responses:   map[string]*OpenApiResponse{"204": {Description: "No Content"}},
+	}
+
+	return item
+}
+
+func buildOpenApiRpcPath(fn *objects.Function, project ProjectPackage, schemas map[string]*OpenApiSchema) (*OpenApiPathItem, error) {
+	result, err := ExtractRpcFunction(fn)
+	if err != nil {
+		return nil, err
+	}
+	result.Project = project
+	modelsPackage := project.GetModelsPackage()
+
+	mapImports := make(map[string]bool)
+	params, err := result.GetParams(mapImports)
+	if err != nil {
+		return nil, err
+	}
+
+	returnDecl, returnColumns, isReturnArr, err := result.GetReturn(mapImports)
+	if err != nil {
+		return nil, err
+	}
+
+	requestSchema := &OpenApiSchema{Type: "object", Properties: make(map[string]*OpenApiSchema)}
+	for _, p := range params {
+		name, isOptional := getOpenApiJsonName(p.Tag)
+		requestSchema.Properties[name] = goTypeToOpenApiSchema(strings.TrimPrefix(p.Type, "*"), modelsPackage)
+		if !isOptional {
+			requestSchema.Required = append(requestSchema.Required, name)
+		}
+	}
+
+	var responseSchema *OpenApiSchema
+	if len(returnColumns) > 0 {
+		responseSchema = &OpenApiSchema{Type: "object", Properties: make(map[string]*OpenApiSchema)}
+		for _, c := range returnColumns {
+			name, _ := getOpenApiJsonName(c.Tag)
+			responseSchema.Properties[name] = goTypeToOpenApiSchema(c.Type, modelsPackage)
+		}
+	} else {
+		responseSchema = goTypeToOpenApiSchema(returnDecl, modelsPackage)
+		if responseSchema.Ref != "" {
+			if _, exist := schemas[strings.TrimPrefix(responseSchema.Ref, "#/components/schemas/")]; !exist {
+				OpenApiLogger.Debug("rpc return model is not imported, use object as return schema", "rpc", fn.Name, "return", returnDecl)
+				responseSchema = &OpenApiSchema{Type: "object"}
+			}
+		}
+	}
+
+	if isReturnArr {
+		responseSchema = &OpenApiSchema{Type: "array", Items: responseSchema}
+	}
+
+	operation := &OpenApiOperation{
+		OperationId: "rpc" + utils.SnakeCaseToPascalCase(fn.Name),
+		Summary:     fmt.Sprintf("call %s.%s", fn.Schema, fn.Name),
+		Tags:        []string{"rpc"},
+		RequestBody: &OpenApiRequestBody{
+			Required: len(requestSchema.Required) > 0,
+			Content:  map[string]OpenApiMediaType{OpenApiContentType: {Schema: requestSchema}},
+		},
+		Responses: map[string]*OpenApiResponse{
+			"200": {
+				Description: "OK",
+				Content:     map[string]OpenApiMediaType{OpenApiContentType: {Schema: responseSchema}},
+			},
+		},
+	}
+
+	return &OpenApiPathItem{Post: operation}, nil
+}
+
+// getOpenApiJsonName get json key from struct tag and check if field is optional
+func getOpenApiJsonName(tag string) (string, bool) {
+	jsonTag := reflect.StructTag(tag).Get("json")
+	split := strings.Split(jsonTag, ",")
+	return split[0], len(split) > 1 && split[1] == "omitempty"
+}
+
+// goTypeToOpenApiSchema convert go type that used in generated model and rpc to openapi schema,
+// type of models package is converted to reference of table schema
+func goTypeToOpenApiSchema(goType string, modelsPackage string) *OpenApiSchema {
+	goType = strings.TrimPrefix(goType, "*")
+
+	if strings.HasPrefix(goType, "[]") {
+		return &OpenApiSchema{Type: "array", Items: goTypeToOpenApiSchema(strings.TrimPrefix(goType, "[]"), modelsPackage)}
+	}
+
+	// range is serialized as range literal
+	if strings.HasPrefix(goType, "raiden.Range[") {
+		return &OpenApiSchema{Type: "string"}
+	}
+
+	if modelType, isModel := strings.CutPrefix(goType, modelsPackage+"."); isModel {
+		return &OpenApiSchema{Ref: "#/components/schemas/" + modelType}
+	}
+
+	switch goType {
+	case "int16", "int32":
+		return &OpenApiSchema{Type: "integer", Format: "int32"}
+	case "int", "int64":
+		return &OpenApiSchema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &OpenApiSchema{Type: "number", Format: "float"}
+	case "float64":
+		return &OpenApiSchema{Type: "number", Format: "double"}
+	case "bool":
+		return &OpenApiSchema{Type: "boolean"}
+	case "string":
+		return &OpenApiSchema{Type: "string"}
+	case "time.Time":
+		return &OpenApiSchema{Type: "string", Format: "date-time"}
+	case "time.Duration":
+		return &OpenApiSchema{Type: "string"}
+	case "uuid.UUID":
+		return &OpenApiSchema{Type: "string", Format: "uuid"}
+	case "map[string]any", "map[string]interface{}":
+		return &OpenApiSchema{Type: "object"}
+	default:
+		// json.RawMessage, interface{} and custom type can hold any value
+		return &OpenApiSchema{}
+	}
+}
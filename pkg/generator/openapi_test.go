@@ -0,0 +1,127 @@
+package generator_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func openApiTestTables() []objects.Table {
+	return []objects.Table{
+		{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint", IsIdentity: true, IdentityGeneration: "ALWAYS"},
+				{Name: "note", DataType: "text", IsNullable: true},
+				{Name: "status", DataType: "text", Check: "status = ANY (ARRAY['pending'::text, 'paid'::text])"},
+				{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: "now()"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "orders"}},
+		},
+		{
+			Name:   "logs",
+			Schema: "audit",
+			Columns: []objects.Column{
+				{Name: "message", DataType: "text"},
+			},
+		},
+	}
+}
+
+func openApiTestFunctions() []objects.Function {
+	return []objects.Function{
+		{
+			Schema:     "public",
+			Name:       "count_orders",
+			Language:   "plpgsql",
+			Definition: `BEGIN RETURN (SELECT count(*) FROM orders o WHERE o.status = in_status); END;`,
+			ReturnType: "bigint",
+			Behavior:   "STABLE",
+			Args: []objects.FunctionArg{
+				{Mode: "in", Name: "in_status"},
+				{Mode: "in", Name: "in_limit", HasDefault: true},
+			},
+			ArgumentTypes: "in_status text, in_limit integer DEFAULT 10",
+		},
+	}
+}
+
+func TestBuildOpenApiDocument(t *testing.T) {
+	doc := generator.BuildOpenApiDocument("test", "", openApiTestTables(), openApiTestFunctions())
+
+	assert.Equal(t, generator.OpenApiVersion, doc.OpenApi)
+	assert.Equal(t, "test", doc.Info.Title)
+
+	orders := doc.Components.Schemas["Orders"]
+	assert.NotNil(t, orders)
+	assert.Equal(t, []string{"status"}, orders.Required)
+	assert.Equal(t, "integer", orders.Properties["id"].Type)
+	assert.True(t, orders.Properties["id"].ReadOnly)
+	assert.True(t, orders.Properties["note"].Nullable)
+	assert.Equal(t, []string{"pending", "paid"}, orders.Properties["status"].Enum)
+	assert.Equal(t, "date-time", orders.Properties["created_at"].Format)
+
+	ordersPath := doc.Paths["/orders"]
+	assert.NotNil(t, ordersPath)
+	assert.NotNil(t, ordersPath.Get)
+	assert.NotNil(t, ordersPath.Post)
+	assert.NotNil(t, ordersPath.Patch)
+	assert.NotNil(t, ordersPath.Delete)
+	assert.Equal(t, "#/components/schemas/Orders", ordersPath.Post.RequestBody.Content[generator.OpenApiContentType].Schema.Ref)
+
+	// table without primary key only expose read operation
+	logsPath := doc.Paths["/logs"]
+	assert.NotNil(t, logsPath)
+	assert.NotNil(t, logsPath.Get)
+	assert.Nil(t, logsPath.Post)
+	assert.NotNil(t, doc.Components.Schemas["AuditLogs"])
+	assert.Contains(t, logsPath.Get.Parameters, generator.OpenApiParameter{
+		Name: "Accept-Profile", In: "header", Required: true,
+		Schema: &generator.OpenApiSchema{Type: "string", Enum: []string{"audit"}},
+	})
+
+	rpcPath := doc.Paths["/rpc/count_orders"]
+	assert.NotNil(t, rpcPath)
+	assert.NotNil(t, rpcPath.Post)
+
+	request := rpcPath.Post.RequestBody.Content[generator.OpenApiContentType].Schema
+	assert.Equal(t, []string{"status"}, request.Required)
+	assert.Equal(t, "string", request.Properties["status"].Type)
+	assert.Equal(t, "integer", request.Properties["limit"].Type)
+
+	response := rpcPath.Post.Responses["200"].Content[generator.OpenApiContentType].Schema
+	assert.Equal(t, "integer", response.Type)
+}
+
+func TestGenerateOpenApi(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "openapi.yaml")
+	err := generator.GenerateOpenApi(yamlPath, "test", "", openApiTestTables(), openApiTestFunctions())
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(yamlPath)
+	assert.NoError(t, err)
+
+	var yamlDoc map[string]any
+	assert.NoError(t, yaml.Unmarshal(content, &yamlDoc))
+	assert.Equal(t, generator.OpenApiVersion, yamlDoc["openapi"])
+
+	jsonPath := filepath.Join(dir, "openapi.json")
+	err = generator.GenerateOpenApi(jsonPath, "test", "", openApiTestTables(), openApiTestFunctions())
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(jsonPath)
+	assert.NoError(t, err)
+
+	var jsonDoc generator.OpenApiDocument
+	assert.NoError(t, json.Unmarshal(content, &jsonDoc))
+	assert.Contains(t, jsonDoc.Paths, "/rpc/count_orders")
+}
@@ -2,8 +2,8 @@ package generator
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
-	"unicode"
 
 	"github.com/sev-2/raiden/pkg/supabase"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
@@ -14,98 +14,139 @@ type Rls struct {
 	CanRead  []string
 }
 
+// BuildRlsTag build acl tag from policies, insert check and update using are used
+// as the default write expression, update check and delete using only emitted
+// as separate tag when the expression differ from the default one
 func BuildRlsTag(rlsList objects.Policies, name string, rlsType supabase.RlsType) string {
 	var rls Rls
 
-	var readUsingTag, writeCheckTag, writeUsingTag string
+	var readUsingTag, insertCheckTag, updateCheckTag, updateUsingTag, deleteUsingTag string
+	policyType := strings.ToLower(string(rlsType))
 	for _, v := range rlsList {
 		switch v.Command {
 		case objects.PolicyCommandSelect:
-			if v.Name == supabase.GetPolicyName(objects.PolicyCommandSelect, strings.ToLower(string(rlsType)), name) {
+			if v.Name == supabase.GetPolicyName(objects.PolicyCommandSelect, policyType, name) {
 				rls.CanRead = append(rls.CanRead, v.Roles...)
 				if v.Definition != "" {
 					readUsingTag = v.Definition
 				}
 			}
-		case objects.PolicyCommandInsert, objects.PolicyCommandUpdate, objects.PolicyCommandDelete:
-			if v.Name == supabase.GetPolicyName(objects.PolicyCommandInsert, strings.ToLower(string(rlsType)), name) {
+		case objects.PolicyCommandInsert:
+			if v.Name == supabase.GetPolicyName(objects.PolicyCommandInsert, policyType, name) {
 				if len(rls.CanWrite) == 0 {
 					rls.CanWrite = append(rls.CanWrite, v.Roles...)
 				}
 
-				if len(writeCheckTag) == 0 && v.Check != nil {
-					writeCheckTag = *v.Check
+				if v.Check != nil {
+					insertCheckTag = *v.Check
 				}
 			}
-
-			if v.Name == supabase.GetPolicyName(objects.PolicyCommandUpdate, strings.ToLower(string(rlsType)), name) && len(rls.CanWrite) == 0 {
+		case objects.PolicyCommandUpdate:
+			if v.Name == supabase.GetPolicyName(objects.PolicyCommandUpdate, policyType, name) {
 				if len(rls.CanWrite) == 0 {
 					rls.CanWrite = append(rls.CanWrite, v.Roles...)
 				}
 
-				if len(writeCheckTag) == 0 && v.Check != nil {
-					writeCheckTag = *v.Check
-				}
-
-				if len(writeUsingTag) == 0 && v.Definition != "" {
-					writeUsingTag = v.Definition
+				if v.Check != nil {
+					updateCheckTag = *v.Check
 				}
+				updateUsingTag = v.Definition
 			}
-
-			if v.Name == supabase.GetPolicyName(objects.PolicyCommandDelete, strings.ToLower(string(rlsType)), name) && len(rls.CanWrite) == 0 {
+		case objects.PolicyCommandDelete:
+			if v.Name == supabase.GetPolicyName(objects.PolicyCommandDelete, policyType, name) {
 				if len(rls.CanWrite) == 0 {
 					rls.CanWrite = append(rls.CanWrite, v.Roles...)
 				}
-
-				if len(writeUsingTag) == 0 && v.Definition != "" {
-					writeUsingTag = v.Definition
-				}
+				deleteUsingTag = v.Definition
 			}
 		}
 	}
 
-	rlsTag := fmt.Sprintf("read:%q write:%q", strings.Join(rls.CanRead, ","), strings.Join(rls.CanWrite, ","))
-	if len(readUsingTag) > 0 {
-		cleanTag := strings.TrimLeft(strings.TrimRight(readUsingTag, ")"), "(")
-		if rlsType == supabase.RlsTypeStorage {
-			cleanTag = cleanupRlsTagStorage(name, cleanTag)
-		}
+	readUsingTag = cleanRlsTag(name, readUsingTag, rlsType)
+	insertCheckTag = cleanRlsTag(name, insertCheckTag, rlsType)
+	updateCheckTag = cleanRlsTag(name, updateCheckTag, rlsType)
+	updateUsingTag = cleanRlsTag(name, updateUsingTag, rlsType)
+	deleteUsingTag = cleanRlsTag(name, deleteUsingTag, rlsType)
 
-		if cleanTag != "" {
-			rlsTag = fmt.Sprintf("%s readUsing:%q", rlsTag, cleanTag)
-		}
+	writeCheckTag := insertCheckTag
+	if writeCheckTag == "" {
+		writeCheckTag = updateCheckTag
 	}
 
-	if len(writeCheckTag) > 0 {
-		cleanTag := strings.TrimLeft(strings.TrimRight(writeCheckTag, ")"), "(")
-		if rlsType == supabase.RlsTypeStorage {
-			cleanTag = cleanupRlsTagStorage(name, cleanTag)
-		}
+	writeUsingTag := updateUsingTag
+	if writeUsingTag == "" {
+		writeUsingTag = deleteUsingTag
+	}
 
-		if cleanTag != "" {
-			rlsTag = fmt.Sprintf("%s writeCheck:%q", rlsTag, cleanTag)
-		}
+	rlsTag := fmt.Sprintf("read:%q write:%q", strings.Join(rls.CanRead, ","), strings.Join(rls.CanWrite, ","))
+	if readUsingTag != "" {
+		rlsTag = fmt.Sprintf("%s readUsing:%q", rlsTag, readUsingTag)
 	}
 
-	if len(writeUsingTag) > 0 {
-		cleanTag := strings.TrimLeft(strings.TrimRight(writeUsingTag, ")"), "(")
-		if rlsType == supabase.RlsTypeStorage {
-			cleanTag = cleanupRlsTagStorage(name, cleanTag)
-		}
+	if writeCheckTag != "" {
+		rlsTag = fmt.Sprintf("%s writeCheck:%q", rlsTag, writeCheckTag)
+	}
 
-		if cleanTag != "" {
-			rlsTag = fmt.Sprintf("%s writeUsing:%q", rlsTag, cleanTag)
-		}
+	if writeUsingTag != "" {
+		rlsTag = fmt.Sprintf("%s writeUsing:%q", rlsTag, writeUsingTag)
+	}
+
+	if updateCheckTag != "" && updateCheckTag != writeCheckTag {
+		rlsTag = fmt.Sprintf("%s updateCheck:%q", rlsTag, updateCheckTag)
+	}
+
+	if deleteUsingTag != "" && deleteUsingTag != writeUsingTag {
+		rlsTag = fmt.Sprintf("%s deleteUsing:%q", rlsTag, deleteUsingTag)
 	}
 
 	return rlsTag
 }
 
-func cleanupRlsTagStorage(name, tag string) string {
-	// clean storage identifier
-	cleanTag := strings.Replace(tag, fmt.Sprintf("bucket_id = '%s'", name), "", 1)
-	cleanTag = strings.Replace(cleanTag, "AND", "", 1)
-	cleanTag = strings.Replace(cleanTag, "OR", "", 1)
-	cleanTag = strings.TrimLeftFunc(cleanTag, unicode.IsSpace)
+func cleanRlsTag(name, tag string, rlsType supabase.RlsType) string {
+	if tag == "" {
+		return tag
+	}
+
+	cleanTag := trimWrappingParentheses(tag)
+	if rlsType == supabase.RlsTypeStorage {
+		cleanTag = cleanupRlsTagStorage(name, cleanTag)
+	}
 	return cleanTag
 }
+
+// trimWrappingParentheses remove outer parentheses that wrap whole expression,
+// expression like "(a) AND (b)" or "auth.uid()" is returned as is
+func trimWrappingParentheses(expr string) string {
+	for strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		depth := 0
+		for i, c := range expr {
+			switch c {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+
+			if depth == 0 && i < len(expr)-1 {
+				return expr
+			}
+		}
+		expr = expr[1 : len(expr)-1]
+	}
+	return expr
+}
+
+// cleanupRlsTagStorage remove default bucket expression that always added
+// when storage policy is applied, e.g :
+//   - "bucket_id = 'avatar'::text" become ""
+//   - "(bucket_id = 'avatar'::text) AND (owner = auth.uid())" become "owner = auth.uid()"
+func cleanupRlsTagStorage(name, tag string) string {
+	bucketRe := regexp.MustCompile(fmt.Sprintf(`^\(?bucket_id = '%s'(::text)?\)?(\s+AND\s+|$)`, regexp.QuoteMeta(name)))
+	loc := bucketRe.FindStringIndex(tag)
+	if loc == nil {
+		return tag
+	}
+
+	cleanTag := strings.TrimSpace(tag[loc[1]:])
+	return trimWrappingParentheses(cleanTag)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/supabase"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
@@ -85,6 +86,106 @@ func TestBuildStorageRlsTag(t *testing.T) {
 
 	storagePolicies := policies.FilterByBucket(bucket)
 	rlsTag := generator.BuildRlsTag(storagePolicies, bucket.Name, supabase.RlsTypeStorage)
-	expectedTag := `read:"admin_scouter,anon,authenticated" write:"admin_scouter,authenticated" readUsing:"bucket_id = 'my-storage'::text" writeCheck:"bucket_id = 'my-storage'::text" writeUsing:"bucket_id = 'my-storage'::text"`
+	expectedTag := `read:"admin_scouter,anon,authenticated" write:"admin_scouter,authenticated"`
 	assert.Equal(t, expectedTag, rlsTag)
 }
+
+func TestBuildStorageRlsTag_CustomExpression(t *testing.T) {
+	var bucket = objects.Bucket{Name: "avatar"}
+
+	ownerCheck := "((bucket_id = 'avatar'::text) AND (owner = auth.uid()))"
+	policies := objects.Policies{
+		{
+			Schema:     "storage",
+			Table:      "objects",
+			Name:       supabase.GetPolicyName(objects.PolicyCommandSelect, "storage", bucket.Name),
+			Command:    objects.PolicyCommandSelect,
+			Roles:      []string{"authenticated"},
+			Definition: "(bucket_id = 'avatar'::text)",
+		},
+		{
+			Schema:  "storage",
+			Table:   "objects",
+			Name:    supabase.GetPolicyName(objects.PolicyCommandInsert, "storage", bucket.Name),
+			Command: objects.PolicyCommandInsert,
+			Roles:   []string{"authenticated"},
+			Check:   &ownerCheck,
+		},
+		{
+			Schema:     "storage",
+			Table:      "objects",
+			Name:       supabase.GetPolicyName(objects.PolicyCommandSelect, "storage", "avatar-private"),
+			Command:    objects.PolicyCommandSelect,
+			Roles:      []string{"service_role"},
+			Definition: "(bucket_id = 'avatar-private'::text)",
+		},
+	}
+
+	storagePolicies := policies.FilterByBucket(bucket)
+	assert.Equal(t, 2, len(storagePolicies))
+
+	rlsTag := generator.BuildRlsTag(storagePolicies, bucket.Name, supabase.RlsTypeStorage)
+	expectedTag := `read:"authenticated" write:"authenticated" writeCheck:"owner = auth.uid()"`
+	assert.Equal(t, expectedTag, rlsTag)
+}
+
+func TestBuildModelRlsTag_UpdateDelete(t *testing.T) {
+	usingExpr, checkExpr := "(user_id = auth.uid())", "(is_active = true)"
+	policies := objects.Policies{
+		{
+			Name:    supabase.GetPolicyName(objects.PolicyCommandInsert, supabase.RlsTypeModel, "profile"),
+			Command: objects.PolicyCommandInsert,
+			Roles:   []string{"authenticated"},
+			Check:   &usingExpr,
+		},
+		{
+			Name:       supabase.GetPolicyName(objects.PolicyCommandUpdate, supabase.RlsTypeModel, "profile"),
+			Command:    objects.PolicyCommandUpdate,
+			Roles:      []string{"authenticated"},
+			Definition: usingExpr,
+			Check:      &checkExpr,
+		},
+		{
+			Name:       supabase.GetPolicyName(objects.PolicyCommandDelete, supabase.RlsTypeModel, "profile"),
+			Command:    objects.PolicyCommandDelete,
+			Roles:      []string{"authenticated"},
+			Definition: usingExpr,
+		},
+	}
+
+	rlsTag := generator.BuildRlsTag(policies, "profile", supabase.RlsTypeModel)
+	expectedTag := `read:"" write:"authenticated" writeCheck:"user_id = auth.uid()" writeUsing:"user_id = auth.uid()" updateCheck:"is_active = true"`
+	assert.Equal(t, expectedTag, rlsTag)
+}
+
+func TestBuildModelRlsTag_CustomFunction(t *testing.T) {
+	usingExpr := `(public.has_role(auth.uid(), 'support'::public.app_role) OR ("OwnerId" = auth.uid()))`
+	checkExpr := `public.can_write_order(auth.uid(), tenant_id)`
+	policies := objects.Policies{
+		{
+			Name:       supabase.GetPolicyName(objects.PolicyCommandSelect, supabase.RlsTypeModel, "orders"),
+			Command:    objects.PolicyCommandSelect,
+			Roles:      []string{"authenticated", "support_agent"},
+			Definition: usingExpr,
+		},
+		{
+			Name:    supabase.GetPolicyName(objects.PolicyCommandInsert, supabase.RlsTypeModel, "orders"),
+			Command: objects.PolicyCommandInsert,
+			Roles:   []string{"support_agent"},
+			Check:   &checkExpr,
+		},
+	}
+
+	rlsTag := generator.BuildRlsTag(policies, "orders", supabase.RlsTypeModel)
+	expectedTag := `read:"authenticated,support_agent" write:"support_agent" ` +
+		`readUsing:"public.has_role(auth.uid(), 'support'::public.app_role) OR (\"OwnerId\" = auth.uid())" ` +
+		`writeCheck:"public.can_write_order(auth.uid(), tenant_id)"`
+	assert.Equal(t, expectedTag, rlsTag)
+
+	// expression and role is read back unchanged from generated tag
+	aclTag := raiden.UnmarshalAclTag(rlsTag)
+	assert.Equal(t, []string{"authenticated", "support_agent"}, aclTag.Read.Roles)
+	assert.Equal(t, `public.has_role(auth.uid(), 'support'::public.app_role) OR ("OwnerId" = auth.uid())`, aclTag.Read.Using)
+	assert.Equal(t, []string{"support_agent"}, aclTag.Write.Roles)
+	assert.Equal(t, checkExpr, *aclTag.Write.Check)
+}
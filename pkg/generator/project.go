@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// ProjectPackage is go module path and models package name of generated project,
+// every generated import to other internal package is resolved from it
+type ProjectPackage struct {
+	ProjectName   string
+	ModulePath    string
+	ModelsPackage string
+}
+
+// NewProjectPackage create project package from configured project name, module path and models package
+func NewProjectPackage(config *raiden.Config) ProjectPackage {
+	return ProjectPackage{
+		ProjectName:   config.ProjectName,
+		ModulePath:    config.ModulePath,
+		ModelsPackage: config.ModelsPackage,
+	}
+}
+
+// GetModulePath return go module path of project, module path is used
+// when set and module name derived from project name is used otherwise
+func (p ProjectPackage) GetModulePath() string {
+	modulePath := strings.TrimRight(p.ModulePath, "/")
+	if modulePath == "" {
+		modulePath = utils.ToGoModuleName(p.ProjectName)
+	}
+	return modulePath
+}
+
+// GetImportPath return import path of project folder, example : internal/rpc
+func (p ProjectPackage) GetImportPath(dir string) string {
+	return fmt.Sprintf("%s/%s", p.GetModulePath(), filepath.ToSlash(dir))
+}
+
+// GetModelsPackage return package name of generated model, models is used when not set
+func (p ProjectPackage) GetModelsPackage() string {
+	if p.ModelsPackage == "" {
+		return ModelsPackage
+	}
+	return p.ModelsPackage
+}
+
+// GetModelsImportPath return import path of models folder
+func (p ProjectPackage) GetModelsImportPath() string {
+	return p.GetImportPath(ModelDir)
+}
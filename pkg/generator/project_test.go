@@ -0,0 +1,113 @@
+package generator_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectPackage(t *testing.T) {
+	project := generator.ProjectPackage{ProjectName: "My Project"}
+	assert.Equal(t, "myproject", project.GetModulePath())
+	assert.Equal(t, "myproject/internal/rpc", project.GetImportPath(generator.RpcDir))
+	assert.Equal(t, "models", project.GetModelsPackage())
+
+	project = generator.ProjectPackage{ProjectName: "My Project", ModulePath: "example.com/proj/", ModelsPackage: "entity"}
+	assert.Equal(t, "example.com/proj", project.GetModulePath())
+	assert.Equal(t, "example.com/proj/internal/models", project.GetModelsImportPath())
+	assert.Equal(t, "entity", project.GetModelsPackage())
+}
+
+func TestGenerate_ModulePathAndModelsPackageBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skip build of generated project in short mode")
+	}
+
+	raidenPath, err := filepath.Abs(filepath.Join("..", ".."))
+	require.NoError(t, err)
+
+	goSum, err := os.ReadFile(filepath.Join(raidenPath, "go.sum"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	project := generator.ProjectPackage{ProjectName: "proj", ModulePath: "example.com/proj", ModelsPackage: "entity"}
+	goMod := fmt.Sprintf("module %s\n\ngo 1.21.5\n\nrequire github.com/sev-2/raiden v0.0.0\n\nreplace github.com/sev-2/raiden => %s\n", project.ModulePath, raidenPath)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), goSum, 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	ctx := context.Background()
+	models := []*generator.GenerateModelInput{
+		{
+			Table: objects.Table{
+				Name:        "posts",
+				Schema:      "public",
+				Columns:     []objects.Column{{Name: "id", DataType: "bigint"}, {Name: "title", DataType: "text"}},
+				PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+			},
+			ProjectName:   project.ProjectName,
+			ModulePath:    project.ModulePath,
+			ModelsPackage: project.ModelsPackage,
+		},
+	}
+	require.NoError(t, generator.GenerateModelsWithContext(ctx, dir, models, generator.Generate, false))
+
+	functions := []objects.Function{
+		{
+			Schema:                 "public",
+			Name:                   "get_posts",
+			Language:               "sql",
+			Definition:             "SELECT * FROM posts p WHERE p.title = title",
+			ReturnType:             "SETOF posts",
+			IsSetReturningFunction: true,
+			Args:                   []objects.FunctionArg{{Mode: "in", Name: "title"}},
+			ArgumentTypes:          "title text",
+		},
+		{
+			Schema:     "public",
+			Name:       "handle_new_post",
+			Language:   "plpgsql",
+			Definition: "BEGIN RETURN NEW; END;",
+			ReturnType: "trigger",
+		},
+	}
+	require.NoError(t, generator.GenerateRpcWithContext(ctx, dir, project, functions, models, generator.Generate))
+
+	triggers := []objects.Trigger{
+		{Name: "on_post_inserted", Schema: "public", Table: "posts", Activation: "AFTER", Events: []string{"INSERT"}, Orientation: "ROW", FunctionName: "handle_new_post", FunctionSchema: "public"},
+	}
+	require.NoError(t, generator.GenerateTriggers(ctx, dir, project, triggers, generator.Generate))
+
+	require.NoError(t, generator.GenerateModelRegisterWithPackage(dir, project, generator.Generate))
+	require.NoError(t, generator.GenerateRpcRegisterWithPackage(dir, project, generator.Generate))
+	require.NoError(t, generator.GenerateTriggerRegisterWithPackage(dir, project, generator.Generate))
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.RpcDir, "get_posts.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"example.com/proj/internal/models"`)
+	assert.Contains(t, string(content), "entity.Posts")
+
+	// setof return of rpc refer to model in configured models package
+	tables := []objects.Table{models[0].Table}
+	doc := generator.BuildOpenApiDocument("proj", project.ModelsPackage, tables, functions[:1])
+	response := doc.Paths["/rpc/get_posts"].Post.Responses["200"].Content[generator.OpenApiContentType].Schema
+	require.NotNil(t, response.Items)
+	assert.Equal(t, "#/components/schemas/Posts", response.Items.Ref)
+
+	tsData := generator.BuildTypeScriptData(generator.GenerateTypeScriptInput{Tables: tables, Functions: functions[:1], ModelsPackage: project.ModelsPackage})
+	require.Len(t, tsData.Functions, 1)
+	assert.Equal(t, "Posts[]", tsData.Functions[0].ReturnType)
+
+	cmd := exec.Command("go", "build", "-mod=mod", "./...")
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(output))
+}
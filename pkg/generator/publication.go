@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var PublicationLogger hclog.Logger = logger.HcLog().Named("generator.publication")
+
+// ----- Define type, variable and constant -----
+type GeneratePublicationData struct {
+	Imports    []string
+	Package    string
+	Name       string
+	StructName string
+	AllTables  bool
+	Tables     []string
+	Actions    []string
+}
+
+const (
+	PublicationDir      = "internal/publications"
+	PublicationTemplate = `package {{ .Package }}
+{{- if gt (len .Imports) 0 }}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{- end }}
+
+type {{ .StructName }} struct {
+	raiden.PublicationBase
+}
+
+func (p *{{ .StructName }}) Name() string {
+	return "{{ .Name }}"
+}
+{{- if .AllTables }}
+
+func (p *{{ .StructName }}) AllTables() bool {
+	return true
+}
+{{- end }}
+{{- if gt (len .Tables) 0 }}
+
+func (p *{{ .StructName }}) Tables() []string {
+	return []string{
+		{{- range .Tables }}
+		{{ printf "%q" . }},
+		{{- end }}
+	}
+}
+{{- end }}
+{{- if gt (len .Actions) 0 }}
+
+func (p *{{ .StructName }}) Actions() []raiden.PublicationAction {
+	return []raiden.PublicationAction{ {{- range $i, $v := .Actions }}{{ if $i }}, {{ end }}{{ $v }}{{- end }}}
+}
+{{- end }}
+`
+)
+
+func GeneratePublications(ctx context.Context, basePath string, publications []objects.Publication, generateFn GenerateFn) error {
+	folderPath := filepath.Join(basePath, PublicationDir)
+	PublicationLogger.Trace("create publications folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	for i := range publications {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := GeneratePublication(folderPath, publications[i], generateFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func GeneratePublication(folderPath string, publication objects.Publication, generateFn GenerateFn) error {
+	input := GenerateInput{
+		BindData:     BuildPublicationData(publication),
+		Template:     PublicationTemplate,
+		TemplateName: "publicationTemplate",
+		OutputPath:   filepath.Join(folderPath, fmt.Sprintf("%s.%s", utils.ToSnakeCase(publication.Name), "go")),
+	}
+
+	PublicationLogger.Debug("generate publication", "path", input.OutputPath)
+	return generateFn(input, nil)
+}
+
+// BuildPublicationData map publication to template data, table in public schema
+// is written without schema and actions is only generated when not all action is published
+func BuildPublicationData(publication objects.Publication) GeneratePublicationData {
+	data := GeneratePublicationData{
+		Package:    "publications",
+		Imports:    []string{fmt.Sprintf("%q", "github.com/sev-2/raiden")},
+		Name:       publication.Name,
+		StructName: utils.SnakeCaseToPascalCase(publication.Name),
+		AllTables:  publication.AllTables,
+	}
+
+	if !publication.AllTables {
+		for _, t := range publication.TableKeys() {
+			data.Tables = append(data.Tables, strings.TrimPrefix(t, raiden.DefaultPublicationTableSchema+"."))
+		}
+	}
+
+	actions := []struct {
+		enabled bool
+		decl    string
+	}{
+		{publication.PublishInsert, "raiden.PublicationActionInsert"},
+		{publication.PublishUpdate, "raiden.PublicationActionUpdate"},
+		{publication.PublishDelete, "raiden.PublicationActionDelete"},
+		{publication.PublishTruncate, "raiden.PublicationActionTruncate"},
+	}
+
+	var decls []string
+	for _, a := range actions {
+		if a.enabled {
+			decls = append(decls, a.decl)
+		}
+	}
+
+	if len(decls) < len(actions) {
+		data.Actions = decls
+	}
+
+	return data
+}
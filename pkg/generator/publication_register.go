@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var PublicationRegisterLogger hclog.Logger = logger.HcLog().Named("generator.publication_register")
+
+// ----- Define type, variable and constant -----
+type (
+	GenerateRegisterPublicationData struct {
+		Imports      []string
+		Package      string
+		Publications []string
+	}
+)
+
+const (
+	PublicationRegisterFilename = "publications.go"
+	PublicationRegisterDir      = "internal/bootstrap"
+	PublicationRegisterTemplate = `// Code generated by raiden-cli; DO NOT EDIT.
+package {{ .Package }}
+{{if gt (len .Imports) 0 }}
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{end }}
+func RegisterPublications() {
+	resource.RegisterPublication(
+		{{- range .Publications}}
+		&publications.{{.}}{},
+		{{- end}}
+	)
+}
+`
+)
+
+func GeneratePublicationRegister(basePath string, projectName string, generateFn GenerateFn) error {
+	return GeneratePublicationRegisterWithPackage(basePath, ProjectPackage{ProjectName: projectName}, generateFn)
+}
+
+// GeneratePublicationRegisterWithPackage is GeneratePublicationRegister with configured module path
+func GeneratePublicationRegisterWithPackage(basePath string, project ProjectPackage, generateFn GenerateFn) error {
+	publicationRegisterDir := filepath.Join(basePath, PublicationRegisterDir)
+	PublicationRegisterLogger.Trace("create bootstrap folder if not exist", "path", publicationRegisterDir)
+	if exist := utils.IsFolderExists(publicationRegisterDir); !exist {
+		if err := utils.CreateFolder(publicationRegisterDir); err != nil {
+			return err
+		}
+	}
+
+	publicationDir := filepath.Join(basePath, PublicationDir)
+	PublicationRegisterLogger.Trace("create publications folder if not exist", "path", publicationDir)
+	if exist := utils.IsFolderExists(publicationDir); !exist {
+		if err := utils.CreateFolder(publicationDir); err != nil {
+			return err
+		}
+	}
+
+	// scan all publication
+	publicationList, err := WalkScanPublication(publicationDir)
+	if err != nil {
+		return err
+	}
+
+	input, err := createPublicationRegisterInput(project, publicationRegisterDir, publicationList)
+	if err != nil {
+		return err
+	}
+
+	PublicationRegisterLogger.Debug("generate publication register", "path", input.OutputPath)
+	return generateFn(input, nil)
+}
+
+func createPublicationRegisterInput(project ProjectPackage, publicationRegisterDir string, publicationList []string) (input GenerateInput, err error) {
+	// set file path
+	filePath := filepath.Join(publicationRegisterDir, PublicationRegisterFilename)
+
+	// set imports path
+	imports := []string{
+		fmt.Sprintf("%q", "github.com/sev-2/raiden/pkg/resource"),
+	}
+
+	if len(publicationList) > 0 {
+		publicationsImportPath := project.GetImportPath(PublicationDir)
+		imports = append(imports, fmt.Sprintf("%q", publicationsImportPath))
+	}
+
+	// set passed parameter
+	data := GenerateRegisterPublicationData{
+		Package:      "bootstrap",
+		Imports:      imports,
+		Publications: publicationList,
+	}
+
+	input = GenerateInput{
+		BindData:     data,
+		Template:     PublicationRegisterTemplate,
+		TemplateName: "publicationRegisterTemplate",
+		OutputPath:   filePath,
+	}
+
+	return
+}
+
+func WalkScanPublication(publicationDir string) ([]string, error) {
+	PublicationRegisterLogger.Trace("scan all registered publications", "path", publicationDir)
+
+	publications := make([]string, 0)
+	err := filepath.Walk(publicationDir, func(path string, info fs.FileInfo, err error) error {
+		if strings.HasSuffix(path, ".go") {
+			PublicationRegisterLogger.Trace("collect publications", "file-path", path)
+			rs, e := getStructByBaseName(path, "PublicationBase")
+			if e != nil {
+				return e
+			}
+
+			publications = append(publications, rs...)
+
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return publications, nil
+}
@@ -0,0 +1,69 @@
+package generator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratePublications(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	publications := []objects.Publication{
+		{
+			Name:            "supabase_realtime",
+			PublishInsert:   true,
+			PublishUpdate:   true,
+			PublishDelete:   true,
+			PublishTruncate: true,
+			Tables: []objects.PublicationTable{
+				{Schema: "public", Name: "votes"},
+				{Schema: "billing", Name: "invoices"},
+			},
+		},
+		{
+			Name:          "audit_stream",
+			AllTables:     true,
+			PublishInsert: true,
+		},
+	}
+
+	err := generator.GeneratePublications(context.Background(), dir, publications, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.PublicationDir, "supabase_realtime.go"))
+	assert.NoError(t, err)
+
+	result := string(content)
+	assert.Contains(t, result, "type SupabaseRealtime struct {\n\traiden.PublicationBase\n}")
+	assert.Contains(t, result, `return "supabase_realtime"`)
+	assert.Contains(t, result, "return []string{\n\t\t\"billing.invoices\",\n\t\t\"votes\",\n\t}")
+
+	// default value from publication base is not generated
+	assert.NotContains(t, result, "AllTables()")
+	assert.NotContains(t, result, "Actions()")
+
+	content, err = os.ReadFile(filepath.Join(dir, generator.PublicationDir, "audit_stream.go"))
+	assert.NoError(t, err)
+
+	result = string(content)
+	assert.Contains(t, result, "func (p *AuditStream) AllTables() bool {\n\treturn true\n}")
+	assert.Contains(t, result, "return []raiden.PublicationAction{raiden.PublicationActionInsert}")
+	assert.NotContains(t, result, "Tables() []string")
+}
+
+func TestWalkPublicationDir(t *testing.T) {
+	testPath, err := utils.GetAbsolutePath("/testdata")
+	assert.NoError(t, err)
+
+	rs, err := generator.WalkScanPublication(testPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SupabaseRealtime"}, rs)
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"text/template"
@@ -29,6 +30,7 @@ type GenerateRoleData struct {
 	CanCreateRole          bool
 	CanLogin               bool
 	ValidUntil             string
+	MemberOf               []string
 }
 
 const (
@@ -110,11 +112,22 @@ func (r *{{ .Name | ToGoIdentifier }}) ValidUntil() *objects.SupabaseTime {
 	return objects.NewSupabaseTime(t)
 }
 {{- end }}
+{{- if gt (len .MemberOf) 0 }}
+
+func (r *{{ .Name | ToGoIdentifier }}) MemberOf() []string {
+	return []string{ {{- range $i, $v := .MemberOf }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{- end }}}
+}
+{{- end }}
 
 `
 )
 
 func GenerateRoles(basePath string, roles []objects.Role, generateFn GenerateFn) (err error) {
+	return GenerateRolesWithContext(context.Background(), basePath, roles, generateFn)
+}
+
+// GenerateRolesWithContext generate role and stop when context is cancelled
+func GenerateRolesWithContext(ctx context.Context, basePath string, roles []objects.Role, generateFn GenerateFn) (err error) {
 	folderPath := filepath.Join(basePath, RoleDir)
 	RoleLogger.Trace("create roles folder if not exist", folderPath)
 	if exist := utils.IsFolderExists(folderPath); !exist {
@@ -124,6 +137,10 @@ func GenerateRoles(basePath string, roles []objects.Role, generateFn GenerateFn)
 	}
 
 	for _, v := range roles {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		if err := GenerateRole(folderPath, v, generateFn); err != nil {
 			return err
 		}
@@ -171,6 +188,7 @@ func GenerateRole(folderPath string, role objects.Role, generateFn GenerateFn) e
 		CanCreateRole:          role.CanCreateRole,
 		CanLogin:               role.CanLogin,
 		ValidUntil:             validUntil,
+		MemberOf:               role.MemberOf,
 	}
 
 	// set input
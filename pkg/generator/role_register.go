@@ -45,6 +45,11 @@ func RegisterRoles() {
 )
 
 func GenerateRoleRegister(basePath string, projectName string, generateFn GenerateFn) error {
+	return GenerateRoleRegisterWithPackage(basePath, ProjectPackage{ProjectName: projectName}, generateFn)
+}
+
+// GenerateRoleRegisterWithPackage is GenerateRoleRegister with configured module path
+func GenerateRoleRegisterWithPackage(basePath string, project ProjectPackage, generateFn GenerateFn) error {
 	roleRegisterDir := filepath.Join(basePath, RoleRegisterDir)
 	RoleRegisterLogger.Trace("create bootstrap folder if not exist", roleRegisterDir)
 	if exist := utils.IsFolderExists(roleRegisterDir); !exist {
@@ -67,7 +72,7 @@ func GenerateRoleRegister(basePath string, projectName string, generateFn Genera
 		return err
 	}
 
-	input, err := createRoleRegisterInput(projectName, roleRegisterDir, roleList)
+	input, err := createRoleRegisterInput(project, roleRegisterDir, roleList)
 	if err != nil {
 		return err
 	}
@@ -76,7 +81,7 @@ func GenerateRoleRegister(basePath string, projectName string, generateFn Genera
 	return generateFn(input, nil)
 }
 
-func createRoleRegisterInput(projectName string, roleRegisterDir string, roleList []string) (input GenerateInput, err error) {
+func createRoleRegisterInput(project ProjectPackage, roleRegisterDir string, roleList []string) (input GenerateInput, err error) {
 	// set file path
 	filePath := filepath.Join(roleRegisterDir, RoleRegisterFilename)
 
@@ -86,7 +91,7 @@ func createRoleRegisterInput(projectName string, roleRegisterDir string, roleLis
 	}
 
 	if len(roleList) > 0 {
-		rolesImportPath := fmt.Sprintf("%s/internal/roles", utils.ToGoModuleName(projectName))
+		rolesImportPath := project.GetImportPath(RoleDir)
 		imports = append(imports, fmt.Sprintf("%q", rolesImportPath))
 	}
 
@@ -0,0 +1,46 @@
+package generator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRole_MemberOf(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	roles := []objects.Role{
+		{Name: "editor", InheritRole: true, ConnectionLimit: 60, MemberOf: []string{"authenticated", "reviewer"}},
+		{Name: "reviewer", InheritRole: true, ConnectionLimit: 60},
+	}
+
+	err := generator.GenerateRoles(dir, roles, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.RoleDir, "editor.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "func (r *Editor) MemberOf() []string {")
+	assert.Contains(t, string(content), `return []string{"authenticated", "reviewer"}`)
+
+	content, err = os.ReadFile(filepath.Join(dir, generator.RoleDir, "reviewer.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "MemberOf()")
+}
+
+func TestGenerateRolesWithContext_Cancelled(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := generator.GenerateRolesWithContext(ctx, dir, []objects.Role{{Name: "editor"}}, generator.Generate)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoFileExists(t, filepath.Join(dir, generator.RoleDir, "editor.go"))
+}
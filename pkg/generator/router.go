@@ -83,6 +83,11 @@ func RegisterRoute(server *raiden.Server) {
 
 // Generate route configuration file
 func GenerateRoute(basePath string, projectName string, generateFn GenerateFn) error {
+	return GenerateRouteWithPackage(basePath, ProjectPackage{ProjectName: projectName}, generateFn)
+}
+
+// GenerateRouteWithPackage generate route with controller, model and storage imported from project module path
+func GenerateRouteWithPackage(basePath string, project ProjectPackage, generateFn GenerateFn) error {
 	routePath := filepath.Join(basePath, RouterDir)
 	RouterLogger.Trace("create bootstrap folder if not exist", routePath)
 	if exist := utils.IsFolderExists(routePath); !exist {
@@ -99,7 +104,7 @@ func GenerateRoute(basePath string, projectName string, generateFn GenerateFn) e
 		return err
 	}
 
-	input, err := createRouteInput(projectName, routePath, routes)
+	input, err := createRouteInput(project, routePath, routes)
 	if err != nil {
 		return err
 	}
@@ -341,7 +346,7 @@ func generateRoute(foundRoute *FoundRoute) (GenerateRouteItem, error) {
 	return r, nil
 }
 
-func createRouteInput(projectName string, routePath string, routes []GenerateRouteItem) (input GenerateInput, err error) {
+func createRouteInput(project ProjectPackage, routePath string, routes []GenerateRouteItem) (input GenerateInput, err error) {
 	// set file path
 	filePath := filepath.Join(routePath, RouterFilename)
 
@@ -351,18 +356,19 @@ func createRouteInput(projectName string, routePath string, routes []GenerateRou
 	}
 
 	if len(routes) > 0 {
-		routeImportPath := fmt.Sprintf("%s/internal/controllers", utils.ToGoModuleName(projectName))
+		routeImportPath := project.GetImportPath(ControllerDir)
 		imports = append(imports, fmt.Sprintf("%q", routeImportPath))
 	}
 
-	isHaveModel := false
+	var modelPackages []string
 	isHaveMethods := false
 	isHaveStorage := false
 	for i := range routes {
 		r := routes[i]
 
-		if r.Model != "" && !isHaveModel {
-			isHaveModel = true
+		// model is declared as <package>.<Model>{} in controller
+		if packageName, _, found := strings.Cut(r.Model, "."); found && !utils.Contains(modelPackages, packageName) {
+			modelPackages = append(modelPackages, packageName)
 		}
 
 		if r.Methods != "" && r.Methods != "[]string{}" && !isHaveMethods {
@@ -374,8 +380,12 @@ func createRouteInput(projectName string, routePath string, routes []GenerateRou
 		}
 	}
 
-	if isHaveModel {
-		modelImportPath := fmt.Sprintf("%s/internal/models", utils.ToGoModuleName(projectName))
+	// model outside models package is placed in schema subpackage
+	for _, packageName := range modelPackages {
+		modelImportPath := project.GetModelsImportPath()
+		if packageName != project.GetModelsPackage() {
+			modelImportPath = fmt.Sprintf("%s/%s", modelImportPath, packageName)
+		}
 		imports = append(imports, fmt.Sprintf("%q", modelImportPath))
 	}
 
@@ -384,7 +394,7 @@ func createRouteInput(projectName string, routePath string, routes []GenerateRou
 	}
 
 	if isHaveStorage {
-		storageImportPath := fmt.Sprintf("%s/internal/storages", utils.ToGoModuleName(projectName))
+		storageImportPath := project.GetImportPath(StorageDir)
 		imports = append(imports, fmt.Sprintf("%q", storageImportPath))
 	}
 
@@ -1,9 +1,11 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -36,6 +38,13 @@ type (
 		MapScannedTable    map[string]*RpcScannedTable
 		OriginalReturnType string
 		UseParamPrefix     bool
+
+		// project that rpc generated to, model is referred from its models package
+		Project ProjectPackage
+
+		// resolved model input by <schema>.<table>, model that returned or bound by rpc refer
+		// to its struct name and package, see GetModelType
+		ModelInputs map[string]*GenerateModelInput
 	}
 
 	GenerateRpcData struct {
@@ -143,6 +152,12 @@ func (r *{{ .Name }}) GetRawDefinition() string {
 )
 
 func GenerateRpc(basePath string, projectName string, functions []objects.Function, generateFn GenerateFn) (err error) {
+	return GenerateRpcWithContext(context.Background(), basePath, ProjectPackage{ProjectName: projectName}, functions, nil, generateFn)
+}
+
+// GenerateRpcWithContext generate rpc and stop when context is cancelled, model of table
+// is referred by resolved model input and fallback to table name in models package
+func GenerateRpcWithContext(ctx context.Context, basePath string, project ProjectPackage, functions []objects.Function, models []*GenerateModelInput, generateFn GenerateFn) (err error) {
 	folderPath := filepath.Join(basePath, RpcDir)
 	RpcLogger.Trace("create rpc folder if not exist", "path", folderPath)
 	if exist := utils.IsFolderExists(folderPath); !exist {
@@ -151,9 +166,18 @@ func GenerateRpc(basePath string, projectName string, functions []objects.Functi
 		}
 	}
 
+	modelInputs := make(map[string]*GenerateModelInput, len(models))
+	for _, m := range models {
+		modelInputs[fmt.Sprintf("%s.%s", m.Table.Schema, m.Table.Name)] = m
+	}
+
 	for i := range functions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		f := functions[i]
-		if err := generateRpcItem(folderPath, projectName, &f, generateFn); err != nil {
+		if err := generateRpcItem(folderPath, project, modelInputs, &f, generateFn); err != nil {
 			return err
 		}
 	}
@@ -161,7 +185,7 @@ func GenerateRpc(basePath string, projectName string, functions []objects.Functi
 	return nil
 }
 
-func generateRpcItem(folderPath string, projectName string, function *objects.Function, generateFn GenerateFn) error {
+func generateRpcItem(folderPath string, project ProjectPackage, modelInputs map[string]*GenerateModelInput, function *objects.Function, generateFn GenerateFn) error {
 	// define binding func
 	funcMaps := []template.FuncMap{
 		{"ToSnakeCase": utils.ToSnakeCase},
@@ -182,6 +206,8 @@ func generateRpcItem(folderPath string, projectName string, function *objects.Fu
 	if err != nil {
 		return err
 	}
+	result.Project = project
+	result.ModelInputs = modelInputs
 
 	rpcParams, err := result.GetParams(importsMap)
 	if err != nil {
@@ -198,10 +224,9 @@ func generateRpcItem(folderPath string, projectName string, function *objects.Fu
 		return err
 	}
 
-	if result.GetModelDecl() != "" {
-		modelsImportPath := fmt.Sprintf("%s/%s", utils.ToGoModuleName(projectName), ModelDir)
-		modePath := fmt.Sprintf("%q", modelsImportPath)
-		importsMap[modePath] = true
+	for _, t := range result.MapScannedTable {
+		_, importPath := result.GetModelType("", t.Name)
+		importsMap[fmt.Sprintf("%q", importPath)] = true
 	}
 
 	var importsPath []string
@@ -240,6 +265,27 @@ func generateRpcItem(folderPath string, projectName string, function *objects.Fu
 	return generateFn(generateInput, nil)
 }
 
+// ValidateRpcFunction check function can be converted to rpc declaration,
+// function that failed validation will fail the generate process
+func ValidateRpcFunction(fn *objects.Function) error {
+	result, err := ExtractRpcFunction(fn)
+	if err != nil {
+		return err
+	}
+
+	mapImports := make(map[string]bool)
+	if _, err := result.GetParams(mapImports); err != nil {
+		return err
+	}
+
+	if _, _, _, err := result.GetReturn(mapImports); err != nil {
+		return err
+	}
+
+	_, err = raiden.GetValidRpcReturnNameDecl(result.Rpc.ReturnType, true)
+	return err
+}
+
 func ExtractRpcFunction(fn *objects.Function) (result ExtractRpcDataResult, err error) {
 	//  extract param
 	params, usePrefix, e := ExtractRpcParam(fn)
@@ -273,9 +319,9 @@ func ExtractRpcFunction(fn *objects.Function) (result ExtractRpcDataResult, err
 
 	returnType := raiden.RpcReturnDataTypeVoid
 	returnTypeLc := strings.ToLower(fn.ReturnType)
-	if strings.Contains(returnTypeLc, "setof") {
+	if strings.HasPrefix(returnTypeLc, "setof") {
 		returnType = raiden.RpcReturnDataTypeSetOf
-	} else if strings.Contains(returnTypeLc, "table") {
+	} else if strings.HasPrefix(returnTypeLc, "table") || (fn.IsSetReturningFunction && strings.Contains(returnTypeLc, "(")) {
 		returnType = raiden.RpcReturnDataTypeTable
 	} else {
 		returnType, err = raiden.GetValidRpcReturnType(fn.ReturnType, true)
@@ -287,7 +333,7 @@ func ExtractRpcFunction(fn *objects.Function) (result ExtractRpcDataResult, err
 	// assign rpc params
 	result.Rpc.Params = params
 	result.Rpc.Schema = fn.Schema
-	result.Rpc.Behavior = raiden.RpcBehaviorType(fn.Behavior)
+	result.Rpc.Behavior = raiden.RpcBehaviorType(strings.ToUpper(fn.Behavior))
 	result.Rpc.Name = fn.Name
 	result.Rpc.Definition = bindModelToDefinition(definition, mapScannedTable, result.Rpc.Params, usePrefix)
 	result.Rpc.CompleteStatement = fn.CompleteStatement
@@ -579,7 +625,16 @@ func (r *ExtractRpcDataResult) GetParams(mapImports map[string]bool) (columns []
 			Tag:   fmt.Sprintf("json:%q column:%q", p.Name, rpcTag),
 		}
 
-		splitType := strings.Split(c.Type, ".")
+		// param with default value is optional, declare as pointer
+		// so caller can skip it and function default value is used
+		if p.Default != nil {
+			if !strings.HasPrefix(c.Type, "[]") && !strings.HasPrefix(c.Type, "map[") {
+				c.Type = "*" + c.Type
+			}
+			c.Tag = fmt.Sprintf("json:%q column:%q", p.Name+",omitempty", rpcTag)
+		}
+
+		splitType := strings.Split(strings.TrimPrefix(c.Type, "*"), ".")
 		if len(splitType) > 1 {
 			importPackage := splitType[0]
 			var importPackageName string
@@ -607,11 +662,30 @@ func (r *ExtractRpcDataResult) GetModelDecl() (modelDecl string) {
 
 	var bindModelDeclArr []string
 	for _, v := range r.MapScannedTable {
-		bindModelDeclArr = append(bindModelDeclArr, fmt.Sprintf("BindModel(models.%s{}, %q)", utils.SnakeCaseToPascalCase(v.Name), v.Alias))
+		modelType, _ := r.GetModelType("", v.Name)
+		bindModelDeclArr = append(bindModelDeclArr, fmt.Sprintf("BindModel(%s{}, %q)", modelType, v.Alias))
 	}
+	sort.Strings(bindModelDeclArr)
 	return "r." + strings.Join(bindModelDeclArr, ".")
 }
 
+// GetModelType return qualified type and import path of generated model of table, table without
+// schema is looked up in rpc schema and then public schema. Table that not resolved as model
+// input fallback to pascal case of table name in models package
+func (r *ExtractRpcDataResult) GetModelType(schema, table string) (modelType string, importPath string) {
+	schemas := []string{schema}
+	if schema == "" {
+		schemas = []string{r.Rpc.Schema, raiden.DefaultRpcSchema}
+	}
+
+	for _, s := range schemas {
+		if input, exist := r.ModelInputs[fmt.Sprintf("%s.%s", s, table)]; exist {
+			return input.GetModelType()
+		}
+	}
+	return fmt.Sprintf("%s.%s", r.Project.GetModelsPackage(), utils.SnakeCaseToPascalCase(table)), r.Project.GetModelsImportPath()
+}
+
 func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl string, returnColumns []RpcColumn, isReturnArr bool, err error) {
 	// set result decl
 	frCheck := strings.ToLower(r.OriginalReturnType)
@@ -625,19 +699,28 @@ func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl
 			}
 		}
 
-		tableName := split[1]
-		_, isExist := r.MapScannedTable[tableName]
-		if !isExist {
-			err = fmt.Errorf("table %s is not declare in definition function of rpc %s", tableName, r.Rpc.Name)
-			return
+		// return type can be schema qualified, example : setof public.candidate
+		var schemaName string
+		tableName := strings.Trim(strings.TrimSpace(split[1]), `"`)
+		if idx := strings.LastIndex(tableName, "."); idx >= 0 {
+			schemaName = strings.Trim(tableName[:idx], `"`)
+			tableName = strings.Trim(tableName[idx+1:], `"`)
 		}
 
+		// returned table is not used in definition, model is still
+		// reused as row type because it generated from same table
+		if _, isExist := r.MapScannedTable[tableName]; !isExist {
+			RpcLogger.Debug("setof table is not declared in definition, use model as return type", "rpc", r.Rpc.Name, "table", tableName)
+		}
+
+		var importPath string
 		isReturnArr = true
-		returnDecl = fmt.Sprintf("models.%s", utils.SnakeCaseToPascalCase(tableName))
+		returnDecl, importPath = r.GetModelType(schemaName, tableName)
+		mapImports[fmt.Sprintf("%q", importPath)] = true
 	case raiden.RpcReturnDataTypeTable:
 		// example : "TABLE(id integer, created_at timestamp without time zone, sc_name character varying, c_name character varying)"
-		rsType := strings.TrimRight(strings.ReplaceAll(frCheck, "table(", ""), ")")
-		rsSplit := strings.Split(rsType, ",")
+		rsType := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(frCheck), "table("), ")")
+		rsSplit := splitRpcReturnColumns(rsType)
 		for _, v := range rsSplit {
 			splitC := strings.SplitN(strings.TrimLeft(v, " "), " ", 2)
 			if len(splitC) != 2 {
@@ -696,6 +779,30 @@ func (r *ExtractRpcDataResult) GetReturn(mapImports map[string]bool) (returnDecl
 	return
 }
 
+// splitRpcReturnColumns split table return column by comma, comma inside
+// parentheses is part of column type, example : amount numeric(10,2)
+func splitRpcReturnColumns(decl string) (columns []string) {
+	depth, start := 0, 0
+	for i, c := range decl {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				columns = append(columns, decl[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if start < len(decl) {
+		columns = append(columns, decl[start:])
+	}
+	return
+}
+
 func (r *ExtractRpcDataResult) GetSecurity() (security string) {
 	switch r.Rpc.SecurityType {
 	case raiden.RpcSecurityTypeDefiner:
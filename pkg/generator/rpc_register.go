@@ -45,6 +45,11 @@ func RegisterRpc() {
 )
 
 func GenerateRpcRegister(basePath string, projectName string, generateFn GenerateFn) error {
+	return GenerateRpcRegisterWithPackage(basePath, ProjectPackage{ProjectName: projectName}, generateFn)
+}
+
+// GenerateRpcRegisterWithPackage is GenerateRpcRegister that resolve rpc import from module path
+func GenerateRpcRegisterWithPackage(basePath string, project ProjectPackage, generateFn GenerateFn) error {
 	rpcRegisterDir := filepath.Join(basePath, RpcRegisterDir)
 	RpcRegisterLogger.Trace("create bootstrap folder if not exist", "path", rpcRegisterDir)
 	if exist := utils.IsFolderExists(rpcRegisterDir); !exist {
@@ -67,7 +72,7 @@ func GenerateRpcRegister(basePath string, projectName string, generateFn Generat
 		return err
 	}
 
-	input, err := createRegisterRpcInput(projectName, rpcRegisterDir, rpcList)
+	input, err := createRegisterRpcInput(project, rpcRegisterDir, rpcList)
 	if err != nil {
 		return err
 	}
@@ -76,7 +81,7 @@ func GenerateRpcRegister(basePath string, projectName string, generateFn Generat
 	return generateFn(input, nil)
 }
 
-func createRegisterRpcInput(projectName string, rpcRegisterDir string, rpcList []string) (input GenerateInput, err error) {
+func createRegisterRpcInput(project ProjectPackage, rpcRegisterDir string, rpcList []string) (input GenerateInput, err error) {
 	// set file path
 	filePath := filepath.Join(rpcRegisterDir, RpcRegisterFilename)
 
@@ -86,7 +91,7 @@ func createRegisterRpcInput(projectName string, rpcRegisterDir string, rpcList [
 	}
 
 	if len(rpcList) > 0 {
-		rpcImportPath := fmt.Sprintf("%s/internal/rpc", utils.ToGoModuleName(projectName))
+		rpcImportPath := project.GetImportPath(RpcDir)
 		imports = append(imports, fmt.Sprintf("%q", rpcImportPath))
 	}
 
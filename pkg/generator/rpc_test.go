@@ -1,6 +1,10 @@
 package generator_test
 
 import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sev-2/raiden"
@@ -41,6 +45,109 @@ func TestRpcExtractParam(t *testing.T) {
 	assert.Equal(t, raiden.RpcParamDataTypeVarcharAlias, param[1].Type)
 }
 
+func TestRpcGetParams_OptionalParam(t *testing.T) {
+	fn := objects.Function{
+		Name: "foo",
+		Args: []objects.FunctionArg{
+			{Mode: "in", Name: "a", TypeId: 23},
+			{Mode: "in", Name: "b", TypeId: 23, HasDefault: true},
+		},
+		ArgumentTypes: "a integer, b integer DEFAULT 5",
+	}
+
+	params, _, err := generator.ExtractRpcParam(&fn)
+	assert.NoError(t, err)
+
+	result := generator.ExtractRpcDataResult{}
+	result.Rpc.Params = params
+
+	columns, err := result.GetParams(make(map[string]bool))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(columns))
+
+	assert.Equal(t, "int64", columns[0].Type)
+	assert.Equal(t, `json:"a" column:"name:a;type:integer"`, columns[0].Tag)
+
+	assert.Equal(t, "*int64", columns[1].Type)
+	assert.Equal(t, `json:"b,omitempty" column:"name:b;type:integer;default:5"`, columns[1].Tag)
+}
+
+func TestRpcGetReturn_SetOfTable(t *testing.T) {
+	result := generator.ExtractRpcDataResult{
+		OriginalReturnType: "SETOF public.candidate",
+		MapScannedTable:    map[string]*generator.RpcScannedTable{},
+	}
+	result.Rpc.Name = "get_candidates"
+	result.Rpc.ReturnType = raiden.RpcReturnDataTypeSetOf
+
+	returnDecl, returnColumns, isReturnArr, err := result.GetReturn(make(map[string]bool))
+	assert.NoError(t, err)
+	assert.True(t, isReturnArr)
+	assert.Equal(t, "models.Candidate", returnDecl)
+	assert.Equal(t, 0, len(returnColumns))
+}
+
+func TestRpcGetReturn_SetOfResolvedModel(t *testing.T) {
+	posts := &generator.GenerateModelInput{
+		Table:         objects.Table{Name: "posts", Schema: "public"},
+		ProjectName:   "test",
+		SchemaPackage: true,
+	}
+	users := &generator.GenerateModelInput{
+		Table:         objects.Table{Name: "users", Schema: "auth"},
+		ProjectName:   "test",
+		ModelsPackage: "entity",
+		StructNames:   map[string]string{"auth.users": "AuthUsers"},
+	}
+
+	result := generator.ExtractRpcDataResult{
+		OriginalReturnType: "SETOF posts",
+		MapScannedTable:    map[string]*generator.RpcScannedTable{},
+		ModelInputs:        map[string]*generator.GenerateModelInput{"public.posts": posts, "auth.users": users},
+	}
+	result.Rpc.Name = "get_posts"
+	result.Rpc.Schema = "public"
+	result.Rpc.ReturnType = raiden.RpcReturnDataTypeSetOf
+
+	// model in schema subpackage is qualified by schema package
+	mapImports := make(map[string]bool)
+	returnDecl, _, isReturnArr, err := result.GetReturn(mapImports)
+	assert.NoError(t, err)
+	assert.True(t, isReturnArr)
+	assert.Equal(t, "public.Posts", returnDecl)
+	assert.True(t, mapImports[`"test/internal/models/public"`])
+
+	// struct name override and models package of resolved model is used
+	result.OriginalReturnType = "SETOF auth.users"
+	mapImports = make(map[string]bool)
+	returnDecl, _, _, err = result.GetReturn(mapImports)
+	assert.NoError(t, err)
+	assert.Equal(t, "entity.AuthUsers", returnDecl)
+	assert.True(t, mapImports[`"test/internal/models"`])
+}
+
+func TestRpcGetReturn_Table(t *testing.T) {
+	result := generator.ExtractRpcDataResult{
+		OriginalReturnType: "TABLE(id integer, created_at timestamp without time zone, c_name character varying)",
+	}
+	result.Rpc.Name = "get_submissions"
+	result.Rpc.ReturnType = raiden.RpcReturnDataTypeTable
+
+	mapImports := make(map[string]bool)
+	returnDecl, returnColumns, isReturnArr, err := result.GetReturn(mapImports)
+	assert.NoError(t, err)
+	assert.True(t, isReturnArr)
+	assert.Equal(t, "", returnDecl)
+	assert.Equal(t, 3, len(returnColumns))
+	assert.Equal(t, "Id", returnColumns[0].Field)
+	assert.Equal(t, "int64", returnColumns[0].Type)
+	assert.Equal(t, "CreatedAt", returnColumns[1].Field)
+	assert.Equal(t, "time.Time", returnColumns[1].Type)
+	assert.Equal(t, "CName", returnColumns[2].Field)
+	assert.Equal(t, "string", returnColumns[2].Type)
+	assert.True(t, mapImports[`"time"`])
+}
+
 func TestExtractRpcData(t *testing.T) {
 	fn := objects.Function{
 		Schema:   "public",
@@ -147,3 +254,61 @@ func TestNormalizeTableAlias(t *testing.T) {
 	assert.Equal(t, "sc", mapAlias["scouter"].Alias)
 
 }
+
+func TestGenerateRpc_StableSecurityDefiner(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	fn := objects.Function{
+		Schema:          "public",
+		Name:            "get_candidate_count",
+		Language:        "plpgsql",
+		Definition:      `BEGIN RETURN (SELECT count(*) FROM candidate c WHERE c.name = candidate_name); END;`,
+		ReturnType:      "bigint",
+		Behavior:        "STABLE",
+		SecurityDefiner: true,
+		Args: []objects.FunctionArg{
+			{Mode: "in", Name: "candidate_name"},
+		},
+		ArgumentTypes: "candidate_name text",
+	}
+
+	err := generator.GenerateRpc(dir, "test", []objects.Function{fn}, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.RpcDir, "get_candidate_count.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "return raiden.RpcSecurityTypeDefiner")
+	assert.Contains(t, string(content), "return raiden.RpcBehaviorStable")
+}
+
+func TestGenerateRpc_OptionalParamImport(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	fn := objects.Function{
+		Schema:     "public",
+		Name:       "get_posts",
+		Language:   "plpgsql",
+		Definition: `BEGIN RETURN (SELECT count(*) FROM posts p WHERE p.created_at > since AND p.created_at < until); END;`,
+		ReturnType: "bigint",
+		Args: []objects.FunctionArg{
+			{Mode: "in", Name: "since", TypeId: 1184, HasDefault: true},
+			{Mode: "in", Name: "until", TypeId: 1114, HasDefault: true},
+		},
+		ArgumentTypes: "since timestamp with time zone DEFAULT now(), until timestamp without time zone DEFAULT now()",
+	}
+
+	err := generator.GenerateRpc(dir, "test", []objects.Function{fn}, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.RpcDir, "get_posts.go"))
+	assert.NoError(t, err)
+	// pointer of optional param must not break import of its package
+	assert.Contains(t, string(content), "Since *time.Time")
+	assert.Contains(t, string(content), `"time"`)
+	assert.NotContains(t, string(content), "\t\"\"\n")
+
+	_, err = parser.ParseFile(token.NewFileSet(), "", content, 0)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var SeedLogger hclog.Logger = logger.HcLog().Named("generator.seed")
+
+// ----- Define type, variable and constant -----
+type (
+	// GenerateSeedInput is rows of single table, rows key is column name
+	GenerateSeedInput struct {
+		ModelInput *GenerateModelInput
+		Rows       []map[string]json.RawMessage
+	}
+
+	GenerateSeedItem struct {
+		Schema string
+		Table  string
+		Model  string
+		Rows   string
+	}
+
+	GenerateSeedData struct {
+		Package string
+		Imports []string
+		Items   []GenerateSeedItem
+	}
+)
+
+const (
+	SeedDir      = "internal/seeds"
+	SeedFile     = "seed.go"
+	SeedTemplate = `// Code generated by raiden-cli; DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .Imports }}
+	{{ . }}
+{{- end }}
+)
+
+// Seed insert seed data to database, table is ordered so parent table is inserted first
+func Seed(cfg *raiden.Config) error {
+	return resource.Seed(cfg, seeds...)
+}
+
+var seeds = []resource.SeedData{
+{{- range .Items }}
+	// {{ .Schema }}.{{ .Table }}
+	{
+		Model: &{{ .Model }}{},
+		Rows:  {{ .Rows }},
+	},
+{{- end }}
+}
+`
+)
+
+// GenerateSeed write seed data of table to <basePath>/internal/seeds/seed.go,
+// input must be already ordered by table dependency
+func GenerateSeed(basePath string, inputs []GenerateSeedInput, generateFn GenerateFn) error {
+	folderPath := filepath.Join(basePath, SeedDir)
+	SeedLogger.Trace("create seeds folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	data, err := BuildSeedData(inputs)
+	if err != nil {
+		return err
+	}
+
+	generateInput := GenerateInput{
+		BindData:     data,
+		Template:     SeedTemplate,
+		TemplateName: "seedTemplate",
+		OutputPath:   filepath.Join(folderPath, SeedFile),
+	}
+
+	SeedLogger.Debug("generate seed", "path", generateInput.OutputPath)
+	return generateFn(generateInput, nil)
+}
+
+// BuildSeedData map table rows to template data, rows is re-keyed
+// with model json key so it can be decoded to generated model
+func BuildSeedData(inputs []GenerateSeedInput) (data GenerateSeedData, err error) {
+	data = GenerateSeedData{
+		Package: "seeds",
+		Imports: []string{
+			fmt.Sprintf("%q", "github.com/sev-2/raiden"),
+			fmt.Sprintf("%q", "github.com/sev-2/raiden/pkg/resource"),
+		},
+	}
+
+	for _, input := range inputs {
+		mi := input.ModelInput
+		if mi == nil {
+			continue
+		}
+
+		modelType, modelsImportPath := mi.GetModelType()
+		data.Imports = appendImportPath(data.Imports, fmt.Sprintf("%q", modelsImportPath))
+
+		rows, err := buildSeedRows(input.Rows, mi.JsonCase)
+		if err != nil {
+			return data, fmt.Errorf("build seed of table %s.%s : %s", mi.Table.Schema, mi.Table.Name, err)
+		}
+
+		data.Items = append(data.Items, GenerateSeedItem{
+			Schema: mi.Table.Schema,
+			Table:  mi.Table.Name,
+			Model:  modelType,
+			Rows:   rows,
+		})
+	}
+	return data, nil
+}
+
+// buildSeedRows encode rows as go string literal with one row per line
+func buildSeedRows(rows []map[string]json.RawMessage, jsonCase JsonCase) (string, error) {
+	lines := make([]string, 0, len(rows))
+	for _, r := range rows {
+		row := make(map[string]json.RawMessage, len(r))
+		for k, v := range r {
+			row[ToJsonKey(k, jsonCase)] = v
+		}
+
+		b, err := json.Marshal(row)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, "\t\t\t"+string(b))
+	}
+
+	rowsJson := "[]"
+	if len(lines) > 0 {
+		rowsJson = "[\n" + strings.Join(lines, ",\n") + "\n\t\t]"
+	}
+
+	// raw string literal can't contain backtick
+	if strings.Contains(rowsJson, "`") {
+		return fmt.Sprintf("%q", rowsJson), nil
+	}
+	return "`" + rowsJson + "`", nil
+}
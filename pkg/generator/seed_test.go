@@ -0,0 +1,57 @@
+package generator_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSeed(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	inputs := []generator.GenerateSeedInput{
+		{
+			ModelInput: &generator.GenerateModelInput{
+				Table:       objects.Table{Name: "user_roles", Schema: "public"},
+				ProjectName: "test",
+				JsonCase:    generator.JsonCaseCamel,
+			},
+			Rows: []map[string]json.RawMessage{
+				{"id": json.RawMessage(`1`), "role_name": json.RawMessage(`"admin"`)},
+			},
+		},
+		{
+			ModelInput: &generator.GenerateModelInput{
+				Table:         objects.Table{Name: "users", Schema: "auth"},
+				ProjectName:   "test",
+				SchemaPackage: true,
+			},
+			Rows: []map[string]json.RawMessage{
+				{"id": json.RawMessage(`9007199254740993`), "note": json.RawMessage("\"`quoted`\"")},
+			},
+		},
+	}
+
+	err := generator.GenerateSeed(dir, inputs, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.SeedDir, generator.SeedFile))
+	assert.NoError(t, err)
+
+	result := string(content)
+	assert.Contains(t, result, "package seeds")
+	assert.Contains(t, result, `"test/internal/models"`)
+	assert.Contains(t, result, `"test/internal/models/auth"`)
+	assert.Contains(t, result, "Model: &models.UserRoles{},")
+	assert.Contains(t, result, `{"id":1,"roleName":"admin"}`)
+	assert.Contains(t, result, "Model: &auth.Users{},")
+	assert.Contains(t, result, `"[\n\t\t\t{\"id\":9007199254740993,\"note\":\"`+"`quoted`"+`\"}\n\t\t]"`)
+	assert.Less(t, strings.Index(result, "models.UserRoles"), strings.Index(result, "auth.Users"))
+}
@@ -1,12 +1,15 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/logger"
 	"github.com/sev-2/raiden/pkg/supabase"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
@@ -62,7 +65,7 @@ func (r *{{ .StructName | ToGoIdentifier }}) Public() bool {
 {{- if ne .FileSizeLimit 0}}
 
 func (r *{{ .StructName | ToGoIdentifier }}) FileSizeLimit() int {
-	return {{ .FileSizeLimit }} // bytes
+	return {{ .FileSizeLimit | ToFileSizeDecl }}
 }
 {{- end }}
 {{- if ne .AllowedMimeTypes "" }}
@@ -74,6 +77,11 @@ func (r *{{ .StructName | ToGoIdentifier }}) AllowedMimeTypes() []string {
 )
 
 func GenerateStorages(basePath string, storages []*GenerateStorageInput, generateFn GenerateFn) (err error) {
+	return GenerateStoragesWithContext(context.Background(), basePath, storages, generateFn)
+}
+
+// GenerateStoragesWithContext generate storage and stop when context is cancelled
+func GenerateStoragesWithContext(ctx context.Context, basePath string, storages []*GenerateStorageInput, generateFn GenerateFn) (err error) {
 	folderPath := filepath.Join(basePath, StorageDir)
 	StorageLogger.Trace("create storages folder", "path", folderPath)
 	if exist := utils.IsFolderExists(folderPath); !exist {
@@ -83,6 +91,10 @@ func GenerateStorages(basePath string, storages []*GenerateStorageInput, generat
 	}
 
 	for _, v := range storages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		if err := GenerateStorage(folderPath, v, generateFn); err != nil {
 			return err
 		}
@@ -95,6 +107,7 @@ func GenerateStorage(folderPath string, storage *GenerateStorageInput, generateF
 	// define binding func
 	funcMaps := []template.FuncMap{
 		{"ToGoIdentifier": utils.SnakeCaseToPascalCase},
+		{"ToFileSizeDecl": buildFileSizeLimitDecl},
 	}
 
 	// define file path
@@ -151,3 +164,15 @@ func GenerateStorage(folderPath string, storage *GenerateStorageInput, generateF
 	StorageLogger.Debug("generate storages", "path", input.OutputPath)
 	return generateFn(input, nil)
 }
+
+// buildFileSizeLimitDecl render file size limit with raiden size unit,
+// e.g 5242880 become "5 * raiden.MB" and 1500 become "1500 // bytes"
+func buildFileSizeLimitDecl(size int) string {
+	humanSize := raiden.FormatFileSize(size)
+	for _, unit := range []string{"GB", "MB", "KB"} {
+		if strings.HasSuffix(humanSize, unit) {
+			return fmt.Sprintf("%s * raiden.%s", strings.TrimSuffix(humanSize, unit), unit)
+		}
+	}
+	return fmt.Sprintf("%d // bytes", size)
+}
@@ -46,6 +46,11 @@ func RegisterStorages() {
 )
 
 func GenerateStoragesRegister(basePath string, projectName string, generateFn GenerateFn) error {
+	return GenerateStoragesRegisterWithPackage(basePath, ProjectPackage{ProjectName: projectName}, generateFn)
+}
+
+// GenerateStoragesRegisterWithPackage generate storage register of project with configured module path
+func GenerateStoragesRegisterWithPackage(basePath string, project ProjectPackage, generateFn GenerateFn) error {
 	storageRegisterDir := filepath.Join(basePath, StorageRegisterDir)
 	StorageRegisterLogger.Trace("create bootstrap folder if not exist", "path", storageRegisterDir)
 	if exist := utils.IsFolderExists(storageRegisterDir); !exist {
@@ -68,7 +73,7 @@ func GenerateStoragesRegister(basePath string, projectName string, generateFn Ge
 		return err
 	}
 
-	input, err := createStorageRegisterInput(projectName, storageRegisterDir, storageList)
+	input, err := createStorageRegisterInput(project, storageRegisterDir, storageList)
 	if err != nil {
 		return err
 	}
@@ -77,7 +82,7 @@ func GenerateStoragesRegister(basePath string, projectName string, generateFn Ge
 	return generateFn(input, nil)
 }
 
-func createStorageRegisterInput(projectName string, storageRegisterDir string, storageList []string) (input GenerateInput, err error) {
+func createStorageRegisterInput(project ProjectPackage, storageRegisterDir string, storageList []string) (input GenerateInput, err error) {
 	// set file path
 	filePath := filepath.Join(storageRegisterDir, StorageRegisterFilename)
 
@@ -87,7 +92,7 @@ func createStorageRegisterInput(projectName string, storageRegisterDir string, s
 	}
 
 	if len(storageList) > 0 {
-		rolesImportPath := fmt.Sprintf("%s/internal/storages", utils.ToGoModuleName(projectName))
+		rolesImportPath := project.GetImportPath(StorageDir)
 		imports = append(imports, fmt.Sprintf("%q", rolesImportPath))
 	}
 
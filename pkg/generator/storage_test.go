@@ -0,0 +1,51 @@
+package generator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateStorages(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	fileSizeLimit := 5 * 1024 * 1024
+	avatarDefinition := "(bucket_id = 'avatar'::text)"
+	storages := []*generator.GenerateStorageInput{
+		{
+			Bucket: objects.Bucket{Name: "avatar", Public: true, FileSizeLimit: &fileSizeLimit, AllowedMimeTypes: []string{"image/png", "image/jpeg"}},
+			Policies: objects.Policies{
+				{
+					Schema:     "storage",
+					Table:      "objects",
+					Name:       supabase.GetPolicyName(objects.PolicyCommandSelect, "storage", "avatar"),
+					Command:    objects.PolicyCommandSelect,
+					Roles:      []string{"anon", "authenticated"},
+					Definition: avatarDefinition,
+				},
+			},
+		},
+		{
+			Bucket: objects.Bucket{Name: "document"},
+		},
+	}
+
+	err := generator.GenerateStorages(dir, storages, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.StorageDir, "avatar.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "`json:\"-\" read:\"anon,authenticated\" write:\"\"`")
+	assert.Contains(t, string(content), "return 5 * raiden.MB")
+	assert.Contains(t, string(content), `return []string{"image/png", "image/jpeg"}`)
+
+	content, err = os.ReadFile(filepath.Join(dir, generator.StorageDir, "document.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "`json:\"-\" read:\"\" write:\"\"`")
+}
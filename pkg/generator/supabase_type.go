@@ -0,0 +1,13 @@
+package generator
+
+import "github.com/sev-2/raiden"
+
+// SupabaseJsonColumnTypes is go type of json column in well known supabase auth and storage table,
+// so imported auth.users and storage.objects is generated with typed metadata instead of raw json.
+// Type of the same column in JSON_COLUMN_TYPES config override this type
+var SupabaseJsonColumnTypes = []raiden.JsonColumnType{
+	{Column: "auth.users.raw_app_meta_data", Type: "raiden.AuthAppMetadata"},
+	{Column: "auth.users.raw_user_meta_data", Type: "raiden.AuthUserMetadata"},
+	{Column: "auth.identities.identity_data", Type: "raiden.AuthUserMetadata"},
+	{Column: "storage.objects.metadata", Type: "raiden.StorageObjectMetadata"},
+}
@@ -0,0 +1,236 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// ----- Define type, variable and constant -----
+type (
+	GenerateTableControllerKey struct {
+		Field  string
+		Type   string
+		Column string
+	}
+
+	GenerateTableControllerData struct {
+		Package   string
+		Imports   []string
+		Name      string
+		Table     string
+		Schema    string
+		Model     string
+		Path      string
+		Keys      []GenerateTableControllerKey
+		ItemPath  string
+		IsMutable bool
+
+		// soft delete column of model, list handler exclude soft deleted row
+		// and delete handler set the column instead of remove the row
+		SoftDelete string
+		ListFilter string
+		GetFilter  string
+	}
+)
+
+const (
+	TableControllerTemplate = `package {{ .Package }}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// This file is created once by raiden-cli and never overwritten,
+// implement the handler of {{ .Schema }}.{{ .Table }} table in this file.
+
+type {{ .Name }}Controller struct {
+	raiden.ControllerBase
+	Http    string ` + "`path:\"{{ .Path }}\" type:\"custom\"`" + `
+	Payload *{{ .Model }}
+	Result  []{{ .Model }}
+}
+
+// Get return list of {{ .Table }}
+func (c *{{ .Name }}Controller) Get(ctx raiden.Context) error {
+{{- if .SoftDelete }}
+	// TODO : query list of {{ .Table }} with {{ .ListFilter }}(), soft deleted row is excluded
+{{- else }}
+	// TODO : query list of {{ .Table }}
+{{- end }}
+	return ctx.SendJson(c.Result)
+}
+{{- if .IsMutable }}
+
+// Post create new {{ .Table }} from payload
+func (c *{{ .Name }}Controller) Post(ctx raiden.Context) error {
+	// TODO : insert c.Payload to {{ .Table }}
+	return ctx.SendJson(c.Result)
+}
+
+type {{ .Name }}ItemRequest struct {
+{{- range .Keys }}
+	{{ .Field }} {{ .Type }} ` + "`path:\"{{ .Column }}\"`" + `
+{{- end }}
+	Data *{{ .Model }} ` + "`json:\"data,omitempty\"`" + `
+}
+
+type {{ .Name }}ItemController struct {
+	raiden.ControllerBase
+	Http    string ` + "`path:\"{{ .ItemPath }}\" type:\"custom\"`" + `
+	Payload *{{ .Name }}ItemRequest
+	Result  {{ .Model }}
+}
+
+// Get return single {{ .Table }} by primary key
+func (c *{{ .Name }}ItemController) Get(ctx raiden.Context) error {
+{{- if .SoftDelete }}
+	// TODO : query {{ .Table }} with {{ .GetFilter }}(row), soft deleted row is excluded
+{{- else }}
+	// TODO : query {{ .Table }} by primary key
+{{- end }}
+	return ctx.SendJson(c.Result)
+}
+
+// Patch update {{ .Table }} by primary key with c.Payload.Data
+func (c *{{ .Name }}ItemController) Patch(ctx raiden.Context) error {
+	// TODO : update {{ .Table }} by primary key
+	return ctx.SendJson(c.Result)
+}
+
+// Delete remove {{ .Table }} by primary key
+func (c *{{ .Name }}ItemController) Delete(ctx raiden.Context) error {
+{{- if .SoftDelete }}
+	// TODO : soft delete {{ .Table }} by primary key, set {{ .SoftDelete }} to now()
+{{- else }}
+	// TODO : delete {{ .Table }} by primary key
+{{- end }}
+	return ctx.SendJson(c.Result)
+}
+{{- end }}
+`
+)
+
+// GenerateTableControllers create crud controller scaffold of every model to controllers folder,
+// controller is only created when the file is not exist so implemented handler is never replaced
+func GenerateTableControllers(ctx context.Context, basePath string, inputs []*GenerateModelInput, generateFn GenerateFn) error {
+	folderPath := filepath.Join(basePath, ControllerDir)
+	ControllerLogger.Trace("create controller folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	for _, input := range inputs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := GenerateTableController(folderPath, input, generateFn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func GenerateTableController(folderPath string, input *GenerateModelInput, generateFn GenerateFn) error {
+	data := buildTableControllerData(input)
+	filePath := filepath.Join(folderPath, fmt.Sprintf("%s.go", utils.ToSnakeCase(data.Name)))
+	if utils.IsFileExists(filePath) {
+		ControllerLogger.Trace("skip generate table controller, file already exist", "path", filePath)
+		return nil
+	}
+
+	generateInput := GenerateInput{
+		BindData:     data,
+		Template:     TableControllerTemplate,
+		TemplateName: "tableControllerTemplate",
+		OutputPath:   filePath,
+	}
+
+	ControllerLogger.Debug("generate table controller", "path", generateInput.OutputPath)
+	return generateFn(generateInput, nil)
+}
+
+// buildTableControllerData map model to controller, view and table without primary key
+// can't be targeted by single row handler so only list handler is generated
+func buildTableControllerData(input *GenerateModelInput) GenerateTableControllerData {
+	structName := input.GetStructName()
+	modelsImportPath := input.GetModelsImportPath()
+
+	data := GenerateTableControllerData{
+		Package:   "controllers",
+		Imports:   []string{"github.com/sev-2/raiden", modelsImportPath},
+		Name:      structName,
+		Table:     input.Table.Name,
+		Schema:    input.Table.Schema,
+		Model:     qualifyTypeName(structName, input.GetModelsPackage()),
+		Path:      "/" + input.Table.Name,
+		IsMutable: !input.Table.IsView && !IsReadOnlyTable(input.Table),
+
+		SoftDelete: input.GetSoftDeleteColumn(),
+	}
+
+	// soft deleted row is excluded by filter helper of model
+	modelPackage := input.GetModelsPackage()
+	if input.SchemaPackage {
+		modelPackage = ToSchemaPackageName(input.Table.Schema)
+	}
+	if data.SoftDelete != "" {
+		data.ListFilter = qualifyTypeName("List"+structName+"Filter", modelPackage)
+		data.GetFilter = qualifyTypeName("Get"+structName+"Filter", modelPackage)
+	}
+
+	// controller of all schema is placed in controllers package, controller name and
+	// path of table outside public schema is prefixed by schema for prevent conflict
+	if input.SchemaPackage {
+		schemaPackage := ToSchemaPackageName(input.Table.Schema)
+		data.Imports[1] = fmt.Sprintf("%s/%s", modelsImportPath, schemaPackage)
+		data.Model = qualifyTypeName(structName, schemaPackage)
+		if input.Table.Schema != "public" {
+			data.Name = utils.SnakeCaseToPascalCase(schemaPackage) + structName
+			data.Path = fmt.Sprintf("/%s/%s", input.Table.Schema, input.Table.Name)
+		}
+	}
+
+	if !data.IsMutable {
+		return data
+	}
+
+	nameTransformer := input.GetNameTransformer()
+	itemPath := []string{data.Path}
+	for _, pk := range input.Table.PrimaryKeys {
+		data.Keys = append(data.Keys, GenerateTableControllerKey{
+			Field:  nameTransformer.Column(pk.Name),
+			Type:   getTableControllerKeyType(input, pk.Name),
+			Column: pk.Name,
+		})
+		itemPath = append(itemPath, fmt.Sprintf("{%s}", pk.Name))
+	}
+	data.ItemPath = strings.Join(itemPath, "/")
+
+	return data
+}
+
+// getTableControllerKeyType return go type of primary key path param,
+// path param only can be bind to integer and string field
+func getTableControllerKeyType(input *GenerateModelInput, columnName string) string {
+	for _, c := range input.Table.Columns {
+		if c.Name != columnName {
+			continue
+		}
+
+		switch goType := postgres.ToGoType(postgres.DataType(c.DataType), false); goType {
+		case "int", "int16", "int32", "int64":
+			return goType
+		}
+	}
+	return "string"
+}
@@ -0,0 +1,92 @@
+package generator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTableControllers(t *testing.T) {
+	orders := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:   "orders",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "code", DataType: "text"},
+				{Name: "deleted_at", DataType: "timestamp with time zone", IsNullable: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		ProjectName:      "shop",
+		SoftDeleteColumn: "deleted_at",
+	}
+	sessions := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:        "sessions",
+			Schema:      "auth",
+			Columns:     []objects.Column{{Name: "id", DataType: "uuid"}},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		ProjectName:   "shop",
+		SchemaPackage: true,
+	}
+	summary := &generator.GenerateModelInput{
+		Table:       objects.Table{Name: "order_summary", Schema: "public", IsView: true},
+		ProjectName: "shop",
+	}
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	err := generator.GenerateTableControllers(context.Background(), dir, []*generator.GenerateModelInput{orders, sessions, summary}, generator.Generate)
+	assert.NoError(t, err)
+
+	controllerDir := filepath.Join(dir, generator.ControllerDir)
+	content, err := os.ReadFile(filepath.Join(controllerDir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"shop/internal/models\"")
+	assert.Contains(t, string(content), "Payload *models.Orders")
+	assert.Contains(t, string(content), "Id int64 `path:\"id\"`")
+	assert.Contains(t, string(content), "Http    string `path:\"/orders/{id}\" type:\"custom\"`")
+	assert.Contains(t, string(content), "query list of orders with models.ListOrdersFilter(), soft deleted row is excluded")
+	assert.Contains(t, string(content), "query orders with models.GetOrdersFilter(row), soft deleted row is excluded")
+
+	content, err = os.ReadFile(filepath.Join(controllerDir, "auth_sessions.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "\"shop/internal/models/auth\"")
+	assert.Contains(t, string(content), "type AuthSessionsController struct")
+	assert.Contains(t, string(content), "Id string `path:\"id\"`")
+
+	content, err = os.ReadFile(filepath.Join(controllerDir, "order_summary.go"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "ItemController")
+	assert.NotContains(t, string(content), "Post(")
+
+	routes, err := generator.WalkScanControllers(controllerDir)
+	assert.NoError(t, err)
+
+	mapRoute := make(map[string]string)
+	for _, r := range routes {
+		mapRoute[r.Path] = r.Methods
+	}
+	assert.Equal(t, 5, len(mapRoute))
+	assert.Equal(t, "[]string{fasthttp.MethodGet, fasthttp.MethodPost}", mapRoute["\"/orders\""])
+	assert.Equal(t, "[]string{fasthttp.MethodGet, fasthttp.MethodPatch, fasthttp.MethodDelete}", mapRoute["\"/orders/{id}\""])
+	assert.Equal(t, "[]string{fasthttp.MethodGet}", mapRoute["\"/order_summary\""])
+
+	// implemented controller is never replaced
+	filePath := filepath.Join(controllerDir, "orders.go")
+	assert.NoError(t, os.WriteFile(filePath, []byte("package controllers\n"), 0644))
+	err = generator.GenerateTableControllers(context.Background(), dir, []*generator.GenerateModelInput{orders}, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err = os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "package controllers\n", string(content))
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// ----- Template override -----
+//
+// override template is placed in project templates folder and named by resource kind, example :
+//
+//	templates/
+//	  model.tmpl
+//	  rpc.tmpl
+//
+// template receive the same bind data and func map as default template,
+// default template is used for resource kind that doesn't have override file
+type TemplateKind string
+
+const (
+	TemplateKindModel     TemplateKind = "model"
+	TemplateKindModelView TemplateKind = "model_view"
+	TemplateKindModelStub TemplateKind = "model_stub"
+	TemplateKindEnum      TemplateKind = "enum"
+	TemplateKindRpc       TemplateKind = "rpc"
+	TemplateKindRole      TemplateKind = "role"
+	TemplateKindStorage   TemplateKind = "storage"
+)
+
+const (
+	TemplateOverrideDir = "templates"
+	TemplateOverrideExt = ".tmpl"
+)
+
+// map template name that used by generator to resource kind
+var templateNameKinds = map[string]TemplateKind{
+	"modelTemplate":     TemplateKindModel,
+	"modelViewTemplate": TemplateKindModelView,
+	"modelStubTemplate": TemplateKindModelStub,
+	"enumTemplate":      TemplateKindEnum,
+	"rpcTemplate":       TemplateKindRpc,
+	"roleTemplate":      TemplateKindRole,
+	"storageTemplate":   TemplateKindStorage,
+}
+
+type TemplateOverrides map[TemplateKind]string
+
+// LoadTemplateOverrides read override template of every resource kind from fsys,
+// resource kind without override file is skipped
+func LoadTemplateOverrides(fsys fs.FS) (TemplateOverrides, error) {
+	overrides := make(TemplateOverrides)
+	for _, kind := range templateNameKinds {
+		fileName := string(kind) + TemplateOverrideExt
+		content, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed read override template %s : %v", fileName, err)
+		}
+
+		GeneratorLogger.Debug("use override template", "kind", kind, "file", fileName)
+		overrides[kind] = string(content)
+	}
+	return overrides, nil
+}
+
+// LoadProjectTemplateOverrides read override template from templates folder in project path,
+// empty override is returned when folder is not exist
+func LoadProjectTemplateOverrides(projectPath string) (TemplateOverrides, error) {
+	folderPath := filepath.Join(projectPath, TemplateOverrideDir)
+	if !utils.IsFolderExists(folderPath) {
+		return nil, nil
+	}
+	return LoadTemplateOverrides(os.DirFS(folderPath))
+}
+
+// WithTemplateOverrides replace template of generate input with override template
+// before passed to generateFn, input is passed as is when resource kind is not overridden
+func WithTemplateOverrides(overrides TemplateOverrides, generateFn GenerateFn) GenerateFn {
+	if len(overrides) == 0 {
+		return generateFn
+	}
+
+	return func(input GenerateInput, writer io.Writer) error {
+		if kind, exist := templateNameKinds[input.TemplateName]; exist {
+			if tmpl, isOverridden := overrides[kind]; isOverridden {
+				input.Template = tmpl
+			}
+		}
+		return generateFn(input, writer)
+	}
+}
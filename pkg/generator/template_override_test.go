@@ -0,0 +1,59 @@
+package generator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTemplateOverrides(t *testing.T) {
+	fsys := fstest.MapFS{
+		"model.tmpl":  {Data: []byte("package {{ .Package }}\n\n// license banner\ntype {{ .StructName }} struct{}\n")},
+		"readme.tmpl": {Data: []byte("not a resource template")},
+	}
+
+	overrides, err := generator.LoadTemplateOverrides(fsys)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(overrides))
+	assert.Contains(t, overrides[generator.TemplateKindModel], "license banner")
+}
+
+func TestWithTemplateOverrides(t *testing.T) {
+	dir := t.TempDir()
+	overrides := generator.TemplateOverrides{
+		generator.TemplateKindModel: "package {{ .Package }}\n\n// license banner\ntype {{ .StructName }} struct{}\n",
+	}
+
+	input := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Name:        "orders",
+			Schema:      "public",
+			Columns:     []objects.Column{{Name: "id", DataType: "bigint"}},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id"}},
+		},
+		WithStub: true,
+	}
+
+	err := generator.GenerateModel(dir, input, generator.WithTemplateOverrides(overrides, generator.Generate))
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "orders_gen.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, "package models\n\n// license banner\ntype Orders struct{}\n", string(content))
+
+	// stub is not overridden and use default template
+	content, err = os.ReadFile(filepath.Join(dir, "orders.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "put custom method for Orders model in this file")
+}
+
+func TestLoadProjectTemplateOverrides_NotExist(t *testing.T) {
+	overrides, err := generator.LoadProjectTemplateOverrides(t.TempDir())
+	assert.NoError(t, err)
+	assert.Nil(t, overrides)
+}
@@ -0,0 +1,18 @@
+package testdata
+
+import "github.com/sev-2/raiden"
+
+type SupabaseRealtime struct {
+	raiden.PublicationBase
+}
+
+func (p *SupabaseRealtime) Name() string {
+	return "supabase_realtime"
+}
+
+func (p *SupabaseRealtime) Tables() []string {
+	return []string{
+		"candidates",
+		"votes",
+	}
+}
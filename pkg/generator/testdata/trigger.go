@@ -0,0 +1,27 @@
+package testdata
+
+import "github.com/sev-2/raiden"
+
+type OnVoteInserted struct {
+	raiden.TriggerBase
+}
+
+func (t *OnVoteInserted) Name() string {
+	return "on_vote_inserted"
+}
+
+func (t *OnVoteInserted) Table() string {
+	return "votes"
+}
+
+func (t *OnVoteInserted) Events() []raiden.TriggerEvent {
+	return []raiden.TriggerEvent{raiden.TriggerEventInsert}
+}
+
+func (t *OnVoteInserted) Orientation() raiden.TriggerOrientation {
+	return raiden.TriggerOrientationRow
+}
+
+func (t *OnVoteInserted) Function() raiden.Rpc {
+	return &GetVoteBy{}
+}
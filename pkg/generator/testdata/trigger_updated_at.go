@@ -0,0 +1,61 @@
+package testdata
+
+import "github.com/sev-2/raiden"
+
+// trigger name is only unique in the same table, the same trigger is declared in every table
+
+type CandidatesSetUpdatedAt struct {
+	raiden.TriggerBase
+}
+
+func (t *CandidatesSetUpdatedAt) Name() string {
+	return "set_updated_at"
+}
+
+func (t *CandidatesSetUpdatedAt) Table() string {
+	return "candidates"
+}
+
+func (t *CandidatesSetUpdatedAt) Timing() raiden.TriggerTiming {
+	return raiden.TriggerTimingBefore
+}
+
+func (t *CandidatesSetUpdatedAt) Events() []raiden.TriggerEvent {
+	return []raiden.TriggerEvent{raiden.TriggerEventUpdate}
+}
+
+func (t *CandidatesSetUpdatedAt) Orientation() raiden.TriggerOrientation {
+	return raiden.TriggerOrientationRow
+}
+
+func (t *CandidatesSetUpdatedAt) Function() raiden.Rpc {
+	return &GetVoteBy{}
+}
+
+type VotesSetUpdatedAt struct {
+	raiden.TriggerBase
+}
+
+func (t *VotesSetUpdatedAt) Name() string {
+	return "set_updated_at"
+}
+
+func (t *VotesSetUpdatedAt) Table() string {
+	return "votes"
+}
+
+func (t *VotesSetUpdatedAt) Timing() raiden.TriggerTiming {
+	return raiden.TriggerTimingBefore
+}
+
+func (t *VotesSetUpdatedAt) Events() []raiden.TriggerEvent {
+	return []raiden.TriggerEvent{raiden.TriggerEventUpdate}
+}
+
+func (t *VotesSetUpdatedAt) Orientation() raiden.TriggerOrientation {
+	return raiden.TriggerOrientationRow
+}
+
+func (t *VotesSetUpdatedAt) Function() raiden.Rpc {
+	return &GetVoteBy{}
+}
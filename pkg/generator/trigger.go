@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var TriggerLogger hclog.Logger = logger.HcLog().Named("generator.trigger")
+
+// ----- Define type, variable and constant -----
+type GenerateTriggerData struct {
+	Imports     []string
+	Package     string
+	Name        string
+	StructName  string
+	Schema      string
+	Table       string
+	Timing      string
+	Events      []string
+	Orientation string
+	Condition   string
+	Arguments   []string
+	Function    string
+}
+
+const (
+	TriggerDir      = "internal/triggers"
+	TriggerTemplate = `package {{ .Package }}
+{{- if gt (len .Imports) 0 }}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{- end }}
+
+type {{ .StructName }} struct {
+	raiden.TriggerBase
+}
+
+func (t *{{ .StructName }}) Name() string {
+	return "{{ .Name }}"
+}
+{{- if ne .Schema "" }}
+
+func (t *{{ .StructName }}) Schema() string {
+	return "{{ .Schema }}"
+}
+{{- end }}
+
+func (t *{{ .StructName }}) Table() string {
+	return "{{ .Table }}"
+}
+{{- if ne .Timing "" }}
+
+func (t *{{ .StructName }}) Timing() raiden.TriggerTiming {
+	return {{ .Timing }}
+}
+{{- end }}
+
+func (t *{{ .StructName }}) Events() []raiden.TriggerEvent {
+	return []raiden.TriggerEvent{ {{- range $i, $v := .Events }}{{ if $i }}, {{ end }}{{ $v }}{{- end }}}
+}
+{{- if ne .Orientation "" }}
+
+func (t *{{ .StructName }}) Orientation() raiden.TriggerOrientation {
+	return {{ .Orientation }}
+}
+{{- end }}
+{{- if ne .Condition "" }}
+
+func (t *{{ .StructName }}) Condition() string {
+	return {{ printf "%q" .Condition }}
+}
+{{- end }}
+{{- if gt (len .Arguments) 0 }}
+
+func (t *{{ .StructName }}) Arguments() []string {
+	return []string{ {{- range $i, $v := .Arguments }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{- end }}}
+}
+{{- end }}
+
+func (t *{{ .StructName }}) Function() raiden.Rpc {
+	return &rpc.{{ .Function }}{}
+}
+`
+)
+
+var (
+	mapTriggerTimingDecl = map[string]string{
+		string(raiden.TriggerTimingBefore):    "raiden.TriggerTimingBefore",
+		string(raiden.TriggerTimingAfter):     "raiden.TriggerTimingAfter",
+		string(raiden.TriggerTimingInsteadOf): "raiden.TriggerTimingInsteadOf",
+	}
+
+	mapTriggerEventDecl = map[string]string{
+		string(raiden.TriggerEventInsert):   "raiden.TriggerEventInsert",
+		string(raiden.TriggerEventUpdate):   "raiden.TriggerEventUpdate",
+		string(raiden.TriggerEventDelete):   "raiden.TriggerEventDelete",
+		string(raiden.TriggerEventTruncate): "raiden.TriggerEventTruncate",
+	}
+
+	mapTriggerOrientationDecl = map[string]string{
+		string(raiden.TriggerOrientationRow):       "raiden.TriggerOrientationRow",
+		string(raiden.TriggerOrientationStatement): "raiden.TriggerOrientationStatement",
+	}
+)
+
+func GenerateTriggers(ctx context.Context, basePath string, project ProjectPackage, triggers []objects.Trigger, generateFn GenerateFn) error {
+	folderPath := filepath.Join(basePath, TriggerDir)
+	TriggerLogger.Trace("create triggers folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	for i := range triggers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := GenerateTrigger(folderPath, project, triggers[i], generateFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func GenerateTrigger(folderPath string, project ProjectPackage, trigger objects.Trigger, generateFn GenerateFn) error {
+	data, err := BuildTriggerData(project, trigger)
+	if err != nil {
+		return err
+	}
+
+	input := GenerateInput{
+		BindData:     data,
+		Template:     TriggerTemplate,
+		TemplateName: "triggerTemplate",
+		OutputPath:   filepath.Join(folderPath, fmt.Sprintf("%s.%s", GetTriggerFileName(trigger), "go")),
+	}
+
+	TriggerLogger.Debug("generate trigger", "path", input.OutputPath)
+	return generateFn(input, nil)
+}
+
+// GetTriggerFileName return generated file name of trigger without extension, trigger name
+// is only unique in the same table so it is prefixed by table name (e.g orders_set_updated_at)
+func GetTriggerFileName(trigger objects.Trigger) string {
+	name := utils.ToSnakeCase(trigger.Name)
+	table := utils.ToSnakeCase(trigger.Table)
+	if table == "" || strings.HasPrefix(name, table+"_") {
+		return name
+	}
+	return fmt.Sprintf("%s_%s", table, name)
+}
+
+// GetTriggerStructName return generated struct name of trigger, see GetTriggerFileName
+func GetTriggerStructName(trigger objects.Trigger) string {
+	return utils.SnakeCaseToPascalCase(GetTriggerFileName(trigger))
+}
+
+// BuildTriggerData map trigger to template data, method with default value
+// from raiden.TriggerBase is not generated
+func BuildTriggerData(project ProjectPackage, trigger objects.Trigger) (data GenerateTriggerData, err error) {
+	data = GenerateTriggerData{
+		Package: "triggers",
+		Imports: []string{
+			fmt.Sprintf("%q", "github.com/sev-2/raiden"),
+			fmt.Sprintf("%q", project.GetImportPath(RpcDir)),
+		},
+		Name:       trigger.Name,
+		StructName: GetTriggerStructName(trigger),
+		Table:      trigger.Table,
+		Condition:  trigger.GetCondition(),
+		Arguments:  trigger.FunctionArgs,
+		Function:   utils.SnakeCaseToPascalCase(trigger.FunctionName),
+	}
+
+	if trigger.Schema != "" && trigger.Schema != raiden.DefaultTriggerSchema {
+		data.Schema = trigger.Schema
+	}
+
+	activation := strings.ToUpper(trigger.Activation)
+	if activation != "" && activation != string(raiden.TriggerTimingAfter) {
+		decl, isValid := mapTriggerTimingDecl[activation]
+		if !isValid {
+			return data, fmt.Errorf("unsupported timing %q in trigger %s", trigger.Activation, trigger.Name)
+		}
+		data.Timing = decl
+	}
+
+	orientation := strings.ToUpper(trigger.Orientation)
+	if orientation != "" && orientation != string(raiden.TriggerOrientationStatement) {
+		decl, isValid := mapTriggerOrientationDecl[orientation]
+		if !isValid {
+			return data, fmt.Errorf("unsupported orientation %q in trigger %s", trigger.Orientation, trigger.Name)
+		}
+		data.Orientation = decl
+	}
+
+	for _, e := range trigger.Events {
+		decl, isValid := mapTriggerEventDecl[strings.ToUpper(e)]
+		if !isValid {
+			return data, fmt.Errorf("unsupported event %q in trigger %s", e, trigger.Name)
+		}
+		data.Events = append(data.Events, decl)
+	}
+
+	return data, nil
+}
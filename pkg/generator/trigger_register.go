@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var TriggerRegisterLogger hclog.Logger = logger.HcLog().Named("generator.trigger_register")
+
+// ----- Define type, variable and constant -----
+type (
+	GenerateRegisterTriggerData struct {
+		Imports  []string
+		Package  string
+		Triggers []string
+	}
+)
+
+const (
+	TriggerRegisterFilename = "triggers.go"
+	TriggerRegisterDir      = "internal/bootstrap"
+	TriggerRegisterTemplate = `// Code generated by raiden-cli; DO NOT EDIT.
+package {{ .Package }}
+{{if gt (len .Imports) 0 }}
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{end }}
+func RegisterTriggers() {
+	resource.RegisterTrigger(
+		{{- range .Triggers}}
+		&triggers.{{.}}{},
+		{{- end}}
+	)
+}
+`
+)
+
+func GenerateTriggerRegister(basePath string, projectName string, generateFn GenerateFn) error {
+	return GenerateTriggerRegisterWithPackage(basePath, ProjectPackage{ProjectName: projectName}, generateFn)
+}
+
+// GenerateTriggerRegisterWithPackage is GenerateTriggerRegister with configured module path
+func GenerateTriggerRegisterWithPackage(basePath string, project ProjectPackage, generateFn GenerateFn) error {
+	triggerRegisterDir := filepath.Join(basePath, TriggerRegisterDir)
+	TriggerRegisterLogger.Trace("create bootstrap folder if not exist", "path", triggerRegisterDir)
+	if exist := utils.IsFolderExists(triggerRegisterDir); !exist {
+		if err := utils.CreateFolder(triggerRegisterDir); err != nil {
+			return err
+		}
+	}
+
+	triggerDir := filepath.Join(basePath, TriggerDir)
+	TriggerRegisterLogger.Trace("create triggers folder if not exist", "path", triggerDir)
+	if exist := utils.IsFolderExists(triggerDir); !exist {
+		if err := utils.CreateFolder(triggerDir); err != nil {
+			return err
+		}
+	}
+
+	// scan all trigger
+	triggerList, err := WalkScanTrigger(triggerDir)
+	if err != nil {
+		return err
+	}
+
+	input, err := createTriggerRegisterInput(project, triggerRegisterDir, triggerList)
+	if err != nil {
+		return err
+	}
+
+	TriggerRegisterLogger.Debug("generate trigger register", "path", input.OutputPath)
+	return generateFn(input, nil)
+}
+
+func createTriggerRegisterInput(project ProjectPackage, triggerRegisterDir string, triggerList []string) (input GenerateInput, err error) {
+	// set file path
+	filePath := filepath.Join(triggerRegisterDir, TriggerRegisterFilename)
+
+	// set imports path
+	imports := []string{
+		fmt.Sprintf("%q", "github.com/sev-2/raiden/pkg/resource"),
+	}
+
+	if len(triggerList) > 0 {
+		triggersImportPath := project.GetImportPath(TriggerDir)
+		imports = append(imports, fmt.Sprintf("%q", triggersImportPath))
+	}
+
+	// set passed parameter
+	data := GenerateRegisterTriggerData{
+		Package:  "bootstrap",
+		Imports:  imports,
+		Triggers: triggerList,
+	}
+
+	input = GenerateInput{
+		BindData:     data,
+		Template:     TriggerRegisterTemplate,
+		TemplateName: "triggerRegisterTemplate",
+		OutputPath:   filePath,
+	}
+
+	return
+}
+
+func WalkScanTrigger(triggerDir string) ([]string, error) {
+	TriggerRegisterLogger.Trace("scan all registered triggers", "path", triggerDir)
+
+	triggers := make([]string, 0)
+	err := filepath.Walk(triggerDir, func(path string, info fs.FileInfo, err error) error {
+		if strings.HasSuffix(path, ".go") {
+			TriggerRegisterLogger.Trace("collect triggers", "file-path", path)
+			rs, e := getStructByBaseName(path, "TriggerBase")
+			if e != nil {
+				return e
+			}
+
+			triggers = append(triggers, rs...)
+
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return triggers, nil
+}
@@ -0,0 +1,96 @@
+package generator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTriggers(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	condition := "new.amount > 0"
+	triggers := []objects.Trigger{
+		{
+			Name:           "on_order_inserted",
+			Schema:         "public",
+			Table:          "orders",
+			Activation:     "AFTER",
+			Events:         []string{"INSERT"},
+			Orientation:    "ROW",
+			Condition:      &condition,
+			FunctionName:   "handle_new_order",
+			FunctionSchema: "public",
+		},
+	}
+
+	err := generator.GenerateTriggers(context.Background(), dir, generator.ProjectPackage{ProjectName: "test"}, triggers, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.TriggerDir, "orders_on_order_inserted.go"))
+	assert.NoError(t, err)
+
+	result := string(content)
+	assert.Contains(t, result, `"test/internal/rpc"`)
+	assert.Contains(t, result, "type OrdersOnOrderInserted struct {\n\traiden.TriggerBase\n}")
+	assert.Contains(t, result, `return "orders"`)
+	assert.Contains(t, result, "return []raiden.TriggerEvent{raiden.TriggerEventInsert}")
+	assert.Contains(t, result, "return raiden.TriggerOrientationRow")
+	assert.Contains(t, result, `return "new.amount > 0"`)
+	assert.Contains(t, result, "return &rpc.HandleNewOrder{}")
+
+	// default value from trigger base is not generated
+	assert.NotContains(t, result, "Timing()")
+	assert.NotContains(t, result, "Schema()")
+	assert.NotContains(t, result, "Arguments()")
+}
+
+func TestBuildTriggerData_InvalidEvent(t *testing.T) {
+	_, err := generator.BuildTriggerData(generator.ProjectPackage{ProjectName: "test"}, objects.Trigger{
+		Name:         "on_order_changed",
+		Table:        "orders",
+		Events:       []string{"SELECT"},
+		FunctionName: "handle_order",
+	})
+	assert.Error(t, err)
+}
+
+func TestWalkTriggerDir(t *testing.T) {
+	testPath, err := utils.GetAbsolutePath("/testdata")
+	assert.NoError(t, err)
+
+	rs, err := generator.WalkScanTrigger(testPath)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"OnVoteInserted", "CandidatesSetUpdatedAt", "VotesSetUpdatedAt"}, rs)
+}
+
+func TestGenerateTriggers_SameNameInOtherTable(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "internal"), 0755))
+
+	triggers := []objects.Trigger{
+		{Name: "set_updated_at", Schema: "public", Table: "candidates", Activation: "BEFORE", Events: []string{"UPDATE"}, Orientation: "ROW", FunctionName: "set_updated_at"},
+		{Name: "set_updated_at", Schema: "public", Table: "votes", Activation: "BEFORE", Events: []string{"UPDATE"}, Orientation: "ROW", FunctionName: "set_updated_at"},
+		{Name: "votes_audit", Schema: "public", Table: "votes", Events: []string{"DELETE"}, FunctionName: "audit"},
+	}
+
+	err := generator.GenerateTriggers(context.Background(), dir, generator.ProjectPackage{ProjectName: "test"}, triggers, generator.Generate)
+	assert.NoError(t, err)
+
+	for file, structName := range map[string]string{
+		"candidates_set_updated_at.go": "CandidatesSetUpdatedAt",
+		"votes_set_updated_at.go":      "VotesSetUpdatedAt",
+		"votes_audit.go":               "VotesAudit",
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, generator.TriggerDir, file))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "type "+structName+" struct {")
+	}
+}
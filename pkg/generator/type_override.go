@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+var majorVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+// ParseTypeOverride parse go type of type override, type is written with import path
+// when declared in other package (e.g github.com/shopspring/decimal.Decimal) and
+// returned as qualified type (e.g decimal.Decimal) with import path
+func ParseTypeOverride(value string) (goType string, importPath string, err error) {
+	name := strings.TrimLeft(value, "[]*")
+	prefix := value[:len(value)-len(name)]
+
+	dotIndex := strings.LastIndex(name, ".")
+	if dotIndex < strings.LastIndex(name, "/") {
+		dotIndex = -1
+	}
+
+	if dotIndex == -1 {
+		if !token.IsIdentifier(name) {
+			return "", "", fmt.Errorf("invalid type override %q, type must be go type with import path (e.g github.com/shopspring/decimal.Decimal)", value)
+		}
+		return value, "", nil
+	}
+
+	importPath, typeName := name[:dotIndex], name[dotIndex+1:]
+	packageName := path.Base(importPath)
+
+	// package of major version module is declared without version suffix (e.g github.com/jackc/pgx/v5)
+	if majorVersionRegex.MatchString(packageName) && strings.Contains(importPath, "/") {
+		packageName = path.Base(path.Dir(importPath))
+	}
+	packageName = strings.ReplaceAll(packageName, "-", "")
+
+	if importPath == "" || !token.IsIdentifier(typeName) || !token.IsIdentifier(packageName) {
+		return "", "", fmt.Errorf("invalid type override %q, type must be go type with import path (e.g github.com/shopspring/decimal.Decimal)", value)
+	}
+	return fmt.Sprintf("%s%s.%s", prefix, packageName, typeName), importPath, nil
+}
+
+// getTypeOverride return overridden go type of column, column is matched by
+// postgres type name (e.g citext) and then by data type (e.g numeric)
+func getTypeOverride(c objects.Column, typeOverrides map[string]string, isPointer bool) (goType string, importPath string, found bool) {
+	value, exist := typeOverrides[c.Format]
+	if !exist {
+		value, exist = typeOverrides[c.DataType]
+	}
+
+	if !exist || value == "" {
+		return "", "", false
+	}
+
+	goType, importPath, err := ParseTypeOverride(value)
+	if err != nil {
+		return "", "", false
+	}
+
+	if isPointer && !strings.HasPrefix(goType, "*") && !strings.HasPrefix(goType, "[]") {
+		goType = "*" + goType
+	}
+	return goType, importPath, true
+}
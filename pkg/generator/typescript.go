@@ -0,0 +1,298 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var TypeScriptLogger hclog.Logger = logger.HcLog().Named("generator.typescript")
+
+// ----- Define type, variable and constant -----
+type (
+	GenerateTypeScriptInput struct {
+		Tables    []objects.Table
+		Types     []objects.Type
+		Functions []objects.Function
+		JsonCase  JsonCase
+
+		// package of generated model, setof rpc return is matched with it
+		ModelsPackage string
+	}
+
+	GenerateTypeScriptField struct {
+		Name     string
+		Type     string
+		Optional bool
+	}
+
+	GenerateTypeScriptEnum struct {
+		Name   string
+		Schema string
+		Type   string
+		Values string
+	}
+
+	GenerateTypeScriptInterface struct {
+		Name   string
+		Schema string
+		Type   string
+		Fields []GenerateTypeScriptField
+	}
+
+	GenerateTypeScriptFunction struct {
+		Name       string
+		Schema     string
+		Type       string
+		Params     []GenerateTypeScriptField
+		ReturnType string
+	}
+
+	GenerateTypeScriptData struct {
+		Enums      []GenerateTypeScriptEnum
+		Interfaces []GenerateTypeScriptInterface
+		Functions  []GenerateTypeScriptFunction
+	}
+)
+
+const (
+	TypeScriptDir      = "types"
+	TypeScriptFile     = "database.d.ts"
+	TypeScriptTemplate = `// Code generated by raiden-cli; DO NOT EDIT.
+
+export type Json = string | number | boolean | null | { [key: string]: Json | undefined } | Json[];
+{{- range .Enums }}
+
+// {{ .Type }} represent postgres enum type {{ .Schema }}.{{ .Name }}
+export type {{ .Type }} = {{ .Values }};
+{{- end }}
+{{- range .Interfaces }}
+
+// {{ .Type }} represent table {{ .Schema }}.{{ .Name }}
+export interface {{ .Type }} {
+{{- range .Fields }}
+  {{ .Name }}: {{ .Type }};
+{{- end }}
+}
+{{- end }}
+{{- range .Functions }}
+
+// {{ .Type }} represent rpc function {{ .Schema }}.{{ .Name }}
+export interface {{ .Type }}Params {
+{{- range .Params }}
+  {{ .Name }}{{ if .Optional }}?{{ end }}: {{ .Type }};
+{{- end }}
+}
+
+export type {{ .Type }} = (params: {{ .Type }}Params) => Promise<{{ .ReturnType }}>;
+{{- end }}
+`
+)
+
+// GenerateTypeScript write typescript declaration of table, enum and rpc function
+// to <basePath>/types/database.d.ts, type mapping is shared with generated go model
+func GenerateTypeScript(basePath string, input GenerateTypeScriptInput, generateFn GenerateFn) error {
+	folderPath := filepath.Join(basePath, TypeScriptDir)
+	TypeScriptLogger.Trace("create types folder if not exist", "path", folderPath)
+	if exist := utils.IsFolderExists(folderPath); !exist {
+		if err := utils.CreateFolder(folderPath); err != nil {
+			return err
+		}
+	}
+
+	generateInput := GenerateInput{
+		BindData:     BuildTypeScriptData(input),
+		Template:     TypeScriptTemplate,
+		TemplateName: "typeScriptTemplate",
+		OutputPath:   filepath.Join(folderPath, TypeScriptFile),
+	}
+
+	TypeScriptLogger.Debug("generate typescript", "path", generateInput.OutputPath)
+	return generateFn(generateInput, nil)
+}
+
+// BuildTypeScriptData map resource to typescript declaration,
+// declaration is sorted so the output is stable between import
+func BuildTypeScriptData(input GenerateTypeScriptInput) GenerateTypeScriptData {
+	var data GenerateTypeScriptData
+
+	for _, t := range input.Types {
+		if !t.IsEnum() {
+			continue
+		}
+
+		values := make([]string, 0, len(t.Enums))
+		for _, v := range t.Enums {
+			values = append(values, fmt.Sprintf("%q", v))
+		}
+
+		data.Enums = append(data.Enums, GenerateTypeScriptEnum{
+			Name:   t.Name,
+			Schema: t.Schema,
+			Type:   toEnumIdentifier(t.Name),
+			Values: strings.Join(values, " | "),
+		})
+	}
+	sort.SliceStable(data.Enums, func(i, j int) bool {
+		return data.Enums[i].Type < data.Enums[j].Type
+	})
+
+	tables := make([]objects.Table, len(input.Tables))
+	copy(tables, input.Tables)
+	sort.SliceStable(tables, func(i, j int) bool {
+		if tables[i].Schema != tables[j].Schema {
+			return tables[i].Schema < tables[j].Schema
+		}
+		return tables[i].Name < tables[j].Name
+	})
+
+	mapTableType := make(map[string]string)
+	declared := make(map[string]bool)
+	for _, t := range tables {
+		// table with the same name in other schema is prefixed with schema name
+		typeName := utils.SnakeCaseToPascalCase(t.Name)
+		if declared[typeName] {
+			typeName = utils.SnakeCaseToPascalCase(t.Schema) + typeName
+		}
+		declared[typeName] = true
+
+		if _, exist := mapTableType[t.Name]; !exist {
+			mapTableType[t.Name] = typeName
+		}
+
+		data.Interfaces = append(data.Interfaces, GenerateTypeScriptInterface{
+			Name:   t.Name,
+			Schema: t.Schema,
+			Type:   typeName,
+			Fields: buildTypeScriptColumns(t, input.JsonCase),
+		})
+	}
+
+	for i := range input.Functions {
+		fn := input.Functions[i]
+		tsFn, err := buildTypeScriptFunction(&fn, ProjectPackage{ModelsPackage: input.ModelsPackage}, mapTableType)
+		if err != nil {
+			TypeScriptLogger.Warn("skip typescript declaration, failed extract rpc", "schema", fn.Schema, "name", fn.Name, "reason", err.Error())
+			continue
+		}
+		data.Functions = append(data.Functions, tsFn)
+	}
+	sort.SliceStable(data.Functions, func(i, j int) bool {
+		return data.Functions[i].Type < data.Functions[j].Type
+	})
+
+	return data
+}
+
+func buildTypeScriptColumns(table objects.Table, jsonCase JsonCase) (fields []GenerateTypeScriptField) {
+	for _, c := range table.Columns {
+		var tsType string
+		switch postgres.DataType(c.DataType) {
+		case postgres.UserDefinedType:
+			tsType = "unknown"
+			if len(c.Enums) > 0 {
+				tsType = toEnumIdentifier(c.Format)
+			}
+		case postgres.ArrayType:
+			tsType = postgres.ToTypeScriptArrayType(c.Format)
+			if len(c.Enums) > 0 {
+				tsType = toEnumIdentifier(postgres.GetArrayElementType(c.Format)) + "[]"
+			}
+		default:
+			tsType = postgres.ToTypeScriptType(postgres.DataType(c.DataType), false)
+		}
+
+		// check constraint that only allow list of value is declared as union of the value
+		if values, isEnumCheck := ParseCheckInValues(c.Name, c.GetCheck()); isEnumCheck {
+			quoted := make([]string, 0, len(values))
+			for _, v := range values {
+				quoted = append(quoted, fmt.Sprintf("%q", v))
+			}
+			tsType = strings.Join(quoted, " | ")
+		}
+
+		if c.IsNullable && tsType != "unknown" {
+			tsType += " | null"
+		}
+
+		fields = append(fields, GenerateTypeScriptField{
+			Name: ToJsonKey(c.Name, jsonCase),
+			Type: tsType,
+		})
+	}
+	return
+}
+
+func buildTypeScriptFunction(fn *objects.Function, project ProjectPackage, mapTableType map[string]string) (tsFn GenerateTypeScriptFunction, err error) {
+	result, err := ExtractRpcFunction(fn)
+	if err != nil {
+		return tsFn, err
+	}
+	result.Project = project
+
+	tsFn = GenerateTypeScriptFunction{
+		Name:   fn.Name,
+		Schema: fn.Schema,
+		Type:   utils.SnakeCaseToPascalCase(fn.Name),
+	}
+
+	for _, p := range result.Rpc.Params {
+		tsFn.Params = append(tsFn.Params, GenerateTypeScriptField{
+			Name:     p.Name,
+			Type:     rpcTypeToTypeScript(string(p.Type)),
+			Optional: p.Default != nil,
+		})
+	}
+
+	returnDecl, returnColumns, isReturnArr, err := result.GetReturn(make(map[string]bool))
+	if err != nil {
+		return tsFn, err
+	}
+
+	switch result.Rpc.ReturnType {
+	case raiden.RpcReturnDataTypeSetOf:
+		tsFn.ReturnType = "Record<string, unknown>"
+		for tableName, typeName := range mapTableType {
+			if project.GetModelsPackage()+"."+utils.SnakeCaseToPascalCase(tableName) == returnDecl {
+				tsFn.ReturnType = typeName
+				break
+			}
+		}
+	case raiden.RpcReturnDataTypeTable:
+		fields := make([]string, 0, len(returnColumns))
+		for _, c := range returnColumns {
+			tag, e := raiden.UnmarshalRpcParamTag(reflect.StructTag(c.Tag).Get("column"))
+			if e != nil {
+				return tsFn, e
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s", tag.Name, rpcTypeToTypeScript(tag.Type)))
+		}
+		tsFn.ReturnType = "{ " + strings.Join(fields, "; ") + " }"
+	case raiden.RpcReturnDataTypeVoid:
+		tsFn.ReturnType = "void"
+	case raiden.RpcReturnDataTypeRecord:
+		tsFn.ReturnType = "Record<string, unknown>"
+	default:
+		tsFn.ReturnType = rpcTypeToTypeScript(string(result.Rpc.ReturnType))
+	}
+
+	if isReturnArr {
+		tsFn.ReturnType += "[]"
+	}
+	return tsFn, nil
+}
+
+// rpcTypeToTypeScript convert rpc param and return type to typescript type,
+// rpc type is upper case postgres type name so it can use the same type mapping
+func rpcTypeToTypeScript(rpcType string) string {
+	return postgres.ToTypeScriptType(postgres.DataType(strings.ToLower(rpcType)), false)
+}
@@ -0,0 +1,72 @@
+package generator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	dir := t.TempDir()
+
+	input := generator.GenerateTypeScriptInput{
+		Tables: []objects.Table{
+			{
+				Name:   "orders",
+				Schema: "public",
+				Columns: []objects.Column{
+					{Name: "id", DataType: "bigint"},
+					{Name: "note", DataType: "text", IsNullable: true},
+					{Name: "state", DataType: "USER-DEFINED", Format: "order_state", Enums: []string{"draft", "done"}},
+					{Name: "tags", DataType: "ARRAY", Format: "_text"},
+					{Name: "status", DataType: "text", Check: "status = ANY (ARRAY['pending'::text, 'paid'::text])"},
+					{Name: "created_at", DataType: "timestamp with time zone"},
+				},
+				PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "orders"}},
+			},
+		},
+		Types: []objects.Type{
+			{Name: "order_state", Schema: "public", Format: "enum", Enums: []string{"draft", "done"}},
+		},
+		Functions: []objects.Function{
+			{
+				Schema:     "public",
+				Name:       "count_orders",
+				Language:   "plpgsql",
+				Definition: `BEGIN RETURN (SELECT count(*) FROM orders o WHERE o.status = in_status); END;`,
+				ReturnType: "bigint",
+				Behavior:   "STABLE",
+				Args: []objects.FunctionArg{
+					{Mode: "in", Name: "in_status"},
+					{Mode: "in", Name: "in_limit", HasDefault: true},
+				},
+				ArgumentTypes: "in_status text, in_limit integer DEFAULT 10",
+			},
+		},
+		JsonCase: generator.JsonCaseCamel,
+	}
+
+	err := generator.GenerateTypeScript(dir, input, generator.Generate)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, generator.TypeScriptDir, generator.TypeScriptFile))
+	assert.NoError(t, err)
+
+	result := string(content)
+	assert.Contains(t, result, `export type OrderState = "draft" | "done";`)
+	assert.Contains(t, result, "export interface Orders {")
+	assert.Contains(t, result, "  id: number;")
+	assert.Contains(t, result, "  note: string | null;")
+	assert.Contains(t, result, "  state: OrderState;")
+	assert.Contains(t, result, "  tags: string[];")
+	assert.Contains(t, result, `  status: "pending" | "paid";`)
+	assert.Contains(t, result, "  createdAt: string;")
+	assert.Contains(t, result, "export interface CountOrdersParams {")
+	assert.Contains(t, result, "  status: string;")
+	assert.Contains(t, result, "  limit?: number;")
+	assert.Contains(t, result, "export type CountOrders = (params: CountOrdersParams) => Promise<number>;")
+}
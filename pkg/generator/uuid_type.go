@@ -0,0 +1,19 @@
+package generator
+
+// UuidType is go type of uuid column in generated model
+type UuidType string
+
+const (
+	UuidTypeUuid   UuidType = "uuid.UUID"
+	UuidTypeString UuidType = "string"
+)
+
+// IsValidUuidType check if uuid type is supported, empty uuid type is valid and use uuid.UUID
+func IsValidUuidType(uuidType UuidType) bool {
+	switch uuidType {
+	case "", UuidTypeUuid, UuidTypeString:
+		return true
+	default:
+		return false
+	}
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -94,42 +95,337 @@ const (
 
 	// jsonbType represents the JSONB data type in PostgreSQL.
 	JsonbType DataType = "jsonb"
+
+	// ----- Array Type -----
+
+	// arrayType represents array of other data type in PostgreSQL, element type is available in column format.
+	ArrayType DataType = "ARRAY"
+
+	// ----- Range Type -----
+
+	// int4RangeType represents range of integer in PostgreSQL.
+	Int4RangeType DataType = "int4range"
+
+	// int8RangeType represents range of bigint in PostgreSQL.
+	Int8RangeType DataType = "int8range"
+
+	// numRangeType represents range of numeric in PostgreSQL.
+	NumRangeType DataType = "numrange"
+
+	// tsRangeType represents range of timestamp without time zone in PostgreSQL.
+	TsRangeType DataType = "tsrange"
+
+	// tsTzRangeType represents range of timestamp with time zone in PostgreSQL.
+	TsTzRangeType DataType = "tstzrange"
+
+	// dateRangeType represents range of date in PostgreSQL.
+	DateRangeType DataType = "daterange"
+
+	// ----- User Defined Type -----
+
+	// userDefinedType represents data type created by user (e.g enum) in PostgreSQL.
+	UserDefinedType DataType = "USER-DEFINED"
 )
 
+// TypeMapping is type that used by generated code to represent postgres data type
+type TypeMapping struct {
+	Go         string
+	TypeScript string
+}
+
+// typeMappings is shared by every code generator, so the same column
+// is represented consistently in generated go and typescript code
+var typeMappings = map[DataType]TypeMapping{
+	SmallIntType:         {Go: "int16", TypeScript: "number"},
+	SerialType:           {Go: "int16", TypeScript: "number"},
+	SmallSerialType:      {Go: "int16", TypeScript: "number"},
+	IntType:              {Go: "int32", TypeScript: "number"},
+	BigIntType:           {Go: "int64", TypeScript: "number"},
+	BigSerialType:        {Go: "int64", TypeScript: "number"},
+	DecimalType:          {Go: "float64", TypeScript: "number"},
+	NumericType:          {Go: "float64", TypeScript: "number"},
+	RealType:             {Go: "float64", TypeScript: "number"},
+	DoublePrecisionType:  {Go: "float64", TypeScript: "number"},
+	VarcharType:          {Go: "string", TypeScript: "string"},
+	VarcharTypeAlias:     {Go: "string", TypeScript: "string"},
+	CharType:             {Go: "string", TypeScript: "string"},
+	BpcharType:           {Go: "string", TypeScript: "string"},
+	TextType:             {Go: "string", TypeScript: "string"},
+	TimestampType:        {Go: "time.Time", TypeScript: "string"},
+	TimestampTypeAlias:   {Go: "time.Time", TypeScript: "string"},
+	TimestampTzType:      {Go: "time.Time", TypeScript: "string"},
+	TimestampTzTypeAlias: {Go: "time.Time", TypeScript: "string"},
+	TimeType:             {Go: "time.Time", TypeScript: "string"},
+	TimeTypeAlias:        {Go: "time.Time", TypeScript: "string"},
+	TimeTzType:           {Go: "time.Time", TypeScript: "string"},
+	TimeTzTypeAlias:      {Go: "time.Time", TypeScript: "string"},
+	DateType:             {Go: "time.Time", TypeScript: "string"},
+	IntervalType:         {Go: "time.Duration", TypeScript: "string"},
+	BooleanType:          {Go: "bool", TypeScript: "boolean"},
+	UuidType:             {Go: "uuid.UUID", TypeScript: "string"},
+	JsonType:             {Go: "json.RawMessage", TypeScript: "Json"},
+	JsonbType:            {Go: "json.RawMessage", TypeScript: "Json"},
+
+	// range is serialized as range literal (e.g [1,10)) by postgrest
+	Int4RangeType: {Go: "raiden.Range[int32]", TypeScript: "string"},
+	Int8RangeType: {Go: "raiden.Range[int64]", TypeScript: "string"},
+	NumRangeType:  {Go: "raiden.Range[float64]", TypeScript: "string"},
+	TsRangeType:   {Go: "raiden.Range[time.Time]", TypeScript: "string"},
+	TsTzRangeType: {Go: "raiden.Range[time.Time]", TypeScript: "string"},
+	DateRangeType: {Go: "raiden.Range[time.Time]", TypeScript: "string"},
+}
+
+// arrayElementTypeMappings is keyed by array element format (e.g text for _text)
+var arrayElementTypeMappings = map[string]TypeMapping{
+	"int2":        {Go: "int16", TypeScript: "number"},
+	"int4":        {Go: "int32", TypeScript: "number"},
+	"int8":        {Go: "int64", TypeScript: "number"},
+	"float4":      {Go: "float64", TypeScript: "number"},
+	"float8":      {Go: "float64", TypeScript: "number"},
+	"numeric":     {Go: "float64", TypeScript: "number"},
+	"text":        {Go: "string", TypeScript: "string"},
+	"varchar":     {Go: "string", TypeScript: "string"},
+	"bpchar":      {Go: "string", TypeScript: "string"},
+	"char":        {Go: "string", TypeScript: "string"},
+	"bool":        {Go: "bool", TypeScript: "boolean"},
+	"uuid":        {Go: "uuid.UUID", TypeScript: "string"},
+	"timestamp":   {Go: "time.Time", TypeScript: "string"},
+	"timestamptz": {Go: "time.Time", TypeScript: "string"},
+	"date":        {Go: "time.Time", TypeScript: "string"},
+	"time":        {Go: "time.Time", TypeScript: "string"},
+	"timetz":      {Go: "time.Time", TypeScript: "string"},
+}
+
+// builtinTypes is keyed by oid of built in type, oid of built in type is the same in every
+// postgres database so type that only referred by oid (e.g composite attribute) can be resolved
+var builtinTypes = map[int]DataType{
+	16:   BooleanType,
+	20:   BigIntType,
+	21:   SmallIntType,
+	23:   IntType,
+	25:   TextType,
+	114:  JsonType,
+	700:  RealType,
+	701:  DoublePrecisionType,
+	1042: BpcharType,
+	1043: VarcharType,
+	1082: DateType,
+	1083: TimeType,
+	1114: TimestampType,
+	1184: TimestampTzType,
+	1186: IntervalType,
+	1266: TimeTzType,
+	1700: NumericType,
+	2950: UuidType,
+	3802: JsonbType,
+	3904: Int4RangeType,
+	3906: NumRangeType,
+	3908: TsRangeType,
+	3910: TsTzRangeType,
+	3912: DateRangeType,
+	3926: Int8RangeType,
+}
+
+// builtinArrayTypes is keyed by oid of built in array type, value is the array format
+var builtinArrayTypes = map[int]string{
+	1000: "_bool",
+	1005: "_int2",
+	1007: "_int4",
+	1009: "_text",
+	1014: "_bpchar",
+	1015: "_varchar",
+	1016: "_int8",
+	1021: "_float4",
+	1022: "_float8",
+	1115: "_timestamp",
+	1182: "_date",
+	1185: "_timestamptz",
+	1231: "_numeric",
+	2951: "_uuid",
+}
+
+// GetBuiltinType return data type of built in type oid, format is only set for array type (e.g _text)
+func GetBuiltinType(oid int) (dataType DataType, format string, found bool) {
+	if format, found = builtinArrayTypes[oid]; found {
+		return ArrayType, format, true
+	}
+	dataType, found = builtinTypes[oid]
+	return
+}
+
 // ToGoType Convert postgres type to golang type
 func ToGoType(pgType DataType, isNullable bool) (goType string) {
-	switch pgType {
-	case SmallIntType, SerialType, SmallSerialType:
-		goType = "int16"
-	case IntType:
-		goType = "int32"
-	case BigIntType, BigSerialType:
-		goType = "int64"
-	case DecimalType, NumericType, RealType, DoublePrecisionType:
-		goType = "float64"
-	case VarcharType, VarcharTypeAlias, CharType, BpcharType, TextType:
-		goType = "string"
-	case TimestampType, TimestampTypeAlias, TimestampTzType, TimestampTzTypeAlias, TimeType, TimeTypeAlias, TimeTzType, TimeTzTypeAlias, DateType:
-		goType = "time.Time"
-	case IntervalType:
-		goType = "time.Duration"
-	case BooleanType:
-		goType = "bool"
-	case UuidType:
-		goType = "uuid.UUID" // Assuming you have a UUID library imported
-	case JsonType, JsonbType:
-		goType = "interface{}" // Use a more specific type based on your JSON library
-	default:
-		goType = "interface{}"
+	goType = "interface{}"
+	if m, exist := typeMappings[pgType]; exist {
+		goType = m.Go
 	}
 
-	if isNullable && goType != "interface{}" {
+	// interface{} and json.RawMessage already can hold null value
+	if isNullable && goType != "interface{}" && goType != "json.RawMessage" {
 		goType = fmt.Sprintf("*%s", goType)
 	}
 
 	return
 }
 
+// ToTypeScriptType convert postgres type to typescript type
+func ToTypeScriptType(pgType DataType, isNullable bool) (tsType string) {
+	tsType = "unknown"
+	if m, exist := typeMappings[pgType]; exist {
+		tsType = m.TypeScript
+	}
+
+	// unknown already include null value
+	if isNullable && tsType != "unknown" {
+		tsType += " | null"
+	}
+
+	return
+}
+
+// IsRangeType check if postgres type is built in range type (e.g tstzrange)
+func IsRangeType(pgType DataType) bool {
+	switch pgType {
+	case Int4RangeType, Int8RangeType, NumRangeType, TsRangeType, TsTzRangeType, DateRangeType:
+		return true
+	}
+	return false
+}
+
+// ToGoArrayType convert postgres array element format (e.g _text, _int8) to golang slice type.
+// pg-meta does not report array dimension and postgres does not enforce it, so array is
+// mapped as one dimension slice and element type that can't be mapped fallback to json.RawMessage
+// which also able to hold multi-dimensional array value.
+func ToGoArrayType(format string) (goType string) {
+	if m, exist := arrayElementTypeMappings[GetArrayElementType(format)]; exist {
+		return "[]" + m.Go
+	}
+	return "json.RawMessage"
+}
+
+// ToTypeScriptArrayType convert postgres array element format to typescript array type,
+// element type that can't be mapped fallback to Json the same as go array type.
+func ToTypeScriptArrayType(format string) (tsType string) {
+	if m, exist := arrayElementTypeMappings[GetArrayElementType(format)]; exist {
+		return m.TypeScript + "[]"
+	}
+	return "Json"
+}
+
+// GetArrayElementType return element type from array format,
+// postgres prefix array type name with underscore (e.g _text for text[])
+func GetArrayElementType(format string) string {
+	return strings.TrimPrefix(format, "_")
+}
+
+// ParseTypeModifier split type modifier from type declared in column tag,
+// example : numeric(12,2) will return numeric and [12, 2]
+func ParseTypeModifier(value string) (string, []int) {
+	start := strings.Index(value, "(")
+	if start < 0 || !strings.HasSuffix(value, ")") {
+		return value, nil
+	}
+
+	var modifiers []int
+	for _, m := range strings.Split(value[start+1:len(value)-1], ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(m))
+		if err != nil {
+			return value, nil
+		}
+		modifiers = append(modifiers, n)
+	}
+	return strings.TrimSpace(value[:start]), modifiers
+}
+
+// IsUuidGenerateFunc check if column default generate random uuid,
+// example : gen_random_uuid() and extensions.uuid_generate_v4()
+func IsUuidGenerateFunc(defaultValue string) bool {
+	fn := strings.ToLower(strings.TrimSpace(defaultValue))
+	if i := strings.LastIndex(fn, "."); i >= 0 {
+		fn = fn[i+1:]
+	}
+
+	switch strings.ReplaceAll(fn, " ", "") {
+	case "gen_random_uuid()", "uuid_generate_v4()":
+		return true
+	}
+	return false
+}
+
+// NormalizeDefaultValue return comparable form of column default, postgres keep
+// CURRENT_TIMESTAMP as written while it is the same value with now()
+func NormalizeDefaultValue(defaultValue string) string {
+	value := strings.TrimSpace(defaultValue)
+	switch strings.ToLower(strings.ReplaceAll(value, " ", "")) {
+	case "now()", "current_timestamp", "transaction_timestamp()":
+		return "now()"
+	case "true":
+		return "true"
+	case "false":
+		return "false"
+	}
+	return value
+}
+
+// IsDefaultExpression check if column default is sql expression that must be written
+// without quote, example : now(), CURRENT_TIMESTAMP and '2024-01-01'::date
+func IsDefaultExpression(defaultValue string) bool {
+	value := strings.TrimSpace(defaultValue)
+	if strings.Contains(value, "()") || strings.Contains(value, "::") {
+		return true
+	}
+
+	switch strings.ToUpper(value) {
+	case "CURRENT_TIMESTAMP", "CURRENT_DATE", "CURRENT_TIME", "LOCALTIMESTAMP", "LOCALTIME", "CURRENT_USER", "NULL":
+		return true
+	}
+	return false
+}
+
+// GetNextvalSequence return sequence name used by nextval default,
+// example : orders_id_seq for nextval('orders_id_seq'::regclass)
+func GetNextvalSequence(defaultValue string) string {
+	value := strings.TrimSpace(defaultValue)
+	if !strings.HasPrefix(strings.ToLower(value), "nextval(") || !strings.HasSuffix(value, ")") {
+		return ""
+	}
+
+	arg := strings.TrimSpace(value[len("nextval(") : len(value)-1])
+	arg = strings.TrimSuffix(arg, "::regclass")
+	return strings.Trim(arg, "'")
+}
+
+// ToSerialType return serial type that use integer type as column type, example : bigserial for bigint
+func ToSerialType(pgType DataType) DataType {
+	switch pgType {
+	case SmallIntType:
+		return SmallSerialType
+	case IntType:
+		return SerialType
+	case BigIntType:
+		return BigSerialType
+	}
+	return ""
+}
+
+// FromSerialType return integer type of serial column, example : bigint for bigserial
+func FromSerialType(pgType DataType) (DataType, bool) {
+	switch pgType {
+	case SmallSerialType:
+		return SmallIntType, true
+	case SerialType:
+		return IntType, true
+	case BigSerialType:
+		return BigIntType, true
+	}
+	return pgType, false
+}
+
+// IsArrayType check if type declared in column tag is array type (e.g text[])
+func IsArrayType(value string) bool {
+	return strings.HasSuffix(value, "[]")
+}
+
 // ToPostgresType converts a Go type to its corresponding PostgreSQL data type.
 func ToPostgresType(goType string) (pgType DataType) {
 	switch goType {
@@ -159,6 +455,8 @@ func ToPostgresType(goType string) (pgType DataType) {
 		pgType = BooleanType
 	case "uuid.UUID":
 		pgType = UuidType
+	case "RawMessage":
+		pgType = JsonbType
 	case "interface{}", "any":
 		pgType = TextType
 
@@ -188,6 +486,8 @@ func IsValidDataType(value string) bool {
 		UuidType: {},
 		// ----- Json Type -----
 		JsonType: {}, JsonbType: {},
+		// ----- Range Type -----
+		Int4RangeType: {}, Int8RangeType: {}, NumRangeType: {}, TsRangeType: {}, TsTzRangeType: {}, DateRangeType: {},
 	}
 
 	dataType := DataType(strings.ToLower(value))
@@ -262,7 +562,9 @@ func GetPgDataTypeName(pgType DataType, returnAlias bool) DataType {
 	case JsonType:
 		return JsonType
 	case JsonbType:
-		return JsonType
+		return JsonbType
+	case Int4RangeType, Int8RangeType, NumRangeType, TsRangeType, TsTzRangeType, DateRangeType:
+		return pgType
 	}
 
 	return TextType
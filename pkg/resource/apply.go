@@ -13,10 +13,12 @@ import (
 	"github.com/sev-2/raiden/pkg/logger"
 	"github.com/sev-2/raiden/pkg/resource/migrator"
 	"github.com/sev-2/raiden/pkg/resource/policies"
+	"github.com/sev-2/raiden/pkg/resource/publications"
 	"github.com/sev-2/raiden/pkg/resource/roles"
 	"github.com/sev-2/raiden/pkg/resource/rpc"
 	"github.com/sev-2/raiden/pkg/resource/storages"
 	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/resource/triggers"
 	"github.com/sev-2/raiden/pkg/state"
 	"github.com/sev-2/raiden/pkg/supabase"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
@@ -26,11 +28,13 @@ import (
 var ApplyLogger hclog.Logger = logger.HcLog().Named("apply")
 
 type MigrateData struct {
-	Tables   []tables.MigrateItem
-	Roles    []roles.MigrateItem
-	Rpc      []rpc.MigrateItem
-	Policies []policies.MigrateItem
-	Storages []storages.MigrateItem
+	Tables       []tables.MigrateItem
+	Roles        []roles.MigrateItem
+	Rpc          []rpc.MigrateItem
+	Policies     []policies.MigrateItem
+	Storages     []storages.MigrateItem
+	Triggers     []triggers.MigrateItem
+	Publications []publications.MigrateItem
 }
 
 // Migrate resource :
@@ -79,6 +83,10 @@ type MigrateData struct {
 //
 // [x] migrate function (Rpc)
 //
+// [x] migrate trigger, run after function because trigger execute function
+//
+// [x] migrate publication, run after table because publication refer to table
+//
 // [x] migrate storage
 //
 //	[x] create new storage
@@ -87,6 +95,12 @@ type MigrateData struct {
 //	[ ] add storage acl
 //	[ ] update storage acl
 func Apply(flags *Flags, config *raiden.Config) error {
+	// resource of import source is merged into one state and applied with primary config only,
+	// migrate it is not supported until state keep the source of every resource
+	if len(config.ImportSources) > 0 {
+		return errors.New("apply is not supported when IMPORT_SOURCES is set, apply every source from its own project")
+	}
+
 	// declare default variable
 	var migrateData MigrateData
 	var localState state.LocalState
@@ -116,10 +130,15 @@ func Apply(flags *Flags, config *raiden.Config) error {
 	}
 
 	ApplyLogger.Info("extract table, role, and rpc from local state")
-	appTables, appRoles, appRpcFunctions, appStorage, err := extractAppResource(flags, latestLocalState)
+	appTables, appRoles, appRpcFunctions, appStorage, appTriggers, appPublications, err := extractAppResource(flags, latestLocalState)
 	if err != nil {
 		return err
 	}
+
+	// view and foreign table model is only used for read data, skip it from migration
+	appTables.New = filterExtractMigratableTable(appTables.New)
+	appTables.Existing = filterExtractMigratableTable(appTables.Existing)
+	appTables.Delete = filterExtractMigratableTable(appTables.Delete)
 	appPolicies := mergeAllPolicy(appTables, appStorage)
 
 	// validate table relation
@@ -137,6 +156,15 @@ func Apply(flags *Flags, config *raiden.Config) error {
 		return err
 	}
 
+	// validate storage allowed mime types
+	var validateStorages []objects.Bucket
+	validateStorages = append(validateStorages, appStorage.New.ToFlatStorage()...)
+	validateStorages = append(validateStorages, appStorage.Existing.ToFlatStorage()...)
+	ApplyLogger.Info("validate local storage")
+	if err := storages.ValidateAllowedMimeTypes(validateStorages); err != nil {
+		return err
+	}
+
 	// load supabase resource
 	ApplyLogger.Info("load resource from supabase")
 	resource, err := Load(flags, config)
@@ -148,6 +176,10 @@ func Apply(flags *Flags, config *raiden.Config) error {
 	ApplyLogger.Debug("start filter table and function by allowed schema", "allowed-schema", flags.AllowedSchema)
 	ApplyLogger.Trace("filter table by schema")
 	resource.Tables = filterTableBySchema(resource.Tables, strings.Split(flags.AllowedSchema, ",")...)
+	resource.Tables = filterMigratableTable(resource.Tables)
+	if !config.ImportPartitions {
+		resource.Tables, _ = collapseTablePartition(resource.Tables)
+	}
 	ApplyLogger.Trace("filter function by schema")
 	resource.Functions = filterFunctionBySchema(resource.Functions, strings.Split(flags.AllowedSchema, ",")...)
 	ApplyLogger.Debug("finish filter table and function by allowed schema", "allowed-schema", flags.AllowedSchema)
@@ -170,6 +202,8 @@ func Apply(flags *Flags, config *raiden.Config) error {
 		} else {
 			migrateData.Tables = data
 		}
+
+		migrateData.Publications = publications.BuildMigrateData(appPublications, resource.Publications)
 	}
 
 	if flags.All() || flags.RpcOnly {
@@ -178,6 +212,12 @@ func Apply(flags *Flags, config *raiden.Config) error {
 		} else {
 			migrateData.Rpc = data
 		}
+
+		if data, err := triggers.BuildMigrateData(appTriggers, resource.Triggers); err != nil {
+			return err
+		} else {
+			migrateData.Triggers = data
+		}
 	}
 
 	if flags.All() || flags.StoragesOnly {
@@ -277,12 +317,36 @@ func Migrate(config *raiden.Config, importState *state.LocalState, projectPath s
 		}
 	}
 
-	if len(resource.Rpc) > 0 {
+	if len(resource.Rpc) > 0 || len(resource.Triggers) > 0 {
 		wg.Add(1)
 		go func(w *sync.WaitGroup, eChan chan []error) {
 			defer wg.Done()
 
-			errors := rpc.Migrate(config, resource.Rpc, stateChan, rpc.ActionFunc)
+			if len(resource.Rpc) > 0 {
+				errors := rpc.Migrate(config, resource.Rpc, stateChan, rpc.ActionFunc)
+				if len(errors) > 0 {
+					eChan <- errors
+					return
+				}
+			}
+
+			// function executed by trigger must be exist before trigger is created
+			if len(resource.Triggers) > 0 {
+				errors := triggers.Migrate(config, resource.Triggers, stateChan, triggers.ActionFunc)
+				if len(errors) > 0 {
+					eChan <- errors
+					return
+				}
+			}
+		}(&wg, errChan)
+	}
+
+	if len(resource.Publications) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan []error) {
+			defer w.Done()
+
+			errors := publications.Migrate(config, resource.Publications, stateChan, publications.ActionFunc)
 			if len(errors) > 0 {
 				eChan <- errors
 				return
@@ -466,6 +530,20 @@ func validateRoleIsExist(appPolicies state.ExtractedPolicies, appRoles state.Ext
 		mapRole[r.Name] = true
 	}
 
+	// validate role membership
+	for i := range allRoles {
+		r := allRoles[i]
+		for _, parent := range r.MemberOf {
+			if _, exist := mapRole[parent]; !exist {
+				return fmt.Errorf("role %s member of : role %s is not exist", r.Name, parent)
+			}
+		}
+	}
+
+	if err := roles.ValidateMemberOf(allRoles); err != nil {
+		return err
+	}
+
 	// prepare policies data
 	var allPolicies []objects.Policy
 	allPolicies = append(allPolicies, appPolicies.New...)
@@ -746,6 +824,65 @@ func UpdateLocalStateFromApply(projectPath string, localState *state.LocalState,
 					rState.LastUpdate = time.Now()
 					localState.UpdateStorage(fIndex, rState)
 				}
+			case *triggers.MigrateItem:
+				switch m.Type {
+				case migrator.MigrateTypeCreate:
+					if m.NewData.Name == "" {
+						continue
+					}
+					triggerStruct := generator.GetTriggerStructName(m.NewData)
+					triggerPath := fmt.Sprintf("%s/%s/%s.go", projectPath, generator.TriggerDir, generator.GetTriggerFileName(m.NewData))
+
+					t := state.TriggerState{
+						Trigger:       m.NewData,
+						TriggerPath:   triggerPath,
+						TriggerStruct: triggerStruct,
+						LastUpdate:    time.Now(),
+					}
+					localState.AddTrigger(t)
+				case migrator.MigrateTypeDelete:
+					if m.OldData.Name == "" {
+						continue
+					}
+					localState.DeleteTrigger(m.OldData.Key())
+				case migrator.MigrateTypeUpdate:
+					fIndex, tState, found := localState.FindTrigger(m.NewData.Key())
+					if !found {
+						continue
+					}
+
+					tState.Trigger = m.NewData
+					tState.LastUpdate = time.Now()
+					localState.UpdateTrigger(fIndex, tState)
+				}
+			case *publications.MigrateItem:
+				switch m.Type {
+				case migrator.MigrateTypeCreate, migrator.MigrateTypeUpdate:
+					if m.NewData.Name == "" {
+						continue
+					}
+
+					// publication that removed from database is created again with existing state
+					if fIndex, pState, found := localState.FindPublication(m.NewData.Key()); found {
+						pState.Publication = m.NewData
+						pState.LastUpdate = time.Now()
+						localState.UpdatePublication(fIndex, pState)
+						continue
+					}
+
+					p := state.PublicationState{
+						Publication:       m.NewData,
+						PublicationPath:   fmt.Sprintf("%s/%s/%s.go", projectPath, generator.PublicationDir, utils.ToSnakeCase(m.NewData.Name)),
+						PublicationStruct: utils.SnakeCaseToPascalCase(m.NewData.Name),
+						LastUpdate:        time.Now(),
+					}
+					localState.AddPublication(p)
+				case migrator.MigrateTypeDelete:
+					if m.OldData.Name == "" {
+						continue
+					}
+					localState.DeletePublication(m.OldData.Key())
+				}
 			}
 		}
 		done <- localState.Persist()
@@ -771,6 +908,14 @@ func PrintApplyChangeReport(migrateData MigrateData) {
 	if len(diffRpc) > 0 {
 		diffMessage = append(diffMessage, diffRpc)
 	}
+	diffTrigger := triggers.GetDiffChangeMessage(migrateData.Triggers)
+	if len(diffTrigger) > 0 {
+		diffMessage = append(diffMessage, diffTrigger)
+	}
+	diffPublication := publications.GetDiffChangeMessage(migrateData.Publications)
+	if len(diffPublication) > 0 {
+		diffMessage = append(diffMessage, diffPublication)
+	}
 	diffStorage := storages.GetDiffChangeMessage(migrateData.Storages)
 	if len(diffStorage) > 0 {
 		diffMessage = append(diffMessage, diffStorage)
@@ -2,13 +2,17 @@ package resource
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/cli/configure"
 	"github.com/sev-2/raiden/pkg/cli/generate"
+	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/resource/tables"
 	"github.com/sev-2/raiden/pkg/state"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 	"github.com/spf13/cobra"
@@ -26,6 +30,36 @@ type Flags struct {
 	TraceMode     bool
 	Generate      generate.Flags
 	DryRun        bool
+	ModelStub     bool
+	SingleFile    bool
+	Incremental   bool
+	Graph         string
+	OpenApi       string
+	Force         bool
+	NoClobber     bool
+	Seed          string
+
+	// how generated file that modified after previous import is handled, see ImportConflictMode
+	Conflict string
+
+	// only regenerate resource that changed after the date or time, see SinceTime
+	Since string
+
+	// coma separated table name pattern, see TableFilter
+	IncludeTables string
+	ExcludeTables string
+
+	// file path of import json report, report is not written when empty
+	Report string
+
+	// only replace relation of existing model file, see generator.WithModelRelationsOnly
+	RelationsOnly bool
+
+	// RelationResolver infer model relation on import, default resolver is used when not set
+	RelationResolver tables.RelationResolver
+
+	// Hook is called after generated file of imported resource is written, see ImportHook
+	Hook ImportHook
 }
 
 // LoadAll is function to check is all resource need to import or apply
@@ -33,6 +67,32 @@ func (f *Flags) All() bool {
 	return !f.RpcOnly && !f.RolesOnly && !f.ModelsOnly && !f.StoragesOnly
 }
 
+// SinceTime parse since flag, value is date (2006-01-02) or RFC3339 time
+func (f *Flags) SinceTime() (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, f.Since); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, f.Since)
+	if err != nil {
+		return t, fmt.Errorf("invalid --since value %q, value must be date (2006-01-02) or RFC3339 time", f.Since)
+	}
+	return t, nil
+}
+
+// GenerateMode return how import write generated file, by default
+// only file with changed content is written
+func (f *Flags) GenerateMode() ImportGenerateMode {
+	if f.Force {
+		return ImportGenerateModeForce
+	}
+
+	if f.NoClobber {
+		return ImportGenerateModeNoClobber
+	}
+	return ImportGenerateModeChanged
+}
+
 func (f *Flags) BindLog(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVar(&f.DebugMode, "debug", false, "enable log with debug mode")
 	cmd.PersistentFlags().BoolVar(&f.TraceMode, "trace", false, "enable log with trace mode")
@@ -63,6 +123,20 @@ func RegisterRpc(list ...raiden.Rpc) {
 	registeredRpc = append(registeredRpc, list...)
 }
 
+// ----- Handle register triggers -----
+var registeredTriggers []raiden.Trigger
+
+func RegisterTrigger(list ...raiden.Trigger) {
+	registeredTriggers = append(registeredTriggers, list...)
+}
+
+// ----- Handle register publications -----
+var registeredPublications []raiden.Publication
+
+func RegisterPublication(list ...raiden.Publication) {
+	registeredPublications = append(registeredPublications, list...)
+}
+
 // ----- Handle register roles -----
 var registeredRoles []raiden.Role
 
@@ -86,16 +160,22 @@ func RegisterStorages(list ...raiden.Bucket) {
 
 // ----- Filter function -----
 func filterTableBySchema(input []objects.Table, allowedSchema ...string) (output []objects.Table) {
-	filterSchema := []string{"public"}
-	if len(allowedSchema) > 0 && allowedSchema[0] != "" {
-		filterSchema = allowedSchema
-	}
+	mapSchema := buildMapAllowedSchema(allowedSchema...)
 
-	mapSchema := map[string]bool{}
-	for _, s := range filterSchema {
-		mapSchema[s] = true
+	for i := range input {
+		t := input[i]
+
+		if _, exist := mapSchema[t.Schema]; exist {
+			output = append(output, t)
+		}
 	}
 
+	return
+}
+
+func filterFunctionBySchema(input []objects.Function, allowedSchema ...string) (output []objects.Function) {
+	mapSchema := buildMapAllowedSchema(allowedSchema...)
+
 	for i := range input {
 		t := input[i]
 
@@ -107,28 +187,187 @@ func filterTableBySchema(input []objects.Table, allowedSchema ...string) (output
 	return
 }
 
-func filterFunctionBySchema(input []objects.Function, allowedSchema ...string) (output []objects.Function) {
-	filterSchema := []string{"public"}
-	if len(allowedSchema) > 0 && allowedSchema[0] != "" {
-		filterSchema = allowedSchema
+// filterTriggerByFunction only keep trigger that execute imported function,
+// trigger refer to generated rpc so trigger of skipped function is skipped too
+func filterTriggerByFunction(input []objects.Trigger, functions []objects.Function) (output []objects.Trigger, skipped []SkippedResource) {
+	mapFunction := make(map[string]bool)
+	for i := range functions {
+		f := functions[i]
+		mapFunction[fmt.Sprintf("%s.%s", f.Schema, f.Name)] = true
 	}
 
-	mapSchema := map[string]bool{}
-	for _, s := range filterSchema {
-		mapSchema[s] = true
+	for i := range input {
+		t := input[i]
+		if !mapFunction[fmt.Sprintf("%s.%s", t.FunctionSchema, t.FunctionName)] {
+			skipped = append(skipped, SkippedResource{
+				Type: "trigger", Schema: t.Schema, Name: t.Name,
+				Err: fmt.Errorf("function %s.%s is not imported", t.FunctionSchema, t.FunctionName),
+			})
+			continue
+		}
+		output = append(output, t)
+	}
+	return
+}
+
+// filterParsableFunction split function that can be generated to rpc and function
+// that can`t be parsed, unparsable function is skipped instead of fail the import
+func filterParsableFunction(input []objects.Function) (output []objects.Function, skipped []SkippedResource) {
+	for i := range input {
+		f := input[i]
+		if err := generator.ValidateRpcFunction(&f); err != nil {
+			skipped = append(skipped, SkippedResource{Type: "function", Schema: f.Schema, Name: f.Name, Err: err})
+			continue
+		}
+		output = append(output, f)
+	}
+	return
+}
+
+// filterEnumTypeByTable only keep enum type that used by table column or composite type attribute,
+// type is matched by name because column only have information about type name
+func filterEnumTypeByTable(input []objects.Type, tables []objects.Table, composites []objects.Type) (output []objects.Type) {
+	mapUsedType := getCompositeAttributeTypes(input, composites)
+	for i := range tables {
+		for _, c := range tables[i].Columns {
+			if len(c.Enums) == 0 {
+				continue
+			}
+
+			switch postgres.DataType(c.DataType) {
+			case postgres.UserDefinedType:
+				mapUsedType[c.Format] = true
+			case postgres.ArrayType:
+				mapUsedType[postgres.GetArrayElementType(c.Format)] = true
+			}
+		}
 	}
 
 	for i := range input {
 		t := input[i]
+		if !t.IsEnum() || !mapUsedType[t.Name] {
+			continue
+		}
 
-		if _, exist := mapSchema[t.Schema]; exist {
-			output = append(output, t)
+		// prevent generate duplicate type when enum with same name exist in multiple schema
+		delete(mapUsedType, t.Name)
+		output = append(output, t)
+	}
+
+	return
+}
+
+// filterMigratableTable remove view and foreign table from list of table,
+// both is read only and never migrated
+func filterMigratableTable(input []objects.Table) (output []objects.Table) {
+	for i := range input {
+		if !input[i].IsView && !input[i].IsForeign {
+			output = append(output, input[i])
 		}
 	}
+	return
+}
 
+func filterExtractMigratableTable(input state.ExtractTableItems) (output state.ExtractTableItems) {
+	for i := range input {
+		if !input[i].Table.IsView && !input[i].Table.IsForeign {
+			output = append(output, input[i])
+		}
+	}
 	return
 }
 
+// collapseTablePartition remove partition table from list of table, partition
+// data is accessed through the partitioned parent table so only parent is keep
+func collapseTablePartition(input []objects.Table) (output []objects.Table, collapsed int) {
+	mapPartition := make(map[string]bool)
+	for i := range input {
+		t := input[i]
+		if t.PartitionOfID != 0 {
+			mapPartition[fmt.Sprintf("%s.%s", t.Schema, t.Name)] = true
+		}
+	}
+
+	if len(mapPartition) == 0 {
+		return input, 0
+	}
+
+	for i := range input {
+		t := input[i]
+		if mapPartition[fmt.Sprintf("%s.%s", t.Schema, t.Name)] {
+			collapsed++
+			continue
+		}
+
+		var relations []objects.TablesRelationship
+		for ri := range t.Relationships {
+			r := t.Relationships[ri]
+			if mapPartition[fmt.Sprintf("%s.%s", r.SourceSchema, r.SourceTableName)] || mapPartition[fmt.Sprintf("%s.%s", r.TargetTableSchema, r.TargetTableName)] {
+				continue
+			}
+			relations = append(relations, r)
+		}
+		t.Relationships = relations
+
+		output = append(output, t)
+	}
+
+	return
+}
+
+// filterTableRelationBySchema remove relation that refer to table in not allowed schema
+func filterTableRelationBySchema(input []objects.Table, allowedSchema ...string) (output []objects.Table) {
+	mapSchema := buildMapAllowedSchema(allowedSchema...)
+
+	for i := range input {
+		t := input[i]
+
+		var relations []objects.TablesRelationship
+		for ri := range t.Relationships {
+			r := t.Relationships[ri]
+			_, sourceExist := mapSchema[r.SourceSchema]
+			_, targetExist := mapSchema[r.TargetTableSchema]
+			if sourceExist && targetExist {
+				relations = append(relations, r)
+			}
+		}
+		t.Relationships = relations
+
+		output = append(output, t)
+	}
+
+	return
+}
+
+// filterPolicyBySchema keep policy in allowed schema,
+// storage policy is always keep because bucket policy is defined in storage schema
+func filterPolicyBySchema(input objects.Policies, allowedSchema ...string) (output objects.Policies) {
+	mapSchema := buildMapAllowedSchema(allowedSchema...)
+
+	for i := range input {
+		p := input[i]
+
+		if _, exist := mapSchema[p.Schema]; exist || p.Schema == "storage" {
+			output = append(output, p)
+		}
+	}
+
+	return
+}
+
+func buildMapAllowedSchema(allowedSchema ...string) map[string]bool {
+	filterSchema := []string{"public"}
+	if len(allowedSchema) > 0 && allowedSchema[0] != "" {
+		filterSchema = allowedSchema
+	}
+
+	mapSchema := map[string]bool{}
+	for _, s := range filterSchema {
+		mapSchema[s] = true
+	}
+	return mapSchema
+}
+
 func filterUserRole(roles []objects.Role, mapNativeRole map[string]raiden.Role) (userRole []objects.Role) {
 	for i := range roles {
 		r := roles[i]
@@ -161,6 +400,7 @@ func filterIsNativeRole(mapNativeRole map[string]raiden.Role, supabaseRole []obj
 func extractAppResource(f *Flags, latestState *state.State) (
 	extractedTable state.ExtractTableResult, extractedRole state.ExtractRoleResult,
 	extractedRpc state.ExtractRpcResult, extractedStorage state.ExtractStorageResult,
+	extractedTrigger state.ExtractTriggerResult, extractedPublication state.ExtractPublicationResult,
 	err error,
 ) {
 	if latestState == nil {
@@ -183,6 +423,13 @@ func extractAppResource(f *Flags, latestState *state.State) (
 			return
 		}
 		ImportLogger.Debug("Finish extract table")
+
+		ImportLogger.Debug("Start extract publication")
+		extractedPublication, err = state.ExtractPublication(latestState.Publications, registeredPublications)
+		if err != nil {
+			return
+		}
+		ImportLogger.Debug("Finish extract publication")
 	}
 
 	if f.All() || f.RolesOnly {
@@ -201,6 +448,13 @@ func extractAppResource(f *Flags, latestState *state.State) (
 			return
 		}
 		ImportLogger.Debug("FInish extract rpc")
+
+		ImportLogger.Debug("Start extract trigger")
+		extractedTrigger, err = state.ExtractTrigger(latestState.Triggers, registeredTriggers)
+		if err != nil {
+			return
+		}
+		ImportLogger.Debug("Finish extract trigger")
 	}
 
 	if f.All() || f.StoragesOnly {
@@ -214,3 +468,104 @@ func extractAppResource(f *Flags, latestState *state.State) (
 
 	return
 }
+
+// filterDomainTypeByTable only keep domain type that used by table column or composite type attribute
+func filterDomainTypeByTable(input []objects.Type, tables []objects.Table, composites []objects.Type) (output []objects.Type) {
+	mapUsedType := make(map[string]bool)
+	for name := range getCompositeAttributeTypes(input, composites) {
+		for _, t := range input {
+			if t.Name == name && t.IsDomain() {
+				mapUsedType[fmt.Sprintf("%s.%s", t.Schema, t.Name)] = true
+			}
+		}
+	}
+	for i := range tables {
+		for _, c := range tables[i].Columns {
+			if c.Domain != "" {
+				mapUsedType[c.GetDomain()] = true
+			}
+		}
+	}
+
+	mapGenerated := make(map[string]bool)
+	for i := range input {
+		t := input[i]
+		if !t.IsDomain() || !mapUsedType[fmt.Sprintf("%s.%s", t.Schema, t.Name)] {
+			continue
+		}
+
+		// domain type is generated in models package, so domain with the same name is generated once
+		if mapGenerated[t.Name] {
+			ImportLogger.Warn("skip domain, domain with the same name already generated", "schema", t.Schema, "name", t.Name)
+			continue
+		}
+		mapGenerated[t.Name] = true
+		output = append(output, t)
+	}
+
+	return
+}
+
+// filterCompositeTypeByTable only keep composite type that used by table column, composite
+// type that used by attribute of other composite type is also kept because it is field type
+func filterCompositeTypeByTable(input []objects.Type, tables []objects.Table) (output []objects.Type) {
+	mapUsedType := make(map[string]bool)
+	for i := range tables {
+		for _, c := range tables[i].Columns {
+			if postgres.DataType(c.DataType) == postgres.UserDefinedType && len(c.Enums) == 0 {
+				mapUsedType[c.Format] = true
+			}
+		}
+	}
+
+	mapTypes := make(map[int]objects.Type, len(input))
+	for _, t := range input {
+		mapTypes[t.ID] = t
+	}
+
+	mapGenerated := make(map[string]bool)
+	queue := make([]objects.Type, 0)
+	for _, t := range input {
+		if t.IsComposite() && mapUsedType[t.Name] {
+			queue = append(queue, t)
+		}
+	}
+
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+
+		// composite type is generated in models package, so type with the same name is generated once
+		if mapGenerated[t.Name] {
+			continue
+		}
+		mapGenerated[t.Name] = true
+		output = append(output, t)
+
+		for _, a := range t.Attributes {
+			if at, exist := mapTypes[a.TypeID]; exist && at.IsComposite() {
+				queue = append(queue, at)
+			}
+		}
+	}
+
+	return
+}
+
+// getCompositeAttributeTypes return name of user defined type that used by composite type attribute
+func getCompositeAttributeTypes(input []objects.Type, composites []objects.Type) map[string]bool {
+	mapTypes := make(map[int]objects.Type, len(input))
+	for _, t := range input {
+		mapTypes[t.ID] = t
+	}
+
+	mapUsedType := make(map[string]bool)
+	for _, c := range composites {
+		for _, a := range c.Attributes {
+			if t, exist := mapTypes[a.TypeID]; exist {
+				mapUsedType[t.Name] = true
+			}
+		}
+	}
+	return mapUsedType
+}
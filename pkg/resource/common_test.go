@@ -0,0 +1,99 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollapseTablePartition(t *testing.T) {
+	tables := []objects.Table{
+		{
+			ID: 1, Name: "events", Schema: "analytics", IsPartitioned: true,
+			Relationships: []objects.TablesRelationship{
+				{SourceSchema: "analytics", SourceTableName: "events", TargetTableSchema: "public", TargetTableName: "users"},
+				{SourceSchema: "analytics", SourceTableName: "events_2024", TargetTableSchema: "public", TargetTableName: "users"},
+			},
+		},
+		{ID: 2, Name: "events_2024", Schema: "analytics", PartitionOfID: 1},
+		{ID: 3, Name: "events_2025", Schema: "analytics", PartitionOfID: 1},
+		{ID: 4, Name: "users", Schema: "public"},
+	}
+
+	output, collapsed := collapseTablePartition(tables)
+	assert.Equal(t, 2, collapsed)
+	assert.Equal(t, 2, len(output))
+	assert.Equal(t, "events", output[0].Name)
+	assert.Equal(t, 1, len(output[0].Relationships))
+	assert.Equal(t, "users", output[1].Name)
+}
+
+func TestFilterParsableFunction(t *testing.T) {
+	functions := []objects.Function{
+		{
+			Schema:        "public",
+			Name:          "get_total",
+			Definition:    "BEGIN RETURN 1; END;",
+			ReturnType:    "integer",
+			Behavior:      "VOLATILE",
+			ArgumentTypes: "",
+		},
+		{
+			Schema:        "public",
+			Name:          "get_point",
+			Definition:    "BEGIN RETURN NULL; END;",
+			ReturnType:    "point",
+			Behavior:      "VOLATILE",
+			ArgumentTypes: "",
+		},
+	}
+
+	output, skipped := filterParsableFunction(functions)
+	assert.Equal(t, 1, len(output))
+	assert.Equal(t, "get_total", output[0].Name)
+	assert.Equal(t, 1, len(skipped))
+	assert.Equal(t, "function", skipped[0].Type)
+	assert.Equal(t, "get_point", skipped[0].Name)
+	assert.Error(t, skipped[0].Err)
+}
+
+func TestFilterMigratableTable(t *testing.T) {
+	tables := []objects.Table{
+		{Name: "orders", Schema: "public"},
+		{Name: "order_summaries", Schema: "public", IsView: true},
+		{Name: "exchange_rates", Schema: "public", IsForeign: true},
+	}
+
+	output := filterMigratableTable(tables)
+	assert.Equal(t, 1, len(output))
+	assert.Equal(t, "orders", output[0].Name)
+}
+
+func TestFilterCompositeTypeByTable(t *testing.T) {
+	types := []objects.Type{
+		{ID: 1, Name: "country", Schema: "public", Enums: []string{"id", "sg"}},
+		{ID: 2, Name: "geo_point", Schema: "public", Attributes: []objects.TypeAttribute{{Name: "lat", TypeID: 701}}},
+		{ID: 3, Name: "address", Schema: "public", Attributes: []objects.TypeAttribute{
+			{Name: "country", TypeID: 1},
+			{Name: "location", TypeID: 2},
+		}},
+		{ID: 4, Name: "unused", Schema: "public", Attributes: []objects.TypeAttribute{{Name: "note", TypeID: 25}}},
+	}
+	tables := []objects.Table{
+		{Name: "stores", Schema: "public", Columns: []objects.Column{
+			{Name: "address", DataType: "USER-DEFINED", Format: "address"},
+		}},
+	}
+
+	// nested composite type is kept with the composite type that use it
+	composites := filterCompositeTypeByTable(types, tables)
+	assert.Equal(t, 2, len(composites))
+	assert.Equal(t, "address", composites[0].Name)
+	assert.Equal(t, "geo_point", composites[1].Name)
+
+	// enum that only used by composite type attribute is still generated
+	enums := filterEnumTypeByTable(types, tables, composites)
+	assert.Equal(t, 1, len(enums))
+	assert.Equal(t, "country", enums[0].Name)
+}
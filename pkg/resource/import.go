@@ -1,77 +1,163 @@
 package resource
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
 	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"text/tabwriter"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/resource/publications"
 	"github.com/sev-2/raiden/pkg/resource/roles"
 	"github.com/sev-2/raiden/pkg/resource/rpc"
 	"github.com/sev-2/raiden/pkg/resource/storages"
 	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/resource/triggers"
 	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/client/net"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 	"github.com/sev-2/raiden/pkg/utils"
 )
 
 var ImportLogger hclog.Logger = logger.HcLog().Named("import")
 
+// ImportMaxWorker is maximum number of file generated concurrently when import resource
+var ImportMaxWorker = 10
+
+// ImportRetryBaseDelay and ImportRetryMaxDelay is backoff range when retry fetch resource
+var (
+	ImportRetryBaseDelay = 500 * time.Millisecond
+	ImportRetryMaxDelay  = 10 * time.Second
+)
+
 // List of import resource
 // [x] import table, relation, column specification and acl
 // [x] import role
 // [x] import function
 // [x] import storage
+// [x] import enum type
+// [x] import view (when enabled from config)
+// [x] import trigger that execute imported function
+// [x] import publication and published table
+// [x] collapse partition table into partitioned parent table (configurable from config)
+// [x] validate generated struct name conflict before write file
+// [x] generate seed data from rows of selected table
+// [x] resolve resource into model input without write file (see Resolve)
+// [x] return summary of generated resource (see ImportWithSummary)
+// [x] call hook after generated file of resource is written (see ImportHook)
+// [x] only regenerate resource that changed after since flag (see filterChangedSince)
 func Import(flags *Flags, config *raiden.Config) error {
+	return ImportWithEventHandler(flags, config, nil)
+}
+
+// ImportWithEventHandler run import and call eventHandler every time resource is generated,
+// eventHandler can be used for render import progress
+func ImportWithEventHandler(flags *Flags, config *raiden.Config, eventHandler ImportEventHandler) error {
+	return ImportWithContext(context.Background(), flags, config, eventHandler)
+}
+
+// ImportWithContext run import that can be cancelled from ctx, generate process stop
+// before write the next file and ctx.Err() is returned when ctx is cancelled
+func ImportWithContext(ctx context.Context, flags *Flags, config *raiden.Config, eventHandler ImportEventHandler) error {
+	summary, err := ImportWithSummary(ctx, flags, config, eventHandler)
+	if err != nil {
+		return err
+	}
+
+	PrintImportSummary(summary)
+	return nil
+}
+
+// ImportWithSummary run import and return total of generated resource, summary is
+// empty when import is stopped before generate (e.g compare error in dry run mode)
+func ImportWithSummary(ctx context.Context, flags *Flags, config *raiden.Config, eventHandler ImportEventHandler) (summary ImportSummary, err error) {
 	if flags.DryRun {
 		ImportLogger.Info("running import in dry run mode")
 	}
 
-	// load map native role
-	ImportLogger.Info("load native role")
-	mapNativeRole, err := loadMapNativeRole()
-	if err != nil {
-		return err
+	if flags.Force && flags.NoClobber {
+		return summary, errors.New("--force and --no-clobber can`t be used together")
 	}
 
-	// load supabase resource
-	ImportLogger.Info("load resource from supabase")
-	spResource, err := Load(flags, config)
-	if err != nil {
-		return err
+	switch ImportConflictMode(flags.Conflict) {
+	case "", ImportConflictModePrompt, ImportConflictModeFail:
+	default:
+		return summary, fmt.Errorf("invalid --conflict value %q, value must be prompt or fail", flags.Conflict)
 	}
 
-	// create import state
-	ImportLogger.Debug("get native roles")
-	nativeStateRoles := filterIsNativeRole(mapNativeRole, spResource.Roles)
+	// table is only regenerated when changed, so since mode is always incremental
+	if flags.Since != "" {
+		if _, err := flags.SinceTime(); err != nil {
+			return summary, err
+		}
+		flags.Incremental = true
+	}
+
+	if flags.SingleFile && (flags.ModelStub || config.SchemaPackages) {
+		return summary, errors.New("--single-file can`t be used with --model-stub or schema packages")
+	}
 
-	// filter table for with allowed schema
-	ImportLogger.Debug("start filter table and function by allowed schema", "allowed-schema", flags.AllowedSchema)
-	ImportLogger.Trace("filter table by schema")
-	spResource.Tables = filterTableBySchema(spResource.Tables, strings.Split(flags.AllowedSchema, ",")...)
+	// relation is replaced per model file, other resource is not regenerated
+	if flags.RelationsOnly {
+		if flags.SingleFile {
+			return summary, errors.New("--relations-only can`t be used with --single-file")
+		}
+		flags.ModelsOnly = true
+	}
 
-	ImportLogger.Trace("filter function by schema")
-	spResource.Functions = filterFunctionBySchema(spResource.Functions, strings.Split(flags.AllowedSchema, ",")...)
-	ImportLogger.Debug("finish filter table and function by allowed schema")
+	// retry fetch that failed with transient error, retry is stopped when import is cancelled
+	if config.ImportRetries > 0 {
+		net.SetRetryPolicy(&net.RetryPolicy{
+			Context:    ctx,
+			MaxRetries: config.ImportRetries,
+			BaseDelay:  ImportRetryBaseDelay,
+			MaxDelay:   ImportRetryMaxDelay,
+		})
+		defer net.SetRetryPolicy(nil)
+	}
 
-	ImportLogger.Trace("remove native role for supabase list role")
-	spResource.Roles = filterUserRole(spResource.Roles, mapNativeRole)
+	spResource, nativeStateRoles, err := loadImportResource(flags, config)
+	if err != nil {
+		return summary, err
+	}
+
+	if flags.Graph != "" {
+		ImportLogger.Info("write relation diagram", "path", flags.Graph)
+		if err := writeRelationDiagram(config, flags, spResource.Tables); err != nil {
+			return summary, err
+		}
+	}
+
+	if flags.OpenApi != "" {
+		ImportLogger.Info("write openapi document", "path", flags.OpenApi)
+		if err := generator.GenerateOpenApi(flags.OpenApi, config.ProjectName, config.ModelsPackage, spResource.Tables, spResource.Functions); err != nil {
+			return summary, err
+		}
+	}
 
 	// load app resource
 	ImportLogger.Info("load resource from local state")
 	localState, err := state.Load()
 	if err != nil {
-		return err
+		return summary, err
 	}
 
 	ImportLogger.Info("extract data from local state")
-	appTables, appRoles, appRpcFunctions, appStorage, err := extractAppResource(flags, localState)
+	appTables, appRoles, appRpcFunctions, appStorage, appTriggers, appPublications, err := extractAppResource(flags, localState)
 	if err != nil {
-		return err
+		return summary, err
 	}
 
 	importState := state.LocalState{
@@ -100,7 +186,7 @@ func Import(flags *Flags, config *raiden.Config) error {
 			if flags.DryRun {
 				dryRunError = append(dryRunError, err.Error())
 			} else {
-				return err
+				return summary, err
 			}
 		}
 		if !flags.DryRun {
@@ -116,7 +202,7 @@ func Import(flags *Flags, config *raiden.Config) error {
 			if flags.DryRun {
 				dryRunError = append(dryRunError, err.Error())
 			} else {
-				return err
+				return summary, err
 			}
 		}
 		if !flags.DryRun {
@@ -132,7 +218,7 @@ func Import(flags *Flags, config *raiden.Config) error {
 			if flags.DryRun {
 				dryRunError = append(dryRunError, err.Error())
 			} else {
-				return err
+				return summary, err
 			}
 		}
 		if !flags.DryRun {
@@ -140,6 +226,38 @@ func Import(flags *Flags, config *raiden.Config) error {
 		}
 	}
 
+	if (flags.All() || flags.RpcOnly) && len(appTriggers.Existing) > 0 {
+		if !flags.DryRun {
+			ImportLogger.Debug("start compare trigger")
+		}
+		if err := triggers.Compare(spResource.Triggers, appTriggers.Existing); err != nil {
+			if flags.DryRun {
+				dryRunError = append(dryRunError, err.Error())
+			} else {
+				return summary, err
+			}
+		}
+		if !flags.DryRun {
+			ImportLogger.Debug("finish compare trigger")
+		}
+	}
+
+	if (flags.All() || flags.ModelsOnly) && len(appPublications.Existing) > 0 {
+		if !flags.DryRun {
+			ImportLogger.Debug("start compare publication")
+		}
+		if err := publications.Compare(spResource.Publications, appPublications.Existing); err != nil {
+			if flags.DryRun {
+				dryRunError = append(dryRunError, err.Error())
+			} else {
+				return summary, err
+			}
+		}
+		if !flags.DryRun {
+			ImportLogger.Debug("finish compare publication")
+		}
+	}
+
 	if (flags.All() || flags.StoragesOnly) && len(appStorage.Existing) > 0 {
 		if !flags.DryRun {
 			ImportLogger.Debug("start compare storage")
@@ -156,7 +274,7 @@ func Import(flags *Flags, config *raiden.Config) error {
 			if flags.DryRun {
 				dryRunError = append(dryRunError, err.Error())
 			} else {
-				return err
+				return summary, err
 			}
 		}
 		if !flags.DryRun {
@@ -166,97 +284,444 @@ func Import(flags *Flags, config *raiden.Config) error {
 
 	// import report
 	importReport := ImportReport{
-		Role:    roles.GetNewCountData(spResource.Roles, appRoles),
-		Table:   tables.GetNewCountData(spResource.Tables, appTables),
-		Storage: storages.GetNewCountData(spResource.Storages, appStorage),
-		Rpc:     rpc.GetNewCountData(spResource.Functions, appRpcFunctions),
+		Role:        roles.GetNewCountData(spResource.Roles, appRoles),
+		Table:       tables.GetNewCountData(spResource.Tables, appTables),
+		Storage:     storages.GetNewCountData(spResource.Storages, appStorage),
+		Rpc:         rpc.GetNewCountData(spResource.Functions, appRpcFunctions),
+		Trigger:     triggers.GetNewCountData(spResource.Triggers, appTriggers),
+		Publication: publications.GetNewCountData(spResource.Publications, appPublications),
+		Skipped:     len(spResource.Skipped),
 	}
 	if !flags.DryRun {
 		// generate resource
-		if err := generateImportResource(config, &importState, flags.ProjectPath, spResource); err != nil {
-			return err
+		if summary, _, err = generateImportResource(ctx, config, &importState, flags, spResource, localState, eventHandler); err != nil {
+			return summary, err
 		}
 		PrintImportReport(importReport, false)
 	} else {
 		if len(dryRunError) > 0 {
 			errMessage := strings.Join(dryRunError, "\n")
 			ImportLogger.Error("got error", "err-msg", errMessage)
-			return nil
+			return summary, nil
+		}
+
+		// simulate generate resource without write file
+		var dryRunReport ImportDryRunReport
+		summary, dryRunReport, err = generateImportResource(ctx, config, &importState, flags, spResource, localState, eventHandler)
+		if err != nil {
+			return summary, err
 		}
+		PrintImportDryRunReport(dryRunReport)
 		PrintImportReport(importReport, true)
 	}
 
-	return nil
+	return summary, nil
 }
 
 // ----- Generate import data -----
-func generateImportResource(config *raiden.Config, importState *state.LocalState, projectPath string, resource *Resource) error {
-	if err := generator.CreateInternalFolder(projectPath); err != nil {
-		return err
+func generateImportResource(ctx context.Context, config *raiden.Config, importState *state.LocalState, flags *Flags, resource *Resource, previousState *state.State, eventHandler ImportEventHandler) (summary ImportSummary, dryRunReport ImportDryRunReport, err error) {
+	projectPath, dryRun, mode := flags.ProjectPath, flags.DryRun, flags.GenerateMode()
+
+	// relation is reused from previous import when relation metadata is unchanged
+	relationCache := state.RelationCache{}
+	if flags.Incremental && previousState != nil {
+		relationCache = previousState.RelationCache
+	}
+	resource.RelationCache = &relationCache
+
+	// build model input and validate generated name before any file is written
+	tableInputs, err := ResolveModels(config, flags, resource)
+	if err != nil {
+		return summary, dryRunReport, err
+	}
+
+	if !dryRun {
+		importState.SetRelationCache(relationCache)
+	}
+
+	for _, s := range resource.Skipped {
+		summary.Skipped = append(summary.Skipped, fmt.Sprintf("%s %s.%s", s.Type, s.Schema, s.Name))
+	}
+
+	// table input can be replaced when generate changed table only, pair seed before generate
+	seedInputs := buildImportSeedInputs(tableInputs, resource.Seeds)
+
+	templateOverrides, err := generator.LoadProjectTemplateOverrides(projectPath)
+	if err != nil {
+		return summary, dryRunReport, err
+	}
+
+	// modified file is checked before any file is written
+	keptFiles, err := resolveImportConflicts(flags, previousState)
+	if err != nil {
+		return summary, dryRunReport, err
+	}
+
+	generated := importGenerateResource{
+		Roles:        resource.Roles,
+		Functions:    resource.Functions,
+		Types:        resource.Types,
+		Domains:      resource.Domains,
+		Composites:   resource.Composites,
+		Triggers:     resource.Triggers,
+		Publications: resource.Publications,
+		Storages:     storages.BuildGenerateStorageInput(resource.Storages, resource.Policies),
+	}
+
+	// table is filtered by incremental import, since flag is validated before load resource
+	if flags.Since != "" {
+		since, _ := flags.SinceTime()
+		unchanged := filterChangedSince(&generated, previousState, since, importState)
+		ImportLogger.Info("skip generate resource that not changed since", "since", since, "total", unchanged)
 	}
 
+	if !dryRun {
+		if err := generator.CreateInternalFolder(projectPath); err != nil {
+			return summary, dryRunReport, err
+		}
+
+		// file that not generated again (e.g unchanged table or kept file) keep previous hash
+		if previousState != nil {
+			for path, hash := range previousState.FileHashes {
+				importState.SetFileHash(path, hash)
+			}
+		}
+	}
+
+	// context is cancelled when generate is returned, generate process that still running
+	// is stopped before write the next file and never block on closed listener
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	wg, errChan, stateChan := sync.WaitGroup{}, make(chan error), make(chan any)
-	doneListen := UpdateLocalStateFromImport(importState, stateChan)
+	workerChan := make(chan struct{}, ImportMaxWorker)
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		if len(resource.Tables) > 0 {
-			tableInputs := tables.BuildGenerateModelInputs(resource.Tables, resource.Policies)
-			ImportLogger.Info("start generate tables")
+	// summary is complete after listener is done
+	var doneListen chan error
+	summaryChan := ListenImportSummary(&summary, stateChan)
+
+	var jsonReport *ImportJsonReport
+	if flags.Report != "" {
+		jsonReport = NewImportJsonReport(projectPath, dryRun, resource.Skipped)
+		summaryChan = ListenImportJsonReport(jsonReport, summaryChan)
+	}
+
+	if dryRun {
+		doneListen = ListenImportDryRun(&dryRunReport, summaryChan)
+	} else {
+		doneListen = UpdateLocalStateFromImport(importState, summaryChan)
+	}
+
+	// controller is scaffolded and rpc refer to model of all table, including unchanged table in incremental import
+	controllerInputs, rpcModelInputs := tableInputs, tableInputs
+	if len(tableInputs) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			// each category is cancelled by its own timeout
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			// only generate changed table and keep previous state for unchanged table,
+			// single file is always generated from all table
+			if flags.Incremental && !flags.SingleFile && previousState != nil {
+				changedInputs, unchangedStates := tables.FilterChangedModelInputs(tableInputs, previousState.Tables)
+				ImportLogger.Debug("skip generate unchanged tables", "total", len(unchangedStates))
+				for i := range unchangedStates {
+					importState.AddTable(unchangedStates[i])
+				}
+				tableInputs = changedInputs
+			}
+
+			if err := ctx.Err(); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+
+			logArgs := importPhaseLogArgs(ImportPhaseTables, tableInputs, func(i *generator.GenerateModelInput) string { return i.Table.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			progress := newImportProgress(ImportPhaseTables, len(tableInputs), eventHandler)
 			captureFunc := ImportDecorateFunc(tableInputs, func(item *generator.GenerateModelInput, input generator.GenerateInput) bool {
 				if i, ok := input.BindData.(generator.GenerateModelData); ok {
-					if i.StructName == utils.SnakeCaseToPascalCase(item.Table.Name) {
+					if i.StructName == item.GetStructName() {
 						return true
 					}
 				}
 				return false
-			}, stateChan)
+			}, stateChan, dryRun, mode, keptFiles, progress, flags.Hook)
 
-			if err := generator.GenerateModels(projectPath, tableInputs, captureFunc); err != nil {
-				errChan <- err
+			generateFn := limitGenerateFunc(ctx, workerChan, captureFunc)
+			if flags.RelationsOnly {
+				generateFn = generator.WithModelRelationsOnly(generateFn)
+			}
+
+			if err := generator.GenerateModelsWithContext(ctx, projectPath, tableInputs, generator.WithTemplateOverrides(templateOverrides, generateFn), flags.SingleFile); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
 			}
-			ImportLogger.Info("finish generate tables")
-		}
 
-		// generate all roles from cloud / pg-meta
-		if len(resource.Roles) > 0 {
-			ImportLogger.Info("start generate roles")
-			captureFunc := ImportDecorateFunc(resource.Roles, func(item objects.Role, input generator.GenerateInput) bool {
+			// model in single file is not found by generated struct name, report all model to generated file
+			if flags.SingleFile {
+				modelsFilePath := filepath.Join(projectPath, generator.ModelDir, generator.ModelsFileName)
+				for i := range tableInputs {
+					stateChan <- map[string]any{
+						"item":  tableInputs[i],
+						"input": generator.GenerateInput{OutputPath: modelsFilePath},
+					}
+					progress.Next(getImportResourceName(tableInputs[i]))
+				}
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	// controller scaffold is stub file and not tracked in local state
+	if config.GenerateControllers && len(controllerInputs) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			controllerKeys := make([]string, 0, len(controllerInputs))
+			for _, input := range controllerInputs {
+				controllerKeys = append(controllerKeys, fmt.Sprintf("%s.%s", input.Table.Schema, input.Table.Name))
+			}
+
+			logArgs := importPhaseLogArgs(ImportPhaseControllers, controllerInputs, func(i *generator.GenerateModelInput) string { return i.Table.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc(controllerKeys, func(item string, input generator.GenerateInput) bool {
+				if i, ok := input.BindData.(generator.GenerateTableControllerData); ok {
+					if fmt.Sprintf("%s.%s", i.Schema, i.Table) == item {
+						return true
+					}
+				}
+				return false
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhaseControllers, len(controllerKeys), eventHandler), flags.Hook)
+
+			if err := generator.GenerateTableControllers(ctx, projectPath, controllerInputs, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	// generate all enum type used by table column, constant is named with all imported type
+	// so the name is the same in incremental import
+	if len(generated.Types) > 0 {
+		enumConstants := buildEnumConstantNames(tableInputs, resource.Types, resource.Composites, resource.Domains)
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseTypes, generated.Types, func(t objects.Type) string { return t.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc(generated.Types, func(item objects.Type, input generator.GenerateInput) bool {
+				if i, ok := input.BindData.(generator.GenerateEnumData); ok {
+					if i.Name == item.Name {
+						return true
+					}
+				}
+				return false
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhaseTypes, len(generated.Types), eventHandler), flags.Hook)
+
+			if err := generator.GenerateEnumsWithContext(ctx, projectPath, config.ModelsPackage, generated.Types, enumConstants, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	// generate all domain type used by table column
+	if len(generated.Domains) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseDomains, generated.Domains, func(t objects.Type) string { return t.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc(generated.Domains, func(item objects.Type, input generator.GenerateInput) bool {
+				if i, ok := input.BindData.(generator.GenerateDomainData); ok {
+					if i.Name == item.Name {
+						return true
+					}
+				}
+				return false
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhaseDomains, len(generated.Domains), eventHandler), flags.Hook)
+
+			if err := generator.GenerateDomains(ctx, projectPath, config.ModelsPackage, generated.Domains, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	// generate all composite type used by table column
+	if len(generated.Composites) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseComposites, generated.Composites, func(t objects.Type) string { return t.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc(generated.Composites, func(item objects.Type, input generator.GenerateInput) bool {
+				if i, ok := input.BindData.(generator.GenerateCompositeData); ok {
+					if i.Name == item.Name {
+						return true
+					}
+				}
+				return false
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhaseComposites, len(generated.Composites), eventHandler), flags.Hook)
+
+			// attribute is resolved with every imported type, unchanged type is not generated in since mode
+			var attributeTypes []objects.Type
+			attributeTypes = append(attributeTypes, resource.Types...)
+			attributeTypes = append(attributeTypes, resource.Domains...)
+			attributeTypes = append(attributeTypes, resource.Composites...)
+
+			if err := generator.GenerateComposites(ctx, projectPath, config.ModelsPackage, generated.Composites, attributeTypes, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	// generate all roles from cloud / pg-meta
+	if len(generated.Roles) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseRoles, generated.Roles, nil)
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc(generated.Roles, func(item objects.Role, input generator.GenerateInput) bool {
 				if i, ok := input.BindData.(generator.GenerateRoleData); ok {
 					if i.Name == item.Name {
 						return true
 					}
 				}
 				return false
-			}, stateChan)
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhaseRoles, len(generated.Roles), eventHandler), flags.Hook)
 
-			if err := generator.GenerateRoles(projectPath, resource.Roles, captureFunc); err != nil {
-				errChan <- err
+			if err := generator.GenerateRolesWithContext(ctx, projectPath, generated.Roles, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryRoles, err)
+				return
 			}
-			ImportLogger.Info("finish generate roles")
-		}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	if len(generated.Functions) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
 
-		if len(resource.Functions) > 0 {
-			ImportLogger.Info("start generate functions")
-			captureFunc := ImportDecorateFunc(resource.Functions, func(item objects.Function, input generator.GenerateInput) bool {
+			logArgs := importPhaseLogArgs(ImportPhaseRpc, generated.Functions, func(f objects.Function) string { return f.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc(generated.Functions, func(item objects.Function, input generator.GenerateInput) bool {
 				if i, ok := input.BindData.(generator.GenerateRpcData); ok {
 					if i.Name == utils.SnakeCaseToPascalCase(item.Name) {
 						return true
 					}
 				}
 				return false
-			}, stateChan)
-			if errGenRpc := generator.GenerateRpc(projectPath, config.ProjectName, resource.Functions, captureFunc); errGenRpc != nil {
-				errChan <- errGenRpc
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhaseRpc, len(generated.Functions), eventHandler), flags.Hook)
+
+			if err := generator.GenerateRpcWithContext(ctx, projectPath, generator.NewProjectPackage(config), generated.Functions, rpcModelInputs, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryRpc, err)
+				return
 			}
-			ImportLogger.Info("finish generate roles")
-		}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
 
-		if len(resource.Storages) > 0 {
-			ImportLogger.Info("start generate storages")
-			storageInput := storages.BuildGenerateStorageInput(resource.Storages, resource.Policies)
+	if len(generated.Triggers) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
 
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseTriggers, generated.Triggers, func(t objects.Trigger) string { return t.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc(generated.Triggers, func(item objects.Trigger, input generator.GenerateInput) bool {
+				if i, ok := input.BindData.(generator.GenerateTriggerData); ok {
+					if i.Name == item.Name && i.Table == item.Table {
+						return true
+					}
+				}
+				return false
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhaseTriggers, len(generated.Triggers), eventHandler), flags.Hook)
+
+			if err := generator.GenerateTriggers(ctx, projectPath, generator.NewProjectPackage(config), generated.Triggers, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryRpc, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	if len(generated.Publications) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhasePublications, generated.Publications, nil)
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc(generated.Publications, func(item objects.Publication, input generator.GenerateInput) bool {
+				if i, ok := input.BindData.(generator.GeneratePublicationData); ok {
+					if i.Name == item.Name {
+						return true
+					}
+				}
+				return false
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhasePublications, len(generated.Publications), eventHandler), flags.Hook)
+
+			if err := generator.GeneratePublications(ctx, projectPath, generated.Publications, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	if len(generated.Storages) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseStorages, generated.Storages, nil)
+			ImportLogger.Info("start generate", logArgs...)
+			storageInput := generated.Storages
 			captureFunc := ImportDecorateFunc(storageInput, func(item *generator.GenerateStorageInput, input generator.GenerateInput) bool {
 				if i, ok := input.BindData.(generator.GenerateStoragesData); ok {
 					if utils.ToSnakeCase(i.Name) == utils.ToSnakeCase(item.Bucket.Name) {
@@ -264,13 +729,101 @@ func generateImportResource(config *raiden.Config, importState *state.LocalState
 					}
 				}
 				return false
-			}, stateChan)
-			if errGenStorage := generator.GenerateStorages(projectPath, storageInput, captureFunc); errGenStorage != nil {
-				errChan <- errGenStorage
+			}, stateChan, dryRun, mode, keptFiles, newImportProgress(ImportPhaseStorages, len(storageInput), eventHandler), flags.Hook)
+
+			if err := generator.GenerateStoragesWithContext(ctx, projectPath, storageInput, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryStorages, err)
+				return
 			}
-			ImportLogger.Info("finish generate storages")
-		}
-	}()
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	// typescript declaration is not tracked in local state
+	if config.GenerateTypeScript {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseTypeScript, resource.Tables, func(t objects.Table) string { return t.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc([]any{}, func(item any, input generator.GenerateInput) bool {
+				return false
+			}, stateChan, dryRun, mode, keptFiles, nil, flags.Hook)
+
+			tsInput := generator.GenerateTypeScriptInput{
+				Tables:    resource.Tables,
+				Types:     resource.Types,
+				Functions: resource.Functions,
+				JsonCase:  generator.JsonCase(config.JsonCase),
+
+				ModelsPackage: config.ModelsPackage,
+			}
+			if err := generator.GenerateTypeScript(projectPath, tsInput, limitGenerateFunc(ctx, workerChan, captureFunc)); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	// baseline migration is not tracked in local state
+	if config.GenerateMigration {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseMigration, resource.Tables, func(t objects.Table) string { return t.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc([]any{}, func(item any, input generator.GenerateInput) bool {
+				return false
+			}, stateChan, dryRun, mode, keptFiles, nil, flags.Hook)
+
+			migrationInput := generator.GenerateMigrationInput{
+				Roles:    resource.Roles,
+				Tables:   resource.Tables,
+				Policies: resource.Policies,
+			}
+			migrationInput.Types = append(migrationInput.Types, resource.Types...)
+			migrationInput.Types = append(migrationInput.Types, resource.Domains...)
+			migrationInput.Types = append(migrationInput.Types, resource.Composites...)
+
+			if err := generator.GenerateMigration(projectPath, migrationInput, limitGenerateFunc(ctx, workerChan, captureFunc)); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
+
+	// seed is not tracked in local state
+	if len(seedInputs) > 0 {
+		wg.Add(1)
+		go func(w *sync.WaitGroup, eChan chan error) {
+			defer w.Done()
+
+			ctx, cancel := importCategoryContext(ctx, config)
+			defer cancel()
+
+			logArgs := importPhaseLogArgs(ImportPhaseSeed, seedInputs, func(i generator.GenerateSeedInput) string { return i.ModelInput.Table.Schema })
+			ImportLogger.Info("start generate", logArgs...)
+			captureFunc := ImportDecorateFunc([]any{}, func(item any, input generator.GenerateInput) bool {
+				return false
+			}, stateChan, dryRun, mode, keptFiles, nil, flags.Hook)
+
+			if err := generator.GenerateSeed(projectPath, seedInputs, generator.WithTemplateOverrides(templateOverrides, limitGenerateFunc(ctx, workerChan, captureFunc))); err != nil {
+				eChan <- importCategoryError(ctx, config, ImportCategoryModels, err)
+				return
+			}
+			ImportLogger.Info("finish generate", logArgs...)
+		}(&wg, errChan)
+	}
 
 	go func() {
 		wg.Wait()
@@ -278,28 +831,328 @@ func generateImportResource(config *raiden.Config, importState *state.LocalState
 		close(errChan)
 	}()
 
-	for {
-		select {
-		case rsErr := <-errChan:
-			if rsErr != nil {
-				return rsErr
-			}
-		case saveErr := <-doneListen:
-			return saveErr
+	// each resource category is independent, keep collecting error
+	// until all category is finished instead of stop in first error,
+	// timeout of category is recoverable and only fail the category
+	var errs []error
+	failedCategories := make(map[ImportCategory]bool)
+	for c := range resource.Failed {
+		failedCategories[c] = true
+	}
+
+	for rsErr := range errChan {
+		var timeoutErr *ImportCategoryTimeoutError
+		if errors.As(rsErr, &timeoutErr) {
+			ImportLogger.Warn("stop generate import category, generate is timeout", "category", timeoutErr.Category, "timeout", timeoutErr.Timeout)
+			failedCategories[timeoutErr.Category] = true
+			continue
+		}
+
+		if rsErr != nil {
+			errs = append(errs, rsErr)
+		}
+	}
+
+	if saveErr := <-doneListen; saveErr != nil {
+		errs = append(errs, saveErr)
+	}
+
+	// state is persisted by listener, persist again after keep state of failed category
+	for c := range failedCategories {
+		summary.Failed = append(summary.Failed, string(c))
+		if !dryRun {
+			keepImportCategoryState(importState, previousState, c)
 		}
 	}
+	sort.Strings(summary.Failed)
+
+	if len(failedCategories) > 0 && !dryRun {
+		if saveErr := importState.Persist(); saveErr != nil {
+			errs = append(errs, saveErr)
+		}
+	}
+
+	// every category return the same error when import is cancelled
+	if err := parentCtx.Err(); err != nil {
+		return summary, dryRunReport, err
+	}
+
+	if jsonReport != nil {
+		ImportLogger.Info("write import report", "path", flags.Report)
+		if reportErr := WriteImportJsonReport(flags.Report, jsonReport); reportErr != nil {
+			errs = append(errs, reportErr)
+		}
+	}
+	return summary, dryRunReport, errors.Join(errs...)
 }
 
-func ImportDecorateFunc[T any](data []T, findFunc func(T, generator.GenerateInput) bool, stateChan chan any) generator.GenerateFn {
+// writeRelationDiagram write relation that used by generated model as mermaid diagram
+func writeRelationDiagram(config *raiden.Config, flags *Flags, importTables []objects.Table) error {
+	overrides, err := loadImportRelationOverrides(config, flags)
+	if err != nil {
+		return err
+	}
+
+	mapRelations := tables.BuildGenerateMapRelationsWithResolver(importTables, getImportRelationResolver(config, flags), overrides...)
+	diagram := tables.GenerateMermaidDiagram(importTables, mapRelations)
+	return os.WriteFile(flags.Graph, []byte(diagram), 0644)
+}
+
+// buildImportSeedInputs pair seed rows with model input of the table, seed order is kept
+func buildImportSeedInputs(tableInputs []*generator.GenerateModelInput, seeds []SeedTableRows) []generator.GenerateSeedInput {
+	mapInput := make(map[string]*generator.GenerateModelInput)
+	for _, t := range tableInputs {
+		mapInput[fmt.Sprintf("%s.%s", t.Table.Schema, t.Table.Name)] = t
+	}
+
+	inputs := make([]generator.GenerateSeedInput, 0, len(seeds))
+	for _, s := range seeds {
+		input, exist := mapInput[fmt.Sprintf("%s.%s", s.Table.Schema, s.Table.Name)]
+		if !exist {
+			continue
+		}
+		inputs = append(inputs, generator.GenerateSeedInput{ModelInput: input, Rows: s.Rows})
+	}
+	return inputs
+}
+
+// getImportRelationResolver return relation resolver from flags,
+// default resolver use pivot rule from config
+func getImportRelationResolver(config *raiden.Config, flags *Flags) tables.RelationResolver {
+	if flags.RelationResolver != nil {
+		return flags.RelationResolver
+	}
+
+	rule := tables.NewPivotRule(config.PivotRule)
+	return tables.DefaultRelationResolver{PivotRule: &rule}
+}
+
+// loadImportRelationOverrides load relation override file and
+// add suppressed many to many relation from config
+func loadImportRelationOverrides(config *raiden.Config, flags *Flags) (tables.RelationOverrides, error) {
+	overrides, err := tables.LoadRelationOverrides(flags.ProjectPath)
+	if err != nil {
+		return nil, err
+	}
+	return append(overrides, tables.SuppressManyToManyOverrides(config.SuppressManyToMany)...), nil
+}
+
+// buildImportModelInputs build model input of all imported table
+// with option from config and flags
+func buildImportModelInputs(config *raiden.Config, flags *Flags, resource *Resource) ([]*generator.GenerateModelInput, error) {
+	if len(resource.Tables) == 0 {
+		return nil, nil
+	}
+
+	overrides, err := loadImportRelationOverrides(config, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	if !generator.IsValidJsonCase(generator.JsonCase(config.JsonCase)) {
+		return nil, fmt.Errorf("invalid json case %q, supported json case is snake, camel and pascal", config.JsonCase)
+	}
+
+	if !generator.IsValidUuidType(generator.UuidType(config.UuidType)) {
+		return nil, fmt.Errorf("invalid uuid type %q, supported uuid type is uuid.UUID and string", config.UuidType)
+	}
+
+	if config.ModelsPackage != "" && !token.IsIdentifier(config.ModelsPackage) {
+		return nil, fmt.Errorf("invalid models package %q, models package must be valid go package name", config.ModelsPackage)
+	}
+
+	for pgType, goType := range config.TypeOverrides {
+		if _, _, err := generator.ParseTypeOverride(goType); err != nil {
+			return nil, fmt.Errorf("type override of %s : %w", pgType, err)
+		}
+	}
+
+	if err := validateTablePrimaryKey(config, resource.Tables); err != nil {
+		return nil, err
+	}
+
+	// configured json column type is added last so it override supabase type
+	jsonColumnTypes := append([]raiden.JsonColumnType{}, generator.SupabaseJsonColumnTypes...)
+	mapJsonTypes := buildMapJsonColumnTypes(append(jsonColumnTypes, config.JsonColumnTypes...))
+	compositeTypes := make(map[string]string, len(resource.Composites))
+	for _, t := range resource.Composites {
+		compositeTypes[t.Name] = fmt.Sprintf("%s.%s", t.Schema, t.Name)
+	}
+	nameTransformer := buildNameTransformer(config)
+	tableInputs := tables.BuildGenerateModelInputsWithCache(resource.Tables, resource.Policies, getImportRelationResolver(config, flags), resource.RelationCache, overrides...)
+	for i := range tableInputs {
+		t := tableInputs[i]
+		t.WithStub = flags.ModelStub
+		t.JsonTypes = mapJsonTypes[fmt.Sprintf("%s.%s", t.Table.Schema, t.Table.Name)]
+		t.SchemaPackage = config.SchemaPackages
+		t.ProjectName = config.ProjectName
+		t.ModulePath = config.ModulePath
+		t.ModelsPackage = config.ModelsPackage
+		t.NameTransformer = nameTransformer
+		t.JsonCase = generator.JsonCase(config.JsonCase)
+		t.UuidType = generator.UuidType(config.UuidType)
+		t.SoftDeleteColumn = config.SoftDeleteColumn
+		t.GormTags = config.GormTags
+		t.TypeOverrides = config.TypeOverrides
+		t.CompositeTypes = compositeTypes
+		t.PaginationHelpers = config.PaginationHelpers
+		t.BulkInsertHelpers = config.BulkInsertHelpers
+		t.UpsertHelpers = config.UpsertHelpers
+		t.VersionColumn = config.VersionColumn
+	}
+
+	if err := tables.ExcludeModelColumns(tableInputs, config.ExcludeColumns); err != nil {
+		return nil, err
+	}
+
+	// relation to table that excluded from import refer to struct that not exist
+	if err := tables.ValidateRelationTargets(tableInputs, config.StrictRelations); err != nil {
+		return nil, err
+	}
+
+	if config.SchemaPackages {
+		tables.RemoveCyclicSchemaRelations(tableInputs)
+	}
+
+	if config.ValueRelations {
+		tables.ApplyValueRelations(tableInputs)
+	}
+
+	generator.ResolveModelBase(tableInputs, config.ModelBaseColumns)
+	return tableInputs, nil
+}
+
+// validateTablePrimaryKey check imported table that doesn't have primary key,
+// the table is generated as read only model unless primary key is required by config
+func validateTablePrimaryKey(config *raiden.Config, importTables []objects.Table) error {
+	var tableNames []string
+	for _, t := range importTables {
+		if t.IsForeign || !generator.IsReadOnlyTable(t) {
+			continue
+		}
+
+		tableName := fmt.Sprintf("%s.%s", t.Schema, t.Name)
+		if !config.RequirePrimaryKey {
+			ImportLogger.Warn("table doesn't have primary key, model is generated as read only", "table", tableName)
+			continue
+		}
+		tableNames = append(tableNames, tableName)
+	}
+
+	if len(tableNames) > 0 {
+		return fmt.Errorf("table doesn't have primary key : %s", strings.Join(tableNames, ", "))
+	}
+	return nil
+}
+
+// buildMapJsonColumnTypes group configured json column type by schema.table,
+// each group map column name to go struct name
+func buildMapJsonColumnTypes(columnTypes []raiden.JsonColumnType) map[string]map[string]string {
+	mapJsonTypes := make(map[string]map[string]string)
+	for _, ct := range columnTypes {
+		splitColumn := strings.Split(ct.Column, ".")
+		if len(splitColumn) != 3 || ct.Type == "" {
+			ImportLogger.Warn("skip invalid json column type, column must be written as schema.table.column", "column", ct.Column, "type", ct.Type)
+			continue
+		}
+
+		tableKey := fmt.Sprintf("%s.%s", splitColumn[0], splitColumn[1])
+		if _, exist := mapJsonTypes[tableKey]; !exist {
+			mapJsonTypes[tableKey] = make(map[string]string)
+		}
+		mapJsonTypes[tableKey][splitColumn[2]] = ct.Type
+	}
+	return mapJsonTypes
+}
+
+// buildNameTransformer create name transformer from configured prefix,
+// nil is returned when no prefix is configured and default transformer is used
+func buildNameTransformer(config *raiden.Config) generator.NameTransformer {
+	if len(config.StripColumnPrefixes) == 0 && len(config.StripTablePrefixes) == 0 {
+		return nil
+	}
+
+	return generator.PrefixNameTransformer{
+		ColumnPrefixes: config.StripColumnPrefixes,
+		TablePrefixes:  config.StripTablePrefixes,
+	}
+}
+
+// limitGenerateFunc make sure only limited number of file is generated at the same time,
+// all resource category is generated concurrently and share the same worker chan
+func limitGenerateFunc(ctx context.Context, workerChan chan struct{}, generateFn generator.GenerateFn) generator.GenerateFn {
 	return func(input generator.GenerateInput, writer io.Writer) error {
-		if err := generator.Generate(input, nil); err != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case workerChan <- struct{}{}:
+		}
+		defer func() { <-workerChan }()
+
+		if err := ctx.Err(); err != nil {
 			return err
 		}
-		if rs, found := FindImportResource(data, input, findFunc); found {
-			stateChan <- map[string]any{
-				"item":  rs,
-				"input": input,
+		return generateFn(input, writer)
+	}
+}
+
+// ImportDecorateFunc write generated file and send the result to stateChan, file in keptFiles
+// is modified locally and user choose to keep it, so the file is not overwritten. Hook is
+// called after file of resource is written and its error is returned as generate error
+func ImportDecorateFunc[T any](data []T, findFunc func(T, generator.GenerateInput) bool, stateChan chan any, dryRun bool, mode ImportGenerateMode, keptFiles map[string]bool, progress *ImportProgress, hook ImportHook) generator.GenerateFn {
+	hook = getImportHook(hook)
+	return func(input generator.GenerateInput, writer io.Writer) error {
+		rs, found := FindImportResource(data, input, findFunc)
+		if dryRun {
+			if err := ImportDryRunGenerate(input, mode, stateChan); err != nil {
+				return err
+			}
+
+			// only counted in import summary, dry run doesn't update local state
+			if found {
+				stateChan <- map[string]any{
+					"item":  rs,
+					"input": input,
+				}
+			}
+		} else {
+			action := ImportDryRunActionCreate
+			if utils.IsFileExists(input.OutputPath) {
+				action = ImportDryRunActionUpdate
+			}
+
+			reason := ImportSkipReasonConflict
+			if !keptFiles[input.OutputPath] {
+				var err error
+				if reason, err = ImportGenerate(input, mode); err != nil {
+					return err
+				}
 			}
+
+			if reason != "" {
+				stateChan <- ImportSkippedFile{Path: input.OutputPath, Reason: reason}
+			} else {
+				stateChan <- ImportWrittenFile{Path: input.OutputPath, Action: action}
+			}
+
+			if found && reason == "" {
+				event := ImportHookEvent{Kind: getImportResourceKind(rs, input), Name: getImportResourceName(rs), Path: input.OutputPath}
+				if err := hook.AfterWrite(event); err != nil {
+					return fmt.Errorf("import hook of %s %s : %w", event.Kind, event.Name, err)
+				}
+			}
+
+			// skipped file still belong to imported resource and keep in local state
+			if found {
+				stateChan <- map[string]any{
+					"item":  rs,
+					"input": input,
+				}
+			}
+		}
+
+		if found {
+			progress.Next(getImportResourceName(rs))
 		}
 		return nil
 	}
@@ -315,15 +1168,295 @@ func FindImportResource[T any](data []T, input generator.GenerateInput, findFunc
 	return
 }
 
+// ----- Import generate mode -----
+type ImportGenerateMode string
+
+const (
+	// ImportGenerateModeChanged only write file that content is different with existing file,
+	// unchanged file is not rewritten so modification time is stable for build cache
+	ImportGenerateModeChanged ImportGenerateMode = "changed"
+
+	// ImportGenerateModeForce always overwrite existing file
+	ImportGenerateModeForce ImportGenerateMode = "force"
+
+	// ImportGenerateModeNoClobber never overwrite existing file
+	ImportGenerateModeNoClobber ImportGenerateMode = "no-clobber"
+)
+
+type ImportSkipReason string
+
+const (
+	ImportSkipReasonExist     ImportSkipReason = "exist"
+	ImportSkipReasonUnchanged ImportSkipReason = "unchanged"
+	ImportSkipReasonConflict  ImportSkipReason = "conflict"
+)
+
+// ImportSkippedFile is sent to state channel when generated file is not written
+type ImportSkippedFile struct {
+	Path   string
+	Reason ImportSkipReason
+}
+
+// ImportGenerate write generated file based on generate mode,
+// skip reason is returned when file is not written
+func ImportGenerate(input generator.GenerateInput, mode ImportGenerateMode) (ImportSkipReason, error) {
+	if mode == ImportGenerateModeForce || !utils.IsFileExists(input.OutputPath) {
+		return "", generator.Generate(input, nil)
+	}
+
+	if mode == ImportGenerateModeNoClobber {
+		return ImportSkipReasonExist, nil
+	}
+
+	var buff bytes.Buffer
+	if err := generator.Generate(input, &buff); err != nil {
+		return "", err
+	}
+
+	isChanged, err := isFileContentChanged(input.OutputPath, buff.Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	if !isChanged {
+		return ImportSkipReasonUnchanged, nil
+	}
+
+	file, err := generator.DefaultWriter(input.OutputPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	_, err = file.Write(buff.Bytes())
+	return "", err
+}
+
+// isFileContentChanged compare content hash of existing file with generated content
+func isFileContentChanged(filePath string, content []byte) (bool, error) {
+	existing, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	return utils.HashByte(existing) != utils.HashByte(content), nil
+}
+
+// ----- Import progress event -----
+type ImportPhase string
+
+const (
+	ImportPhaseTables       ImportPhase = "tables"
+	ImportPhaseTypes        ImportPhase = "types"
+	ImportPhaseDomains      ImportPhase = "domains"
+	ImportPhaseComposites   ImportPhase = "composites"
+	ImportPhaseRoles        ImportPhase = "roles"
+	ImportPhaseRpc          ImportPhase = "rpc"
+	ImportPhaseStorages     ImportPhase = "storages"
+	ImportPhaseTriggers     ImportPhase = "triggers"
+	ImportPhasePublications ImportPhase = "publications"
+	ImportPhaseControllers  ImportPhase = "controllers"
+	ImportPhaseTypeScript   ImportPhase = "typescript"
+	ImportPhaseMigration    ImportPhase = "migration"
+	ImportPhaseSeed         ImportPhase = "seed"
+)
+
+// importPhaseLogArgs return structured log field of generate phase, so import progress
+// can be filtered by log pipeline. Schema is only added when schemaFn is set
+func importPhaseLogArgs[T any](phase ImportPhase, items []T, schemaFn func(T) string) []any {
+	args := []any{"phase", phase, "count", len(items)}
+	if schemaFn == nil {
+		return args
+	}
+
+	mapSchema := make(map[string]bool)
+	var schemas []string
+	for _, item := range items {
+		if schema := schemaFn(item); schema != "" && !mapSchema[schema] {
+			mapSchema[schema] = true
+			schemas = append(schemas, schema)
+		}
+	}
+	sort.Strings(schemas)
+	return append(args, "schema", strings.Join(schemas, ","))
+}
+
+type ImportEvent struct {
+	Phase   ImportPhase
+	Current int
+	Total   int
+	Name    string
+}
+
+// ImportEventHandler is called every time resource is generated,
+// each phase is generated concurrently but handler is never called concurrently
+type ImportEventHandler func(event ImportEvent)
+
+var importEventMutex sync.Mutex
+
+// ImportProgress count generated resource in a phase and emit it to handler
+type ImportProgress struct {
+	phase   ImportPhase
+	total   int
+	current int
+	handler ImportEventHandler
+}
+
+func newImportProgress(phase ImportPhase, total int, handler ImportEventHandler) *ImportProgress {
+	return &ImportProgress{phase: phase, total: total, handler: handler}
+}
+
+// Next increase generated resource count and emit event,
+// do nothing when progress or handler is not set
+func (p *ImportProgress) Next(name string) {
+	if p == nil || p.handler == nil {
+		return
+	}
+
+	importEventMutex.Lock()
+	defer importEventMutex.Unlock()
+
+	p.current++
+	p.handler(ImportEvent{
+		Phase:   p.phase,
+		Current: p.current,
+		Total:   p.total,
+		Name:    name,
+	})
+}
+
+func getImportResourceName(item any) string {
+	switch i := item.(type) {
+	case *generator.GenerateModelInput:
+		return i.Table.Name
+	case objects.Type:
+		return i.Name
+	case objects.Role:
+		return i.Name
+	case objects.Function:
+		return i.Name
+	case *generator.GenerateStorageInput:
+		return i.Bucket.Name
+	case objects.Trigger:
+		return i.Name
+	case objects.Publication:
+		return i.Name
+	case string:
+		return i
+	}
+	return ""
+}
+
+// ----- Dry run import -----
+type ImportDryRunAction string
+
+const (
+	ImportDryRunActionCreate ImportDryRunAction = "create"
+	ImportDryRunActionUpdate ImportDryRunAction = "update"
+	ImportDryRunActionSkip   ImportDryRunAction = "skip"
+)
+
+type ImportDryRunItem struct {
+	Path   string
+	Action ImportDryRunAction
+	Size   int
+}
+
+type ImportDryRunReport struct {
+	Items []ImportDryRunItem
+}
+
+// ImportDryRunGenerate render generated content to buffer instead of file
+// and send summary of file that will be written to state channel
+func ImportDryRunGenerate(input generator.GenerateInput, mode ImportGenerateMode, stateChan chan any) error {
+	var buff bytes.Buffer
+	if err := generator.Generate(input, &buff); err != nil {
+		return err
+	}
+
+	action := ImportDryRunActionCreate
+	if utils.IsFileExists(input.OutputPath) {
+		action = ImportDryRunActionUpdate
+		switch mode {
+		case ImportGenerateModeNoClobber:
+			action = ImportDryRunActionSkip
+		case ImportGenerateModeChanged:
+			if isChanged, err := isFileContentChanged(input.OutputPath, buff.Bytes()); err != nil {
+				return err
+			} else if !isChanged {
+				action = ImportDryRunActionSkip
+			}
+		}
+	}
+
+	stateChan <- ImportDryRunItem{
+		Path:   input.OutputPath,
+		Action: action,
+		Size:   buff.Len(),
+	}
+	return nil
+}
+
+func ListenImportDryRun(report *ImportDryRunReport, stateChan chan any) (done chan error) {
+	done = make(chan error, 1)
+	go func() {
+		for rs := range stateChan {
+			if item, isItem := rs.(ImportDryRunItem); isItem {
+				report.Items = append(report.Items, item)
+			}
+		}
+
+		sort.SliceStable(report.Items, func(i, j int) bool {
+			return report.Items[i].Path < report.Items[j].Path
+		})
+		done <- nil
+	}()
+	return done
+}
+
+func PrintImportDryRunReport(report ImportDryRunReport) {
+	if len(report.Items) == 0 {
+		return
+	}
+
+	var buff bytes.Buffer
+	w := tabwriter.NewWriter(&buff, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tACTION\tSIZE")
+	for _, item := range report.Items {
+		fmt.Fprintf(w, "%s\t%s\t%d B\n", item.Path, item.Action, item.Size)
+	}
+	w.Flush()
+
+	ImportLogger.Info("list of file that will be generated", "total", len(report.Items))
+	fmt.Println(buff.String())
+}
+
 // ----- Update imported data in local state -----
+
+// UpdateLocalStateFromImport add every generated resource sent to stateChan to local state and
+// persist it after stateChan is closed. Listener is the only consumer of stateChan, so any number
+// of generate goroutine can send to it concurrently, local state mutation is guarded by its mutex
+// so producer that add state directly (e.g unchanged table in incremental import) is also safe.
 func UpdateLocalStateFromImport(localState *state.LocalState, stateChan chan any) (done chan error) {
-	done = make(chan error)
+	done = make(chan error, 1)
 	go func() {
 		for rs := range stateChan {
 			if rs == nil {
 				continue
 			}
 
+			if written, isWritten := rs.(ImportWrittenFile); isWritten {
+				setImportFileHash(localState, written.Path)
+				continue
+			}
+
+			if skipped, isSkipped := rs.(ImportSkippedFile); isSkipped {
+				ImportLogger.Debug("skip write generated file", "path", skipped.Path, "reason", skipped.Reason)
+				if skipped.Reason == ImportSkipReasonUnchanged {
+					setImportFileHash(localState, skipped.Path)
+				}
+				continue
+			}
+
 			if rsMap, isMap := rs.(map[string]any); isMap {
 				item, input := rsMap["item"], rsMap["input"]
 				if item == nil || input == nil {
@@ -340,10 +1473,13 @@ func UpdateLocalStateFromImport(localState *state.LocalState, stateChan chan any
 					tableState := state.TableState{
 						Table:       parseItem.Table,
 						ModelPath:   genInput.OutputPath,
-						ModelStruct: utils.SnakeCaseToPascalCase(parseItem.Table.Name),
+						ModelStruct: parseItem.GetStructName(),
 						LastUpdate:  time.Now(),
 						Relation:    parseItem.Relations,
 						Policies:    parseItem.Policies,
+						ContentHash: tables.HashModelInput(parseItem),
+
+						DefinitionHash: state.HashTableDefinition(parseItem.Table, parseItem.Relations, parseItem.Policies),
 					}
 					localState.AddTable(tableState)
 				case objects.Role:
@@ -353,6 +1489,8 @@ func UpdateLocalStateFromImport(localState *state.LocalState, stateChan chan any
 						RoleStruct: utils.SnakeCaseToPascalCase(parseItem.Name),
 						IsNative:   false,
 						LastUpdate: time.Now(),
+
+						DefinitionHash: state.HashRoleDefinition(parseItem),
 					}
 					localState.AddRole(roleState)
 				case objects.Function:
@@ -361,6 +1499,8 @@ func UpdateLocalStateFromImport(localState *state.LocalState, stateChan chan any
 						RpcPath:    genInput.OutputPath,
 						RpcStruct:  utils.SnakeCaseToPascalCase(parseItem.Name),
 						LastUpdate: time.Now(),
+
+						DefinitionHash: state.HashFunctionDefinition(parseItem),
 					}
 					localState.AddRpc(rpcState)
 				case *generator.GenerateStorageInput:
@@ -370,8 +1510,47 @@ func UpdateLocalStateFromImport(localState *state.LocalState, stateChan chan any
 						StorageStruct: utils.SnakeCaseToPascalCase(parseItem.Bucket.Name),
 						Policies:      parseItem.Policies,
 						LastUpdate:    time.Now(),
+
+						DefinitionHash: state.HashStorageDefinition(parseItem.Bucket, parseItem.Policies),
 					}
 					localState.AddStorage(storageState)
+				case objects.Type:
+					typeState := state.TypeState{
+						Type:       parseItem,
+						TypePath:   genInput.OutputPath,
+						LastUpdate: time.Now(),
+
+						DefinitionHash: state.HashTypeDefinition(parseItem),
+					}
+					switch typeData := genInput.BindData.(type) {
+					case generator.GenerateEnumData:
+						typeState.TypeStruct = typeData.Type
+					case generator.GenerateDomainData:
+						typeState.TypeStruct = typeData.Type
+					case generator.GenerateCompositeData:
+						typeState.TypeStruct = typeData.Type
+					}
+					localState.AddType(typeState)
+				case objects.Trigger:
+					triggerState := state.TriggerState{
+						Trigger:       parseItem,
+						TriggerPath:   genInput.OutputPath,
+						TriggerStruct: generator.GetTriggerStructName(parseItem),
+						LastUpdate:    time.Now(),
+
+						DefinitionHash: state.HashTriggerDefinition(parseItem),
+					}
+					localState.AddTrigger(triggerState)
+				case objects.Publication:
+					publicationState := state.PublicationState{
+						Publication:       parseItem,
+						PublicationPath:   genInput.OutputPath,
+						PublicationStruct: utils.SnakeCaseToPascalCase(parseItem.Name),
+						LastUpdate:        time.Now(),
+
+						DefinitionHash: state.HashPublicationDefinition(parseItem),
+					}
+					localState.AddPublication(publicationState)
 				}
 			}
 		}
@@ -380,29 +1559,46 @@ func UpdateLocalStateFromImport(localState *state.LocalState, stateChan chan any
 	return done
 }
 
+// setImportFileHash store content hash of generated file, so modified file is detected on next import
+func setImportFileHash(localState *state.LocalState, path string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		ImportLogger.Debug("failed read generated file", "path", path, "err", err)
+		return
+	}
+	localState.SetFileHash(path, utils.HashByte(content))
+}
+
 // ----- Print import report -----
 type ImportReport struct {
-	Table   int
-	Role    int
-	Rpc     int
-	Storage int
+	Table       int
+	Role        int
+	Rpc         int
+	Storage     int
+	Trigger     int
+	Publication int
+	Skipped     int
 }
 
 func PrintImportReport(report ImportReport, dryRun bool) {
+	if report.Skipped > 0 {
+		ImportLogger.Warn("several resources are skipped, check previous warning for the reason", "Skipped", report.Skipped)
+	}
+
 	var message string
 	if !dryRun {
 		message = "import process is complete, your code is up to date"
-		if report.Role > 0 || report.Rpc > 0 || report.Storage > 0 || report.Table > 0 {
+		if report.Role > 0 || report.Rpc > 0 || report.Storage > 0 || report.Table > 0 || report.Trigger > 0 || report.Publication > 0 {
 			message = "import process is complete, adding several new resources to the codebase"
-			ImportLogger.Info(message, "Table", report.Table, "Role", report.Role, "Rpc", report.Rpc, "Storage", report.Storage)
+			ImportLogger.Info(message, "Table", report.Table, "Role", report.Role, "Rpc", report.Rpc, "Storage", report.Storage, "Trigger", report.Trigger, "Publication", report.Publication)
 			return
 		}
 		ImportLogger.Info(message)
 	} else {
 		message = "finish running import in dry run mode, your code is up to date"
-		if report.Role > 0 || report.Rpc > 0 || report.Storage > 0 || report.Table > 0 {
+		if report.Role > 0 || report.Rpc > 0 || report.Storage > 0 || report.Table > 0 || report.Trigger > 0 || report.Publication > 0 {
 			message = "finish running import in dry run mode and add several resource"
-			ImportLogger.Info(message, "Table", report.Table, "Role", report.Role, "Rpc", report.Rpc, "Storage", report.Storage)
+			ImportLogger.Info(message, "Table", report.Table, "Role", report.Role, "Rpc", report.Rpc, "Storage", report.Storage, "Trigger", report.Trigger, "Publication", report.Publication)
 			return
 		}
 		ImportLogger.Info(message)
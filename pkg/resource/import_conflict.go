@@ -0,0 +1,121 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/erikgeiser/promptkit/confirmation"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/utils"
+	"golang.org/x/term"
+)
+
+// ImportConflictMode is how import handle generated file that modified after previous import
+type ImportConflictMode string
+
+const (
+	// ImportConflictModePrompt ask before overwrite every modified file,
+	// import is failed when it is not run in interactive terminal (e.g CI)
+	ImportConflictModePrompt ImportConflictMode = "prompt"
+
+	// ImportConflictModeFail fail import when there is modified file
+	ImportConflictModeFail ImportConflictMode = "fail"
+)
+
+// ImportConflictError is returned when generated file is modified locally and can't be overwritten
+type ImportConflictError struct {
+	Files []string
+}
+
+func (e *ImportConflictError) Error() string {
+	return fmt.Sprintf("generated file is modified locally : %s", strings.Join(e.Files, ", "))
+}
+
+var (
+	// ImportConflictPrompt ask user to overwrite locally modified file
+	ImportConflictPrompt = func(path string) (bool, error) {
+		input := confirmation.New(fmt.Sprintf("%s is modified locally, overwrite ?", path), confirmation.No)
+		return input.RunPrompt()
+	}
+
+	isImportInteractive = func() bool {
+		return term.IsTerminal(int(os.Stdin.Fd()))
+	}
+)
+
+// FindImportConflicts return generated file that content is different with hash
+// that stored on previous import, deleted file is not conflict and generated again
+func FindImportConflicts(previousState *state.State) (files []string, err error) {
+	if previousState == nil {
+		return nil, nil
+	}
+
+	for path, hash := range previousState.FileHashes {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		if utils.HashByte(content) != hash {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// resolveImportConflicts check modified file based on --conflict flag
+// and return file that must be kept as is
+func resolveImportConflicts(flags *Flags, previousState *state.State) (keptFiles map[string]bool, err error) {
+	keptFiles = make(map[string]bool)
+	if flags.Conflict == "" {
+		return keptFiles, nil
+	}
+
+	files, err := FindImportConflicts(previousState)
+	if err != nil || len(files) == 0 {
+		return keptFiles, err
+	}
+
+	if flags.DryRun {
+		for _, f := range files {
+			ImportLogger.Warn("generated file is modified locally and will be overwritten", "path", importConflictPath(flags.ProjectPath, f))
+		}
+		return keptFiles, nil
+	}
+
+	if ImportConflictMode(flags.Conflict) == ImportConflictModeFail || !isImportInteractive() {
+		conflictErr := &ImportConflictError{}
+		for _, f := range files {
+			conflictErr.Files = append(conflictErr.Files, importConflictPath(flags.ProjectPath, f))
+		}
+		return nil, conflictErr
+	}
+
+	for _, f := range files {
+		overwrite, err := ImportConflictPrompt(importConflictPath(flags.ProjectPath, f))
+		if err != nil {
+			return nil, err
+		}
+
+		if !overwrite {
+			keptFiles[f] = true
+		}
+	}
+	return keptFiles, nil
+}
+
+func importConflictPath(projectPath string, path string) string {
+	if rel, err := filepath.Rel(projectPath, path); err == nil && projectPath != "" {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
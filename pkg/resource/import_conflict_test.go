@@ -0,0 +1,106 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveImportConflicts(t *testing.T) {
+	projectPath := t.TempDir()
+	modifiedPath := filepath.Join(projectPath, "internal", "models", "orders.go")
+	unchangedPath := filepath.Join(projectPath, "internal", "models", "users.go")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(modifiedPath), 0755))
+	assert.NoError(t, os.WriteFile(modifiedPath, []byte("package models // edited\n"), 0644))
+	assert.NoError(t, os.WriteFile(unchangedPath, []byte("package models\n"), 0644))
+
+	previousState := &state.State{
+		FileHashes: map[string]string{
+			modifiedPath:  utils.HashByte([]byte("package models\n")),
+			unchangedPath: utils.HashByte([]byte("package models\n")),
+			filepath.Join(projectPath, "internal", "roles", "deleted.go"): "deleted",
+		},
+	}
+
+	files, err := FindImportConflicts(previousState)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{modifiedPath}, files)
+
+	// conflict is not checked by default
+	keptFiles, err := resolveImportConflicts(&Flags{ProjectPath: projectPath}, previousState)
+	assert.NoError(t, err)
+	assert.Empty(t, keptFiles)
+
+	_, err = resolveImportConflicts(&Flags{ProjectPath: projectPath, Conflict: "fail"}, previousState)
+	assert.Equal(t, &ImportConflictError{Files: []string{"internal/models/orders.go"}}, err)
+
+	defaultInteractive, defaultPrompt := isImportInteractive, ImportConflictPrompt
+	t.Cleanup(func() { isImportInteractive, ImportConflictPrompt = defaultInteractive, defaultPrompt })
+
+	// non interactive import can't prompt and failed with list of modified file
+	isImportInteractive = func() bool { return false }
+	_, err = resolveImportConflicts(&Flags{ProjectPath: projectPath, Conflict: "prompt"}, previousState)
+	assert.EqualError(t, err, "generated file is modified locally : internal/models/orders.go")
+
+	var prompted []string
+	isImportInteractive = func() bool { return true }
+	ImportConflictPrompt = func(path string) (bool, error) {
+		prompted = append(prompted, path)
+		return false, nil
+	}
+
+	keptFiles, err = resolveImportConflicts(&Flags{ProjectPath: projectPath, Conflict: "prompt"}, previousState)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"internal/models/orders.go"}, prompted)
+	assert.Equal(t, map[string]bool{modifiedPath: true}, keptFiles)
+}
+
+func TestImportDecorateFunc_KeptFile(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "orders.go")
+	assert.NoError(t, os.WriteFile(outputPath, []byte("custom content"), 0644))
+
+	input := generator.GenerateInput{
+		BindData:     map[string]string{"Name": "orders"},
+		Template:     "package {{ .Name }}\n",
+		TemplateName: "testTemplate",
+		OutputPath:   outputPath,
+	}
+
+	stateChan := make(chan any, 2)
+	generateFn := ImportDecorateFunc([]string{"orders"}, func(item string, input generator.GenerateInput) bool {
+		return true
+	}, stateChan, false, ImportGenerateModeForce, map[string]bool{outputPath: true}, nil, nil)
+	assert.NoError(t, generateFn(input, nil))
+	close(stateChan)
+
+	assert.Equal(t, ImportSkippedFile{Path: outputPath, Reason: ImportSkipReasonConflict}, <-stateChan)
+
+	content, err := os.ReadFile(outputPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "custom content", string(content))
+}
+
+func TestUpdateLocalStateFromImport_FileHash(t *testing.T) {
+	// state is persisted to build folder in current directory
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	outputPath := filepath.Join(t.TempDir(), "orders.go")
+	assert.NoError(t, os.WriteFile(outputPath, []byte("package models\n"), 0644))
+
+	localState := state.LocalState{}
+	stateChan := make(chan any)
+	done := UpdateLocalStateFromImport(&localState, stateChan)
+	stateChan <- ImportWrittenFile{Path: outputPath, Action: ImportDryRunActionCreate}
+	close(stateChan)
+	assert.NoError(t, <-done)
+
+	assert.Equal(t, utils.HashByte([]byte("package models\n")), localState.State.FileHashes[outputPath])
+}
@@ -0,0 +1,72 @@
+package resource
+
+import (
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// ImportHookEvent is generated file of single imported resource, kind is the import
+// phase of resource (e.g tables, rpc) and name is the resource name
+type ImportHookEvent struct {
+	Kind ImportPhase
+	Name string
+	Path string
+}
+
+// ImportHook is called after generated file of imported resource is written, file that is
+// not written (e.g unchanged or kept file) and dry run is not reported. Every phase is generated
+// concurrently so hook can be called concurrently, error returned by hook stop the import
+type ImportHook interface {
+	AfterWrite(event ImportHookEvent) error
+}
+
+// ImportHookFunc is function that can be used as ImportHook
+type ImportHookFunc func(event ImportHookEvent) error
+
+func (f ImportHookFunc) AfterWrite(event ImportHookEvent) error {
+	return f(event)
+}
+
+// NoopImportHook is default hook that do nothing
+type NoopImportHook struct{}
+
+func (NoopImportHook) AfterWrite(ImportHookEvent) error {
+	return nil
+}
+
+// getImportHook return noop hook when hook is not set
+func getImportHook(hook ImportHook) ImportHook {
+	if hook == nil {
+		return NoopImportHook{}
+	}
+	return hook
+}
+
+func getImportResourceKind(item any, input generator.GenerateInput) ImportPhase {
+	switch item.(type) {
+	case *generator.GenerateModelInput:
+		return ImportPhaseTables
+	case objects.Type:
+		switch input.BindData.(type) {
+		case generator.GenerateDomainData:
+			return ImportPhaseDomains
+		case generator.GenerateCompositeData:
+			return ImportPhaseComposites
+		}
+		return ImportPhaseTypes
+	case objects.Role:
+		return ImportPhaseRoles
+	case objects.Function:
+		return ImportPhaseRpc
+	case *generator.GenerateStorageInput:
+		return ImportPhaseStorages
+	case objects.Trigger:
+		return ImportPhaseTriggers
+	case objects.Publication:
+		return ImportPhasePublications
+	case string:
+		// controller is tracked by schema qualified table name
+		return ImportPhaseControllers
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package resource
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportDecorateFunc_Hook(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "editor.go")
+	input := generator.GenerateInput{
+		BindData:     map[string]string{"Name": "editor"},
+		Template:     "package {{ .Name }}\n",
+		TemplateName: "testTemplate",
+		OutputPath:   outputPath,
+	}
+	roles := []objects.Role{{Name: "editor"}}
+	findFunc := func(item objects.Role, input generator.GenerateInput) bool { return true }
+
+	var events []ImportHookEvent
+	hook := ImportHookFunc(func(event ImportHookEvent) error {
+		events = append(events, event)
+		return nil
+	})
+
+	stateChan := make(chan any, 10)
+	generateFn := ImportDecorateFunc(roles, findFunc, stateChan, false, ImportGenerateModeChanged, nil, nil, hook)
+	assert.NoError(t, generateFn(input, nil))
+	assert.Equal(t, []ImportHookEvent{{Kind: ImportPhaseRoles, Name: "editor", Path: outputPath}}, events)
+
+	// unchanged file is not written, so hook is not called
+	assert.NoError(t, generateFn(input, nil))
+	assert.Len(t, events, 1)
+
+	// hook error is returned as generate error
+	hookErr := errors.New("registry is unavailable")
+	generateFn = ImportDecorateFunc(roles, findFunc, stateChan, false, ImportGenerateModeForce, nil, nil, ImportHookFunc(func(event ImportHookEvent) error {
+		return hookErr
+	}))
+	err := generateFn(input, nil)
+	assert.ErrorIs(t, err, hookErr)
+	assert.EqualError(t, err, "import hook of roles editor : registry is unavailable")
+
+	// default hook do nothing
+	generateFn = ImportDecorateFunc(roles, findFunc, stateChan, false, ImportGenerateModeForce, nil, nil, nil)
+	assert.NoError(t, generateFn(input, nil))
+}
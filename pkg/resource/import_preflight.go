@@ -0,0 +1,66 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase"
+)
+
+// preflightImport check connection and privilege of configured credential on imported schema before
+// any resource is loaded, so import doesn't fail after part of resource is fetched or generated
+func preflightImport(flags *Flags, config *raiden.Config) error {
+	if len(config.ImportSources) == 0 {
+		return preflightImportSource("", config, splitAllowedSchema(flags.AllowedSchema))
+	}
+
+	for _, s := range config.ImportSources {
+		schemas := s.Schemas
+		if len(schemas) == 0 {
+			schemas = splitAllowedSchema(flags.AllowedSchema)
+		}
+
+		if err := preflightImportSource(s.Name, config.SourceConfig(s), schemas); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func preflightImportSource(source string, config *raiden.Config, schemas []string) error {
+	target := "supabase"
+	if source != "" {
+		target = "import source " + source
+	}
+
+	ImportLogger.Debug("check connection and schema privilege", "target", target, "schemas", schemas)
+	privileges, err := supabase.GetSchemaPrivileges(config, schemas)
+	if err != nil {
+		return fmt.Errorf("can't connect to %s, check api url and access token : %w", target, err)
+	}
+
+	var inaccessible []string
+	for _, p := range privileges {
+		switch {
+		case !p.Exist:
+			inaccessible = append(inaccessible, p.Name+" (not exist)")
+		case !p.Usage:
+			inaccessible = append(inaccessible, p.Name+" (permission denied)")
+		}
+	}
+
+	if len(inaccessible) > 0 {
+		return fmt.Errorf("credential of %s can't read schema %s", target, strings.Join(inaccessible, ", "))
+	}
+	return nil
+}
+
+func splitAllowedSchema(allowedSchema string) (schemas []string) {
+	for _, s := range strings.Split(allowedSchema, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			schemas = append(schemas, s)
+		}
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package resource
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreflightImport(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		query = body.Query
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"name": "public", "exist": true, "usage": true},
+			{"name": "analytics", "exist": true, "usage": false},
+			{"name": "legacy", "exist": false, "usage": false}
+		]`))
+	}))
+	defer server.Close()
+
+	config := &raiden.Config{
+		DeploymentTarget: raiden.DeploymentTargetSelfHosted,
+		SupabaseApiUrl:   server.URL,
+	}
+
+	err := preflightImport(&Flags{AllowedSchema: "public, analytics,legacy"}, config)
+	assert.EqualError(t, err, "credential of supabase can't read schema analytics (permission denied), legacy (not exist)")
+	assert.Contains(t, query, "ARRAY['public', 'analytics', 'legacy']::text[]")
+
+	// schema of import source is checked with the source credential
+	config.ImportSources = []raiden.ImportSource{{Name: "warehouse", Schemas: []string{"analytics"}}}
+	err = preflightImport(&Flags{}, config)
+	assert.ErrorContains(t, err, "credential of import source warehouse can't read schema analytics (permission denied)")
+	assert.Contains(t, query, "ARRAY['analytics']::text[]")
+}
+
+func TestPreflightImport_ConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	config := &raiden.Config{
+		DeploymentTarget: raiden.DeploymentTargetSelfHosted,
+		SupabaseApiUrl:   server.URL,
+	}
+
+	err := preflightImport(&Flags{AllowedSchema: "public"}, config)
+	assert.ErrorContains(t, err, "can't connect to supabase, check api url and access token")
+}
@@ -0,0 +1,193 @@
+package resource
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// ImportJsonReportVersion is version of import json report schema,
+// version is increased when existing field is changed or removed
+const ImportJsonReportVersion = 1
+
+// ImportJsonReport is machine readable result of import, written by --report flag
+type ImportJsonReport struct {
+	Version int                    `json:"version"`
+	DryRun  bool                   `json:"dry_run"`
+	Items   []ImportJsonReportItem `json:"items"`
+
+	projectPath string
+	mapItem     map[string]*ImportJsonReportItem
+}
+
+// ImportJsonReportItem is single generated file or skipped resource, kind is file
+// for generated file that doesn't belong to imported resource (e.g register file)
+type ImportJsonReportItem struct {
+	Kind     string             `json:"kind"`
+	Schema   string             `json:"schema,omitempty"`
+	Name     string             `json:"name"`
+	Path     string             `json:"path,omitempty"`
+	Action   ImportDryRunAction `json:"action"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
+
+// ImportWrittenFile is sent to state channel when generated file is written
+type ImportWrittenFile struct {
+	Path   string
+	Action ImportDryRunAction
+}
+
+// NewImportJsonReport create report with resource that skipped before generate,
+// path of generated file is reported relative to project path
+func NewImportJsonReport(projectPath string, dryRun bool, skipped []SkippedResource) *ImportJsonReport {
+	report := &ImportJsonReport{
+		Version:     ImportJsonReportVersion,
+		DryRun:      dryRun,
+		Items:       []ImportJsonReportItem{},
+		projectPath: projectPath,
+		mapItem:     make(map[string]*ImportJsonReportItem),
+	}
+
+	for _, s := range skipped {
+		item := ImportJsonReportItem{Kind: s.Type, Schema: s.Schema, Name: s.Name, Action: ImportDryRunActionSkip}
+		if s.Err != nil {
+			item.Warnings = append(item.Warnings, s.Err.Error())
+		}
+		report.Items = append(report.Items, item)
+	}
+	return report
+}
+
+// add fill report item of generated file from item that sent by import generate function,
+// single file receive file action first and followed by imported resource
+func (r *ImportJsonReport) add(rs any) {
+	switch item := rs.(type) {
+	case ImportDryRunItem:
+		r.getItem(item.Path).Action = item.Action
+	case ImportWrittenFile:
+		r.getItem(item.Path).Action = item.Action
+	case ImportSkippedFile:
+		reportItem := r.getItem(item.Path)
+		reportItem.Action = ImportDryRunActionSkip
+		switch item.Reason {
+		case ImportSkipReasonExist:
+			reportItem.Warnings = append(reportItem.Warnings, "file already exist and not overwritten")
+		case ImportSkipReasonConflict:
+			reportItem.Warnings = append(reportItem.Warnings, "file is modified locally and not overwritten")
+		}
+	case map[string]any:
+		input, isGenInput := item["input"].(generator.GenerateInput)
+		if !isGenInput {
+			return
+		}
+
+		reportItem := r.getItem(input.OutputPath)
+		switch parseItem := item["item"].(type) {
+		case *generator.GenerateModelInput:
+			reportItem.Kind, reportItem.Schema, reportItem.Name = "table", parseItem.Table.Schema, parseItem.Table.Name
+			switch {
+			case parseItem.Table.IsView:
+				reportItem.Kind = "view"
+			case parseItem.Table.IsForeign:
+				reportItem.Kind = "foreign_table"
+			}
+
+			if !parseItem.Table.IsForeign && generator.IsReadOnlyTable(parseItem.Table) {
+				reportItem.Warnings = append(reportItem.Warnings, "table doesn't have primary key, model is generated as read only")
+			}
+		case objects.Type:
+			reportItem.Kind, reportItem.Schema, reportItem.Name = "enum", parseItem.Schema, parseItem.Name
+			switch input.BindData.(type) {
+			case generator.GenerateDomainData:
+				reportItem.Kind = "domain"
+			case generator.GenerateCompositeData:
+				reportItem.Kind = "composite"
+			}
+		case objects.Role:
+			reportItem.Kind, reportItem.Name = "role", parseItem.Name
+		case objects.Function:
+			reportItem.Kind, reportItem.Schema, reportItem.Name = "rpc", parseItem.Schema, parseItem.Name
+		case *generator.GenerateStorageInput:
+			reportItem.Kind, reportItem.Name = "storage", parseItem.Bucket.Name
+		case objects.Trigger:
+			reportItem.Kind, reportItem.Schema, reportItem.Name = "trigger", parseItem.Schema, parseItem.Name
+		case objects.Publication:
+			reportItem.Kind, reportItem.Name = "publication", parseItem.Name
+		case string:
+			if data, isController := input.BindData.(generator.GenerateTableControllerData); isController {
+				reportItem.Kind, reportItem.Schema, reportItem.Name = "controller", data.Schema, data.Name
+			}
+		}
+	}
+}
+
+func (r *ImportJsonReport) getItem(path string) *ImportJsonReportItem {
+	if item, exist := r.mapItem[path]; exist {
+		return item
+	}
+
+	reportPath := path
+	if rel, err := filepath.Rel(r.projectPath, path); err == nil && r.projectPath != "" {
+		reportPath = filepath.ToSlash(rel)
+	}
+
+	item := &ImportJsonReportItem{Kind: "file", Name: filepath.Base(path), Path: reportPath}
+	r.mapItem[path] = item
+	return item
+}
+
+// finish move generated file to report items and sort items for stable output
+func (r *ImportJsonReport) finish() {
+	for _, item := range r.mapItem {
+		r.Items = append(r.Items, *item)
+	}
+	r.mapItem = make(map[string]*ImportJsonReportItem)
+
+	sort.SliceStable(r.Items, func(i, j int) bool {
+		a, b := r.Items[i], r.Items[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Schema != b.Schema {
+			return a.Schema < b.Schema
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Path < b.Path
+	})
+}
+
+// ListenImportJsonReport add every item in stateChan to report and forward the item to returned
+// channel, returned channel is closed after stateChan is closed so report can be written after that
+func ListenImportJsonReport(report *ImportJsonReport, stateChan chan any) chan any {
+	forwardChan := make(chan any)
+	go func() {
+		defer close(forwardChan)
+		for rs := range stateChan {
+			report.add(rs)
+			forwardChan <- rs
+		}
+		report.finish()
+	}()
+	return forwardChan
+}
+
+// WriteImportJsonReport write report as indented json to file path
+func WriteImportJsonReport(filePath string, report *ImportJsonReport) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(filePath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filePath, append(content, '\n'), 0644)
+}
@@ -0,0 +1,63 @@
+package resource
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListenImportJsonReport(t *testing.T) {
+	projectPath := t.TempDir()
+	skipped := []SkippedResource{{Type: "function", Schema: "public", Name: "get_point", Err: errors.New("unsupported return type point")}}
+	report := NewImportJsonReport(projectPath, false, skipped)
+
+	stateChan := make(chan any)
+	forwardChan := ListenImportJsonReport(report, stateChan)
+
+	modelPath := filepath.Join(projectPath, "internal", "models", "logs.go")
+	rolePath := filepath.Join(projectPath, "internal", "roles", "editor.go")
+	registerPath := filepath.Join(projectPath, "internal", "bootstrap", "models.go")
+	go func() {
+		stateChan <- ImportWrittenFile{Path: modelPath, Action: ImportDryRunActionCreate}
+		stateChan <- map[string]any{
+			"item":  &generator.GenerateModelInput{Table: objects.Table{Schema: "public", Name: "logs"}},
+			"input": generator.GenerateInput{OutputPath: modelPath},
+		}
+		stateChan <- ImportSkippedFile{Path: rolePath, Reason: ImportSkipReasonExist}
+		stateChan <- map[string]any{
+			"item":  objects.Role{Name: "editor"},
+			"input": generator.GenerateInput{OutputPath: rolePath},
+		}
+		stateChan <- ImportWrittenFile{Path: registerPath, Action: ImportDryRunActionUpdate}
+		close(stateChan)
+	}()
+
+	var forwarded int
+	for range forwardChan {
+		forwarded++
+	}
+	assert.Equal(t, 5, forwarded)
+
+	reportPath := filepath.Join(projectPath, "build", "report.json")
+	assert.NoError(t, WriteImportJsonReport(reportPath, report))
+
+	content, err := os.ReadFile(reportPath)
+	assert.NoError(t, err)
+
+	var written ImportJsonReport
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, ImportJsonReportVersion, written.Version)
+	assert.False(t, written.DryRun)
+	assert.Equal(t, []ImportJsonReportItem{
+		{Kind: "file", Name: "models.go", Path: "internal/bootstrap/models.go", Action: ImportDryRunActionUpdate},
+		{Kind: "function", Schema: "public", Name: "get_point", Action: ImportDryRunActionSkip, Warnings: []string{"unsupported return type point"}},
+		{Kind: "role", Name: "editor", Path: "internal/roles/editor.go", Action: ImportDryRunActionSkip, Warnings: []string{"file already exist and not overwritten"}},
+		{Kind: "table", Schema: "public", Name: "logs", Path: "internal/models/logs.go", Action: ImportDryRunActionCreate, Warnings: []string{"table doesn't have primary key, model is generated as read only"}},
+	}, written.Items)
+}
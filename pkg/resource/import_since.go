@@ -0,0 +1,123 @@
+package resource
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// importGenerateResource is resource that generated by import, unchanged resource is removed in since mode
+type importGenerateResource struct {
+	Roles        []objects.Role
+	Functions    []objects.Function
+	Types        []objects.Type
+	Domains      []objects.Type
+	Composites   []objects.Type
+	Triggers     []objects.Trigger
+	Publications []objects.Publication
+	Storages     []*generator.GenerateStorageInput
+}
+
+// filterChangedSince remove resource that not changed after since from generated resource. Pg-meta
+// doesn't expose modification time, so only bucket is compared with its modification time and the
+// other resource is compared with definition hash of previous state. Previous state of unchanged
+// resource is kept in import state, resource that not exist in previous state is always generated
+func filterChangedSince(rs *importGenerateResource, previousState *state.State, since time.Time, importState *state.LocalState) (unchanged int) {
+	if previousState == nil {
+		return 0
+	}
+
+	var total int
+	rs.Roles, total = filterUnchangedResource(rs.Roles, previousState.Roles,
+		func(r objects.Role) string { return r.Name },
+		func(s state.RoleState) string { return s.Role.Name },
+		func(r objects.Role, s state.RoleState) bool { return s.DefinitionHash == state.HashRoleDefinition(r) },
+		importState.AddRole,
+	)
+	unchanged += total
+
+	rs.Functions, total = filterUnchangedResource(rs.Functions, previousState.Rpc,
+		func(f objects.Function) string { return fmt.Sprintf("%s.%s", f.Schema, f.Name) },
+		func(s state.RpcState) string { return fmt.Sprintf("%s.%s", s.Function.Schema, s.Function.Name) },
+		func(f objects.Function, s state.RpcState) bool {
+			return s.DefinitionHash == state.HashFunctionDefinition(f)
+		},
+		importState.AddRpc,
+	)
+	unchanged += total
+
+	typeKey := func(t objects.Type) string { return fmt.Sprintf("%s.%s", t.Schema, t.Name) }
+	isTypeUnchanged := func(t objects.Type, s state.TypeState) bool { return s.DefinitionHash == state.HashTypeDefinition(t) }
+	rs.Types, total = filterUnchangedResource(rs.Types, previousState.Types, typeKey,
+		func(s state.TypeState) string { return typeKey(s.Type) }, isTypeUnchanged, importState.AddType,
+	)
+	unchanged += total
+
+	rs.Domains, total = filterUnchangedResource(rs.Domains, previousState.Types, typeKey,
+		func(s state.TypeState) string { return typeKey(s.Type) }, isTypeUnchanged, importState.AddType,
+	)
+	unchanged += total
+
+	rs.Composites, total = filterUnchangedResource(rs.Composites, previousState.Types, typeKey,
+		func(s state.TypeState) string { return typeKey(s.Type) }, isTypeUnchanged, importState.AddType,
+	)
+	unchanged += total
+
+	triggerKey := func(t objects.Trigger) string { return fmt.Sprintf("%s.%s.%s", t.Schema, t.Table, t.Name) }
+	rs.Triggers, total = filterUnchangedResource(rs.Triggers, previousState.Triggers, triggerKey,
+		func(s state.TriggerState) string { return triggerKey(s.Trigger) },
+		func(t objects.Trigger, s state.TriggerState) bool {
+			return s.DefinitionHash == state.HashTriggerDefinition(t)
+		},
+		importState.AddTrigger,
+	)
+	unchanged += total
+
+	rs.Publications, total = filterUnchangedResource(rs.Publications, previousState.Publications,
+		func(p objects.Publication) string { return p.Name },
+		func(s state.PublicationState) string { return s.Publication.Name },
+		func(p objects.Publication, s state.PublicationState) bool {
+			return s.DefinitionHash == state.HashPublicationDefinition(p)
+		},
+		importState.AddPublication,
+	)
+	unchanged += total
+
+	// bucket policy doesn't change bucket modification time, policy is compared with hash
+	rs.Storages, total = filterUnchangedResource(rs.Storages, previousState.Storage,
+		func(i *generator.GenerateStorageInput) string { return i.Bucket.Name },
+		func(s state.StorageState) string { return s.Storage.Name },
+		func(i *generator.GenerateStorageInput, s state.StorageState) bool {
+			if i.Bucket.UpdatedAt != nil && i.Bucket.UpdatedAt.After(since) {
+				return false
+			}
+			return s.DefinitionHash == state.HashStorageDefinition(i.Bucket, i.Policies)
+		},
+		importState.AddStorage,
+	)
+	unchanged += total
+
+	return unchanged
+}
+
+// filterUnchangedResource return changed item and total of unchanged item,
+// state of unchanged item is passed to keepState
+func filterUnchangedResource[T any, S any](items []T, states []S, itemKey func(T) string, stateKey func(S) string, isUnchanged func(T, S) bool, keepState func(S)) (changed []T, unchanged int) {
+	mapState := make(map[string]S, len(states))
+	for _, s := range states {
+		mapState[stateKey(s)] = s
+	}
+
+	for _, item := range items {
+		if s, exist := mapState[itemKey(item)]; exist && isUnchanged(item, s) {
+			keepState(s)
+			unchanged++
+			continue
+		}
+		changed = append(changed, item)
+	}
+	return
+}
@@ -0,0 +1,71 @@
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterChangedSince(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before, after := since.Add(-time.Hour), since.Add(time.Hour)
+
+	editor, viewer := objects.Role{Name: "editor"}, objects.Role{Name: "viewer", CanLogin: true}
+	getOrders := objects.Function{Schema: "public", Name: "get_orders", Definition: "select 1"}
+	avatars := objects.Bucket{Name: "avatars", UpdatedAt: &before}
+	invoices := objects.Bucket{Name: "invoices", UpdatedAt: &after}
+
+	previousState := &state.State{
+		Roles: []state.RoleState{
+			{Role: editor, DefinitionHash: state.HashRoleDefinition(editor)},
+			{Role: objects.Role{Name: "viewer"}, DefinitionHash: state.HashRoleDefinition(objects.Role{Name: "viewer"})},
+		},
+		// state without definition hash is compared as changed
+		Rpc: []state.RpcState{{Function: getOrders}},
+		Storage: []state.StorageState{
+			{Storage: avatars, DefinitionHash: state.HashStorageDefinition(avatars, nil)},
+			{Storage: invoices, DefinitionHash: state.HashStorageDefinition(invoices, nil)},
+		},
+	}
+
+	generated := importGenerateResource{
+		Roles:     []objects.Role{editor, viewer, {Name: "auditor"}},
+		Functions: []objects.Function{getOrders},
+		Storages:  []*generator.GenerateStorageInput{{Bucket: avatars}, {Bucket: invoices}},
+	}
+	importState := &state.LocalState{}
+	unchanged := filterChangedSince(&generated, previousState, since, importState)
+
+	assert.Equal(t, 2, unchanged)
+	assert.Equal(t, []objects.Role{viewer, {Name: "auditor"}}, generated.Roles)
+	assert.Equal(t, []objects.Function{getOrders}, generated.Functions)
+
+	// bucket modified after since is generated even when definition is unchanged
+	assert.Equal(t, []*generator.GenerateStorageInput{{Bucket: invoices}}, generated.Storages)
+
+	// state of unchanged resource is kept
+	assert.Equal(t, []state.RoleState{previousState.Roles[0]}, importState.State.Roles)
+	assert.Equal(t, []state.StorageState{previousState.Storage[0]}, importState.State.Storage)
+
+	// every resource is generated without previous state
+	generated = importGenerateResource{Roles: []objects.Role{editor}}
+	assert.Zero(t, filterChangedSince(&generated, nil, since, importState))
+	assert.Equal(t, []objects.Role{editor}, generated.Roles)
+}
+
+func TestFlags_SinceTime(t *testing.T) {
+	since, err := (&Flags{Since: "2024-01-01"}).SinceTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), since)
+
+	since, err = (&Flags{Since: "2024-01-01T10:00:00+07:00"}).SinceTime()
+	assert.NoError(t, err)
+	assert.True(t, since.Equal(time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)))
+
+	_, err = (&Flags{Since: "yesterday"}).SinceTime()
+	assert.EqualError(t, err, `invalid --since value "yesterday", value must be date (2006-01-02) or RFC3339 time`)
+}
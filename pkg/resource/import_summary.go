@@ -0,0 +1,85 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// ImportSummary is total of resource that generated by import, resource is still counted
+// when the file is not rewritten because the content is unchanged. Skipped contain
+// resource that can't be imported and file that not written because already exist.
+type ImportSummary struct {
+	Tables       int
+	Roles        int
+	Functions    int
+	Storages     int
+	Relations    int
+	Types        int
+	Triggers     int
+	Publications int
+	Skipped      []string
+
+	// import category that failed by timeout, see ImportCategoryTimeoutError
+	Failed []string
+}
+
+// add count generated item that sent by import generate function
+func (s *ImportSummary) add(rs any) {
+	switch item := rs.(type) {
+	case ImportSkippedFile:
+		if item.Reason != ImportSkipReasonUnchanged {
+			s.Skipped = append(s.Skipped, fmt.Sprintf("%s (%s)", item.Path, item.Reason))
+		}
+	case map[string]any:
+		switch parseItem := item["item"].(type) {
+		case *generator.GenerateModelInput:
+			s.Tables++
+			s.Relations += len(parseItem.Relations)
+		case objects.Role:
+			s.Roles++
+		case objects.Function:
+			s.Functions++
+		case *generator.GenerateStorageInput:
+			s.Storages++
+		case objects.Type:
+			s.Types++
+		case objects.Trigger:
+			s.Triggers++
+		case objects.Publication:
+			s.Publications++
+		}
+	}
+}
+
+// ListenImportSummary count every item in stateChan and forward the item to returned channel,
+// returned channel is closed after stateChan is closed so summary can be read after that
+func ListenImportSummary(summary *ImportSummary, stateChan chan any) chan any {
+	forwardChan := make(chan any)
+	go func() {
+		defer close(forwardChan)
+		for rs := range stateChan {
+			summary.add(rs)
+			forwardChan <- rs
+		}
+	}()
+	return forwardChan
+}
+
+func PrintImportSummary(summary ImportSummary) {
+	ImportLogger.Info(
+		"import summary",
+		"Table", summary.Tables, "Relation", summary.Relations, "Role", summary.Roles, "Rpc", summary.Functions,
+		"Storage", summary.Storages, "Type", summary.Types, "Trigger", summary.Triggers,
+		"Publication", summary.Publications, "Skipped", len(summary.Skipped),
+	)
+
+	for _, s := range summary.Skipped {
+		ImportLogger.Warn("skipped import", "resource", s)
+	}
+
+	for _, c := range summary.Failed {
+		ImportLogger.Warn("failed import, previous state of category is kept", "category", c)
+	}
+}
@@ -0,0 +1,80 @@
+package resource
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTableFixture(t *testing.T, name string) objects.Table {
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	var table objects.Table
+	require.NoError(t, json.Unmarshal(content, &table))
+	return table
+}
+
+func generateSupabaseModel(t *testing.T, config *raiden.Config, table objects.Table) string {
+	projectPath := t.TempDir()
+	inputs, err := buildImportModelInputs(config, &Flags{ProjectPath: projectPath}, &Resource{Tables: []objects.Table{table}})
+	require.NoError(t, err)
+	require.Len(t, inputs, 1)
+
+	dir := t.TempDir()
+	var outputPath string
+	err = generator.GenerateModel(dir, inputs[0], func(input generator.GenerateInput, writer io.Writer) error {
+		outputPath = input.OutputPath
+		return generator.Generate(input, nil)
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestBuildImportModelInputs_SupabaseAuthUsers(t *testing.T) {
+	table := loadTableFixture(t, "auth_users.json")
+	config := &raiden.Config{ProjectName: "test", UuidType: string(generator.UuidTypeUuid)}
+
+	result := generateSupabaseModel(t, config, table)
+	fields := strings.Join(strings.Fields(result), " ")
+	assert.Contains(t, fields, "Id uuid.UUID `json:\"id,omitempty\"")
+	assert.Contains(t, fields, "InstanceId *uuid.UUID `json:\"instance_id,omitempty\"")
+	assert.Contains(t, fields, "RawAppMetaData *raiden.AuthAppMetadata `json:\"raw_app_meta_data,omitempty\"")
+	assert.Contains(t, fields, "RawUserMetaData *raiden.AuthUserMetadata `json:\"raw_user_meta_data,omitempty\"")
+	assert.Contains(t, fields, "IsSuperAdmin *bool `json:\"is_super_admin,omitempty\"")
+	assert.Contains(t, fields, "ConfirmedAt *time.Time `json:\"confirmed_at,omitempty\" column:\"name:confirmed_at;type:timestampz;readOnly")
+	assert.Contains(t, fields, "Email *string `json:\"email,omitempty\"")
+}
+
+func TestBuildImportModelInputs_SupabaseStorageObjects(t *testing.T) {
+	table := loadTableFixture(t, "storage_objects.json")
+
+	t.Run("supabase type", func(t *testing.T) {
+		result := generateSupabaseModel(t, &raiden.Config{ProjectName: "test"}, table)
+		fields := strings.Join(strings.Fields(result), " ")
+		assert.Contains(t, fields, "Metadata *raiden.StorageObjectMetadata `json:\"metadata,omitempty\"")
+	})
+
+	t.Run("config override supabase type", func(t *testing.T) {
+		config := &raiden.Config{
+			ProjectName:     "test",
+			JsonColumnTypes: []raiden.JsonColumnType{{Column: "storage.objects.metadata", Type: "map[string]any"}},
+		}
+		result := generateSupabaseModel(t, config, table)
+		fields := strings.Join(strings.Fields(result), " ")
+		assert.Contains(t, fields, "Metadata *map[string]any `json:\"metadata,omitempty\"")
+		assert.NotContains(t, fields, "raiden.StorageObjectMetadata")
+	})
+}
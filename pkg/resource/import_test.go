@@ -0,0 +1,261 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateImportResource_NoGoroutineLeakOnError(t *testing.T) {
+	// project folder is not exist, so create models and roles folder is failed
+	flags := &Flags{
+		ProjectPath: filepath.Join(t.TempDir(), "not-exist"),
+		DryRun:      true,
+	}
+	resource := &Resource{
+		Tables: []objects.Table{{ID: 1, Name: "orders", Schema: "public"}},
+		Roles:  []objects.Role{{ID: 1, Name: "staff"}},
+	}
+
+	// wait background goroutine started by dependency package init
+	time.Sleep(50 * time.Millisecond)
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 10; i++ {
+		_, _, err := generateImportResource(context.Background(), &raiden.Config{}, &state.LocalState{}, flags, resource, nil, nil)
+		assert.ErrorContains(t, err, "models")
+		assert.ErrorContains(t, err, "roles")
+	}
+
+	// give exited goroutine time to be cleaned up by runtime
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
+
+func TestGenerateImportResource_Cancelled(t *testing.T) {
+	// state is persisted to build folder in current directory
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	projectPath := t.TempDir()
+	flags := &Flags{ProjectPath: projectPath}
+	resource := &Resource{
+		Tables: []objects.Table{{ID: 1, Name: "orders", Schema: "public"}},
+		Roles:  []objects.Role{{ID: 1, Name: "staff"}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = generateImportResource(ctx, &raiden.Config{}, &state.LocalState{}, flags, resource, nil, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoFileExists(t, filepath.Join(projectPath, "internal", "models", "orders.go"))
+	assert.NoFileExists(t, filepath.Join(projectPath, "internal", "roles", "staff.go"))
+}
+
+func TestGenerateImportResource_Summary(t *testing.T) {
+	projectPath := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(projectPath, "internal"), 0755))
+
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "items_order_id_fkey", SourceSchema: "public", SourceTableName: "items", SourceColumnName: "order_id", TargetTableSchema: "public", TargetTableName: "orders", TargetColumnName: "id"},
+	}
+
+	flags := &Flags{ProjectPath: projectPath, DryRun: true}
+	resource := &Resource{
+		Tables: []objects.Table{
+			{ID: 1, Name: "orders", Schema: "public", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships},
+			{ID: 2, Name: "items", Schema: "public", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships},
+		},
+		Roles:   []objects.Role{{ID: 1, Name: "staff"}},
+		Skipped: []SkippedResource{{Type: "function", Schema: "public", Name: "broken_fn"}},
+	}
+
+	summary, dryRunReport, err := generateImportResource(context.Background(), &raiden.Config{}, &state.LocalState{}, flags, resource, nil, nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, dryRunReport.Items)
+	assert.Equal(t, 2, summary.Tables)
+	assert.Equal(t, 2, summary.Relations)
+	assert.Equal(t, 1, summary.Roles)
+	assert.Equal(t, 0, summary.Functions)
+	assert.Equal(t, []string{"function public.broken_fn"}, summary.Skipped)
+}
+
+func TestUpdateLocalStateFromImport_ConcurrentProducer(t *testing.T) {
+	// state is persisted to build folder in current directory
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	localState := state.LocalState{}
+	stateChan := make(chan any)
+	done := UpdateLocalStateFromImport(&localState, stateChan)
+
+	producer, total := 10, 50
+	wg := sync.WaitGroup{}
+	for p := 0; p < producer; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < total; i++ {
+				name := fmt.Sprintf("item_%d_%d", p, i)
+				input := generator.GenerateInput{OutputPath: name + ".go"}
+
+				// table state of unchanged table is added directly by generate goroutine
+				if i%2 == 0 {
+					localState.AddTable(state.TableState{Table: objects.Table{Name: name}})
+				} else {
+					stateChan <- map[string]any{"item": &generator.GenerateModelInput{Table: objects.Table{Name: name}}, "input": input}
+				}
+				stateChan <- map[string]any{"item": objects.Role{Name: name}, "input": input}
+				stateChan <- ImportSkippedFile{Path: input.OutputPath, Reason: ImportSkipReasonUnchanged}
+			}
+		}(p)
+	}
+
+	wg.Wait()
+	close(stateChan)
+	assert.NoError(t, <-done)
+
+	assert.Equal(t, producer*total, len(localState.State.Tables))
+	assert.Equal(t, producer*total, len(localState.State.Roles))
+	assert.False(t, localState.NeedUpdate)
+
+	mapName := make(map[string]bool)
+	for _, ts := range localState.State.Tables {
+		mapName[ts.Table.Name] = true
+	}
+	assert.Equal(t, producer*total, len(mapName))
+}
+
+func TestValidateTablePrimaryKey(t *testing.T) {
+	importTables := []objects.Table{
+		{ID: 1, Name: "orders", Schema: "public", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}},
+		{ID: 2, Name: "audit_logs", Schema: "public"},
+		{ID: 3, Name: "order_summary", Schema: "public", IsView: true},
+	}
+
+	assert.NoError(t, validateTablePrimaryKey(&raiden.Config{}, importTables))
+
+	err := validateTablePrimaryKey(&raiden.Config{RequirePrimaryKey: true}, importTables)
+	assert.EqualError(t, err, "table doesn't have primary key : public.audit_logs")
+}
+
+func TestImportGenerate_NoClobber(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "orders.go")
+	input := generator.GenerateInput{
+		BindData:     map[string]string{"Name": "orders"},
+		Template:     "package {{ .Name }}\n",
+		TemplateName: "testTemplate",
+		OutputPath:   outputPath,
+	}
+
+	reason, err := ImportGenerate(input, ImportGenerateModeNoClobber)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.FileExists(t, outputPath)
+
+	assert.NoError(t, os.WriteFile(outputPath, []byte("custom content"), 0644))
+	reason, err = ImportGenerate(input, ImportGenerateModeNoClobber)
+	assert.NoError(t, err)
+	assert.Equal(t, ImportSkipReasonExist, reason)
+
+	content, err := os.ReadFile(outputPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "custom content", string(content))
+
+	// skipped file is reported to state channel and resource is still kept in state
+	stateChan := make(chan any, 2)
+	generateFn := ImportDecorateFunc([]string{"orders"}, func(item string, input generator.GenerateInput) bool {
+		return true
+	}, stateChan, false, ImportGenerateModeNoClobber, nil, nil, nil)
+	assert.NoError(t, generateFn(input, nil))
+	close(stateChan)
+
+	var skipped []ImportSkippedFile
+	var items int
+	for rs := range stateChan {
+		switch v := rs.(type) {
+		case ImportSkippedFile:
+			skipped = append(skipped, v)
+		case map[string]any:
+			items++
+		}
+	}
+	assert.Equal(t, []ImportSkippedFile{{Path: outputPath, Reason: ImportSkipReasonExist}}, skipped)
+	assert.Equal(t, 1, items)
+}
+
+func TestImportGenerate_Changed(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "orders.go")
+	input := generator.GenerateInput{
+		BindData:     map[string]string{"Name": "orders"},
+		Template:     "package {{ .Name }}\n",
+		TemplateName: "testTemplate",
+		OutputPath:   outputPath,
+	}
+
+	assert.NoError(t, os.WriteFile(outputPath, []byte("package orders\n"), 0644))
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	assert.NoError(t, os.Chtimes(outputPath, modTime, modTime))
+
+	// identical content is not rewritten and modification time is kept
+	reason, err := ImportGenerate(input, ImportGenerateModeChanged)
+	assert.NoError(t, err)
+	assert.Equal(t, ImportSkipReasonUnchanged, reason)
+
+	info, err := os.Stat(outputPath)
+	assert.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(modTime))
+
+	// force mode always rewrite the file
+	reason, err = ImportGenerate(input, ImportGenerateModeForce)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	info, err = os.Stat(outputPath)
+	assert.NoError(t, err)
+	assert.False(t, info.ModTime().Equal(modTime))
+
+	// changed content is written
+	assert.NoError(t, os.WriteFile(outputPath, []byte("package old\n"), 0644))
+	reason, err = ImportGenerate(input, ImportGenerateModeChanged)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	content, err := os.ReadFile(outputPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "package orders\n", string(content))
+}
+
+func TestImportPhaseLogArgs(t *testing.T) {
+	types := []objects.Type{
+		{Name: "order_state", Schema: "sales"},
+		{Name: "user_role", Schema: "public"},
+		{Name: "item_state", Schema: "sales"},
+	}
+
+	args := importPhaseLogArgs(ImportPhaseTypes, types, func(t objects.Type) string { return t.Schema })
+	assert.Equal(t, []any{"phase", ImportPhaseTypes, "count", 3, "schema", "public,sales"}, args)
+
+	roles := []objects.Role{{Name: "staff"}}
+	args = importPhaseLogArgs(ImportPhaseRoles, roles, nil)
+	assert.Equal(t, []any{"phase", ImportPhaseRoles, "count", 1}, args)
+}
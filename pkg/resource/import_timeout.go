@@ -0,0 +1,116 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/state"
+)
+
+// ImportCategory is group of resource that fetched and generated together,
+// category follow import flags (e.g --models-only and --rpc-only)
+type ImportCategory string
+
+const (
+	ImportCategoryModels   ImportCategory = "models"
+	ImportCategoryRoles    ImportCategory = "roles"
+	ImportCategoryRpc      ImportCategory = "rpc"
+	ImportCategoryStorages ImportCategory = "storages"
+)
+
+// ImportCategoryTimeoutError is returned when fetch or generate of import category
+// is not finished in configured timeout, other category is still imported
+type ImportCategoryTimeoutError struct {
+	Category ImportCategory
+	Timeout  time.Duration
+}
+
+func (e *ImportCategoryTimeoutError) Error() string {
+	return fmt.Sprintf("import %s is timeout after %s", e.Category, e.Timeout)
+}
+
+// getImportCategoryTimeout return timeout of single import category, zero mean no timeout
+func getImportCategoryTimeout(config *raiden.Config) time.Duration {
+	if config == nil || config.ImportTimeout <= 0 {
+		return 0
+	}
+	return time.Duration(config.ImportTimeout) * time.Second
+}
+
+// importCategoryContext create context that cancelled when category timeout is reached
+func importCategoryContext(ctx context.Context, config *raiden.Config) (context.Context, context.CancelFunc) {
+	timeout := getImportCategoryTimeout(config)
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// importCategoryError convert deadline error of category context to category timeout error,
+// error of cancelled import is returned as is
+func importCategoryError(ctx context.Context, config *raiden.Config, category ImportCategory, err error) error {
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &ImportCategoryTimeoutError{Category: category, Timeout: getImportCategoryTimeout(config)}
+	}
+	return err
+}
+
+// keepImportCategoryState copy state of failed category from previous state,
+// so generated file of the category is still tracked and not created again on apply
+func keepImportCategoryState(importState *state.LocalState, previousState *state.State, category ImportCategory) {
+	if previousState == nil {
+		return
+	}
+
+	switch category {
+	case ImportCategoryModels:
+		for _, t := range previousState.Tables {
+			if _, _, found := importState.FindTable(t.Table.ID); !found {
+				importState.AddTable(t)
+			}
+		}
+
+		mapType := make(map[int]bool)
+		for _, t := range importState.State.Types {
+			mapType[t.Type.ID] = true
+		}
+		for _, t := range previousState.Types {
+			if !mapType[t.Type.ID] {
+				importState.AddType(t)
+			}
+		}
+
+		for _, p := range previousState.Publications {
+			if _, _, found := importState.FindPublication(p.Publication.Key()); !found {
+				importState.AddPublication(p)
+			}
+		}
+	case ImportCategoryRoles:
+		for _, r := range previousState.Roles {
+			if _, _, found := importState.FindRole(r.Role.ID); !found {
+				importState.AddRole(r)
+			}
+		}
+	case ImportCategoryRpc:
+		for _, r := range previousState.Rpc {
+			if _, _, found := importState.FindRpc(r.Function.ID); !found {
+				importState.AddRpc(r)
+			}
+		}
+
+		for _, t := range previousState.Triggers {
+			if _, _, found := importState.FindTrigger(t.Trigger.Key()); !found {
+				importState.AddTrigger(t)
+			}
+		}
+	case ImportCategoryStorages:
+		for _, s := range previousState.Storage {
+			if _, _, found := importState.FindStorage(s.Storage.ID); !found {
+				importState.AddStorage(s)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_CategoryTimeout(t *testing.T) {
+	// functions endpoint is stalled until test is finished
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/functions") {
+			<-release
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := &raiden.Config{
+		DeploymentTarget: raiden.DeploymentTargetSelfHosted,
+		SupabaseApiUrl:   server.URL,
+		ImportTimeout:    1,
+	}
+
+	resource, err := Load(&Flags{RpcOnly: true}, config)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resource.Failed))
+
+	var timeoutErr *ImportCategoryTimeoutError
+	assert.ErrorAs(t, resource.Failed[ImportCategoryRpc], &timeoutErr)
+	assert.Equal(t, "import rpc is timeout after 1s", timeoutErr.Error())
+	assert.Nil(t, resource.Functions)
+	assert.Nil(t, resource.Triggers)
+}
+
+func TestGenerateImportResource_CategoryTimeout(t *testing.T) {
+	projectPath := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(projectPath, "internal"), 0755))
+
+	flags := &Flags{ProjectPath: projectPath, DryRun: true}
+	resource := &Resource{
+		Roles:  []objects.Role{{ID: 1, Name: "staff"}},
+		Failed: map[ImportCategory]error{ImportCategoryRpc: &ImportCategoryTimeoutError{Category: ImportCategoryRpc}},
+	}
+
+	// failed category doesn't fail import of the other category
+	summary, _, err := generateImportResource(context.Background(), &raiden.Config{}, &state.LocalState{}, flags, resource, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, summary.Roles)
+	assert.Equal(t, []string{"rpc"}, summary.Failed)
+}
+
+func TestKeepImportCategoryState(t *testing.T) {
+	previousState := &state.State{
+		Rpc: []state.RpcState{
+			{Function: objects.Function{ID: 1, Name: "get_orders"}},
+			{Function: objects.Function{ID: 2, Name: "get_users"}},
+		},
+		Roles: []state.RoleState{{Role: objects.Role{ID: 1, Name: "staff"}}},
+	}
+
+	importState := &state.LocalState{}
+	importState.AddRpc(state.RpcState{Function: objects.Function{ID: 2, Name: "get_users"}, RpcPath: "rpc/get_users.go"})
+	keepImportCategoryState(importState, previousState, ImportCategoryRpc)
+
+	assert.Equal(t, 2, len(importState.State.Rpc))
+	assert.Equal(t, "rpc/get_users.go", importState.State.Rpc[0].RpcPath)
+	assert.Equal(t, "get_orders", importState.State.Rpc[1].Function.Name)
+	assert.Equal(t, 0, len(importState.State.Roles))
+}
+
+func TestImportCategoryError(t *testing.T) {
+	config := &raiden.Config{ImportTimeout: 1}
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+
+	err := importCategoryError(ctx, config, ImportCategoryModels, ctx.Err())
+	assert.Equal(t, &ImportCategoryTimeoutError{Category: ImportCategoryModels, Timeout: getImportCategoryTimeout(config)}, err)
+
+	// cancelled import is not category timeout
+	cancelCtx, cancelFn := context.WithCancel(context.Background())
+	cancelFn()
+	assert.ErrorIs(t, importCategoryError(cancelCtx, config, ImportCategoryModels, cancelCtx.Err()), context.Canceled)
+}
+
+func TestLoadSupabaseResource_CancelAfterTimeout(t *testing.T) {
+	wg, outChan := sync.WaitGroup{}, make(chan any, 1)
+	cancelled := make(chan struct{})
+
+	wg.Add(1)
+	loadSupabaseResource(&wg, &raiden.Config{ImportTimeout: 1}, outChan, []ImportCategory{ImportCategoryRoles}, func(ctx context.Context, cfg *raiden.Config) ([]objects.Role, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	})
+	wg.Wait()
+
+	var timeoutErr *ImportCategoryTimeoutError
+	assert.ErrorAs(t, (<-outChan).(error), &timeoutErr)
+
+	// timed out callback is not leaked
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("callback context is not cancelled after timeout")
+	}
+}
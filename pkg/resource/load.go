@@ -1,30 +1,84 @@
 package resource
 
 import (
+	"context"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/logger"
 	"github.com/sev-2/raiden/pkg/postgres/roles"
-	"github.com/sev-2/raiden/pkg/resource/policies"
+	"github.com/sev-2/raiden/pkg/state"
 	"github.com/sev-2/raiden/pkg/supabase"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 )
 
 var LoadLogger hclog.Logger = logger.HcLog().Named("import.load")
 
-type Resource struct {
-	Tables    []objects.Table
-	Policies  objects.Policies
-	Roles     []objects.Role
+// viewResource wrap loaded view for distinguish it from table in load channel
+type viewResource []objects.Table
+
+// foreignTableResource wrap loaded foreign table for distinguish it from table in load channel
+type foreignTableResource []objects.Table
+
+// functionResource wrap loaded function and function that can`t be fetched
+type functionResource struct {
 	Functions []objects.Function
-	Storages  []objects.Bucket
+	Skipped   []SkippedResource
+}
+
+type Resource struct {
+	Tables       []objects.Table
+	Policies     objects.Policies
+	Roles        []objects.Role
+	Functions    []objects.Function
+	Storages     []objects.Bucket
+	Types        []objects.Type
+	Domains      []objects.Type
+	Composites   []objects.Type
+	Triggers     []objects.Trigger
+	Publications []objects.Publication
+
+	// rows of seed table, ordered so parent table is placed first
+	Seeds []SeedTableRows
+
+	// import source of table (schema.table), only set when loaded from import sources
+	TableSources map[string]string
+
+	// resource that can`t be processed, skipped resource doesn`t
+	// stop import of the other resource
+	Skipped []SkippedResource
+
+	// category that failed to load (e.g load timeout), resource of failed
+	// category is not imported and previous state of the category is kept
+	Failed map[ImportCategory]error
+
+	// cached relation from previous import, relation is rebuilt and cache
+	// is replaced when relation metadata of table is changed
+	RelationCache *state.RelationCache
+}
+
+// SkippedResource is single resource that skipped from import and the reason
+type SkippedResource struct {
+	Type   string
+	Schema string
+	Name   string
+	Err    error
 }
 
 // The Load function loads resources based on the provided flags and project ID, and returns a resource
 // objects or an error.
 func Load(flags *Flags, cfg *raiden.Config) (*Resource, error) {
+	if len(cfg.ImportSources) > 0 {
+		return loadImportSources(flags, cfg)
+	}
+	return loadSource(flags, cfg)
+}
+
+// loadSource load resource of single supabase project
+func loadSource(flags *Flags, cfg *raiden.Config) (*Resource, error) {
 	resource := &Resource{}
 	loadChan := loadResource(cfg, flags)
 
@@ -33,110 +87,246 @@ func Load(flags *Flags, cfg *raiden.Config) (*Resource, error) {
 	for result := range loadChan {
 		switch rs := result.(type) {
 		case []objects.Table:
-			resource.Tables = rs
+			resource.Tables = append(resource.Tables, rs...)
 			LoadLogger.Debug("Finish Get Table From Supabase")
+		case viewResource:
+			resource.Tables = append(resource.Tables, rs...)
+			LoadLogger.Debug("Finish Get View From Supabase")
+		case foreignTableResource:
+			resource.Tables = append(resource.Tables, rs...)
+			LoadLogger.Debug("Finish Get Foreign Table From Supabase")
 		case []objects.Role:
 			resource.Roles = rs
 			LoadLogger.Debug("Finish Get Role From Supabase")
 		case objects.Policies:
 			resource.Policies = rs
 			LoadLogger.Debug("Finish Get Policy From Supabase")
-		case []objects.Function:
-			resource.Functions = rs
+		case functionResource:
+			resource.Functions = rs.Functions
+			resource.Skipped = append(resource.Skipped, rs.Skipped...)
 			LoadLogger.Debug("Finish Get Function From Supabase")
 		case []objects.Bucket:
 			resource.Storages = rs
 			LoadLogger.Debug("Finish Get Bucket From Supabase")
+		case []objects.Type:
+			resource.Types = rs
+			LoadLogger.Debug("Finish Get Type From Supabase")
+		case []objects.Trigger:
+			resource.Triggers = rs
+			LoadLogger.Debug("Finish Get Trigger From Supabase")
+		case []objects.Publication:
+			resource.Publications = rs
+			LoadLogger.Debug("Finish Get Publication From Supabase")
+		case *ImportCategoryTimeoutError:
+			LoadLogger.Warn("skip import category, load resource is timeout", "category", rs.Category, "timeout", rs.Timeout)
+			if resource.Failed == nil {
+				resource.Failed = make(map[ImportCategory]error)
+			}
+			resource.Failed[rs.Category] = rs
 		case error:
 			return nil, rs
 		}
 	}
 
+	// resource of failed category may be partially loaded, failed category is not imported
+	for c := range resource.Failed {
+		resource.clearCategory(c)
+	}
+
 	return resource, nil
 }
 
+// clearCategory remove loaded resource of import category
+func (r *Resource) clearCategory(category ImportCategory) {
+	switch category {
+	case ImportCategoryModels:
+		r.Tables, r.Types, r.Publications = nil, nil, nil
+	case ImportCategoryRoles:
+		r.Roles = nil
+	case ImportCategoryRpc:
+		r.Functions, r.Triggers = nil, nil
+	case ImportCategoryStorages:
+		r.Storages = nil
+	}
+}
+
 // The `loadResource` function loads different types of Supabase resources based on the flags provided
 // and sends them to an output channel.
 func loadResource(cfg *raiden.Config, flags *Flags) <-chan any {
 	wg, outChan := sync.WaitGroup{}, make(chan any)
 
-	go func() {
-		wg.Wait()
-		close(outChan)
-	}()
-
 	if flags.All() || flags.ModelsOnly || flags.StoragesOnly {
 		wg.Add(1)
 		LoadLogger.Debug("Get Policy From Supabase")
-		go loadSupabaseResource(&wg, cfg, outChan, func(cfg *raiden.Config) (objects.Policies, error) {
-			rs, e := supabase.GetPolicies(cfg)
-			if e != nil {
-				return rs, e
-			}
-
-			// cleanup policy expression
-			var cleanedPolicies objects.Policies
-			for i := range rs {
-				p := rs[i]
-				policies.CleanupAclExpression(&p)
-				cleanedPolicies = append(cleanedPolicies, p)
-			}
-
-			return cleanedPolicies, nil
+		// policy expression is kept verbatim, type cast and call to custom
+		// function is part of the expression and needed when policy is applied
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryModels, ImportCategoryStorages}, func(ctx context.Context, cfg *raiden.Config) (objects.Policies, error) {
+			return supabase.GetPolicies(cfg.WithContext(ctx))
 		})
 
 		wg.Add(1)
 		LoadLogger.Debug("Get Role From Supabase")
-		go loadSupabaseResource(&wg, cfg, outChan, func(cfg *raiden.Config) ([]objects.Role, error) {
-			return supabase.GetRoles(cfg)
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryRoles}, func(ctx context.Context, cfg *raiden.Config) ([]objects.Role, error) {
+			return supabase.GetRoles(cfg.WithContext(ctx))
 		})
 	}
 
 	if flags.All() || flags.ModelsOnly {
 		wg.Add(1)
 		LoadLogger.Debug("Get Table From Supabase")
-		go loadSupabaseResource(&wg, cfg, outChan, func(cfg *raiden.Config) ([]objects.Table, error) {
-			return supabase.GetTables(cfg, supabase.DefaultIncludedSchema)
+		includedSchema := supabase.DefaultIncludedSchema
+		if flags.AllowedSchema != "" {
+			includedSchema = strings.Split(flags.AllowedSchema, ",")
+		}
+
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryModels}, func(ctx context.Context, cfg *raiden.Config) ([]objects.Table, error) {
+			return supabase.GetTables(cfg.WithContext(ctx), includedSchema)
+		})
+
+		if cfg.ImportViews {
+			wg.Add(1)
+			LoadLogger.Debug("Get View From Supabase")
+			go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryModels}, func(ctx context.Context, cfg *raiden.Config) (viewResource, error) {
+				return supabase.GetViews(cfg.WithContext(ctx), includedSchema)
+			})
+		}
+
+		if cfg.ImportForeignTables {
+			wg.Add(1)
+			LoadLogger.Debug("Get Foreign Table From Supabase")
+			go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryModels}, func(ctx context.Context, cfg *raiden.Config) (foreignTableResource, error) {
+				return supabase.GetForeignTables(cfg.WithContext(ctx), includedSchema)
+			})
+		}
+
+		wg.Add(1)
+		LoadLogger.Debug("Get Type From Supabase")
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryModels}, func(ctx context.Context, cfg *raiden.Config) ([]objects.Type, error) {
+			return supabase.GetTypes(cfg.WithContext(ctx))
 		})
 
+		wg.Add(1)
+		LoadLogger.Debug("Get Publication From Supabase")
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryModels}, func(ctx context.Context, cfg *raiden.Config) ([]objects.Publication, error) {
+			return supabase.GetPublications(cfg.WithContext(ctx))
+		})
 	}
 
 	if flags.All() || flags.RolesOnly {
 		wg.Add(1)
 		LoadLogger.Debug("Get Role From Supabase")
-		go loadSupabaseResource(&wg, cfg, outChan, func(cfg *raiden.Config) ([]objects.Role, error) {
-			return supabase.GetRoles(cfg)
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryRoles}, func(ctx context.Context, cfg *raiden.Config) ([]objects.Role, error) {
+			return supabase.GetRoles(cfg.WithContext(ctx))
 		})
 	}
 
 	if flags.All() || flags.RpcOnly {
+		includedSchema := supabase.DefaultIncludedSchema
+		if flags.AllowedSchema != "" {
+			includedSchema = strings.Split(flags.AllowedSchema, ",")
+		}
+
 		wg.Add(1)
 		LoadLogger.Debug("Get Function From Supabase")
-		go loadSupabaseResource(&wg, cfg, outChan, func(cfg *raiden.Config) ([]objects.Function, error) {
-			return supabase.GetFunctions(cfg)
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryRpc}, func(ctx context.Context, cfg *raiden.Config) (functionResource, error) {
+			return loadFunctions(cfg.WithContext(ctx), includedSchema)
+		})
+
+		wg.Add(1)
+		LoadLogger.Debug("Get Trigger From Supabase")
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryRpc}, func(ctx context.Context, cfg *raiden.Config) ([]objects.Trigger, error) {
+			return supabase.GetTriggers(cfg.WithContext(ctx), includedSchema)
 		})
 	}
 
 	if flags.All() || flags.StoragesOnly {
 		wg.Add(1)
 		LoadLogger.Debug("Get Bucket From Supabase")
-		go loadSupabaseResource(&wg, cfg, outChan, func(cfg *raiden.Config) ([]objects.Bucket, error) {
-			return supabase.GetBuckets(cfg)
+		go loadSupabaseResource(&wg, cfg, outChan, []ImportCategory{ImportCategoryStorages}, func(ctx context.Context, cfg *raiden.Config) ([]objects.Bucket, error) {
+			return supabase.GetBuckets(cfg.WithContext(ctx))
 		})
 	}
 
+	// out chan is closed when every added loader is finished
+	go func() {
+		wg.Wait()
+		close(outChan)
+	}()
+
 	return outChan
 }
 
-func loadSupabaseResource[T any](wg *sync.WaitGroup, cfg *raiden.Config, outChan chan any, callback func(cfg *raiden.Config) (T, error)) {
+// loadSupabaseResource send loaded resource to out chan, every category of resource
+// is failed with timeout error when resource is not loaded in configured timeout,
+// context of callback is cancelled after timeout so pending request is stopped
+func loadSupabaseResource[T any](wg *sync.WaitGroup, cfg *raiden.Config, outChan chan any, categories []ImportCategory, callback func(ctx context.Context, cfg *raiden.Config) (T, error)) {
 	defer wg.Done()
 
-	rs, err := callback(cfg)
-	if err != nil {
-		outChan <- err
-		return
+	ctx, cancel := context.WithCancel(cfg.Context())
+	defer cancel()
+
+	type loadResult struct {
+		rs  T
+		err error
+	}
+
+	// result chan is buffered, so callback that finished after timeout is not blocked
+	resultChan := make(chan loadResult, 1)
+	go func() {
+		rs, err := callback(ctx, cfg)
+		resultChan <- loadResult{rs: rs, err: err}
+	}()
+
+	var timeoutChan <-chan time.Time
+	if timeout := getImportCategoryTimeout(cfg); timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutChan = timer.C
+	}
+
+	select {
+	case result := <-resultChan:
+		if result.err != nil {
+			outChan <- result.err
+			return
+		}
+		outChan <- result.rs
+	case <-timeoutChan:
+		for _, c := range categories {
+			outChan <- &ImportCategoryTimeoutError{Category: c, Timeout: getImportCategoryTimeout(cfg)}
+		}
+	}
+}
+
+// loadFunctions fetch all function at once, when it is failed (e.g definition of one function
+// can`t be read) function is fetched one by one and function that can`t be fetched is skipped
+func loadFunctions(cfg *raiden.Config, includedSchema []string) (functionResource, error) {
+	functions, err := supabase.GetFunctions(cfg)
+	if err == nil {
+		return functionResource{Functions: functions}, nil
+	}
+
+	LoadLogger.Warn("failed fetch all function, fetch function one by one", "err", err)
+	ids, idErr := supabase.GetFunctionIds(cfg, includedSchema)
+	if idErr != nil {
+		return functionResource{}, err
+	}
+
+	var rs functionResource
+	for _, f := range ids {
+		if err := cfg.Context().Err(); err != nil {
+			return functionResource{}, err
+		}
+
+		fn, err := supabase.GetFunctionById(cfg, f.ID)
+		if err != nil {
+			LoadLogger.Warn("skip import function", "schema", f.Schema, "name", f.Name, "reason", err.Error())
+			rs.Skipped = append(rs.Skipped, SkippedResource{Type: "function", Schema: f.Schema, Name: f.Name, Err: err})
+			continue
+		}
+		rs.Functions = append(rs.Functions, fn)
 	}
-	outChan <- rs
+	return rs, nil
 }
 
 func loadMapNativeRole() (map[string]raiden.Role, error) {
@@ -0,0 +1,60 @@
+package resource
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_SkipUnfetchableFunction(t *testing.T) {
+	// definition of get_broken can`t be read, so fetch all function is failed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/functions") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"cannot read function definition"}`))
+			return
+		}
+
+		if !strings.HasSuffix(r.URL.Path, "/query") {
+			w.Write([]byte("[]"))
+			return
+		}
+
+		var p struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&p)
+
+		switch {
+		case strings.Contains(p.Query, "f.oid = 1"):
+			w.Write([]byte(`[{"id":1,"schema":"public","name":"get_orders"}]`))
+		case strings.Contains(p.Query, "f.oid = 2"):
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"cannot read function definition"}`))
+		case strings.Contains(p.Query, "from\n  pg_proc as f"):
+			w.Write([]byte(`[{"id":1,"schema":"public","name":"get_orders"},{"id":2,"schema":"public","name":"get_broken"}]`))
+		default:
+			w.Write([]byte("[]"))
+		}
+	}))
+	defer server.Close()
+
+	config := &raiden.Config{
+		DeploymentTarget: raiden.DeploymentTargetSelfHosted,
+		SupabaseApiUrl:   server.URL,
+	}
+
+	resource, err := Load(&Flags{RpcOnly: true}, config)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resource.Functions))
+	assert.Equal(t, "get_orders", resource.Functions[0].Name)
+	assert.Equal(t, 1, len(resource.Skipped))
+	assert.Equal(t, "get_broken", resource.Skipped[0].Name)
+	assert.Equal(t, "function", resource.Skipped[0].Type)
+}
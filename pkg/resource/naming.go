@@ -0,0 +1,205 @@
+package resource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// generatedName is go declaration created by generate process and
+// resource that produce it, same declaration in one package can`t be compiled
+type generatedName struct {
+	Package string
+	Name    string
+	Source  string
+}
+
+func (n generatedName) key() string {
+	return fmt.Sprintf("%s.%s", n.Package, n.Name)
+}
+
+func collectGeneratedNames(modelInputs []*generator.GenerateModelInput, types, composites, domains []objects.Type, functions []objects.Function, roles []objects.Role, storages []objects.Bucket, triggers []objects.Trigger, publications []objects.Publication) (names []generatedName) {
+	var base *generator.GenerateModelBase
+	for _, m := range modelInputs {
+		names = append(names, generatedName{
+			Package: getModelPackageName(m),
+			Name:    m.GetStructName(),
+			Source:  fmt.Sprintf("%s.%s", m.Table.Schema, m.Table.Name),
+		})
+
+		if base == nil && m.Base != nil {
+			base = m.Base
+		}
+	}
+
+	// model base is always generated in models package
+	if base != nil {
+		names = append(names, generatedName{Package: "models", Name: base.StructName, Source: "model base"})
+	}
+
+	// composite and domain type is generated in models package
+	for _, t := range append(append([]objects.Type{}, composites...), domains...) {
+		names = append(names, generatedName{Package: "models", Name: generator.GetEnumTypeName(t), Source: fmt.Sprintf("%s.%s", t.Schema, t.Name)})
+	}
+
+	// enum type and constant of enum value is generated in models package
+	constantNames := buildEnumConstantNames(modelInputs, types, composites, domains)
+	for _, t := range types {
+		if !t.IsEnum() {
+			continue
+		}
+
+		typeName := generator.GetEnumTypeName(t)
+		source := fmt.Sprintf("%s.%s", t.Schema, t.Name)
+		names = append(names, generatedName{Package: "models", Name: typeName, Source: source})
+		for _, v := range t.Enums {
+			names = append(names, generatedName{
+				Package: "models",
+				Name:    constantNames.Get(typeName, v),
+				Source:  fmt.Sprintf("%s.%s", source, v),
+			})
+		}
+	}
+
+	for _, f := range functions {
+		names = append(names, generatedName{
+			Package: "rpc",
+			Name:    utils.SnakeCaseToPascalCase(f.Name),
+			Source:  fmt.Sprintf("%s.%s", f.Schema, f.Name),
+		})
+	}
+
+	for _, r := range roles {
+		names = append(names, generatedName{
+			Package: "roles",
+			Name:    utils.SnakeCaseToPascalCase(r.Name),
+			Source:  r.Name,
+		})
+	}
+
+	for _, s := range storages {
+		names = append(names, generatedName{
+			Package: "storages",
+			Name:    utils.SnakeCaseToPascalCase(utils.ToSnakeCase(s.Name)),
+			Source:  s.Name,
+		})
+	}
+
+	for _, t := range triggers {
+		names = append(names, generatedName{
+			Package: "triggers",
+			Name:    generator.GetTriggerStructName(t),
+			Source:  t.Key(),
+		})
+	}
+
+	for _, p := range publications {
+		names = append(names, generatedName{
+			Package: "publications",
+			Name:    utils.SnakeCaseToPascalCase(p.Name),
+			Source:  p.Name,
+		})
+	}
+	return
+}
+
+// buildEnumConstantNames name constant of enum value, constant that collide with model,
+// composite or domain type in models package is prefixed with enum type name
+func buildEnumConstantNames(modelInputs []*generator.GenerateModelInput, types, composites, domains []objects.Type) generator.EnumConstantNames {
+	var reserved []string
+	for _, m := range modelInputs {
+		if m.Base != nil {
+			reserved = append(reserved, m.Base.StructName)
+		}
+
+		if getModelPackageName(m) == "models" {
+			reserved = append(reserved, m.GetStructName())
+		}
+	}
+
+	for _, t := range append(append([]objects.Type{}, composites...), domains...) {
+		reserved = append(reserved, generator.GetEnumTypeName(t))
+	}
+	return generator.BuildEnumConstantNames(types, reserved...)
+}
+
+func getModelPackageName(input *generator.GenerateModelInput) string {
+	if input.SchemaPackage {
+		return generator.ToSchemaPackageName(input.Table.Schema)
+	}
+	return "models"
+}
+
+// validateGeneratedNames return error that list all conflicting declaration
+// and the source resource, validation is done before any file is written
+func validateGeneratedNames(names []generatedName) error {
+	var keys []string
+	mapSources := make(map[string][]string)
+	for _, n := range names {
+		k := n.key()
+		if _, exist := mapSources[k]; !exist {
+			keys = append(keys, k)
+		}
+		mapSources[k] = append(mapSources[k], n.Source)
+	}
+
+	var conflicts []string
+	for _, k := range keys {
+		sources := mapSources[k]
+		if len(sources) < 2 {
+			continue
+		}
+		sort.Strings(sources)
+		conflicts = append(conflicts, fmt.Sprintf("%s from %s", k, strings.Join(sources, ", ")))
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("generated name conflict : %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+// resolveModelNameConflicts add schema name as suffix to struct name of
+// conflicting model, model in public schema keep the original struct name
+func resolveModelNameConflicts(inputs []*generator.GenerateModelInput) {
+	mapGroup := make(map[string][]*generator.GenerateModelInput)
+	for _, m := range inputs {
+		k := fmt.Sprintf("%s.%s", getModelPackageName(m), m.GetStructName())
+		mapGroup[k] = append(mapGroup[k], m)
+	}
+
+	structNames := make(map[string]string)
+	for _, group := range mapGroup {
+		if len(group) < 2 {
+			continue
+		}
+
+		hasPublic := false
+		for _, m := range group {
+			if m.Table.Schema == "public" {
+				hasPublic = true
+				break
+			}
+		}
+
+		for _, m := range group {
+			if hasPublic && m.Table.Schema == "public" {
+				continue
+			}
+			key := fmt.Sprintf("%s.%s", m.Table.Schema, m.Table.Name)
+			structNames[key] = m.GetStructName() + utils.SnakeCaseToPascalCase(m.Table.Schema)
+		}
+	}
+
+	if len(structNames) == 0 {
+		return
+	}
+
+	for _, m := range inputs {
+		m.StructNames = structNames
+	}
+}
@@ -0,0 +1,89 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGeneratedNames(t *testing.T) {
+	modelInputs := []*generator.GenerateModelInput{
+		{Table: objects.Table{Schema: "public", Name: "users"}},
+		{Table: objects.Table{Schema: "auth", Name: "users"}},
+		{Table: objects.Table{Schema: "public", Name: "profiles"}},
+	}
+	functions := []objects.Function{
+		{Schema: "public", Name: "get_user"},
+		{Schema: "private", Name: "get_user"},
+	}
+	roles := []objects.Role{{Name: "editor"}}
+	storages := []objects.Bucket{{Name: "avatar"}}
+
+	names := collectGeneratedNames(modelInputs, nil, nil, nil, functions, roles, storages, nil, nil)
+	err := validateGeneratedNames(names)
+	assert.EqualError(t, err, "generated name conflict : models.Users from auth.users, public.users; rpc.GetUser from private.get_user, public.get_user")
+
+	// model in schema package doesn`t conflict
+	for _, m := range modelInputs {
+		m.SchemaPackage = true
+	}
+	names = collectGeneratedNames(modelInputs, nil, nil, nil, functions[:1], roles, storages, nil, nil)
+	assert.NoError(t, validateGeneratedNames(names))
+}
+
+func TestResolveModelNameConflicts(t *testing.T) {
+	modelInputs := []*generator.GenerateModelInput{
+		{Table: objects.Table{Schema: "public", Name: "users"}},
+		{Table: objects.Table{Schema: "auth", Name: "users"}},
+		{Table: objects.Table{Schema: "public", Name: "profiles"}},
+	}
+
+	resolveModelNameConflicts(modelInputs)
+	assert.Equal(t, "Users", modelInputs[0].GetStructName())
+	assert.Equal(t, "UsersAuth", modelInputs[1].GetStructName())
+	assert.Equal(t, "users_auth", modelInputs[1].GetFileName())
+	assert.Equal(t, "Profiles", modelInputs[2].GetStructName())
+
+	names := collectGeneratedNames(modelInputs, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, validateGeneratedNames(names))
+}
+
+func TestValidateGeneratedNames_TriggerInOtherTable(t *testing.T) {
+	triggers := []objects.Trigger{
+		{Schema: "public", Table: "candidates", Name: "set_updated_at"},
+		{Schema: "public", Table: "votes", Name: "set_updated_at"},
+	}
+
+	names := collectGeneratedNames(nil, nil, nil, nil, nil, nil, nil, triggers, nil)
+	assert.NoError(t, validateGeneratedNames(names))
+	assert.Equal(t, "CandidatesSetUpdatedAt", names[0].Name)
+	assert.Equal(t, "VotesSetUpdatedAt", names[1].Name)
+}
+
+func TestValidateGeneratedNames_EnumValue(t *testing.T) {
+	modelInputs := []*generator.GenerateModelInput{
+		{Table: objects.Table{Schema: "public", Name: "active"}},
+	}
+	types := []objects.Type{
+		{Schema: "public", Name: "order_status", Enums: []string{"pending", "active"}},
+		{Schema: "public", Name: "user_status", Enums: []string{"active", "1st_login"}},
+		{Schema: "public", Name: "address", Attributes: []objects.TypeAttribute{{Name: "street"}}},
+	}
+
+	// constant that collide with other constant or model is prefixed with type name
+	names := collectGeneratedNames(modelInputs, types, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, validateGeneratedNames(names))
+
+	var constants []string
+	for _, n := range names[1:] {
+		constants = append(constants, n.Name)
+	}
+	assert.Equal(t, []string{"OrderStatus", "Pending", "OrderStatusActive", "UserStatus", "UserStatusActive", "UserStatus1StLogin"}, constants)
+
+	// composite type is generated in the same package as model
+	composites := []objects.Type{{Schema: "public", Name: "active", Attributes: []objects.TypeAttribute{{Name: "street"}}}}
+	names = collectGeneratedNames(modelInputs, types, composites, nil, nil, nil, nil, nil, nil)
+	assert.EqualError(t, validateGeneratedNames(names), "generated name conflict : models.Active from public.active, public.active")
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/sev-2/raiden/pkg/utils"
 )
 
+// CleanupAclExpression remove type cast from policy expression, only used when compare
+// policy because postgres add type cast to stored expression (e.g 'active'::text)
 func CleanupAclExpression(policy *objects.Policy) {
 	// cleanup check
 	if policy.Check != nil {
@@ -41,6 +41,10 @@ func CompareItem(source, target objects.Policy) (diffResult CompareDiffResult) {
 	diffResult.TargetResource = target
 	updateItem.Name = source.Name
 
+	// imported expression is kept verbatim, only compared without type cast
+	CleanupAclExpression(&source)
+	CleanupAclExpression(&target)
+
 	sourceName := strings.ToLower(source.Name)
 	targetName := strings.ToLower(target.Name)
 	if sourceName != targetName {
@@ -58,9 +62,9 @@ func CompareItem(source, target objects.Policy) (diffResult CompareDiffResult) {
 	if len(source.Roles) != len(target.Roles) {
 		updateItem.ChangeItems = append(updateItem.ChangeItems, objects.UpdatePolicyRoles)
 	} else {
-		for sr := range source.Roles {
+		for _, sr := range source.Roles {
 			isFound := false
-			for tr := range target.Roles {
+			for _, tr := range target.Roles {
 				if sr == tr {
 					isFound = true
 					break
@@ -0,0 +1,21 @@
+package publications
+
+import (
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+func GetNewCountData(supabaseData []objects.Publication, localData state.ExtractPublicationResult) int {
+	var newCount int
+
+	mapData := localData.ToDeleteFlatMap()
+	for i := range supabaseData {
+		p := supabaseData[i]
+
+		if _, exist := mapData[p.Key()]; exist {
+			newCount++
+		}
+	}
+
+	return newCount
+}
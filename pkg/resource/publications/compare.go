@@ -0,0 +1,67 @@
+package publications
+
+import (
+	"sort"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+)
+
+type CompareDiffResult struct {
+	Name           string
+	SourceResource objects.Publication
+	TargetResource objects.Publication
+	IsConflict     bool
+}
+
+func Compare(source []objects.Publication, target []objects.Publication) error {
+	diffResult := CompareList(source, target)
+	return PrintDiffResult(diffResult)
+}
+
+func CompareList(sourcePublication []objects.Publication, targetPublication []objects.Publication) (diffResult []CompareDiffResult) {
+	mapTargetPublication := make(map[string]objects.Publication)
+	for i := range targetPublication {
+		p := targetPublication[i]
+		mapTargetPublication[p.Key()] = p
+	}
+
+	for i := range sourcePublication {
+		s := sourcePublication[i]
+
+		t, isExist := mapTargetPublication[s.Key()]
+		if !isExist {
+			continue
+		}
+
+		diffResult = append(diffResult, CompareItem(s, t))
+	}
+
+	return
+}
+
+// CompareItem compare create statement of both publication, published
+// table is sorted so table order doesn't make a conflict
+func CompareItem(source, target objects.Publication) (diffResult CompareDiffResult) {
+	diffResult.SourceResource = source
+	diffResult.TargetResource = target
+	diffResult.Name = source.Name
+	diffResult.IsConflict = buildCompareQuery(source) != buildCompareQuery(target)
+	return
+}
+
+func buildCompareQuery(p objects.Publication) string {
+	tables := make([]objects.PublicationTable, 0, len(p.Tables))
+	for _, t := range p.Tables {
+		if t.Schema == "" {
+			t.Schema = "public"
+		}
+		tables = append(tables, objects.PublicationTable{Schema: t.Schema, Name: t.Name})
+	}
+
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Key() < tables[j].Key()
+	})
+	p.Tables = tables
+	return query.BuildCreatePublicationQuery(p)
+}
@@ -0,0 +1,84 @@
+package publications
+
+import (
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+type MigrateItem = migrator.MigrateItem[objects.Publication, objects.UpdatePublicationParam]
+type MigrateActionFunc = migrator.MigrateActionFunc[objects.Publication, objects.UpdatePublicationParam]
+
+var ActionFunc = MigrateActionFunc{
+	CreateFunc: supabase.CreatePublication,
+	UpdateFunc: supabase.UpdatePublication,
+	DeleteFunc: supabase.DeletePublication,
+}
+
+func BuildMigrateData(extractedLocalData state.ExtractPublicationResult, supabaseData []objects.Publication) (migrateData []MigrateItem) {
+	Logger.Info("start build migrate publication data")
+	migrateData = append(migrateData, BuildMigrateItem(supabaseData, extractedLocalData.Existing)...)
+
+	// publication that removed from supabase but still exist in local is created again
+	mapSupabasePublication := make(map[string]bool)
+	for i := range supabaseData {
+		mapSupabasePublication[supabaseData[i].Key()] = true
+	}
+
+	Logger.Debug("filter new publication data")
+	newData := extractedLocalData.New
+	for i := range extractedLocalData.Existing {
+		p := extractedLocalData.Existing[i]
+		if !mapSupabasePublication[p.Key()] {
+			newData = append(newData, p)
+		}
+	}
+
+	for i := range newData {
+		migrateData = append(migrateData, MigrateItem{
+			Type:    migrator.MigrateTypeCreate,
+			NewData: newData[i],
+		})
+	}
+
+	Logger.Debug("filter delete publication data")
+	for i := range extractedLocalData.Delete {
+		p := extractedLocalData.Delete[i]
+		if mapSupabasePublication[p.Key()] {
+			migrateData = append(migrateData, MigrateItem{
+				Type:    migrator.MigrateTypeDelete,
+				OldData: p,
+			})
+		}
+	}
+	Logger.Info("finish build migrate publication data")
+	return
+}
+
+func BuildMigrateItem(supabaseData []objects.Publication, localData []objects.Publication) (migratedData []MigrateItem) {
+	Logger.Info("compare supabase and local resource for existing publication data")
+	result := CompareList(localData, supabaseData)
+	for i := range result {
+		r := result[i]
+
+		migrateType := migrator.MigrateTypeIgnore
+		if r.IsConflict {
+			migrateType = migrator.MigrateTypeUpdate
+		}
+
+		migratedData = append(migratedData, MigrateItem{
+			Type:           migrateType,
+			NewData:        r.SourceResource,
+			OldData:        r.TargetResource,
+			MigrationItems: objects.UpdatePublicationParam{OldData: r.TargetResource},
+		})
+	}
+
+	return
+}
+
+func Migrate(config *raiden.Config, publications []MigrateItem, stateChan chan any, actions MigrateActionFunc) []error {
+	return migrator.MigrateResource(config, publications, stateChan, actions, migrator.DefaultMigrator)
+}
@@ -0,0 +1,121 @@
+package publications
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"github.com/fatih/color"
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// ----- print diff section -----
+func PrintDiffResult(diffResult []CompareDiffResult) error {
+	isConflict := false
+	for i := range diffResult {
+		d := diffResult[i]
+		if d.IsConflict {
+			PrintDiff(d)
+			isConflict = true
+		}
+	}
+
+	if isConflict {
+		return errors.New("canceled import process, you have conflict publication. please fix it first")
+	}
+
+	return nil
+}
+
+func PrintDiff(diffData CompareDiffResult) {
+	fileName := utils.ToSnakeCase(diffData.TargetResource.Name)
+	printScope := color.New(color.FgHiBlack).PrintfFunc()
+	printUpdate := color.New(color.FgHiYellow).SprintfFunc()
+	printIndent := color.New(color.FgHiBlack).SprintfFunc()
+
+	sourceSql := buildCompareQuery(diffData.SourceResource)
+	targetSql := buildCompareQuery(diffData.TargetResource)
+
+	printScope("*** Found diff in %s/%s.go ***\n", "/internal/publications", fileName)
+	fmt.Printf("%s %s %s\n", printUpdate("~"), printIndent("from:"), targetSql)
+	fmt.Printf("%s %s   %s\n", printUpdate("~"), printIndent("to:"), sourceSql)
+	printScope("*** End found diff ***\n")
+}
+
+func GetDiffChangeMessage(items []MigrateItem) string {
+	newData := []string{}
+	deleteData := []string{}
+	updateData := []string{}
+
+	for i := range items {
+		item := items[i]
+
+		var name string
+		if item.NewData.Name != "" {
+			name = item.NewData.Key()
+		} else if item.OldData.Name != "" {
+			name = item.OldData.Key()
+		}
+
+		switch item.Type {
+		case migrator.MigrateTypeCreate:
+			newData = append(newData, fmt.Sprintf("- %s", name))
+		case migrator.MigrateTypeUpdate:
+			updateData = append(updateData, fmt.Sprintf("- %s", name))
+		case migrator.MigrateTypeDelete:
+			deleteData = append(deleteData, fmt.Sprintf("- %s", name))
+		}
+	}
+
+	changeMsg, err := GenerateDiffChangeMessage(newData, updateData, deleteData)
+	if err != nil {
+		Logger.Error("print change publication error", "msg", err.Error())
+		return ""
+	}
+	return changeMsg
+}
+
+// ----- diff change -----
+const DiffChangeTemplate = `
+  {{- if gt (len .NewData) 0}}
+  New Publication
+  {{- range .NewData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  {{- if gt (len .UpdateData) 0}}
+  Update Publication
+  {{- range .UpdateData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  {{- if gt (len .DeleteData) 0}}
+  Delete Publication
+  {{- range .DeleteData}}
+  {{.}}
+  {{- end }}
+  {{- end -}}
+  `
+
+func GenerateDiffChangeMessage(newData []string, updateData []string, deleteData []string) (string, error) {
+	param := map[string]any{
+		"NewData":    newData,
+		"UpdateData": updateData,
+		"DeleteData": deleteData,
+	}
+
+	tmplInstance := template.New("generate diff change publication")
+	tmpl, err := tmplInstance.Parse(DiffChangeTemplate)
+	if err != nil {
+		return "", fmt.Errorf("error parsing : %v", err)
+	}
+
+	var buff bytes.Buffer
+	if err := tmpl.Execute(&buff, param); err != nil {
+		return "", err
+	}
+
+	return buff.String(), nil
+}
@@ -0,0 +1,182 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/resource/roles"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// ResolvedResource is filtered supabase resource and model input of every imported table,
+// it is the same data that used by import for generate project file
+type ResolvedResource struct {
+	Resource *Resource
+	Models   []*generator.GenerateModelInput
+}
+
+// Resolve load resource from supabase and resolve it into model input without write any file,
+// can be used by tool (linter, docs, test) that only need to inspect the model graph
+func Resolve(flags *Flags, config *raiden.Config) (*ResolvedResource, error) {
+	resource, _, err := loadImportResource(flags, config)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := ResolveModels(config, flags, resource)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolvedResource{Resource: resource, Models: models}, nil
+}
+
+// ResolveModels build model input of loaded resource with option from config and flags,
+// generated name is validated so returned input can be generated as is
+func ResolveModels(config *raiden.Config, flags *Flags, resource *Resource) ([]*generator.GenerateModelInput, error) {
+	tableInputs, err := buildImportModelInputs(config, flags, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.SchemaSuffixOnConflict {
+		resolveModelNameConflicts(tableInputs)
+	}
+
+	generatedNames := collectGeneratedNames(tableInputs, resource.Types, resource.Composites, resource.Domains, resource.Functions, resource.Roles, resource.Storages, resource.Triggers, resource.Publications)
+	if err := validateGeneratedNames(generatedNames); err != nil {
+		return nil, err
+	}
+	return tableInputs, nil
+}
+
+// loadImportResource load resource from supabase and filter resource that can be imported,
+// native role is returned separately because it is removed from resource role
+func loadImportResource(flags *Flags, config *raiden.Config) (*Resource, []state.RoleState, error) {
+	// use import schema from configuration when allowed schema is not set from flags
+	if flags.AllowedSchema == "" && len(config.ImportSchemas) > 0 {
+		flags.AllowedSchema = strings.Join(config.ImportSchemas, ",")
+	}
+
+	// schema of import sources is allowed when every source set its schema
+	if flags.AllowedSchema == "" && len(config.ImportSources) > 0 {
+		flags.AllowedSchema = strings.Join(importSourceSchemas(config.ImportSources), ",")
+	}
+
+	tableFilter, err := NewTableFilter(flags.IncludeTables, flags.ExcludeTables)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// load map native role
+	ImportLogger.Info("load native role")
+	mapNativeRole, err := loadMapNativeRole()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// fail before load resource when credential can't read imported schema
+	ImportLogger.Info("check supabase connection")
+	if err := preflightImport(flags, config); err != nil {
+		return nil, nil, err
+	}
+
+	// load supabase resource
+	ImportLogger.Info("load resource from supabase")
+	spResource, err := Load(flags, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// native role is stored to import state as is
+	ImportLogger.Debug("get native roles")
+	nativeStateRoles := filterIsNativeRole(mapNativeRole, spResource.Roles)
+
+	// filter table for with allowed schema
+	ImportLogger.Debug("start filter table, function and policy by allowed schema", "allowed-schema", flags.AllowedSchema)
+	ImportLogger.Trace("filter table by schema")
+	spResource.Tables = filterTableBySchema(spResource.Tables, strings.Split(flags.AllowedSchema, ",")...)
+
+	ImportLogger.Trace("filter table relation by schema")
+	spResource.Tables = filterTableRelationBySchema(spResource.Tables, strings.Split(flags.AllowedSchema, ",")...)
+
+	if tableFilter != nil {
+		ImportLogger.Debug("filter table by name", "include", flags.IncludeTables, "exclude", flags.ExcludeTables)
+		var droppedRelations []objects.TablesRelationship
+		spResource.Tables, droppedRelations = filterTableByName(spResource.Tables, tableFilter)
+		for _, r := range droppedRelations {
+			ImportLogger.Warn("skip relation, related table is excluded",
+				"constraint", r.ConstraintName,
+				"source", fmt.Sprintf("%s.%s", r.SourceSchema, r.SourceTableName),
+				"target", fmt.Sprintf("%s.%s", r.TargetTableSchema, r.TargetTableName),
+			)
+		}
+	}
+
+	if !config.ImportPartitions {
+		var collapsed int
+		spResource.Tables, collapsed = collapseTablePartition(spResource.Tables)
+		if collapsed > 0 {
+			ImportLogger.Info("collapse partition table into partitioned parent table", "total", collapsed)
+		}
+	}
+
+	ImportLogger.Trace("filter composite type by table")
+	spResource.Composites = filterCompositeTypeByTable(spResource.Types, spResource.Tables)
+
+	ImportLogger.Trace("filter domain type by table")
+	spResource.Domains = filterDomainTypeByTable(spResource.Types, spResource.Tables, spResource.Composites)
+
+	ImportLogger.Trace("filter enum type by table")
+	spResource.Types = filterEnumTypeByTable(spResource.Types, spResource.Tables, spResource.Composites)
+
+	ImportLogger.Trace("filter function by schema")
+	spResource.Functions = filterFunctionBySchema(spResource.Functions, strings.Split(flags.AllowedSchema, ",")...)
+
+	ImportLogger.Trace("skip function that can`t be parsed")
+	var skippedFunctions []SkippedResource
+	spResource.Functions, skippedFunctions = filterParsableFunction(spResource.Functions)
+	spResource.Skipped = append(spResource.Skipped, skippedFunctions...)
+	for _, sr := range skippedFunctions {
+		ImportLogger.Warn("skip import "+sr.Type, "schema", sr.Schema, "name", sr.Name, "reason", sr.Err.Error())
+	}
+
+	ImportLogger.Trace("skip trigger that execute not imported function")
+	var skippedTriggers []SkippedResource
+	spResource.Triggers, skippedTriggers = filterTriggerByFunction(spResource.Triggers, spResource.Functions)
+	spResource.Skipped = append(spResource.Skipped, skippedTriggers...)
+	for _, sr := range skippedTriggers {
+		ImportLogger.Warn("skip import "+sr.Type, "schema", sr.Schema, "name", sr.Name, "reason", sr.Err.Error())
+	}
+
+	ImportLogger.Trace("filter policy by schema")
+	spResource.Policies = filterPolicyBySchema(spResource.Policies, strings.Split(flags.AllowedSchema, ",")...)
+	spResource.Policies = filterPolicyByTable(spResource.Policies, tableFilter)
+	ImportLogger.Debug("finish filter table, function and policy by allowed schema")
+
+	ImportLogger.Trace("remove native role for supabase list role")
+	spResource.Roles = filterUserRole(spResource.Roles, mapNativeRole)
+
+	ImportLogger.Trace("validate role membership")
+	if err := roles.ValidateMemberOf(spResource.Roles); err != nil {
+		return nil, nil, err
+	}
+
+	// use seed table from configuration when seed table is not set from flags
+	seedTables := config.SeedTables
+	if flags.Seed != "" {
+		seedTables = strings.Split(flags.Seed, ",")
+	}
+
+	if (flags.All() || flags.ModelsOnly) && len(seedTables) > 0 {
+		ImportLogger.Info("load seed data from supabase")
+		spResource.Seeds, err = loadSeedRows(config, spResource.TableSources, filterSeedTables(spResource.Tables, seedTables...))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return spResource, nativeStateRoles, nil
+}
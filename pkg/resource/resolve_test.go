@@ -0,0 +1,48 @@
+package resource
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveModels(t *testing.T) {
+	projectPath := t.TempDir()
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "class_teacher_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "teacher_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id"},
+	}
+	resource := &Resource{
+		Tables: []objects.Table{
+			{ID: 1, Schema: "public", Name: "teacher", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships},
+			{ID: 2, Schema: "public", Name: "class", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships},
+		},
+		Policies: objects.Policies{{Name: "read class", Schema: "public", Table: "class", Command: objects.PolicyCommandSelect}},
+	}
+
+	models, err := ResolveModels(&raiden.Config{ProjectName: "school"}, &Flags{ProjectPath: projectPath}, resource)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(models))
+
+	assert.Equal(t, "class", models[0].Table.Name)
+	assert.Equal(t, "school", models[0].ProjectName)
+	assert.Equal(t, 1, len(models[0].Relations))
+	assert.Equal(t, raiden.RelationTypeBelongsTo, models[0].Relations[0].RelationType)
+	assert.Equal(t, 1, len(models[0].Policies))
+
+	assert.Equal(t, "teacher", models[1].Table.Name)
+	assert.Equal(t, 1, len(models[1].Relations))
+	assert.Equal(t, raiden.RelationTypeHasMany, models[1].Relations[0].RelationType)
+
+	// resolve doesn't write any file
+	entries, err := os.ReadDir(projectPath)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// generated name conflict is returned before generate
+	resource.Tables = append(resource.Tables, objects.Table{ID: 3, Schema: "auth", Name: "teacher", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}})
+	_, err = ResolveModels(&raiden.Config{}, &Flags{ProjectPath: projectPath}, resource)
+	assert.ErrorContains(t, err, "generated name conflict")
+}
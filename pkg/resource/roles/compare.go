@@ -137,8 +137,31 @@ func CompareItem(source, target objects.Role) (diffResult CompareDiffResult) {
 		}
 	}
 
+	if !isSameMemberOf(source.MemberOf, target.MemberOf) {
+		updateItem.ChangeItems = append(updateItem.ChangeItems, objects.UpdateRoleMemberOf)
+	}
+
 	diffResult.IsConflict = len(updateItem.ChangeItems) > 0
 	diffResult.DiffItems = updateItem
 
 	return
 }
+
+func isSameMemberOf(source, target []string) bool {
+	if len(source) != len(target) {
+		return false
+	}
+
+	mapTarget := make(map[string]bool)
+	for _, r := range target {
+		mapTarget[r] = true
+	}
+
+	for _, r := range source {
+		if !mapTarget[r] {
+			return false
+		}
+	}
+
+	return true
+}
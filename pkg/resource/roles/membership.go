@@ -0,0 +1,63 @@
+package roles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// ValidateMemberOf check role membership graph and return error
+// when there is role that is member of itself, directly or through other role
+func ValidateMemberOf(roles []objects.Role) error {
+	mapMemberOf := make(map[string][]string)
+	for _, r := range roles {
+		mapMemberOf[r.Name] = r.MemberOf
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	status := make(map[string]int)
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch status[name] {
+		case visiting:
+			cycleStart := 0
+			for i, p := range path {
+				if p == name {
+					cycleStart = i
+					break
+				}
+			}
+			cycle := append(append([]string{}, path[cycleStart:]...), name)
+			return fmt.Errorf("circular role membership detected : %s", strings.Join(cycle, " -> "))
+		case visited:
+			return nil
+		}
+
+		status[name] = visiting
+		path = append(path, name)
+		for _, parent := range mapMemberOf[name] {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		status[name] = visited
+		return nil
+	}
+
+	for _, r := range roles {
+		if err := visit(r.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package roles_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/roles"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMemberOf(t *testing.T) {
+	err := roles.ValidateMemberOf([]objects.Role{
+		{Name: "editor", MemberOf: []string{"reviewer", "authenticated"}},
+		{Name: "reviewer", MemberOf: []string{"authenticated"}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidateMemberOf_Circular(t *testing.T) {
+	err := roles.ValidateMemberOf([]objects.Role{
+		{Name: "editor", MemberOf: []string{"reviewer"}},
+		{Name: "reviewer", MemberOf: []string{"publisher"}},
+		{Name: "publisher", MemberOf: []string{"editor"}},
+	})
+	assert.EqualError(t, err, "circular role membership detected : editor -> reviewer -> publisher -> editor")
+}
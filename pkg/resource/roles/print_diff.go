@@ -215,6 +215,13 @@ func PrintDiff(diffData CompareDiffResult) {
 				continue
 			}
 			changes = append(changes, diffStr)
+		case objects.UpdateRoleMemberOf:
+			diffStr, err := GenerateDiffMessage(fileName, DiffTypeUpdate, v, formatMemberOf(diffData.TargetResource.MemberOf), formatMemberOf(diffData.SourceResource.MemberOf))
+			if err != nil {
+				Logger.Error("print diff roles error", "msg", err.Error())
+				continue
+			}
+			changes = append(changes, diffStr)
 		}
 	}
 
@@ -261,6 +268,14 @@ func GetDiffChangeMessage(items []MigrateItem) string {
 	return changeMsg
 }
 
+func formatMemberOf(memberOf []string) string {
+	var quoted []string
+	for _, r := range memberOf {
+		quoted = append(quoted, strconv.Quote(r))
+	}
+	return fmt.Sprintf("[]string{%s}", strings.Join(quoted, ", "))
+}
+
 // ----- generate message section ------
 const DiffTemplate = ` 
  {{- if or (eq .Type "create") (eq .Type "delete")}}
@@ -354,6 +369,8 @@ func GenerateDiffMessage(name string, diffType DiffType, updateType objects.Upda
   }
   return objects.NewSupabaseTime(t)`,
 		)
+	case objects.UpdateRoleMemberOf:
+		tmplStr = buildDiffTemplate("MemberOf() []string", "", "")
 	default:
 		return "", errors.New("unsupported update type")
 	}
@@ -469,6 +486,8 @@ func GenerateDiffChangeUpdateMessage(name string, item MigrateItem) (string, err
 				newValue = item.NewData.ValidUntil.Format(raiden.DefaultRoleValidUntilLayout)
 			}
 			changeMsgArr = append(changeMsgArr, fmt.Sprintf("- %s : %s >>> %s", "valid until", oldValue, newValue))
+		case objects.UpdateRoleMemberOf:
+			changeMsgArr = append(changeMsgArr, fmt.Sprintf("- %s : %v >>> %v", "member of", item.OldData.MemberOf, item.NewData.MemberOf))
 		}
 	}
 
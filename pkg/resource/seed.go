@@ -0,0 +1,181 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/supabase"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+var SeedLogger hclog.Logger = logger.HcLog().Named("seed")
+
+// SeedMaxRows is maximum rows that fetched from every seed table
+var SeedMaxRows = 1000
+
+// SeedTableRows is rows of seed table that fetched when import, row key is column name
+type SeedTableRows struct {
+	Table objects.Table
+	Rows  []map[string]json.RawMessage
+}
+
+// SeedData is rows of single model that used by generated seed,
+// rows is json array with model json key
+type SeedData struct {
+	Model any
+	Rows  string
+}
+
+// Seed insert all seed data in the given order, row that conflict
+// with existing row is skipped so seed can be run more than once
+func Seed(cfg *raiden.Config, data ...SeedData) error {
+	for _, d := range data {
+		schema, table, columns, rowsJson, total, err := buildSeedInsertData(d)
+		if err != nil {
+			return err
+		}
+
+		if total == 0 {
+			continue
+		}
+
+		SeedLogger.Info("insert seed data", "schema", schema, "table", table, "total", total)
+		if err := supabase.InsertTableRows(cfg, schema, table, columns, rowsJson); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildSeedInsertData decode rows to model and encode it back with column name as key,
+// column that can't be written is skipped except identity column
+func buildSeedInsertData(data SeedData) (schema, table string, columns []string, rowsJson string, total int, err error) {
+	modelType := reflect.TypeOf(data.Model)
+	if modelType == nil {
+		return schema, table, columns, rowsJson, total, fmt.Errorf("seed model is not defined")
+	}
+
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+
+	if modelType.Kind() != reflect.Struct {
+		return schema, table, columns, rowsJson, total, fmt.Errorf("seed model %s is not struct", modelType.Name())
+	}
+
+	table, schema = raiden.GetTableName(modelType), "public"
+	if metadataField, isExist := modelType.FieldByName("Metadata"); isExist {
+		if s := metadataField.Tag.Get("schema"); s != "" {
+			schema = s
+		}
+	}
+
+	rowsValue := reflect.New(reflect.SliceOf(modelType))
+	if err = json.Unmarshal([]byte(data.Rows), rowsValue.Interface()); err != nil {
+		return schema, table, columns, rowsJson, total, fmt.Errorf("decode seed rows of table %s.%s : %s", schema, table, err)
+	}
+
+	// column can be promoted from embedded model base
+	var fieldIndexes [][]int
+	for _, field := range reflect.VisibleFields(modelType) {
+		tag := field.Tag.Get("column")
+		if field.Anonymous || tag == "" {
+			continue
+		}
+
+		column := raiden.UnmarshalColumnTag(tag)
+		if column.Name == "" || (column.ReadOnly && !column.AutoIncrement) {
+			continue
+		}
+		columns = append(columns, column.Name)
+		fieldIndexes = append(fieldIndexes, field.Index)
+	}
+
+	rows := rowsValue.Elem()
+	insertRows := make([]map[string]any, 0, rows.Len())
+	for i := 0; i < rows.Len(); i++ {
+		row := make(map[string]any, len(columns))
+		for j, fieldIndex := range fieldIndexes {
+			row[columns[j]] = rows.Index(i).FieldByIndex(fieldIndex).Interface()
+		}
+		insertRows = append(insertRows, row)
+	}
+
+	b, err := json.Marshal(insertRows)
+	if err != nil {
+		return schema, table, columns, rowsJson, total, err
+	}
+	return schema, table, columns, string(b), len(insertRows), nil
+}
+
+// filterSeedTables return table that listed as seed table ordered by table dependency,
+// seed table can be written as schema.table or only table name for table in any schema
+func filterSeedTables(input []objects.Table, seedTables ...string) (output []objects.Table) {
+	mapSeed := make(map[string]bool)
+	for _, s := range seedTables {
+		if s = strings.TrimSpace(s); s != "" {
+			mapSeed[s] = false
+		}
+	}
+
+	for i := range input {
+		t := input[i]
+		if t.IsView || t.IsForeign {
+			continue
+		}
+
+		key := fmt.Sprintf("%s.%s", t.Schema, t.Name)
+		_, isKeyExist := mapSeed[key]
+		_, isNameExist := mapSeed[t.Name]
+		if !isKeyExist && !isNameExist {
+			continue
+		}
+
+		if isKeyExist {
+			mapSeed[key] = true
+		}
+
+		if isNameExist {
+			mapSeed[t.Name] = true
+		}
+		output = append(output, t)
+	}
+
+	for s, isFound := range mapSeed {
+		if !isFound {
+			ImportLogger.Warn("skip seed table, table is not imported", "table", s)
+		}
+	}
+
+	return tables.SortTablesByDependency(output, tables.BuildGenerateMapRelations(output))
+}
+
+// loadSeedRows fetch rows of every seed table, rows is ordered by primary key
+func loadSeedRows(cfg *raiden.Config, tableSources map[string]string, seedTables []objects.Table) ([]SeedTableRows, error) {
+	seeds := make([]SeedTableRows, 0, len(seedTables))
+	for i := range seedTables {
+		t := seedTables[i]
+
+		var orderBy []string
+		for _, pk := range t.PrimaryKeys {
+			orderBy = append(orderBy, pk.Name)
+		}
+
+		rows, err := supabase.GetTableRows(sourceConfig(cfg, tableSources, t), t.Schema, t.Name, orderBy, SeedMaxRows)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) >= SeedMaxRows {
+			ImportLogger.Warn("seed table has more rows than limit, only first rows is generated", "schema", t.Schema, "table", t.Name, "limit", SeedMaxRows)
+		}
+		seeds = append(seeds, SeedTableRows{Table: t, Rows: rows})
+	}
+	return seeds, nil
+}
@@ -0,0 +1,51 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+type seedUsers struct {
+	Id       int64   `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;readOnly;nullable:false"`
+	FullName string  `json:"fullName,omitempty" column:"name:full_name;type:text;nullable:false"`
+	Slug     *string `json:"slug,omitempty" column:"name:slug;type:text;readOnly;nullable"`
+	Note     *string `json:"note,omitempty" column:"name:note;type:text;nullable"`
+
+	Metadata string `json:"-" schema:"auth" tableName:"users"`
+
+	Acl raiden.Acl `json:"-"`
+}
+
+func TestBuildSeedInsertData(t *testing.T) {
+	schema, table, columns, rowsJson, total, err := buildSeedInsertData(SeedData{
+		Model: &seedUsers{},
+		Rows:  `[{"id":1,"fullName":"john","slug":"john"},{"id":2,"fullName":"jane","note":"admin"}]`,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "auth", schema)
+	assert.Equal(t, "users", table)
+	assert.Equal(t, []string{"id", "full_name", "note"}, columns)
+	assert.Equal(t, 2, total)
+	assert.JSONEq(t, `[{"id":1,"full_name":"john","note":null},{"id":2,"full_name":"jane","note":"admin"}]`, rowsJson)
+}
+
+func TestFilterSeedTables(t *testing.T) {
+	input := []objects.Table{
+		{ID: 1, Schema: "public", Name: "users", Relationships: []objects.TablesRelationship{
+			{SourceSchema: "public", SourceTableName: "users", SourceColumnName: "role_id", TargetTableSchema: "public", TargetTableName: "roles", TargetColumnName: "id"},
+		}},
+		{ID: 2, Schema: "public", Name: "roles"},
+		{ID: 3, Schema: "auth", Name: "roles"},
+		{ID: 4, Schema: "public", Name: "active_users", IsView: true},
+	}
+
+	output := filterSeedTables(input, "users", " public.roles", "active_users", "unknown")
+	assert.Equal(t, 2, len(output))
+	assert.Equal(t, "roles", output[0].Name)
+	assert.Equal(t, "public", output[0].Schema)
+	assert.Equal(t, "users", output[1].Name)
+}
@@ -0,0 +1,264 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// loadImportSources load resource of every import source and merge it into one resource
+func loadImportSources(flags *Flags, cfg *raiden.Config) (*Resource, error) {
+	if err := validateImportSources(cfg.ImportSources); err != nil {
+		return nil, err
+	}
+
+	sources := make([]sourceResource, 0, len(cfg.ImportSources))
+	for _, s := range cfg.ImportSources {
+		sourceFlags := *flags
+		if len(s.Schemas) > 0 {
+			sourceFlags.AllowedSchema = strings.Join(s.Schemas, ",")
+		}
+
+		LoadLogger.Debug("load resource from import source", "source", s.Name, "schemas", sourceFlags.AllowedSchema)
+		rs, err := loadSource(&sourceFlags, cfg.SourceConfig(s))
+		if err != nil {
+			return nil, fmt.Errorf("load import source %s : %w", s.Name, err)
+		}
+
+		if len(s.Schemas) > 0 {
+			filterSourceResourceBySchema(rs, s.Schemas)
+		}
+		sources = append(sources, sourceResource{Name: s.Name, Resource: rs})
+	}
+
+	return mergeSourceResources(sources)
+}
+
+func validateImportSources(sources []raiden.ImportSource) error {
+	names := make(map[string]bool)
+	for _, s := range sources {
+		if s.Name == "" {
+			return fmt.Errorf("name of import source is required")
+		}
+
+		if names[s.Name] {
+			return fmt.Errorf("import source %s is declared more than once", s.Name)
+		}
+		names[s.Name] = true
+	}
+	return nil
+}
+
+// importSourceSchemas return schema of every import source, nil is returned
+// when one of source doesn't set schema so default allowed schema is used
+func importSourceSchemas(sources []raiden.ImportSource) (schemas []string) {
+	for _, s := range sources {
+		if len(s.Schemas) == 0 {
+			return nil
+		}
+		schemas = append(schemas, s.Schemas...)
+	}
+	return
+}
+
+// filterSourceResourceBySchema keep resource in schema of import source,
+// so the same schema of other source is not loaded twice
+func filterSourceResourceBySchema(rs *Resource, schemas []string) {
+	mapSchema := buildMapAllowedSchema(schemas...)
+
+	rs.Tables = filterTableBySchema(rs.Tables, schemas...)
+	rs.Functions = filterFunctionBySchema(rs.Functions, schemas...)
+	rs.Policies = filterPolicyBySchema(rs.Policies, schemas...)
+
+	var types []objects.Type
+	for _, t := range rs.Types {
+		if mapSchema[t.Schema] {
+			types = append(types, t)
+		}
+	}
+	rs.Types = types
+
+	var triggers []objects.Trigger
+	for _, t := range rs.Triggers {
+		if mapSchema[t.Schema] {
+			triggers = append(triggers, t)
+		}
+	}
+	rs.Triggers = triggers
+
+	for i := range rs.Publications {
+		p := &rs.Publications[i]
+		if p.Tables == nil {
+			continue
+		}
+
+		tables := []objects.PublicationTable{}
+		for _, t := range p.Tables {
+			if schema, _, _ := strings.Cut(t.Key(), "."); mapSchema[schema] {
+				tables = append(tables, t)
+			}
+		}
+		p.Tables = tables
+	}
+}
+
+type sourceResource struct {
+	Name     string
+	Resource *Resource
+}
+
+// mergeSourceResources merge resource of import sources in declared order, table and function
+// can only be loaded from one source. Role, bucket, type, policy and trigger that exist in
+// more than one source is taken from the first source and relation to table of other source
+// is dropped because foreign key can't cross database
+func mergeSourceResources(sources []sourceResource) (*Resource, error) {
+	merged := &Resource{TableSources: make(map[string]string)}
+
+	for _, s := range sources {
+		for _, t := range s.Resource.Tables {
+			key := fmt.Sprintf("%s.%s", t.Schema, t.Name)
+			if source, exist := merged.TableSources[key]; exist {
+				return nil, fmt.Errorf("table %s is loaded from import source %s and %s, set different schemas for every import source", key, source, s.Name)
+			}
+			merged.TableSources[key] = s.Name
+			merged.Tables = append(merged.Tables, t)
+		}
+	}
+
+	functionSources := make(map[string]string)
+	for _, s := range sources {
+		for _, f := range s.Resource.Functions {
+			key := fmt.Sprintf("%s.%s", f.Schema, f.Name)
+			if source, exist := functionSources[key]; exist {
+				return nil, fmt.Errorf("function %s is loaded from import source %s and %s, set different schemas for every import source", key, source, s.Name)
+			}
+			functionSources[key] = s.Name
+			merged.Functions = append(merged.Functions, f)
+		}
+	}
+
+	for _, s := range sources {
+		rs := s.Resource
+		merged.Roles = appendUnique(merged.Roles, rs.Roles, func(r objects.Role) string { return r.Name })
+		merged.Storages = appendUnique(merged.Storages, rs.Storages, func(b objects.Bucket) string { return b.Name })
+		merged.Types = appendUnique(merged.Types, rs.Types, func(t objects.Type) string { return fmt.Sprintf("%s.%s", t.Schema, t.Name) })
+		merged.Policies = appendUnique(merged.Policies, rs.Policies, func(p objects.Policy) string {
+			return fmt.Sprintf("%s.%s.%s", p.Schema, p.Table, p.Name)
+		})
+		merged.Triggers = appendUnique(merged.Triggers, rs.Triggers, func(t objects.Trigger) string {
+			return fmt.Sprintf("%s.%s.%s", t.Schema, t.Table, t.Name)
+		})
+		merged.Publications = mergePublications(merged.Publications, rs.Publications)
+		merged.Skipped = append(merged.Skipped, rs.Skipped...)
+
+		for c, err := range rs.Failed {
+			if merged.Failed == nil {
+				merged.Failed = make(map[ImportCategory]error)
+			}
+			merged.Failed[c] = err
+		}
+	}
+
+	// resource of failed category may be loaded from other source, failed category is not imported
+	for c := range merged.Failed {
+		merged.clearCategory(c)
+	}
+
+	for _, r := range dropCrossSourceRelations(merged.Tables, merged.TableSources) {
+		LoadLogger.Warn("skip relation, related table is loaded from other import source",
+			"constraint", r.ConstraintName,
+			"source", fmt.Sprintf("%s.%s", r.SourceSchema, r.SourceTableName),
+			"target", fmt.Sprintf("%s.%s", r.TargetTableSchema, r.TargetTableName),
+		)
+	}
+
+	return merged, nil
+}
+
+// dropCrossSourceRelations remove relation to table that loaded from other import source,
+// dropped relation is returned once for every constraint
+func dropCrossSourceRelations(tables []objects.Table, tableSources map[string]string) (dropped []objects.TablesRelationship) {
+	droppedConstraints := make(map[string]bool)
+
+	for i := range tables {
+		t := &tables[i]
+		source := tableSources[fmt.Sprintf("%s.%s", t.Schema, t.Name)]
+
+		var relations []objects.TablesRelationship
+		for _, r := range t.Relationships {
+			sourceTable, sourceExist := tableSources[fmt.Sprintf("%s.%s", r.SourceSchema, r.SourceTableName)]
+			targetTable, targetExist := tableSources[fmt.Sprintf("%s.%s", r.TargetTableSchema, r.TargetTableName)]
+			if (sourceExist && sourceTable != source) || (targetExist && targetTable != source) {
+				constraintKey := fmt.Sprintf("%s.%s.%s", r.SourceSchema, r.SourceTableName, r.ConstraintName)
+				if !droppedConstraints[constraintKey] {
+					droppedConstraints[constraintKey] = true
+					dropped = append(dropped, r)
+				}
+				continue
+			}
+			relations = append(relations, r)
+		}
+		t.Relationships = relations
+	}
+	return
+}
+
+// mergePublications merge table of publication that exist in more than one source (e.g supabase_realtime)
+func mergePublications(merged []objects.Publication, publications []objects.Publication) []objects.Publication {
+	for _, p := range publications {
+		index := -1
+		for i := range merged {
+			if merged[i].Name == p.Name {
+				index = i
+				break
+			}
+		}
+
+		if index == -1 {
+			merged = append(merged, p)
+			continue
+		}
+
+		// publication for all tables doesn't have table list
+		existing := &merged[index]
+		if existing.AllTables || p.AllTables {
+			continue
+		}
+		existing.Tables = appendUnique(existing.Tables, p.Tables, func(t objects.PublicationTable) string { return t.Key() })
+	}
+	return merged
+}
+
+func appendUnique[T any](merged []T, items []T, keyFn func(T) string) []T {
+	keys := make(map[string]bool, len(merged))
+	for _, m := range merged {
+		keys[keyFn(m)] = true
+	}
+
+	for _, item := range items {
+		key := keyFn(item)
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+// sourceConfig return config of import source that table is loaded from
+func sourceConfig(cfg *raiden.Config, tableSources map[string]string, table objects.Table) *raiden.Config {
+	name, exist := tableSources[fmt.Sprintf("%s.%s", table.Schema, table.Name)]
+	if !exist {
+		return cfg
+	}
+
+	for _, s := range cfg.ImportSources {
+		if s.Name == name {
+			return cfg.SourceConfig(s)
+		}
+	}
+	return cfg
+}
@@ -0,0 +1,97 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSourceResources(t *testing.T) {
+	profileUser := objects.TablesRelationship{
+		ConstraintName: "profiles_user_id_fkey", SourceSchema: "public", SourceTableName: "profiles", SourceColumnName: "user_id",
+		TargetTableSchema: "auth", TargetTableName: "users", TargetColumnName: "id",
+	}
+	orderProfile := objects.TablesRelationship{
+		ConstraintName: "orders_profile_id_fkey", SourceSchema: "public", SourceTableName: "orders", SourceColumnName: "profile_id",
+		TargetTableSchema: "public", TargetTableName: "profiles", TargetColumnName: "id",
+	}
+
+	auth := &Resource{
+		Tables: []objects.Table{{Schema: "auth", Name: "users"}},
+		Roles:  []objects.Role{{Name: "authenticated"}, {Name: "auth_admin"}},
+		Publications: []objects.Publication{
+			{Name: "supabase_realtime", Tables: []objects.PublicationTable{{Schema: "auth", Name: "users"}}},
+		},
+	}
+	app := &Resource{
+		Tables: []objects.Table{
+			{Schema: "public", Name: "profiles", Relationships: []objects.TablesRelationship{profileUser, orderProfile}},
+			{Schema: "public", Name: "orders", Relationships: []objects.TablesRelationship{orderProfile}},
+		},
+		Functions: []objects.Function{{Schema: "public", Name: "get_orders"}},
+		Roles:     []objects.Role{{Name: "authenticated"}, {Name: "editor"}},
+		Publications: []objects.Publication{
+			{Name: "supabase_realtime", Tables: []objects.PublicationTable{{Schema: "public", Name: "orders"}}},
+		},
+	}
+
+	merged, err := mergeSourceResources([]sourceResource{{Name: "auth", Resource: auth}, {Name: "app", Resource: app}})
+	assert.NoError(t, err)
+	assert.Len(t, merged.Tables, 3)
+	assert.Len(t, merged.Functions, 1)
+	assert.Equal(t, map[string]string{"auth.users": "auth", "public.profiles": "app", "public.orders": "app"}, merged.TableSources)
+
+	var roleNames []string
+	for _, r := range merged.Roles {
+		roleNames = append(roleNames, r.Name)
+	}
+	assert.Equal(t, []string{"authenticated", "auth_admin", "editor"}, roleNames)
+
+	// relation to table of other source is dropped, relation in the same source is kept
+	assert.Equal(t, []objects.TablesRelationship{orderProfile}, merged.Tables[1].Relationships)
+	assert.Equal(t, []objects.TablesRelationship{orderProfile}, merged.Tables[2].Relationships)
+
+	assert.Len(t, merged.Publications, 1)
+	assert.Equal(t, []string{"auth.users", "public.orders"}, merged.Publications[0].TableKeys())
+
+	// the same table can't be loaded from two source
+	other := &Resource{Tables: []objects.Table{{Schema: "public", Name: "orders"}}}
+	_, err = mergeSourceResources([]sourceResource{{Name: "app", Resource: app}, {Name: "other", Resource: other}})
+	assert.EqualError(t, err, "table public.orders is loaded from import source app and other, set different schemas for every import source")
+}
+
+func TestFilterSourceResourceBySchema(t *testing.T) {
+	rs := &Resource{
+		Tables:    []objects.Table{{Schema: "auth", Name: "users"}, {Schema: "public", Name: "orders"}},
+		Functions: []objects.Function{{Schema: "public", Name: "get_orders"}},
+		Types:     []objects.Type{{Schema: "auth", Name: "factor_type"}, {Schema: "public", Name: "status"}},
+		Publications: []objects.Publication{
+			{Name: "supabase_realtime", Tables: []objects.PublicationTable{{Schema: "auth", Name: "users"}, {Name: "orders"}}},
+			{Name: "everything", AllTables: true},
+		},
+	}
+	filterSourceResourceBySchema(rs, []string{"auth"})
+
+	assert.Equal(t, []objects.Table{{Schema: "auth", Name: "users"}}, rs.Tables)
+	assert.Empty(t, rs.Functions)
+	assert.Equal(t, []objects.Type{{Schema: "auth", Name: "factor_type"}}, rs.Types)
+	assert.Equal(t, []string{"auth.users"}, rs.Publications[0].TableKeys())
+	assert.Nil(t, rs.Publications[1].Tables)
+}
+
+func TestValidateImportSources(t *testing.T) {
+	assert.NoError(t, validateImportSources([]raiden.ImportSource{{Name: "auth"}, {Name: "app"}}))
+	assert.EqualError(t, validateImportSources([]raiden.ImportSource{{Name: "auth"}, {}}), "name of import source is required")
+	assert.EqualError(t, validateImportSources([]raiden.ImportSource{{Name: "app"}, {Name: "app"}}), "import source app is declared more than once")
+
+	assert.Equal(t, []string{"auth", "public"}, importSourceSchemas([]raiden.ImportSource{{Schemas: []string{"auth"}}, {Schemas: []string{"public"}}}))
+	assert.Nil(t, importSourceSchemas([]raiden.ImportSource{{Schemas: []string{"auth"}}, {}}))
+}
+
+func TestApply_ImportSources(t *testing.T) {
+	config := &raiden.Config{ImportSources: []raiden.ImportSource{{Name: "auth"}, {Name: "app"}}}
+	err := Apply(&Flags{}, config)
+	assert.EqualError(t, err, "apply is not supported when IMPORT_SOURCES is set, apply every source from its own project")
+}
@@ -0,0 +1,23 @@
+package storages
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// mime type is formatted as "type/subtype", subtype can be wildcard like "image/*"
+var mimeTypeRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]*/(\*|[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]*)$`)
+
+// ValidateAllowedMimeTypes return error when bucket have malformed allowed mime type
+func ValidateAllowedMimeTypes(buckets []objects.Bucket) error {
+	for _, b := range buckets {
+		for _, mt := range b.AllowedMimeTypes {
+			if !mimeTypeRegex.MatchString(mt) {
+				return fmt.Errorf("invalid allowed mime type %q in bucket %s", mt, b.Name)
+			}
+		}
+	}
+	return nil
+}
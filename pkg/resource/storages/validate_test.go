@@ -0,0 +1,24 @@
+package storages_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/storages"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAllowedMimeTypes(t *testing.T) {
+	buckets := []objects.Bucket{
+		{Name: "avatar", AllowedMimeTypes: []string{"image/png", "image/*", "application/vnd.ms-excel"}},
+		{Name: "document"},
+	}
+	assert.NoError(t, storages.ValidateAllowedMimeTypes(buckets))
+
+	buckets = append(buckets, objects.Bucket{Name: "invalid", AllowedMimeTypes: []string{"image"}})
+	err := storages.ValidateAllowedMimeTypes(buckets)
+	assert.EqualError(t, err, `invalid allowed mime type "image" in bucket invalid`)
+
+	err = storages.ValidateAllowedMimeTypes([]objects.Bucket{{Name: "space", AllowedMimeTypes: []string{"image/ png"}}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+package resource
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// TableFilterRegexPrefix mark table filter pattern as regular expression,
+// example : re:store_(order|item)s, regex is anchored to whole table name
+const TableFilterRegexPrefix = "re:"
+
+// TableFilter select imported table by include and exclude name pattern, pattern is glob
+// (example : store_*) or anchored regex when prefixed with re:, pattern that contain dot
+// is matched against schema qualified name (example : public.store_*)
+type TableFilter struct {
+	include []tableNamePattern
+	exclude []tableNamePattern
+}
+
+type tableNamePattern struct {
+	glob  string
+	regex *regexp.Regexp
+}
+
+// NewTableFilter create table filter from coma separated include and exclude pattern,
+// nil is returned when no pattern is set
+func NewTableFilter(include, exclude string) (*TableFilter, error) {
+	includePatterns, err := parseTableNamePatterns(include)
+	if err != nil {
+		return nil, err
+	}
+
+	excludePatterns, err := parseTableNamePatterns(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(includePatterns) == 0 && len(excludePatterns) == 0 {
+		return nil, nil
+	}
+	return &TableFilter{include: includePatterns, exclude: excludePatterns}, nil
+}
+
+func parseTableNamePatterns(value string) (patterns []tableNamePattern, err error) {
+	for _, p := range strings.Split(value, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		if expr, isRegex := strings.CutPrefix(p, TableFilterRegexPrefix); isRegex {
+			regex, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", expr))
+			if err != nil {
+				return nil, fmt.Errorf("invalid table filter regex %q : %s", expr, err)
+			}
+			patterns = append(patterns, tableNamePattern{regex: regex})
+			continue
+		}
+
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid table filter pattern %q : %s", p, err)
+		}
+		patterns = append(patterns, tableNamePattern{glob: p})
+	}
+	return
+}
+
+func (p tableNamePattern) match(schema, name string) bool {
+	qualifiedName := fmt.Sprintf("%s.%s", schema, name)
+	if p.regex != nil {
+		return p.regex.MatchString(name) || p.regex.MatchString(qualifiedName)
+	}
+
+	if strings.Contains(p.glob, ".") {
+		isMatch, _ := path.Match(p.glob, qualifiedName)
+		return isMatch
+	}
+
+	isMatch, _ := path.Match(p.glob, name)
+	return isMatch
+}
+
+// Match check if table is imported, table is imported when match one of include pattern
+// or include pattern is not set and not match any exclude pattern
+func (f *TableFilter) Match(schema, name string) bool {
+	if f == nil {
+		return true
+	}
+
+	for _, p := range f.exclude {
+		if p.match(schema, name) {
+			return false
+		}
+	}
+
+	if len(f.include) == 0 {
+		return true
+	}
+
+	for _, p := range f.include {
+		if p.match(schema, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterTableByName keep table that match filter and remove relation
+// that refer to filtered table, removed relation is returned for warning
+func filterTableByName(input []objects.Table, filter *TableFilter) (output []objects.Table, dropped []objects.TablesRelationship) {
+	if filter == nil {
+		return input, nil
+	}
+
+	mapTable := make(map[string]bool)
+	for i := range input {
+		t := input[i]
+		if filter.Match(t.Schema, t.Name) {
+			mapTable[fmt.Sprintf("%s.%s", t.Schema, t.Name)] = true
+		}
+	}
+
+	mapDropped := make(map[string]bool)
+	for i := range input {
+		t := input[i]
+		if !mapTable[fmt.Sprintf("%s.%s", t.Schema, t.Name)] {
+			continue
+		}
+
+		var relations []objects.TablesRelationship
+		for ri := range t.Relationships {
+			r := t.Relationships[ri]
+			if mapTable[fmt.Sprintf("%s.%s", r.SourceSchema, r.SourceTableName)] && mapTable[fmt.Sprintf("%s.%s", r.TargetTableSchema, r.TargetTableName)] {
+				relations = append(relations, r)
+				continue
+			}
+
+			// relation is stored in both source and target table, only report it once
+			key := fmt.Sprintf("%s.%s.%s", r.SourceSchema, r.SourceTableName, r.ConstraintName)
+			if !mapDropped[key] {
+				mapDropped[key] = true
+				dropped = append(dropped, r)
+			}
+		}
+		t.Relationships = relations
+
+		output = append(output, t)
+	}
+
+	return
+}
+
+// filterPolicyByTable remove policy of filtered table, storage policy is always keep
+func filterPolicyByTable(input objects.Policies, filter *TableFilter) (output objects.Policies) {
+	if filter == nil {
+		return input
+	}
+
+	for i := range input {
+		p := input[i]
+		if p.Schema == "storage" || filter.Match(p.Schema, p.Table) {
+			output = append(output, p)
+		}
+	}
+	return
+}
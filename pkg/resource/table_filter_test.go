@@ -0,0 +1,73 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableFilter_Match(t *testing.T) {
+	filter, err := NewTableFilter("store_*, re:inventory_(item|stock)s", "*_audit")
+	assert.NoError(t, err)
+
+	assert.True(t, filter.Match("public", "store_orders"))
+	assert.True(t, filter.Match("public", "inventory_items"))
+	assert.False(t, filter.Match("public", "store_orders_audit"))
+	assert.False(t, filter.Match("public", "inventory_items_log"))
+	assert.False(t, filter.Match("public", "users"))
+
+	// pattern with dot is matched against schema qualified name
+	filter, err = NewTableFilter("", "auth.*")
+	assert.NoError(t, err)
+	assert.False(t, filter.Match("auth", "users"))
+	assert.True(t, filter.Match("public", "users"))
+
+	filter, err = NewTableFilter("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, filter)
+	assert.True(t, filter.Match("public", "users"))
+
+	_, err = NewTableFilter("re:store_(", "")
+	assert.ErrorContains(t, err, "invalid table filter regex")
+
+	_, err = NewTableFilter("store_[", "")
+	assert.ErrorContains(t, err, "invalid table filter pattern")
+}
+
+func TestFilterTableByName(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "store_orders_user_id_fkey", SourceSchema: "public", SourceTableName: "store_orders", TargetTableSchema: "public", TargetTableName: "users"},
+		{ConstraintName: "store_items_order_id_fkey", SourceSchema: "public", SourceTableName: "store_items", TargetTableSchema: "public", TargetTableName: "store_orders"},
+	}
+	tables := []objects.Table{
+		{Schema: "public", Name: "users", Relationships: relationships[:1]},
+		{Schema: "public", Name: "store_orders", Relationships: relationships},
+		{Schema: "public", Name: "store_items", Relationships: relationships[1:]},
+		{Schema: "public", Name: "store_orders_audit"},
+	}
+
+	filter, err := NewTableFilter("store_*", "*_audit")
+	assert.NoError(t, err)
+
+	output, dropped := filterTableByName(tables, filter)
+	assert.Equal(t, 2, len(output))
+	assert.Equal(t, "store_orders", output[0].Name)
+	assert.Equal(t, 1, len(output[0].Relationships))
+	assert.Equal(t, "store_items_order_id_fkey", output[0].Relationships[0].ConstraintName)
+	assert.Equal(t, "store_items", output[1].Name)
+	assert.Equal(t, 1, len(output[1].Relationships))
+
+	assert.Equal(t, 1, len(dropped))
+	assert.Equal(t, "store_orders_user_id_fkey", dropped[0].ConstraintName)
+
+	policies := objects.Policies{
+		{Name: "read users", Schema: "public", Table: "users"},
+		{Name: "read orders", Schema: "public", Table: "store_orders"},
+		{Name: "read bucket", Schema: "storage", Table: "objects"},
+	}
+	outputPolicies := filterPolicyByTable(policies, filter)
+	assert.Equal(t, 2, len(outputPolicies))
+	assert.Equal(t, "read orders", outputPolicies[0].Name)
+	assert.Equal(t, "read bucket", outputPolicies[1].Name)
+}
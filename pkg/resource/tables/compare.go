@@ -2,7 +2,9 @@ package tables
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/sev-2/raiden/pkg/postgres"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 )
 
@@ -20,8 +22,8 @@ func Compare(source []objects.Table, target []objects.Table) error {
 		return err
 	}
 
-	sMapRelation := buildGenerateMapRelations(tableToMap(source))
-	tMapRelation := buildGenerateMapRelations(tableToMap(target))
+	sMapRelation := buildGenerateMapRelations(tableToMap(source), nil)
+	tMapRelation := buildGenerateMapRelations(tableToMap(target), nil)
 
 	return PrintDiffResult(diffResult, sMapRelation, tMapRelation)
 }
@@ -98,7 +100,12 @@ func CompareItem(source, target objects.Table) (diffResult CompareDiffResult) {
 	// compare relations
 	updateItem.ChangeRelationItems = compareRelations(&source, source.Relationships, target.Relationships)
 
-	if len(updateItem.ChangeItems) == 0 && len(updateItem.ChangeColumnItems) == 0 && len(updateItem.ChangeRelationItems) == 0 {
+	// compare indexes, nil index mean index is not tracked (e.g state from previous version)
+	if source.Indexes != nil && target.Indexes != nil {
+		updateItem.ChangeIndexItems = compareIndexes(source.Indexes, target.Indexes)
+	}
+
+	if len(updateItem.ChangeItems) == 0 && len(updateItem.ChangeColumnItems) == 0 && len(updateItem.ChangeRelationItems) == 0 && len(updateItem.ChangeIndexItems) == 0 {
 		diffResult.IsConflict = false
 	} else {
 		diffResult.IsConflict = true
@@ -155,14 +162,23 @@ func compareColumns(source, target []objects.Column) (updateItems []objects.Upda
 			targetDefault = nil
 		}
 
+		// CURRENT_TIMESTAMP and now() is the same default, so apply doesn't reset equal timestamp default
 		if (sourceDefault != nil && targetDefault == nil) ||
 			(sourceDefault == nil && targetDefault != nil) ||
-			(sourceDefault != nil && targetDefault != nil && *sourceDefault != *targetDefault) {
+			(sourceDefault != nil && targetDefault != nil && postgres.NormalizeDefaultValue(*sourceDefault) != postgres.NormalizeDefaultValue(*targetDefault)) {
 			updateColumnItems = append(updateColumnItems, objects.UpdateColumnDefaultValue)
 		}
 
 		// updateColumnItems = append(updateColumnItems, objects.UpdateColumnDefaultValue)
-		if sc.DataType != tc.DataType {
+		// type modifier is not compared when one side doesn't have it (e.g state from previous version),
+		// so unbounded column is not created from model that generated before modifier is tracked
+		sourceModifier, targetModifier := sc.GetTypeModifier(), tc.GetTypeModifier()
+		isModifierChanged := sourceModifier != "" && targetModifier != "" && sourceModifier != targetModifier
+
+		// model generated before domain is tracked doesn't have domain tag, column is only
+		// changed to other type when model declare the domain
+		isDomainChanged := sc.Domain != "" && sc.GetDomain() != tc.GetDomain()
+		if sc.DataType != tc.DataType || isModifierChanged || isDomainChanged {
 			updateColumnItems = append(updateColumnItems, objects.UpdateColumnDataType)
 		}
 
@@ -178,6 +194,14 @@ func compareColumns(source, target []objects.Column) (updateItems []objects.Upda
 			updateColumnItems = append(updateColumnItems, objects.UpdateColumnIdentity)
 		}
 
+		if sc.GetCheck() != tc.GetCheck() {
+			updateColumnItems = append(updateColumnItems, objects.UpdateColumnCheck)
+		}
+
+		if !isColumnPrivilegeEqual(sc, tc) {
+			updateColumnItems = append(updateColumnItems, objects.UpdateColumnPrivilege)
+		}
+
 		if len(updateColumnItems) == 0 {
 			delete(mapTargetColumn, sc.Name)
 			continue
@@ -260,3 +284,62 @@ func compareRelations(table *objects.Table, source, target []objects.TablesRelat
 
 	return
 }
+
+func compareIndexes(source, target []objects.TableIndex) (updateItems []objects.UpdateIndexItem) {
+	mapTargetIndex := make(map[string]objects.TableIndex)
+	for i := range target {
+		idx := target[i]
+		mapTargetIndex[idx.Name] = idx
+	}
+
+	for i := range source {
+		si := source[i]
+
+		ti, exist := mapTargetIndex[si.Name]
+		if !exist {
+			updateItems = append(updateItems, objects.UpdateIndexItem{
+				Data: si,
+				Type: objects.UpdateIndexCreate,
+			})
+			continue
+		}
+		delete(mapTargetIndex, si.Name)
+
+		if si.IsUnique != ti.IsUnique || si.GetMethod() != ti.GetMethod() || si.Predicate != ti.Predicate || strings.Join(si.Columns, ",") != strings.Join(ti.Columns, ",") {
+			updateItems = append(updateItems, objects.UpdateIndexItem{
+				Data: si,
+				Type: objects.UpdateIndexUpdate,
+			})
+			Logger.Debug("update index, definition not match", "index-name", si.Name)
+		}
+	}
+
+	for i := range target {
+		ti := target[i]
+		if _, exist := mapTargetIndex[ti.Name]; !exist {
+			continue
+		}
+
+		updateItems = append(updateItems, objects.UpdateIndexItem{
+			Data: ti,
+			Type: objects.UpdateIndexDelete,
+		})
+	}
+
+	return
+}
+
+// isColumnPrivilegeEqual compare column privilege regardless of order and case of privilege type
+func isColumnPrivilegeEqual(source, target objects.Column) bool {
+	sourcePrivileges, targetPrivileges := source.GetPrivileges(), target.GetPrivileges()
+	if len(sourcePrivileges) != len(targetPrivileges) {
+		return false
+	}
+
+	for i := range sourcePrivileges {
+		if sourcePrivileges[i] != targetPrivileges[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package tables_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareItem_Index(t *testing.T) {
+	source := objects.Table{
+		Name:   "posts",
+		Schema: "public",
+		Indexes: []objects.TableIndex{
+			{Name: "posts_tags_idx", Columns: []string{"tags"}, Method: "gin", Predicate: "deleted_at IS NULL"},
+			{Name: "posts_title_idx", Columns: []string{"title"}},
+		},
+	}
+
+	target := source
+	target.Indexes = []objects.TableIndex{
+		{Name: "posts_tags_idx", Columns: []string{"tags"}, Method: "gin"},
+		{Name: "posts_title_idx", Columns: []string{"title"}, Method: "btree"},
+		{Name: "posts_old_idx", Columns: []string{"old"}, Method: "btree"},
+	}
+
+	rs := tables.CompareItem(source, target)
+	assert.True(t, rs.IsConflict)
+	assert.Equal(t, []objects.UpdateIndexItem{
+		{Data: source.Indexes[0], Type: objects.UpdateIndexUpdate},
+		{Data: target.Indexes[2], Type: objects.UpdateIndexDelete},
+	}, rs.DiffItems.ChangeIndexItems)
+
+	// index is not compared when one side doesn't track index
+	target.Indexes = nil
+	rs = tables.CompareItem(source, target)
+	assert.False(t, rs.IsConflict)
+}
+
+func TestCompareItem_TimestampDefault(t *testing.T) {
+	now, currentTimestamp := "now()", "CURRENT_TIMESTAMP"
+	source := objects.Table{
+		Name:   "accounts",
+		Schema: "public",
+		Columns: []objects.Column{
+			{Name: "is_active", DataType: "boolean", DefaultValue: "true"},
+			{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: &currentTimestamp},
+		},
+	}
+
+	target := source
+	target.Columns = []objects.Column{
+		{Name: "is_active", DataType: "boolean", DefaultValue: "true"},
+		{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: &now},
+	}
+
+	rs := tables.CompareItem(source, target)
+	assert.False(t, rs.IsConflict)
+
+	// boolean default is changed
+	target.Columns = []objects.Column{
+		{Name: "is_active", DataType: "boolean", DefaultValue: "false"},
+		{Name: "created_at", DataType: "timestamp with time zone", DefaultValue: &now},
+	}
+	rs = tables.CompareItem(source, target)
+	assert.True(t, rs.IsConflict)
+	assert.Equal(t, []objects.UpdateColumnItem{
+		{Name: "is_active", UpdateItems: []objects.UpdateColumnType{objects.UpdateColumnDefaultValue}},
+	}, rs.DiffItems.ChangeColumnItems)
+}
@@ -0,0 +1,97 @@
+package tables
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var diagramEntityRegex = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// BuildGenerateMapRelations build relation of every table with the same
+// rule that used when generate model relation
+func BuildGenerateMapRelations(tables []objects.Table, overrides ...RelationOverride) MapRelations {
+	return buildGenerateMapRelations(tableToMap(tables), nil, overrides...)
+}
+
+// BuildGenerateMapRelationsWithResolver build relation of every table with relation
+// inferred by resolver, default resolver is used when resolver is nil
+func BuildGenerateMapRelationsWithResolver(tables []objects.Table, resolver RelationResolver, overrides ...RelationOverride) MapRelations {
+	return buildGenerateMapRelations(tableToMap(tables), resolver, overrides...)
+}
+
+// GenerateMermaidDiagram render table and relation as mermaid erDiagram, example :
+//
+//	erDiagram
+//	  public_orders }o--|| public_users : "belongsTo users (user_id)"
+//	  public_users ||--o{ public_orders : "hasMany orders (user_id)"
+//	  public_teacher }o--o{ public_topic : "manyToMany topic through class"
+func GenerateMermaidDiagram(tables []objects.Table, mapRelations MapRelations) string {
+	mapTable := tableToMap(tables)
+
+	var sb strings.Builder
+	sb.WriteString("erDiagram\n")
+
+	keys := sortedMapTableKeys(mapTable)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("  %s\n", toDiagramEntity(mapTable[k].Schema, mapTable[k].Name)))
+	}
+
+	for _, k := range keys {
+		t := mapTable[k]
+		source := toDiagramEntity(t.Schema, t.Name)
+
+		var relations []state.Relation
+		for _, r := range mapRelations[k] {
+			if r != nil {
+				relations = append(relations, *r)
+			}
+		}
+		sortRelations(relations)
+
+		for _, r := range relations {
+			targetSchema, targetTable := r.Schema, r.Table
+			if targetSchema == "" {
+				targetSchema = t.Schema
+			}
+
+			// self relation is named by foreign key instead of table name
+			if targetSchema == t.Schema && strings.TrimLeft(r.Type, "[]*") == utils.SnakeCaseToPascalCase(t.Name) {
+				targetTable = t.Name
+			}
+
+			if _, exist := mapTable[getMapTableKey(targetSchema, targetTable)]; !exist {
+				continue
+			}
+			target := toDiagramEntity(targetSchema, targetTable)
+
+			switch r.RelationType {
+			case raiden.RelationTypeBelongsTo:
+				sb.WriteString(fmt.Sprintf("  %s }o--|| %s : %q\n", source, target, fmt.Sprintf("belongsTo %s (%s)", r.Table, r.ForeignKey)))
+			case raiden.RelationTypeHasOne:
+				sb.WriteString(fmt.Sprintf("  %s ||--o| %s : %q\n", source, target, fmt.Sprintf("hasOne %s (%s)", r.Table, r.ForeignKey)))
+			case raiden.RelationTypeHasMany:
+				sb.WriteString(fmt.Sprintf("  %s ||--o{ %s : %q\n", source, target, fmt.Sprintf("hasMany %s (%s)", r.Table, r.ForeignKey)))
+			case raiden.RelationTypeManyToMany:
+				through := ""
+				if r.JoinRelation != nil {
+					through = r.Through
+				}
+				sb.WriteString(fmt.Sprintf("  %s }o--o{ %s : %q\n", source, target, fmt.Sprintf("manyToMany %s through %s", r.Table, through)))
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// toDiagramEntity convert table to mermaid entity name,
+// mermaid entity only accept alphanumeric, underscore and dash
+func toDiagramEntity(schema, name string) string {
+	return diagramEntityRegex.ReplaceAllString(fmt.Sprintf("%s_%s", schema, name), "_")
+}
@@ -0,0 +1,49 @@
+package tables_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateMermaidDiagram(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "class_teacher_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "teacher_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id"},
+		{ConstraintName: "class_topic_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "topic_id", TargetTableSchema: "public", TargetTableName: "topic", TargetColumnName: "id"},
+	}
+	selfRelation := objects.TablesRelationship{
+		ConstraintName: "teacher_mentor_id_fkey", SourceSchema: "public", SourceTableName: "teacher", SourceColumnName: "mentor_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id",
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "teacher", Relationships: []objects.TablesRelationship{relationships[0], selfRelation}},
+		{ID: 2, Schema: "public", Name: "topic", Relationships: relationships[1:]},
+		{ID: 3, Schema: "public", Name: "class", Relationships: relationships},
+	}
+
+	mapRelations := tables.BuildGenerateMapRelations(sourceTables)
+	diagram := tables.GenerateMermaidDiagram(sourceTables, mapRelations)
+
+	assert.Contains(t, diagram, "erDiagram\n")
+	assert.Contains(t, diagram, `public_class }o--|| public_teacher : "belongsTo teacher (teacher_id)"`)
+	assert.Contains(t, diagram, `public_teacher ||--o{ public_class : "hasMany class (teacher_id)"`)
+	assert.Contains(t, diagram, `public_teacher }o--o{ public_topic : "manyToMany topic through class"`)
+	assert.Contains(t, diagram, `public_teacher }o--|| public_teacher : "belongsTo mentor (mentor_id)"`)
+	assert.Contains(t, diagram, `public_teacher ||--o{ public_teacher : "hasMany mentor_children (mentor_id)"`)
+}
+
+func TestGenerateMermaidDiagram_SkipUnknownTable(t *testing.T) {
+	sourceTables := []objects.Table{
+		{
+			ID: 1, Schema: "public", Name: "orders",
+			Relationships: []objects.TablesRelationship{
+				{ConstraintName: "orders_user_id_fkey", SourceSchema: "public", SourceTableName: "orders", SourceColumnName: "user_id", TargetTableSchema: "auth", TargetTableName: "users", TargetColumnName: "id"},
+			},
+		},
+	}
+
+	diagram := tables.GenerateMermaidDiagram(sourceTables, tables.BuildGenerateMapRelations(sourceTables))
+	assert.Equal(t, "erDiagram\n  public_orders\n", diagram)
+}
@@ -0,0 +1,76 @@
+package tables
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/generator"
+)
+
+// ExcludeModelColumns omit column that match one of pattern from generated model, pattern is column
+// name or glob (e.g _audit_*) that matched against column name and schema qualified column name
+// (e.g public.orders.internal_*). Column is kept in database, primary key and foreign key column of
+// relation can't be excluded because model is needed to identify row and relation.
+func ExcludeModelColumns(inputs []*generator.GenerateModelInput, patterns []string) error {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid exclude column pattern %q : %s", p, err)
+		}
+	}
+
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var invalid []string
+	for _, input := range inputs {
+		mapPrimaryKey := make(map[string]bool)
+		for _, pk := range input.Table.PrimaryKeys {
+			mapPrimaryKey[pk.Name] = true
+		}
+
+		mapForeignKey := make(map[string]bool)
+		for _, r := range input.Table.Relationships {
+			if r.SourceSchema == input.Table.Schema && r.SourceTableName == input.Table.Name {
+				mapForeignKey[r.SourceColumnName] = true
+			}
+		}
+
+		input.ExcludeColumns = nil
+		for _, c := range input.Table.Columns {
+			if !isExcludedColumn(patterns, input.Table.Schema, input.Table.Name, c.Name) {
+				continue
+			}
+
+			columnName := fmt.Sprintf("%s.%s.%s", input.Table.Schema, input.Table.Name, c.Name)
+			switch {
+			case mapPrimaryKey[c.Name]:
+				invalid = append(invalid, columnName+" (primary key)")
+			case mapForeignKey[c.Name] && !input.Table.IsView:
+				invalid = append(invalid, columnName+" (foreign key)")
+			default:
+				Logger.Debug("exclude column from model", "column", columnName)
+				input.ExcludeColumns = append(input.ExcludeColumns, c.Name)
+			}
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("can't exclude column from model : %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+func isExcludedColumn(patterns []string, schema, table, column string) bool {
+	qualifiedName := fmt.Sprintf("%s.%s.%s", schema, table, column)
+	for _, p := range patterns {
+		if isMatch, _ := path.Match(p, column); isMatch {
+			return true
+		}
+		if isMatch, _ := path.Match(p, qualifiedName); isMatch {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package tables_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func newExcludeColumnTables() []objects.Table {
+	return []objects.Table{
+		{
+			ID: 1, Schema: "public", Name: "orders",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "_audit_created_by", DataType: "uuid"},
+				{Name: "_audit_updated_by", DataType: "uuid"},
+				{Name: "internal_note", DataType: "text"},
+				{Name: "total", DataType: "numeric"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "orders"}},
+		},
+		{
+			ID: 2, Schema: "public", Name: "items",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "_audit_created_by", DataType: "uuid"},
+				{Name: "internal_note", DataType: "text"},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "items"}},
+		},
+	}
+}
+
+func TestExcludeModelColumns(t *testing.T) {
+	inputs := tables.BuildGenerateModelInputs(newExcludeColumnTables(), nil)
+	assert.Equal(t, 2, len(inputs))
+
+	err := tables.ExcludeModelColumns(inputs, []string{"_audit_*", "public.orders.internal_*"})
+	assert.NoError(t, err)
+
+	items, orders := inputs[0], inputs[1]
+	assert.Equal(t, []string{"_audit_created_by"}, items.ExcludeColumns)
+	assert.Equal(t, []string{"_audit_created_by", "_audit_updated_by", "internal_note"}, orders.ExcludeColumns)
+
+	// excluded column is kept in table so it is not removed from state
+	assert.Equal(t, 5, len(orders.Table.Columns))
+}
+
+func TestExcludeModelColumns_PrimaryKey(t *testing.T) {
+	inputs := tables.BuildGenerateModelInputs(newExcludeColumnTables(), nil)
+
+	err := tables.ExcludeModelColumns(inputs, []string{"i*"})
+	assert.EqualError(t, err, "can't exclude column from model : public.items.id (primary key), public.orders.id (primary key)")
+}
+
+func TestExcludeModelColumns_InvalidPattern(t *testing.T) {
+	err := tables.ExcludeModelColumns(nil, []string{"[audit"})
+	assert.ErrorContains(t, err, `invalid exclude column pattern "[audit"`)
+}
@@ -2,6 +2,8 @@ package tables
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/generator"
@@ -27,9 +29,15 @@ func getMapTableKey(schema, name string) string {
 	return fmt.Sprintf("%s.%s", schema, name)
 }
 
-func BuildGenerateModelInputs(tables []objects.Table, policies objects.Policies) []*generator.GenerateModelInput {
+func BuildGenerateModelInputs(tables []objects.Table, policies objects.Policies, overrides ...RelationOverride) []*generator.GenerateModelInput {
+	return BuildGenerateModelInputsWithResolver(tables, policies, nil, overrides...)
+}
+
+// BuildGenerateModelInputsWithResolver build model input with relation inferred by resolver,
+// default resolver is used when resolver is nil
+func BuildGenerateModelInputsWithResolver(tables []objects.Table, policies objects.Policies, resolver RelationResolver, overrides ...RelationOverride) []*generator.GenerateModelInput {
 	mapTable := tableToMap(tables)
-	mapRelations := buildGenerateMapRelations(mapTable)
+	mapRelations := buildGenerateMapRelations(mapTable, resolver, overrides...)
 	return buildGenerateModelInput(mapTable, mapRelations, policies)
 }
 
@@ -45,69 +53,267 @@ type (
 	}
 )
 
-func buildGenerateMapRelations(mapTable MapTable) MapRelations {
+func buildGenerateMapRelations(mapTable MapTable, resolver RelationResolver, overrides ...RelationOverride) MapRelations {
+	resolver = getRelationResolver(resolver)
+
 	mr := make(MapRelations)
-	for _, t := range mapTable {
-		r, m2m := scanGenerateTableRelation(t)
+	for _, k := range sortedMapTableKeys(mapTable) {
+		t := mapTable[k]
+		r, m2m := resolver.ScanRelations(mapTable, t)
 		if len(r) == 0 {
 			continue
 		}
 
-		// merge with existing relation
+		// apply relation override and merge with existing relation
+		r = RelationOverrides(overrides).apply(t.Schema, t.Name, r)
 		mergeGenerateRelations(t, r, mr)
 
 		// merge many to many candidate with table relations
-		mergeGenerateManyToManyCandidate(m2m, mr)
+		if resolver.IsManyToManyPivot(t, m2m) {
+			resolver.MergeManyToMany(m2m, mr, overrides...)
+		}
 	}
+
+	// pg-meta may only report relation from child table
+	mergeGenerateInverseRelations(mapTable, mr, resolver, overrides...)
+
+	BreakCyclicRelations(mr)
 	return mr
 }
 
-func scanGenerateTableRelation(table *objects.Table) (relations []*state.Relation, manyToManyCandidates []*ManyToManyTable) {
+// BreakCyclicRelations change single row relation that is typed by value to pointer when it
+// make struct refer to itself, example : a.b_id -> b and b.a_id -> a generate mutual has one
+// relation and struct with infinite size when both relation is value type
+func BreakCyclicRelations(mapRelations MapRelations) {
+	keys := make([]string, 0, len(mapRelations))
+	for k := range mapRelations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	graph := make(map[string]map[string]bool)
+	for _, source := range keys {
+		sourceSchema, _, _ := strings.Cut(source, ".")
+		for _, r := range mapRelations[source] {
+			if r == nil || strings.HasPrefix(r.Type, "*") || strings.HasPrefix(r.Type, "[]") {
+				continue
+			}
+
+			if r.RelationType != raiden.RelationTypeHasOne && r.RelationType != raiden.RelationTypeBelongsTo {
+				continue
+			}
+
+			schema := r.Schema
+			if schema == "" {
+				schema = sourceSchema
+			}
+
+			target := getMapTableKey(schema, r.Table)
+			if isReachable(graph, target, source) {
+				Logger.Warn("break circular relation, relation is generated as pointer", "table", source, "target", target, "foreign-key", r.ForeignKey, "type", r.RelationType)
+				r.Type = "*" + r.Type
+				continue
+			}
+
+			if _, exist := graph[source]; !exist {
+				graph[source] = make(map[string]bool)
+			}
+			graph[source][target] = true
+		}
+	}
+}
+
+// mergeGenerateInverseRelations add has many relation to parent table
+// for every has one relation in child table if not exist
+func mergeGenerateInverseRelations(mapTable MapTable, mapRelations MapRelations, resolver RelationResolver, overrides ...RelationOverride) {
+	for _, k := range sortedMapTableKeys(mapTable) {
+		t := mapTable[k]
+
+		for _, g := range groupTableRelationships(t.Relationships) {
+			r := g.Relationship
+			if r.SourceTableName != t.Name || r.SourceSchema != t.Schema {
+				continue
+			}
+
+			// self referencing relation already have has many relation
+			if r.SourceTableName == r.TargetTableName && r.SourceSchema == r.TargetTableSchema {
+				continue
+			}
+
+			parentKey := getMapTableKey(r.TargetTableSchema, r.TargetTableName)
+			parent, exist := mapTable[parentKey]
+			if !exist {
+				continue
+			}
+
+			if hasGenerateRelation(mapRelations[parentKey], parent.Schema, t.Schema, t.Name, r.SourceColumnName, r.TargetColumnName) {
+				continue
+			}
+
+			relation := resolver.ChildRelation(t, g.Relationships)
+			Logger.Trace("add inverse relation", "table", parent.Name, "target", t.Name, "foreign-key", r.SourceColumnName, "type", relation.RelationType)
+			relations := RelationOverrides(overrides).apply(parent.Schema, parent.Name, []*state.Relation{&relation})
+			mergeGenerateRelations(parent, relations, mapRelations)
+		}
+	}
+}
+
+// check if relation that use same key is exist, relation type is not checked
+// because relation type of existing relation may be overridden, relation without
+// schema refer to table in the same schema as owner table
+func hasGenerateRelation(relations []*state.Relation, ownerSchema, schema, table, foreignKey, primaryKey string) bool {
+	for _, r := range relations {
+		if r == nil || r.RelationType == raiden.RelationTypeManyToMany {
+			continue
+		}
+
+		relationSchema := r.Schema
+		if relationSchema == "" {
+			relationSchema = ownerSchema
+		}
+
+		if relationSchema == schema && r.Table == table && r.ForeignKey == foreignKey && r.PrimaryKey == primaryKey {
+			return true
+		}
+	}
+	return false
+}
+
+// PivotRule is heuristic threshold to determine table as many to many pivot table,
+// default value only accept classic join table that have exactly two foreign key
+// and doesn`t have any column other than primary key and foreign key
+type PivotRule struct {
+	MinForeignKey  int
+	MaxForeignKey  int
+	MaxExtraColumn int
+}
+
+var DefaultPivotRule = PivotRule{
+	MinForeignKey:  2,
+	MaxForeignKey:  2,
+	MaxExtraColumn: 0,
+}
+
+// NewPivotRule create pivot rule from config, foreign key threshold that not set use default rule
+func NewPivotRule(config raiden.PivotRule) PivotRule {
+	rule := PivotRule{
+		MinForeignKey:  config.MinForeignKey,
+		MaxForeignKey:  config.MaxForeignKey,
+		MaxExtraColumn: config.MaxExtraColumn,
+	}
+
+	if rule.MinForeignKey <= 0 {
+		rule.MinForeignKey = DefaultPivotRule.MinForeignKey
+	}
+
+	if rule.MaxForeignKey <= 0 {
+		rule.MaxForeignKey = DefaultPivotRule.MaxForeignKey
+	}
+	return rule
+}
+
+func isManyToManyPivot(table *objects.Table, candidates []*ManyToManyTable, rule PivotRule) bool {
+	// table with single foreign key is not look like pivot table
+	if len(candidates) < 2 {
+		return false
+	}
+
+	mapKeyColumn := make(map[string]bool)
+	for _, pk := range table.PrimaryKeys {
+		mapKeyColumn[pk.Name] = true
+	}
+
+	for _, r := range table.Relationships {
+		if r.SourceTableName == table.Name && r.SourceSchema == table.Schema {
+			mapKeyColumn[r.SourceColumnName] = true
+		}
+	}
+
+	var extraColumns []string
+	for _, c := range table.Columns {
+		if _, exist := mapKeyColumn[c.Name]; !exist {
+			extraColumns = append(extraColumns, c.Name)
+		}
+	}
+
+	if len(candidates) < rule.MinForeignKey || len(candidates) > rule.MaxForeignKey {
+		Logger.Warn(
+			"skip many to many relation, total foreign key is out of pivot rule",
+			"table", table.Name, "foreign-key", len(candidates),
+			"min-foreign-key", rule.MinForeignKey, "max-foreign-key", rule.MaxForeignKey,
+		)
+		return false
+	}
+
+	if len(extraColumns) > rule.MaxExtraColumn {
+		Logger.Warn(
+			"skip many to many relation, table have column other than primary key and foreign key",
+			"table", table.Name, "extra-column", strings.Join(extraColumns, ","),
+			"max-extra-column", rule.MaxExtraColumn,
+		)
+		return false
+	}
+
+	return true
+}
+
+func scanGenerateTableRelation(mapTable MapTable, table *objects.Table) (relations []*state.Relation, manyToManyCandidates []*ManyToManyTable) {
 	// skip process if doesn`t have relation`
 	if len(table.Relationships) == 0 {
 		return
 	}
 
-	for _, r := range table.Relationships {
-		var tableName string
-		var primaryKey = r.TargetColumnName
-		var foreignKey = r.SourceColumnName
-		var typePrefix = "*"
-		var relationType = raiden.RelationTypeHasMany
-
-		if r.SourceTableName == table.Name {
-			relationType = raiden.RelationTypeHasOne
-			tableName = r.TargetTableName
-
-			// hasOne relation is candidate to many to many relation
-			// assumption table :
-			//  table :
-			// 		- teacher
-			// 		- topic
-			// 		- class
-			// 	relation :
-			// 		- teacher has many class
-			// 		- topic has many class
-			// 		- class has one teacher and has one topic
-			manyToManyCandidates = append(manyToManyCandidates, &ManyToManyTable{
-				Table:      r.TargetTableName,
-				PivotTable: table.Name,
-				PrimaryKey: r.TargetColumnName,
-				ForeignKey: r.SourceColumnName,
-				Schema:     r.TargetTableSchema,
-			})
-		} else {
-			typePrefix = "[]*"
-			tableName = r.SourceTableName
+	for _, g := range groupTableRelationships(table.Relationships) {
+		r := g.Relationship
+
+		// self referencing relation (adjacency list), example :
+		// categories.parent_id -> categories.id
+		if r.SourceTableName == r.TargetTableName && r.SourceSchema == r.TargetTableSchema {
+			relations = append(relations, buildGenerateSelfRelations(table, g)...)
+			continue
+		}
+
+		// foreign key from table with the same name in other schema is owned by that table
+		if r.SourceTableName != table.Name || r.SourceSchema != table.Schema {
+			child := mapTable[getMapTableKey(r.SourceSchema, r.SourceTableName)]
+			if child == nil {
+				child = &objects.Table{Schema: r.SourceSchema, Name: r.SourceTableName}
+			}
+			relation := buildGenerateChildRelation(child, g)
+			relations = append(relations, &relation)
+			continue
 		}
 
+		// table hold the foreign key, so always refer to single row
+		// belongsTo relation is candidate to many to many relation
+		// assumption table :
+		//  table :
+		// 		- teacher
+		// 		- topic
+		// 		- class
+		// 	relation :
+		// 		- teacher has many class
+		// 		- topic has many class
+		// 		- class belongs to teacher and belongs to topic
+		manyToManyCandidates = append(manyToManyCandidates, &ManyToManyTable{
+			Table:      r.TargetTableName,
+			PivotTable: table.Name,
+			PrimaryKey: r.TargetColumnName,
+			ForeignKey: r.SourceColumnName,
+			Schema:     r.TargetTableSchema,
+		})
+
 		relation := state.Relation{
-			Table:        tableName,
-			Type:         typePrefix + utils.SnakeCaseToPascalCase(tableName),
-			RelationType: relationType,
-			PrimaryKey:   primaryKey,
-			ForeignKey:   foreignKey,
+			Table:        r.TargetTableName,
+			Schema:       r.TargetTableSchema,
+			Type:         "*" + utils.SnakeCaseToPascalCase(r.TargetTableName),
+			RelationType: raiden.RelationTypeBelongsTo,
+			PrimaryKey:   r.TargetColumnName,
+			ForeignKey:   r.SourceColumnName,
+			Comment:      g.foreignKeyComment(),
+			Nullable:     isNullableColumns(table, g.SourceColumns),
 		}
+		g.bindCompositeKeys(&relation)
 
 		relations = append(relations, &relation)
 	}
@@ -115,6 +321,204 @@ func scanGenerateTableRelation(table *objects.Table) (relations []*state.Relatio
 	return
 }
 
+// buildGenerateChildRelation build relation from parent table to child table that hold the foreign key,
+// parent only has one child when foreign key column is unique, example :
+// profile.user_id with unique constraint make user has one profile
+func buildGenerateChildRelation(child *objects.Table, g *relationshipGroup) state.Relation {
+	r := g.Relationship
+	relation := state.Relation{
+		Table:        r.SourceTableName,
+		Schema:       r.SourceSchema,
+		Type:         "[]*" + utils.SnakeCaseToPascalCase(r.SourceTableName),
+		RelationType: raiden.RelationTypeHasMany,
+		PrimaryKey:   r.TargetColumnName,
+		ForeignKey:   r.SourceColumnName,
+		Comment:      g.foreignKeyComment(),
+	}
+
+	if isUniqueColumns(child, g.SourceColumns) {
+		relation.Type = "*" + utils.SnakeCaseToPascalCase(r.SourceTableName)
+		relation.RelationType = raiden.RelationTypeHasOne
+	}
+
+	g.bindCompositeKeys(&relation)
+	return relation
+}
+
+// isUniqueColumns check if combination of columns is guaranteed unique in table,
+// columns is unique when contain all column of primary key or unique index
+func isUniqueColumns(table *objects.Table, columns []string) bool {
+	if len(columns) == 0 {
+		return false
+	}
+
+	if len(columns) == 1 {
+		for _, c := range table.Columns {
+			if c.Name == columns[0] && c.IsUnique {
+				return true
+			}
+		}
+	}
+
+	if len(table.PrimaryKeys) > 0 {
+		containPrimaryKey := true
+		for _, pk := range table.PrimaryKeys {
+			if !utils.Contains(columns, pk.Name) {
+				containPrimaryKey = false
+				break
+			}
+		}
+
+		if containPrimaryKey {
+			return true
+		}
+	}
+
+	for _, idx := range table.UniqueIndexes {
+		if len(idx.Columns) == 0 {
+			continue
+		}
+
+		containIndex := true
+		for _, c := range idx.Columns {
+			if !utils.Contains(columns, c) {
+				containIndex = false
+				break
+			}
+		}
+
+		if containIndex {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNullableColumns check if one of foreign key columns is nullable, row with
+// null foreign key doesn't refer to any row
+func isNullableColumns(table *objects.Table, columns []string) bool {
+	for _, c := range table.Columns {
+		if c.IsNullable && utils.Contains(columns, c.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// relationshipGroup is single foreign key constraint,
+// composite foreign key is reported as multiple relationship row by pg-meta
+type relationshipGroup struct {
+	Relationship  objects.TablesRelationship
+	Relationships []objects.TablesRelationship
+	SourceColumns []string
+	TargetColumns []string
+}
+
+func (g *relationshipGroup) bindCompositeKeys(r *state.Relation) {
+	if len(g.SourceColumns) < 2 && len(g.TargetColumns) < 2 {
+		return
+	}
+	r.PrimaryKeys = g.TargetColumns
+	r.ForeignKeys = g.SourceColumns
+}
+
+// foreignKeyComment describe foreign key that the relation came from,
+// example : fk: orders.user_id -> users.id (orders_user_id_fkey)
+func (g *relationshipGroup) foreignKeyComment() string {
+	r := g.Relationship
+	comment := fmt.Sprintf("fk: %s -> %s", formatRelationColumns(r.SourceTableName, g.SourceColumns), formatRelationColumns(r.TargetTableName, g.TargetColumns))
+	if r.ConstraintName != "" {
+		comment += fmt.Sprintf(" (%s)", r.ConstraintName)
+	}
+	return comment
+}
+
+func formatRelationColumns(table string, columns []string) string {
+	if len(columns) == 1 {
+		return table + "." + columns[0]
+	}
+	return fmt.Sprintf("%s(%s)", table, strings.Join(columns, ", "))
+}
+
+func groupTableRelationships(relationships []objects.TablesRelationship) (groups []*relationshipGroup) {
+	mapGroup := make(map[string]*relationshipGroup)
+	for i := range relationships {
+		r := relationships[i]
+
+		key := fmt.Sprintf("%s.%s.%s", r.SourceSchema, r.SourceTableName, r.ConstraintName)
+		if r.ConstraintName == "" {
+			key = fmt.Sprintf("%s.%d", key, i)
+		}
+
+		g, exist := mapGroup[key]
+		if !exist {
+			g = &relationshipGroup{Relationship: r}
+			mapGroup[key] = g
+			groups = append(groups, g)
+		}
+		g.Relationships = append(g.Relationships, r)
+
+		if !utils.Contains(g.SourceColumns, r.SourceColumnName) {
+			g.SourceColumns = append(g.SourceColumns, r.SourceColumnName)
+		}
+
+		if !utils.Contains(g.TargetColumns, r.TargetColumnName) {
+			g.TargetColumns = append(g.TargetColumns, r.TargetColumnName)
+		}
+	}
+	return
+}
+
+func buildGenerateSelfRelations(table *objects.Table, g *relationshipGroup) []*state.Relation {
+	r := g.Relationship
+	structName := utils.SnakeCaseToPascalCase(r.TargetTableName)
+	relations := []*state.Relation{
+		{
+			Table:        getSelfRelationName(r.SourceColumnName, raiden.RelationTypeBelongsTo),
+			Schema:       r.TargetTableSchema,
+			Type:         "*" + structName,
+			RelationType: raiden.RelationTypeBelongsTo,
+			PrimaryKey:   r.TargetColumnName,
+			ForeignKey:   r.SourceColumnName,
+			Nullable:     isNullableColumns(table, g.SourceColumns),
+		},
+		{
+			Table:        getSelfRelationName(r.SourceColumnName, raiden.RelationTypeHasMany),
+			Schema:       r.TargetTableSchema,
+			Type:         "[]*" + structName,
+			RelationType: raiden.RelationTypeHasMany,
+			PrimaryKey:   r.TargetColumnName,
+			ForeignKey:   r.SourceColumnName,
+		},
+	}
+
+	for i := range relations {
+		relations[i].Comment = g.foreignKeyComment()
+		g.bindCompositeKeys(relations[i])
+	}
+	return relations
+}
+
+// getSelfRelationName derive relation name from foreign key column,
+// example : parent_id will produce `parent` for belongs to and `children` for has many
+func getSelfRelationName(foreignKey string, relationType raiden.RelationType) string {
+	name := strings.TrimSuffix(foreignKey, "_id")
+	if name == foreignKey {
+		// avoid conflict with column field name
+		name += "_ref"
+	}
+
+	if relationType == raiden.RelationTypeBelongsTo {
+		return name
+	}
+
+	if name == "parent" {
+		return "children"
+	}
+	return name + "_children"
+}
+
 func mergeGenerateRelations(table *objects.Table, relations []*state.Relation, mapRelations MapRelations) {
 	key := getMapTableKey(table.Schema, table.Name)
 	tableRelations, isExist := mapRelations[key]
@@ -126,49 +530,91 @@ func mergeGenerateRelations(table *objects.Table, relations []*state.Relation, m
 	mapRelations[key] = tableRelations
 }
 
-func mergeGenerateManyToManyCandidate(candidates []*ManyToManyTable, mapRelations MapRelations) {
-	for sourceTableIndex, sourceTable := range candidates {
-		for targetTableIndex, targetTable := range candidates {
-			if sourceTableIndex == targetTableIndex {
-				continue
-			}
+// mergeGenerateManyToManyCandidate add many to many relation between every candidate of pivot table,
+// relation is identified by pivot, source and target table so the same edge is only added once.
+// candidate is ordered by foreign key, so pivot that refer to the same table more than once
+// always use the first foreign key as source side
+func mergeGenerateManyToManyCandidate(candidates []*ManyToManyTable, mapRelations MapRelations, overrides ...RelationOverride) {
+	sortedCandidates := make([]*ManyToManyTable, 0, len(candidates))
+	for _, c := range candidates {
+		if c != nil {
+			sortedCandidates = append(sortedCandidates, c)
+		}
+	}
 
-			if sourceTable == nil || targetTable == nil {
-				continue
-			}
+	sort.SliceStable(sortedCandidates, func(i, j int) bool {
+		return sortedCandidates[i].ForeignKey < sortedCandidates[j].ForeignKey
+	})
 
-			key := getMapTableKey(sourceTable.Schema, sourceTable.Table)
-			rs, exist := mapRelations[key]
-			if !exist {
-				rs = make([]*state.Relation, 0)
-			}
+	// both side of candidate pair is added together, so each side refer to the other
+	// with mirrored join key regardless of the order of the other candidate
+	for i := range sortedCandidates {
+		for j := i + 1; j < len(sortedCandidates); j++ {
+			addManyToManyRelation(sortedCandidates[i], sortedCandidates[j], mapRelations, overrides...)
+			addManyToManyRelation(sortedCandidates[j], sortedCandidates[i], mapRelations, overrides...)
+		}
+	}
+}
 
-			r := state.Relation{
-				Table:        targetTable.Table,
-				Type:         "[]*" + utils.SnakeCaseToPascalCase(targetTable.Table),
-				RelationType: raiden.RelationTypeManyToMany,
-				JoinRelation: &state.JoinRelation{
-					Through: sourceTable.PivotTable,
+// addManyToManyRelation add relation from source table to target table through pivot table
+func addManyToManyRelation(sourceTable, targetTable *ManyToManyTable, mapRelations MapRelations, overrides ...RelationOverride) {
+	key := getMapTableKey(sourceTable.Schema, sourceTable.Table)
+	rs := mapRelations[key]
+	if hasManyToManyRelation(rs, sourceTable, targetTable) {
+		Logger.Trace("skip duplicate many to many relation", "table", sourceTable.Table, "target", targetTable.Table, "through", sourceTable.PivotTable)
+		return
+	}
 
-					SourcePrimaryKey:      sourceTable.PrimaryKey,
-					JoinsSourceForeignKey: sourceTable.ForeignKey,
+	r := state.Relation{
+		Table:        targetTable.Table,
+		Schema:       targetTable.Schema,
+		Type:         "[]*" + utils.SnakeCaseToPascalCase(targetTable.Table),
+		RelationType: raiden.RelationTypeManyToMany,
+		JoinRelation: &state.JoinRelation{
+			Through: sourceTable.PivotTable,
 
-					TargetPrimaryKey:     targetTable.PrimaryKey,
-					JoinTargetForeignKey: targetTable.ForeignKey,
-				},
-			}
+			SourcePrimaryKey:      sourceTable.PrimaryKey,
+			JoinsSourceForeignKey: sourceTable.ForeignKey,
+
+			TargetPrimaryKey:     targetTable.PrimaryKey,
+			JoinTargetForeignKey: targetTable.ForeignKey,
+		},
+		Comment: fmt.Sprintf(
+			"m2m: %s.%s -> %s.%s, %s.%s -> %s.%s",
+			sourceTable.PivotTable, sourceTable.ForeignKey, sourceTable.Table, sourceTable.PrimaryKey,
+			targetTable.PivotTable, targetTable.ForeignKey, targetTable.Table, targetTable.PrimaryKey,
+		),
+	}
+
+	mapRelations[key] = append(rs, RelationOverrides(overrides).apply(sourceTable.Schema, sourceTable.Table, []*state.Relation{&r})...)
+}
 
-			rs = append(rs, &r)
-			mapRelations[key] = rs
+// hasManyToManyRelation check if source table already have many to many relation
+// to target table through the same pivot table, relation without schema refer
+// to table in the same schema as source table
+func hasManyToManyRelation(relations []*state.Relation, source, target *ManyToManyTable) bool {
+	for _, r := range relations {
+		if r == nil || r.RelationType != raiden.RelationTypeManyToMany || r.JoinRelation == nil {
+			continue
+		}
+
+		schema := r.Schema
+		if schema == "" {
+			schema = source.Schema
 		}
 
+		if r.Through == source.PivotTable && r.Table == target.Table && schema == target.Schema {
+			return true
+		}
 	}
+	return false
 }
 
 // --- attach relation to table
 func buildGenerateModelInput(mapTable MapTable, mapRelations MapRelations, policies objects.Policies) []*generator.GenerateModelInput {
 	generateInputs := make([]*generator.GenerateModelInput, 0)
-	for k, v := range mapTable {
+	for _, k := range sortedMapTableKeys(mapTable) {
+		v := mapTable[k]
 		input := generator.GenerateModelInput{
 			Table:    *v,
 			Policies: policies.FilterByTable(v.Name),
@@ -180,9 +626,53 @@ func buildGenerateModelInput(mapTable MapTable, mapRelations MapRelations, polic
 					input.Relations = append(input.Relations, *v)
 				}
 			}
+			sortRelations(input.Relations)
 		}
 
 		generateInputs = append(generateInputs, &input)
 	}
 	return generateInputs
 }
+
+// sortedMapTableKeys return map table key ordered by schema and then table name
+func sortedMapTableKeys(mapTable MapTable) []string {
+	keys := make([]string, 0, len(mapTable))
+	for k := range mapTable {
+		keys = append(keys, k)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		ti, tj := mapTable[keys[i]], mapTable[keys[j]]
+		if ti.Schema != tj.Schema {
+			return ti.Schema < tj.Schema
+		}
+		return ti.Name < tj.Name
+	})
+	return keys
+}
+
+var relationTypeOrder = map[raiden.RelationType]int{
+	raiden.RelationTypeBelongsTo:  0,
+	raiden.RelationTypeHasOne:     1,
+	raiden.RelationTypeHasMany:    2,
+	raiden.RelationTypeManyToMany: 3,
+}
+
+// sortRelations order relation by relation type and then target table name,
+// pivot table and foreign key is used as tie breaker
+func sortRelations(relations []state.Relation) {
+	sort.SliceStable(relations, func(i, j int) bool {
+		ri, rj := relations[i], relations[j]
+		if ri.RelationType != rj.RelationType {
+			return relationTypeOrder[ri.RelationType] < relationTypeOrder[rj.RelationType]
+		}
+		if ri.Table != rj.Table {
+			return ri.Table < rj.Table
+		}
+
+		if ri.JoinRelation != nil && rj.JoinRelation != nil && ri.Through != rj.Through {
+			return ri.Through < rj.Through
+		}
+		return ri.ForeignKey < rj.ForeignKey
+	})
+}
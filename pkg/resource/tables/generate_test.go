@@ -2,11 +2,17 @@ package tables_test
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
 	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/state"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildGenerateModelInputs(t *testing.T) {
@@ -16,9 +22,558 @@ func TestBuildGenerateModelInputs(t *testing.T) {
 	err := json.Unmarshal([]byte(jsonStrData), &sourceTables)
 	assert.NoError(t, err)
 
+	// submission have score, note and created_at column
+	defaultRule := tables.DefaultPivotRule
+	tables.DefaultPivotRule.MaxExtraColumn = 3
+	defer func() { tables.DefaultPivotRule = defaultRule }()
+
 	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
 
 	for _, r := range rs {
 		assert.Equal(t, 2, len(r.Relations))
 	}
+
+	// with default rule submission is not pivot table
+	tables.DefaultPivotRule = defaultRule
+	rs = tables.BuildGenerateModelInputs(sourceTables, nil)
+	for _, r := range rs {
+		if r.Table.Name == "submission" {
+			assert.Equal(t, 2, len(r.Relations))
+			continue
+		}
+		assert.Equal(t, 1, len(r.Relations))
+	}
+}
+
+func TestBuildGenerateModelInputs_SelfReference(t *testing.T) {
+	sourceTables := []objects.Table{
+		{
+			ID:     1,
+			Schema: "public",
+			Name:   "categories",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "parent_id", DataType: "bigint", IsNullable: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "categories"}},
+			Relationships: []objects.TablesRelationship{
+				{
+					ConstraintName:    "categories_parent_id_fkey",
+					SourceSchema:      "public",
+					SourceTableName:   "categories",
+					SourceColumnName:  "parent_id",
+					TargetTableSchema: "public",
+					TargetTableName:   "categories",
+					TargetColumnName:  "id",
+				},
+			},
+		},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 1, len(rs))
+	assert.Equal(t, 2, len(rs[0].Relations))
+
+	assert.Equal(t, "parent", rs[0].Relations[0].Table)
+	assert.Equal(t, "*Categories", rs[0].Relations[0].Type)
+	assert.Equal(t, "children", rs[0].Relations[1].Table)
+	assert.Equal(t, "[]*Categories", rs[0].Relations[1].Type)
+	assert.True(t, rs[0].Relations[0].Nullable)
+	assert.False(t, rs[0].Relations[1].Nullable)
+}
+
+func TestBuildGenerateModelInputs_NullableForeignKey(t *testing.T) {
+	relation := func(column, target string) objects.TablesRelationship {
+		return objects.TablesRelationship{
+			ConstraintName:    "orders_" + column + "_fkey",
+			SourceSchema:      "public",
+			SourceTableName:   "orders",
+			SourceColumnName:  column,
+			TargetTableSchema: "public",
+			TargetTableName:   target,
+			TargetColumnName:  "id",
+		}
+	}
+	customer := relation("customer_id", "customers")
+	coupon := relation("coupon_id", "coupons")
+
+	sourceTables := []objects.Table{
+		{
+			ID:     1,
+			Schema: "public",
+			Name:   "orders",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "customer_id", DataType: "bigint"},
+				{Name: "coupon_id", DataType: "bigint", IsNullable: true},
+			},
+			Relationships: []objects.TablesRelationship{customer, coupon},
+		},
+		{ID: 2, Schema: "public", Name: "customers", Relationships: []objects.TablesRelationship{customer}},
+		{ID: 3, Schema: "public", Name: "coupons", Relationships: []objects.TablesRelationship{coupon}},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	mapRelation := make(map[string]bool)
+	for _, r := range rs {
+		for _, rel := range r.Relations {
+			mapRelation[r.Table.Name+"."+rel.Table] = rel.Nullable
+		}
+	}
+
+	assert.Equal(t, map[string]bool{
+		"orders.customers": false,
+		"orders.coupons":   true,
+		"customers.orders": false,
+		"coupons.orders":   false,
+	}, mapRelation)
+}
+
+func TestBuildGenerateModelInputs_CompositeForeignKey(t *testing.T) {
+	relationships := []objects.TablesRelationship{}
+	for _, pair := range [][2]string{{"order_id", "id"}, {"tenant_id", "tenant_id"}} {
+		relationships = append(relationships, objects.TablesRelationship{
+			ConstraintName:    "order_items_order_id_tenant_id_fkey",
+			SourceSchema:      "public",
+			SourceTableName:   "order_items",
+			SourceColumnName:  pair[0],
+			TargetTableSchema: "public",
+			TargetTableName:   "orders",
+			TargetColumnName:  pair[1],
+		})
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "orders", Relationships: relationships},
+		{ID: 2, Schema: "public", Name: "order_items", Relationships: relationships},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 2, len(rs))
+
+	for _, r := range rs {
+		assert.Equal(t, 1, len(r.Relations))
+		assert.Equal(t, []string{"id", "tenant_id"}, r.Relations[0].PrimaryKeys)
+		assert.Equal(t, []string{"order_id", "tenant_id"}, r.Relations[0].ForeignKeys)
+	}
+}
+
+func TestBuildGenerateModelInputs_RelationComment(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "orders_user_id_fkey", SourceSchema: "public", SourceTableName: "orders", SourceColumnName: "user_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "users", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships},
+		{ID: 2, Schema: "public", Name: "orders", Columns: []objects.Column{{Name: "id"}, {Name: "user_id"}}, PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 2, len(rs))
+	for _, r := range rs {
+		assert.Equal(t, 1, len(r.Relations))
+		assert.Equal(t, "fk: orders.user_id -> users.id (orders_user_id_fkey)", r.Relations[0].Comment)
+	}
+
+	// composite foreign key list all column of constraint
+	relationships = []objects.TablesRelationship{
+		{SourceSchema: "public", SourceTableName: "order_items", SourceColumnName: "order_id", TargetTableSchema: "public", TargetTableName: "orders", TargetColumnName: "id", ConstraintName: "order_items_order_fkey"},
+		{SourceSchema: "public", SourceTableName: "order_items", SourceColumnName: "tenant_id", TargetTableSchema: "public", TargetTableName: "orders", TargetColumnName: "tenant_id", ConstraintName: "order_items_order_fkey"},
+	}
+	rs = tables.BuildGenerateModelInputs([]objects.Table{{ID: 1, Schema: "public", Name: "order_items", Relationships: relationships}}, nil)
+	assert.Equal(t, "fk: order_items(order_id, tenant_id) -> orders(id, tenant_id) (order_items_order_fkey)", rs[0].Relations[0].Comment)
+}
+
+func TestBuildGenerateModelInputs_Ordering(t *testing.T) {
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "topic"},
+		{ID: 2, Schema: "auth", Name: "users"},
+		{ID: 3, Schema: "public", Name: "class"},
+	}
+
+	for i := 0; i < 10; i++ {
+		rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+		assert.Equal(t, 3, len(rs))
+		assert.Equal(t, "users", rs[0].Table.Name)
+		assert.Equal(t, "class", rs[1].Table.Name)
+		assert.Equal(t, "topic", rs[2].Table.Name)
+	}
+}
+
+func TestBuildGenerateModelInputs_RelationOverride(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "class_teacher_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "teacher_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id"},
+		{ConstraintName: "class_topic_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "topic_id", TargetTableSchema: "public", TargetTableName: "topic", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "teacher", Relationships: relationships[:1]},
+		{ID: 2, Schema: "public", Name: "topic", Relationships: relationships[1:]},
+		{ID: 3, Schema: "public", Name: "class", Relationships: relationships},
+	}
+
+	overrides := []tables.RelationOverride{
+		{Table: "teacher", Column: "teacher_id", Target: "topic", Suppress: true},
+		{Table: "teacher", Column: "teacher_id", Target: "class", Type: raiden.RelationTypeHasOne},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil, overrides...)
+	for _, r := range rs {
+		switch r.Table.Name {
+		case "teacher":
+			assert.Equal(t, 1, len(r.Relations))
+			assert.Equal(t, raiden.RelationTypeHasOne, r.Relations[0].RelationType)
+			assert.Equal(t, "*Class", r.Relations[0].Type)
+		case "topic":
+			assert.Equal(t, 2, len(r.Relations))
+		case "class":
+			assert.Equal(t, 2, len(r.Relations))
+		}
+	}
+}
+
+func TestBuildGenerateModelInputs_SuppressManyToMany(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "class_teacher_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "teacher_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id"},
+		{ConstraintName: "class_topic_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "topic_id", TargetTableSchema: "public", TargetTableName: "topic", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "teacher", Relationships: relationships[:1]},
+		{ID: 2, Schema: "public", Name: "topic", Relationships: relationships[1:]},
+		{ID: 3, Schema: "public", Name: "class", Relationships: relationships},
+	}
+
+	overrides := tables.SuppressManyToManyOverrides([]raiden.ManyToManyEdge{
+		{Through: "public.class", Source: "public.teacher", Target: "topic"},
+		{Through: "class_audit", Source: "topic", Target: "teacher"},
+	})
+	assert.Equal(t, "public", overrides[0].Schema)
+	assert.Equal(t, "class", overrides[0].Through)
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil, overrides...)
+	for _, r := range rs {
+		switch r.Table.Name {
+		case "teacher":
+			assert.Equal(t, 1, len(r.Relations))
+			assert.Equal(t, raiden.RelationTypeHasMany, r.Relations[0].RelationType)
+		case "topic":
+			// edge through other pivot table doesn't suppress relation
+			assert.Equal(t, 2, len(r.Relations))
+			assert.Equal(t, raiden.RelationTypeManyToMany, r.Relations[1].RelationType)
+		}
+	}
+}
+
+func TestBuildGenerateModelInputs_InverseRelation(t *testing.T) {
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "candidate"},
+		{
+			ID: 2, Schema: "public", Name: "submission",
+			Relationships: []objects.TablesRelationship{
+				{ConstraintName: "submission_candidate_id_fkey", SourceSchema: "public", SourceTableName: "submission", SourceColumnName: "candidate_id", TargetTableSchema: "public", TargetTableName: "candidate", TargetColumnName: "id"},
+			},
+		},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 2, len(rs))
+
+	assert.Equal(t, "candidate", rs[0].Table.Name)
+	assert.Equal(t, 1, len(rs[0].Relations))
+	assert.Equal(t, raiden.RelationTypeHasMany, rs[0].Relations[0].RelationType)
+	assert.Equal(t, "[]*Submission", rs[0].Relations[0].Type)
+	assert.Equal(t, "candidate_id", rs[0].Relations[0].ForeignKey)
+
+	assert.Equal(t, 1, len(rs[1].Relations))
+	assert.Equal(t, raiden.RelationTypeBelongsTo, rs[1].Relations[0].RelationType)
+}
+
+func TestBuildGenerateModelInputs_UniqueForeignKey(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "profile_user_id_fkey", SourceSchema: "public", SourceTableName: "profile", SourceColumnName: "user_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+		{ConstraintName: "address_user_id_fkey", SourceSchema: "public", SourceTableName: "address", SourceColumnName: "user_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "users", Relationships: relationships},
+		{
+			ID: 2, Schema: "public", Name: "profile", Relationships: relationships[:1],
+			UniqueIndexes: []objects.TableUniqueIndex{{Name: "profile_user_id_key", Columns: []string{"user_id"}}},
+		},
+		{ID: 3, Schema: "public", Name: "address", Relationships: relationships[1:]},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 3, len(rs))
+
+	// profile hold the foreign key, so profile belongs to users and users has one profile
+	assert.Equal(t, "profile", rs[1].Table.Name)
+	assert.Equal(t, 1, len(rs[1].Relations))
+	assert.Equal(t, raiden.RelationTypeBelongsTo, rs[1].Relations[0].RelationType)
+	assert.Equal(t, "*Users", rs[1].Relations[0].Type)
+
+	assert.Equal(t, "users", rs[2].Table.Name)
+	assert.Equal(t, 2, len(rs[2].Relations))
+	for _, r := range rs[2].Relations {
+		switch r.Table {
+		case "profile":
+			assert.Equal(t, raiden.RelationTypeHasOne, r.RelationType)
+			assert.Equal(t, "*Profile", r.Type)
+		case "address":
+			assert.Equal(t, raiden.RelationTypeHasMany, r.RelationType)
+			assert.Equal(t, "[]*Address", r.Type)
+		default:
+			t.Errorf("unexpected relation %s", r.Table)
+		}
+	}
+}
+
+func TestBuildGenerateModelInputs_CrossSchemaRelation(t *testing.T) {
+	// store.users hold foreign key to public.users, both table have the same name
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "users_account_id_fkey", SourceSchema: "store", SourceTableName: "users", SourceColumnName: "account_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "users", Relationships: relationships},
+		{ID: 2, Schema: "store", Name: "users", Relationships: relationships},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 2, len(rs))
+
+	publicUsers, storeUsers := rs[0], rs[1]
+	assert.Equal(t, "public", publicUsers.Table.Schema)
+	assert.Equal(t, 1, len(publicUsers.Relations))
+	assert.Equal(t, raiden.RelationTypeHasMany, publicUsers.Relations[0].RelationType)
+	assert.Equal(t, "store", publicUsers.Relations[0].Schema)
+	assert.Equal(t, "account_id", publicUsers.Relations[0].ForeignKey)
+
+	assert.Equal(t, "store", storeUsers.Table.Schema)
+	assert.Equal(t, 1, len(storeUsers.Relations))
+	assert.Equal(t, raiden.RelationTypeBelongsTo, storeUsers.Relations[0].RelationType)
+	assert.Equal(t, "public", storeUsers.Relations[0].Schema)
+	assert.Equal(t, "account_id", storeUsers.Relations[0].ForeignKey)
+}
+
+func TestBuildGenerateModelInputs_ManyToManyDedup(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "class_teacher_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "teacher_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id"},
+		{ConstraintName: "class_topic_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "topic_id", TargetTableSchema: "public", TargetTableName: "topic", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "teacher", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships[:1]},
+		{ID: 2, Schema: "public", Name: "topic", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships[1:]},
+		{
+			ID: 3, Schema: "public", Name: "class",
+			Columns:       []objects.Column{{Name: "teacher_id"}, {Name: "topic_id"}},
+			PrimaryKeys:   []objects.PrimaryKey{{Name: "teacher_id"}, {Name: "topic_id"}},
+			Relationships: relationships,
+		},
+	}
+
+	for i := 0; i < 10; i++ {
+		rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+		assert.Equal(t, 3, len(rs))
+
+		mapManyToMany := make(map[string][]state.Relation)
+		for _, input := range rs {
+			for _, r := range input.Relations {
+				if r.RelationType == raiden.RelationTypeManyToMany {
+					mapManyToMany[input.Table.Name] = append(mapManyToMany[input.Table.Name], r)
+				}
+			}
+		}
+
+		assert.Equal(t, 0, len(mapManyToMany["class"]))
+		assert.Equal(t, 1, len(mapManyToMany["teacher"]))
+		assert.Equal(t, 1, len(mapManyToMany["topic"]))
+
+		teacher := mapManyToMany["teacher"][0]
+		assert.Equal(t, "topic", teacher.Table)
+		assert.Equal(t, "class", teacher.Through)
+		assert.Equal(t, "id", teacher.SourcePrimaryKey)
+		assert.Equal(t, "teacher_id", teacher.JoinsSourceForeignKey)
+		assert.Equal(t, "id", teacher.TargetPrimaryKey)
+		assert.Equal(t, "topic_id", teacher.JoinTargetForeignKey)
+		assert.Equal(t, "m2m: class.teacher_id -> teacher.id, class.topic_id -> topic.id", teacher.Comment)
+
+		topic := mapManyToMany["topic"][0]
+		assert.Equal(t, "teacher", topic.Table)
+		assert.Equal(t, "class", topic.Through)
+		assert.Equal(t, "id", topic.SourcePrimaryKey)
+		assert.Equal(t, "topic_id", topic.JoinsSourceForeignKey)
+		assert.Equal(t, "id", topic.TargetPrimaryKey)
+		assert.Equal(t, "teacher_id", topic.JoinTargetForeignKey)
+	}
+}
+
+func TestBuildGenerateModelInputs_ManyToManyBothSide(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "class_topic_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "topic_id", TargetTableSchema: "public", TargetTableName: "topic", TargetColumnName: "code"},
+		{ConstraintName: "class_teacher_id_fkey", SourceSchema: "public", SourceTableName: "class", SourceColumnName: "teacher_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id"},
+	}
+	reversed := []objects.TablesRelationship{relationships[1], relationships[0]}
+
+	// relation and table is declared in different order, both side must have the relation
+	for _, rels := range [][]objects.TablesRelationship{relationships, reversed} {
+		class := objects.Table{
+			ID: 3, Schema: "public", Name: "class",
+			Columns:       []objects.Column{{Name: "teacher_id"}, {Name: "topic_id"}},
+			PrimaryKeys:   []objects.PrimaryKey{{Name: "teacher_id"}, {Name: "topic_id"}},
+			Relationships: rels,
+		}
+		teacher := objects.Table{ID: 1, Schema: "public", Name: "teacher", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: rels}
+		topic := objects.Table{ID: 2, Schema: "public", Name: "topic", PrimaryKeys: []objects.PrimaryKey{{Name: "code"}}, Relationships: rels}
+
+		for _, sourceTables := range [][]objects.Table{{teacher, topic, class}, {class, topic, teacher}} {
+			mapManyToMany := make(map[string][]state.Relation)
+			for _, input := range tables.BuildGenerateModelInputs(sourceTables, nil) {
+				for _, r := range input.Relations {
+					if r.RelationType == raiden.RelationTypeManyToMany {
+						mapManyToMany[input.Table.Name] = append(mapManyToMany[input.Table.Name], r)
+					}
+				}
+			}
+
+			require.Len(t, mapManyToMany["teacher"], 1)
+			require.Len(t, mapManyToMany["topic"], 1)
+
+			teacherTopic, topicTeacher := mapManyToMany["teacher"][0], mapManyToMany["topic"][0]
+			assert.Equal(t, "topic", teacherTopic.Table)
+			assert.Equal(t, "teacher", topicTeacher.Table)
+			assert.Equal(t, state.JoinRelation{Through: "class", SourcePrimaryKey: "id", JoinsSourceForeignKey: "teacher_id", TargetPrimaryKey: "code", JoinTargetForeignKey: "topic_id"}, *teacherTopic.JoinRelation)
+			assert.Equal(t, state.JoinRelation{Through: "class", SourcePrimaryKey: "code", JoinsSourceForeignKey: "topic_id", TargetPrimaryKey: "id", JoinTargetForeignKey: "teacher_id"}, *topicTeacher.JoinRelation)
+
+			// rendered join tag of both side is mirrored
+			assert.Equal(t, `json:"topic,omitempty" join:"joinType:manyToMany;through:class;sourcePrimaryKey:id;sourceForeignKey:teacher_id;targetPrimaryKey:code;targetForeign:topic_id"`, generator.BuildJoinTag(&teacherTopic))
+			assert.Equal(t, `json:"teacher,omitempty" join:"joinType:manyToMany;through:class;sourcePrimaryKey:code;sourceForeignKey:topic_id;targetPrimaryKey:id;targetForeign:teacher_id"`, generator.BuildJoinTag(&topicTeacher))
+		}
+	}
+}
+
+func TestBuildGenerateModelInputs_ManyToManySamePivotTarget(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "mentoring_mentor_id_fkey", SourceSchema: "public", SourceTableName: "mentoring", SourceColumnName: "mentor_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id"},
+		{ConstraintName: "mentoring_mentee_id_fkey", SourceSchema: "public", SourceTableName: "mentoring", SourceColumnName: "mentee_id", TargetTableSchema: "public", TargetTableName: "teacher", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "teacher", PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships},
+		{
+			ID: 2, Schema: "public", Name: "mentoring",
+			Columns:       []objects.Column{{Name: "mentor_id"}, {Name: "mentee_id"}},
+			PrimaryKeys:   []objects.PrimaryKey{{Name: "mentor_id"}, {Name: "mentee_id"}},
+			Relationships: relationships,
+		},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 2, len(rs))
+	assert.Equal(t, "teacher", rs[1].Table.Name)
+
+	// both side of pivot refer to teacher, relation is carried by the first foreign key
+	var manyToMany []state.Relation
+	for _, r := range rs[1].Relations {
+		if r.RelationType == raiden.RelationTypeManyToMany {
+			manyToMany = append(manyToMany, r)
+		}
+	}
+	assert.Equal(t, 1, len(manyToMany))
+	assert.Equal(t, "mentoring", manyToMany[0].Through)
+	assert.Equal(t, "mentee_id", manyToMany[0].JoinsSourceForeignKey)
+	assert.Equal(t, "mentor_id", manyToMany[0].JoinTargetForeignKey)
+}
+
+func TestBuildGenerateModelInputs_MutualHasOne(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "users_avatar_id_fkey", SourceSchema: "public", SourceTableName: "users", SourceColumnName: "avatar_id", TargetTableSchema: "public", TargetTableName: "avatars", TargetColumnName: "id"},
+		{ConstraintName: "avatars_user_id_fkey", SourceSchema: "public", SourceTableName: "avatars", SourceColumnName: "user_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{
+			ID: 1, Schema: "public", Name: "users", Relationships: relationships,
+			Columns: []objects.Column{{Name: "id"}, {Name: "avatar_id", IsNullable: true, IsUnique: true}},
+		},
+		{
+			ID: 2, Schema: "public", Name: "avatars", Relationships: relationships,
+			Columns: []objects.Column{{Name: "id"}, {Name: "user_id", IsNullable: true, IsUnique: true}},
+		},
+	}
+
+	// every single row relation between mutually referencing table is pointer
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 2, len(rs))
+	for _, input := range rs {
+		assert.Equal(t, 2, len(input.Relations))
+		for _, r := range input.Relations {
+			assert.Contains(t, []raiden.RelationType{raiden.RelationTypeBelongsTo, raiden.RelationTypeHasOne}, r.RelationType)
+			assert.Equal(t, "*", r.Type[:1])
+		}
+	}
+
+	// relation typed by value is changed to pointer at the side that close the cycle
+	mapRelations := tables.MapRelations{
+		"public.avatars": {
+			{Table: "users", Schema: "public", Type: "Users", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "avatar_id"},
+		},
+		"public.users": {
+			{Table: "avatars", Schema: "public", Type: "Avatars", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "user_id"},
+			{Table: "users", Type: "Users", RelationType: raiden.RelationTypeBelongsTo, PrimaryKey: "id", ForeignKey: "invited_by"},
+		},
+	}
+	tables.BreakCyclicRelations(mapRelations)
+
+	assert.Equal(t, "Users", mapRelations["public.avatars"][0].Type)
+	assert.Equal(t, "*Avatars", mapRelations["public.users"][0].Type)
+	assert.Equal(t, "*Users", mapRelations["public.users"][1].Type)
+}
+
+func TestBuildGenerateModelInputs_MultipleForeignKeySameTable(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "messages_sender_id_fkey", SourceSchema: "public", SourceTableName: "messages", SourceColumnName: "sender_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+		{ConstraintName: "messages_recipient_id_fkey", SourceSchema: "public", SourceTableName: "messages", SourceColumnName: "recipient_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{
+			ID: 1, Schema: "public", Name: "users",
+			Columns:       []objects.Column{{Name: "id", DataType: "bigint"}},
+			PrimaryKeys:   []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "users"}},
+			Relationships: relationships,
+		},
+		{
+			ID: 2, Schema: "public", Name: "messages",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "sender_id", DataType: "bigint"},
+				{Name: "recipient_id", DataType: "bigint"},
+				{Name: "content", DataType: "text"},
+			},
+			PrimaryKeys:   []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "messages"}},
+			Relationships: relationships,
+		},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 2, len(rs))
+
+	dir := t.TempDir()
+	for _, input := range rs {
+		assert.Equal(t, 2, len(input.Relations))
+		assert.NoError(t, generator.GenerateModel(dir, input, generator.Generate))
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "messages.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "Recipient *Users `json:\"recipient,omitempty\" join:\"joinType:belongsTo;primaryKey:id;foreignKey:recipient_id\"`")
+	assert.Contains(t, string(content), "Sender *Users `json:\"sender,omitempty\" join:\"joinType:belongsTo;primaryKey:id;foreignKey:sender_id\"`")
+
+	content, err = os.ReadFile(filepath.Join(dir, "users.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "ReceivedMessages []*Messages `json:\"received_messages,omitempty\" join:\"joinType:hasMany;primaryKey:id;foreignKey:recipient_id\"`")
+	assert.Contains(t, string(content), "SentMessages []*Messages `json:\"sent_messages,omitempty\" join:\"joinType:hasMany;primaryKey:id;foreignKey:sender_id\"`")
 }
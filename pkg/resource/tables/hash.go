@@ -0,0 +1,140 @@
+package tables
+
+import (
+	"encoding/json"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// HashModelInput create content hash from table specification, relations and policies,
+// table statistic (size, row estimate) is ignored because always changing
+func HashModelInput(input *generator.GenerateModelInput) string {
+	table := input.Table
+	table.Bytes = 0
+	table.Size = ""
+	table.LiveRowsEstimate = 0
+	table.DeadRowsEstimate = 0
+
+	data := map[string]any{
+		"table":     table,
+		"relations": input.Relations,
+		"policies":  input.Policies,
+		"with_stub": input.WithStub,
+	}
+
+	// generated name is changed when name transformer is changed
+	if input.NameTransformer != nil {
+		data["name_transformer"] = input.NameTransformer
+	}
+
+	if len(input.StructNames) > 0 {
+		data["struct_names"] = input.StructNames
+	}
+
+	if input.JsonCase != "" {
+		data["json_case"] = input.JsonCase
+	}
+
+	if input.UuidType != "" {
+		data["uuid_type"] = input.UuidType
+	}
+
+	if input.Base != nil {
+		data["model_base"] = input.Base
+	}
+
+	// generator option that change rendered model, option is only added when set
+	// so hash of model that doesn't use the option is not changed
+	if len(input.JsonTypes) > 0 {
+		data["json_types"] = input.JsonTypes
+	}
+
+	if input.SchemaPackage {
+		data["schema_package"] = input.SchemaPackage
+	}
+
+	if input.ProjectName != "" {
+		data["project_name"] = input.ProjectName
+	}
+
+	if input.ModulePath != "" {
+		data["module_path"] = input.ModulePath
+	}
+
+	if input.ModelsPackage != "" {
+		data["models_package"] = input.ModelsPackage
+	}
+
+	if len(input.TypeOverrides) > 0 {
+		data["type_overrides"] = input.TypeOverrides
+	}
+
+	if len(input.CompositeTypes) > 0 {
+		data["composite_types"] = input.CompositeTypes
+	}
+
+	if input.SoftDeleteColumn != "" {
+		data["soft_delete_column"] = input.SoftDeleteColumn
+	}
+
+	if input.GormTags {
+		data["gorm_tags"] = input.GormTags
+	}
+
+	if input.PaginationHelpers {
+		data["pagination_helpers"] = input.PaginationHelpers
+	}
+
+	if input.BulkInsertHelpers {
+		data["bulk_insert_helpers"] = input.BulkInsertHelpers
+	}
+
+	if input.UpsertHelpers {
+		data["upsert_helpers"] = input.UpsertHelpers
+	}
+
+	if input.VersionColumn != "" {
+		data["version_column"] = input.VersionColumn
+	}
+
+	if len(input.ExcludeColumns) > 0 {
+		data["exclude_columns"] = input.ExcludeColumns
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return utils.HashByte(content)
+}
+
+// FilterChangedModelInputs split model input to changed input and unchanged table state,
+// table is unchanged if content hash is equal with previous state and model file is exist
+func FilterChangedModelInputs(inputs []*generator.GenerateModelInput, previousStates []state.TableState) (changed []*generator.GenerateModelInput, unchanged []state.TableState) {
+	mapState := make(map[string]state.TableState)
+	for i := range previousStates {
+		ts := previousStates[i]
+		mapState[getMapTableKey(ts.Table.Schema, ts.Table.Name)] = ts
+	}
+
+	for i := range inputs {
+		input := inputs[i]
+
+		ts, exist := mapState[getMapTableKey(input.Table.Schema, input.Table.Name)]
+		if !exist || ts.ContentHash == "" || !utils.IsFileExists(ts.ModelPath) {
+			changed = append(changed, input)
+			continue
+		}
+
+		if ts.ContentHash != HashModelInput(input) {
+			changed = append(changed, input)
+			continue
+		}
+
+		Logger.Trace("table is unchanged", "table", input.Table.Name)
+		unchanged = append(unchanged, ts)
+	}
+	return
+}
@@ -0,0 +1,74 @@
+package tables_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterChangedModelInputs(t *testing.T) {
+	modelPath := filepath.Join(t.TempDir(), "candidate.go")
+	assert.NoError(t, os.WriteFile(modelPath, []byte("package models"), 0644))
+
+	candidate := &generator.GenerateModelInput{
+		Table: objects.Table{Schema: "public", Name: "candidate", LiveRowsEstimate: 10},
+	}
+	scouter := &generator.GenerateModelInput{
+		Table: objects.Table{Schema: "public", Name: "scouter"},
+	}
+
+	// row estimate is ignored from hash
+	previousCandidate := *candidate
+	previousCandidate.Table.LiveRowsEstimate = 2
+
+	previousStates := []state.TableState{
+		{Table: previousCandidate.Table, ModelPath: modelPath, ContentHash: tables.HashModelInput(&previousCandidate)},
+		{Table: scouter.Table, ModelPath: modelPath, ContentHash: "outdated"},
+	}
+
+	changed, unchanged := tables.FilterChangedModelInputs([]*generator.GenerateModelInput{candidate, scouter}, previousStates)
+	assert.Equal(t, 1, len(changed))
+	assert.Equal(t, "scouter", changed[0].Table.Name)
+	assert.Equal(t, 1, len(unchanged))
+	assert.Equal(t, "candidate", unchanged[0].Table.Name)
+}
+
+func TestHashModelInput_Option(t *testing.T) {
+	input := generator.GenerateModelInput{
+		Table: objects.Table{Schema: "public", Name: "candidate"},
+	}
+	hash := tables.HashModelInput(&input)
+
+	options := map[string]func(i *generator.GenerateModelInput){
+		"json types":     func(i *generator.GenerateModelInput) { i.JsonTypes = map[string]string{"meta": "Meta"} },
+		"schema package": func(i *generator.GenerateModelInput) { i.SchemaPackage = true },
+		"module path":    func(i *generator.GenerateModelInput) { i.ModulePath = "example.com/proj" },
+		"models package": func(i *generator.GenerateModelInput) { i.ModelsPackage = "entity" },
+		"type overrides": func(i *generator.GenerateModelInput) { i.TypeOverrides = map[string]string{"money": "decimal.Decimal"} },
+		"composite types": func(i *generator.GenerateModelInput) {
+			i.CompositeTypes = map[string]string{"address": "public.address"}
+		},
+		"soft delete column":  func(i *generator.GenerateModelInput) { i.SoftDeleteColumn = "deleted_at" },
+		"gorm tags":           func(i *generator.GenerateModelInput) { i.GormTags = true },
+		"pagination helpers":  func(i *generator.GenerateModelInput) { i.PaginationHelpers = true },
+		"bulk insert helpers": func(i *generator.GenerateModelInput) { i.BulkInsertHelpers = true },
+		"upsert helpers":      func(i *generator.GenerateModelInput) { i.UpsertHelpers = true },
+		"version column":      func(i *generator.GenerateModelInput) { i.VersionColumn = "version" },
+		"exclude columns":     func(i *generator.GenerateModelInput) { i.ExcludeColumns = []string{"secret"} },
+	}
+
+	for name, setOption := range options {
+		changed := input
+		setOption(&changed)
+		assert.NotEqual(t, hash, tables.HashModelInput(&changed), name)
+	}
+
+	// hash is stable when option is not changed
+	assert.Equal(t, hash, tables.HashModelInput(&input))
+}
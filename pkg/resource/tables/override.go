@@ -0,0 +1,188 @@
+package tables
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/ory/viper"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// ----- Relation override -----
+//
+// relation override is defined in configs/relations.yaml, example :
+//
+//	relations:
+//	  - schema: public
+//	    table: class
+//	    column: teacher_id
+//	    type: belongsTo
+//	  - schema: public
+//	    table: teacher
+//	    column: teacher_id
+//	    target: topic
+//	    suppress: true
+//	  - table: teacher
+//	    through: class_audit
+//	    target: topic
+//	    suppress: true
+//
+// column is foreign key column of relation, for many to many relation
+// column is foreign key column in pivot table that refer to the table,
+// many to many relation can also be matched by pivot table with through
+type (
+	RelationOverride struct {
+		Schema   string              `mapstructure:"schema"`
+		Table    string              `mapstructure:"table"`
+		Column   string              `mapstructure:"column"`
+		Target   string              `mapstructure:"target"`
+		Through  string              `mapstructure:"through"`
+		Type     raiden.RelationType `mapstructure:"type"`
+		Suppress bool                `mapstructure:"suppress"`
+	}
+
+	RelationOverrides []RelationOverride
+)
+
+var (
+	RelationOverrideDir  = "configs"
+	RelationOverrideFile = "relations.yaml"
+)
+
+// LoadRelationOverrides load relation override file from project path,
+// return empty override if file is not exist
+func LoadRelationOverrides(projectPath string) (RelationOverrides, error) {
+	filePath := filepath.Join(projectPath, RelationOverrideDir, RelationOverrideFile)
+	if !utils.IsFileExists(filePath) {
+		return nil, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(filePath)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Relations RelationOverrides `mapstructure:"relations"`
+	}
+	if err := v.Unmarshal(&data); err != nil {
+		return nil, err
+	}
+
+	return data.Relations, nil
+}
+
+// SuppressManyToManyOverrides convert suppressed many to many edge from config to relation override,
+// edge only suppress relation from source to target so both direction must be listed to remove both
+func SuppressManyToManyOverrides(edges []raiden.ManyToManyEdge) (overrides RelationOverrides) {
+	for _, e := range edges {
+		schema, table := splitOverrideTableName(e.Source)
+		_, target := splitOverrideTableName(e.Target)
+		_, through := splitOverrideTableName(e.Through)
+		overrides = append(overrides, RelationOverride{
+			Schema:   schema,
+			Table:    table,
+			Target:   target,
+			Through:  through,
+			Suppress: true,
+		})
+	}
+	return
+}
+
+// splitOverrideTableName split schema.table, schema is empty when table is not qualified
+func splitOverrideTableName(name string) (schema, table string) {
+	if s, t, found := strings.Cut(strings.TrimSpace(name), "."); found {
+		return s, t
+	}
+	return "", strings.TrimSpace(name)
+}
+
+func (o RelationOverride) match(schema, table string, r *state.Relation) bool {
+	overrideSchema := o.Schema
+	if overrideSchema == "" {
+		overrideSchema = "public"
+	}
+
+	if overrideSchema != schema || o.Table != table {
+		return false
+	}
+
+	column, through := r.ForeignKey, ""
+	if r.RelationType == raiden.RelationTypeManyToMany && r.JoinRelation != nil {
+		column, through = r.JoinsSourceForeignKey, r.Through
+	}
+
+	if o.Through != "" && o.Through != through {
+		return false
+	}
+
+	// column can be omitted when relation is matched by pivot table
+	if (o.Column != "" || o.Through == "") && o.Column != column {
+		return false
+	}
+
+	return o.Target == "" || o.Target == r.Table
+}
+
+// apply override to relation of table, suppressed relation is removed from result
+func (overrides RelationOverrides) apply(schema, table string, relations []*state.Relation) (result []*state.Relation) {
+	if len(overrides) == 0 {
+		return relations
+	}
+
+	for i := range relations {
+		r := relations[i]
+		if r == nil {
+			continue
+		}
+
+		suppressed := false
+		for _, o := range overrides {
+			if !o.match(schema, table, r) {
+				continue
+			}
+
+			if o.Suppress && o.Through != "" {
+				Logger.Info("suppress inferred many to many relation", "table", table, "through", o.Through, "target", r.Table)
+				suppressed = true
+				break
+			}
+
+			if o.Suppress {
+				Logger.Info("suppress inferred relation", "table", table, "column", o.Column, "target", r.Table, "type", r.RelationType)
+				suppressed = true
+				break
+			}
+
+			if o.Type == "" {
+				continue
+			}
+
+			switch o.Type {
+			case raiden.RelationTypeBelongsTo, raiden.RelationTypeHasOne, raiden.RelationTypeHasMany:
+				if r.RelationType == raiden.RelationTypeManyToMany {
+					Logger.Warn("many to many relation can only be suppressed", "table", table, "column", o.Column, "target", r.Table)
+					continue
+				}
+
+				Logger.Info("override inferred relation", "table", table, "column", o.Column, "target", r.Table, "from", r.RelationType, "to", o.Type)
+				r.RelationType = o.Type
+				r.Type = "*" + utils.SnakeCaseToPascalCase(r.Table)
+				if o.Type == raiden.RelationTypeHasMany {
+					r.Type = "[]" + r.Type
+				}
+			default:
+				Logger.Warn("unsupported relation override type", "table", table, "column", o.Column, "type", o.Type)
+			}
+		}
+
+		if !suppressed {
+			result = append(result, r)
+		}
+	}
+	return
+}
@@ -98,6 +98,7 @@ const DiffTemplate = `
 {{- .Metadata}}
 {{- .Acl}}
 {{- .Relations}}
+{{- .Indexes}}
   `
 
 func GenerateDiffMessage(diffData CompareDiffResult, sRelation MapRelations, tRelation MapRelations) (string, error) {
@@ -107,7 +108,7 @@ func GenerateDiffMessage(diffData CompareDiffResult, sRelation MapRelations, tRe
 	fromIndent := printIndent("from:")
 	toIndent := printIndent("to:")
 
-	var diffColumnStr, diffMetadata, diffAclStr, diffRelationStr string
+	var diffColumnStr, diffMetadata, diffAclStr, diffRelationStr, diffIndexStr string
 
 	// start generate message
 	if len(diffData.DiffItems.ChangeItems) > 0 {
@@ -343,12 +344,44 @@ func GenerateDiffMessage(diffData CompareDiffResult, sRelation MapRelations, tRe
 		)
 	}
 
+	if len(diffData.DiffItems.ChangeIndexItems) > 0 {
+		mapSIndex := make(map[string]objects.TableIndex)
+		for _, idx := range diffData.SourceResource.Indexes {
+			mapSIndex[idx.Name] = idx
+		}
+
+		mapTIndex := make(map[string]objects.TableIndex)
+		for _, idx := range diffData.TargetResource.Indexes {
+			mapTIndex[idx.Name] = idx
+		}
+
+		var diffIndexes []string
+		for _, item := range diffData.DiffItems.ChangeIndexItems {
+			tIndex, sIndex := "not declared", "not declared"
+			if idx, exist := mapTIndex[item.Data.Name]; exist {
+				tIndex = fmt.Sprintf("`%s`", generator.BuildIndexTag(idx))
+			}
+
+			if idx, exist := mapSIndex[item.Data.Name]; exist {
+				sIndex = fmt.Sprintf("`%s`", generator.BuildIndexTag(idx))
+			}
+
+			diffIndexes = append(diffIndexes, fmt.Sprintf(
+				"%s %s %s\n%s %s %s",
+				symbol, fromIndent, tIndex,
+				symbol, toIndent, sIndex,
+			))
+		}
+		diffIndexStr = fmt.Sprintf("\n%s\n", strings.Join(diffIndexes, "\n"))
+	}
+
 	param := map[string]any{
 		"Columns":  diffColumnStr,
 		"Metadata": diffMetadata,
 		// Handle ACL Compare
 		"Acl":       diffAclStr,
 		"Relations": diffRelationStr,
+		"Indexes":   diffIndexStr,
 	}
 
 	tmplInstance := template.New("generate diff")
@@ -405,6 +438,12 @@ const DiffChangeUpdateTemplate = `  - Update Table {{ .Name }}
       {{.}}
       {{- end }}
   {{- end -}}
+  {{- if gt (len .ChangeIndexes) 0}}
+      Change Indexes
+      {{- range .ChangeIndexes}}
+      {{.}}
+      {{- end }}
+  {{- end -}}
   `
 
 func GenerateDiffChangeMessage(newTable []string, updateTable []string, deleteTable []string) (string, error) {
@@ -431,7 +470,7 @@ func GenerateDiffChangeMessage(newTable []string, updateTable []string, deleteTa
 func GenerateDiffChangeUpdateMessage(name string, item MigrateItem) (string, error) {
 	diffItems := item.MigrationItems
 
-	var changeMsgArr, changeColumnMsgArr, changeRelationArr []string
+	var changeMsgArr, changeColumnMsgArr, changeRelationArr, changeIndexArr []string
 	for i := range diffItems.ChangeItems {
 		c := diffItems.ChangeItems[i]
 		switch c {
@@ -522,11 +561,25 @@ func GenerateDiffChangeUpdateMessage(name string, item MigrateItem) (string, err
 		}
 	}
 
+	for i := range diffItems.ChangeIndexItems {
+		c := diffItems.ChangeIndexItems[i]
+
+		switch c.Type {
+		case objects.UpdateIndexCreate:
+			changeIndexArr = append(changeIndexArr, fmt.Sprintf("- %s : %s", "create new index", c.Data.Name))
+		case objects.UpdateIndexUpdate:
+			changeIndexArr = append(changeIndexArr, fmt.Sprintf("- %s : %s", "update index", c.Data.Name))
+		case objects.UpdateIndexDelete:
+			changeIndexArr = append(changeIndexArr, fmt.Sprintf("- %s : %s", "delete index", c.Data.Name))
+		}
+	}
+
 	param := map[string]any{
 		"Name":            name,
 		"ChangeItems":     changeMsgArr,
 		"ChangeColumns":   changeColumnMsgArr,
 		"ChangeRelations": changeRelationArr,
+		"ChangeIndexes":   changeIndexArr,
 	}
 
 	tmplInstance := template.New("generate diff change update")
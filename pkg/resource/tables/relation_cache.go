@@ -0,0 +1,121 @@
+package tables
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// relationHashTable is table metadata that used to infer relation,
+// unique column and key decide has one relation and pivot table
+type relationHashTable struct {
+	Schema        string                       `json:"schema"`
+	Name          string                       `json:"name"`
+	Columns       []relationHashColumn         `json:"columns"`
+	PrimaryKeys   []objects.PrimaryKey         `json:"primary_keys"`
+	UniqueIndexes []objects.TableUniqueIndex   `json:"unique_indexes"`
+	Relationships []objects.TablesRelationship `json:"relationships"`
+}
+
+type relationHashColumn struct {
+	Name     string `json:"name"`
+	IsUnique bool   `json:"is_unique"`
+}
+
+// HashRelationInput create hash of table relation metadata, resolver, override and pivot rule,
+// hash is changed when any relation is changed so cached relation must be recomputed
+func HashRelationInput(tables []objects.Table, resolver RelationResolver, overrides ...RelationOverride) string {
+	mapTable := tableToMap(tables)
+
+	hashTables := make([]relationHashTable, 0, len(mapTable))
+	for _, k := range sortedMapTableKeys(mapTable) {
+		t := mapTable[k]
+		ht := relationHashTable{
+			Schema:        t.Schema,
+			Name:          t.Name,
+			PrimaryKeys:   t.PrimaryKeys,
+			UniqueIndexes: t.UniqueIndexes,
+			Relationships: t.Relationships,
+		}
+
+		for _, c := range t.Columns {
+			ht.Columns = append(ht.Columns, relationHashColumn{Name: c.Name, IsUnique: c.IsUnique})
+		}
+		hashTables = append(hashTables, ht)
+	}
+
+	// resolver that embed default resolver use its pivot rule
+	pivotRule := DefaultPivotRule
+	if r, ok := getRelationResolver(resolver).(interface{ GetPivotRule() PivotRule }); ok {
+		pivotRule = r.GetPivotRule()
+	}
+
+	data := map[string]any{
+		"tables":     hashTables,
+		"overrides":  overrides,
+		"pivot_rule": pivotRule,
+		"resolver":   fmt.Sprintf("%T", getRelationResolver(resolver)),
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return utils.HashByte(content)
+}
+
+// BuildGenerateModelInputsWithCache build model input with relation from cache when hash of relation
+// metadata is equal with cache hash, otherwise relation is recomputed and cache is replaced
+func BuildGenerateModelInputsWithCache(tables []objects.Table, policies objects.Policies, resolver RelationResolver, cache *state.RelationCache, overrides ...RelationOverride) []*generator.GenerateModelInput {
+	if cache == nil {
+		return BuildGenerateModelInputsWithResolver(tables, policies, resolver, overrides...)
+	}
+
+	mapTable := tableToMap(tables)
+	hash := HashRelationInput(tables, resolver, overrides...)
+	if hash != "" && cache.Hash == hash {
+		Logger.Debug("relation is unchanged, use cached relation", "hash", hash)
+		return buildGenerateModelInput(mapTable, fromRelationCache(cache.Relations), policies)
+	}
+
+	Logger.Debug("relation is changed, build relation", "hash", hash)
+	mapRelations := buildGenerateMapRelations(mapTable, resolver, overrides...)
+	*cache = state.RelationCache{Hash: hash, Relations: toRelationCache(mapRelations)}
+	return buildGenerateModelInput(mapTable, mapRelations, policies)
+}
+
+func toRelationCache(mapRelations MapRelations) map[string][]state.Relation {
+	cache := make(map[string][]state.Relation, len(mapRelations))
+	for k, relations := range mapRelations {
+		for _, r := range relations {
+			if r != nil {
+				cache[k] = append(cache[k], copyRelation(*r))
+			}
+		}
+	}
+	return cache
+}
+
+func fromRelationCache(cache map[string][]state.Relation) MapRelations {
+	mapRelations := make(MapRelations, len(cache))
+	for k, relations := range cache {
+		for i := range relations {
+			r := copyRelation(relations[i])
+			mapRelations[k] = append(mapRelations[k], &r)
+		}
+	}
+	return mapRelations
+}
+
+// copyRelation copy join relation, so relation that modified after build doesn't change cache
+func copyRelation(r state.Relation) state.Relation {
+	if r.JoinRelation != nil {
+		join := *r.JoinRelation
+		r.JoinRelation = &join
+	}
+	return r
+}
@@ -0,0 +1,101 @@
+package tables_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateRelationTables create table that refer to previous table,
+// relation is stored in both source and target table like pg-meta
+func generateRelationTables(total int) []objects.Table {
+	sourceTables := make([]objects.Table, total)
+	for i := range sourceTables {
+		name := fmt.Sprintf("table_%d", i)
+		sourceTables[i] = objects.Table{
+			ID:     i + 1,
+			Schema: "public",
+			Name:   name,
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "parent_id", DataType: "bigint", IsNullable: true},
+				{Name: "name", DataType: "text", IsNullable: true},
+			},
+			PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: name}},
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		r := objects.TablesRelationship{
+			ConstraintName:    fmt.Sprintf("%s_parent_id_fkey", name),
+			SourceSchema:      "public",
+			SourceTableName:   name,
+			SourceColumnName:  "parent_id",
+			TargetTableSchema: "public",
+			TargetTableName:   sourceTables[i-1].Name,
+			TargetColumnName:  "id",
+		}
+		sourceTables[i].Relationships = append(sourceTables[i].Relationships, r)
+		sourceTables[i-1].Relationships = append(sourceTables[i-1].Relationships, r)
+	}
+	return sourceTables
+}
+
+func TestBuildGenerateModelInputsWithCache(t *testing.T) {
+	sourceTables := generateRelationTables(3)
+
+	cache := state.RelationCache{}
+	expected := tables.BuildGenerateModelInputs(sourceTables, nil)
+	rs := tables.BuildGenerateModelInputsWithCache(sourceTables, nil, nil, &cache)
+	assert.Equal(t, expected, rs)
+	assert.Equal(t, tables.HashRelationInput(sourceTables, nil), cache.Hash)
+	assert.Equal(t, 3, len(cache.Relations))
+
+	// cached relation is used when hash is equal
+	cachedHash := cache.Hash
+	cache.Relations["public.table_0"][0].Tag = "cached"
+	rs = tables.BuildGenerateModelInputsWithCache(sourceTables, nil, nil, &cache)
+	assert.Equal(t, "cached", rs[0].Relations[0].Tag)
+
+	// modified relation doesn't change cache
+	rs[0].Relations[0].Tag = "modified"
+	assert.Equal(t, "cached", cache.Relations["public.table_0"][0].Tag)
+
+	// column that not used by relation doesn't invalidate cache
+	sourceTables[0].Columns[2].DataType = "varchar"
+	assert.Equal(t, cachedHash, tables.HashRelationInput(sourceTables, nil))
+
+	// cache is rebuilt when relation is changed
+	sourceTables = generateRelationTables(4)
+	rs = tables.BuildGenerateModelInputsWithCache(sourceTables, nil, nil, &cache)
+	assert.Equal(t, tables.BuildGenerateModelInputs(sourceTables, nil), rs)
+	assert.NotEqual(t, cachedHash, cache.Hash)
+	assert.Equal(t, 4, len(cache.Relations))
+	assert.NotEqual(t, "cached", cache.Relations["public.table_0"][0].Tag)
+}
+
+func BenchmarkBuildGenerateModelInputs(b *testing.B) {
+	sourceTables := generateRelationTables(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tables.BuildGenerateModelInputs(sourceTables, nil)
+	}
+}
+
+func BenchmarkBuildGenerateModelInputsWithCache(b *testing.B) {
+	sourceTables := generateRelationTables(500)
+	cache := state.RelationCache{}
+	tables.BuildGenerateModelInputsWithCache(sourceTables, nil, nil, &cache)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tables.BuildGenerateModelInputsWithCache(sourceTables, nil, nil, &cache)
+	}
+}
@@ -0,0 +1,49 @@
+package tables
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+)
+
+// ValidateRelationTargets check that target table of every relation is generated as model.
+// Table can be excluded from import (filtered, other schema or excluded view), relation to
+// that table refer to struct that not exist. Relation is removed with warning, or return
+// error that list all missing target when strict is true.
+func ValidateRelationTargets(inputs []*generator.GenerateModelInput, strict bool) error {
+	mapInput := make(map[string]bool)
+	for _, input := range inputs {
+		mapInput[getMapTableKey(input.Table.Schema, input.Table.Name)] = true
+	}
+
+	var missing []string
+	for _, input := range inputs {
+		relations := make([]state.Relation, 0, len(input.Relations))
+		for _, r := range input.Relations {
+			schema := r.Schema
+			if schema == "" {
+				schema = input.Table.Schema
+			}
+
+			if mapInput[getMapTableKey(schema, r.Table)] {
+				relations = append(relations, r)
+				continue
+			}
+
+			if strict {
+				missing = append(missing, fmt.Sprintf("%s.%s -> %s.%s", input.Table.Schema, input.Table.Name, schema, r.Table))
+				continue
+			}
+
+			Logger.Warn("skip relation, target table is not imported", "table", input.Table.Name, "schema", input.Table.Schema, "target", r.Table, "target-schema", schema, "type", r.RelationType)
+		}
+		input.Relations = relations
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("relation target table is not imported : %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
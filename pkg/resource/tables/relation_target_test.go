@@ -0,0 +1,49 @@
+package tables_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRelationTargetTables() []objects.Table {
+	// public.users and audit.logs is not imported
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "orders_user_id_fkey", SourceSchema: "public", SourceTableName: "orders", SourceColumnName: "user_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+		{ConstraintName: "logs_order_id_fkey", SourceSchema: "audit", SourceTableName: "logs", SourceColumnName: "order_id", TargetTableSchema: "public", TargetTableName: "orders", TargetColumnName: "id"},
+		{ConstraintName: "items_order_id_fkey", SourceSchema: "public", SourceTableName: "items", SourceColumnName: "order_id", TargetTableSchema: "public", TargetTableName: "orders", TargetColumnName: "id"},
+	}
+
+	return []objects.Table{
+		{ID: 1, Schema: "public", Name: "orders", Relationships: relationships},
+		{ID: 2, Schema: "public", Name: "items", Relationships: relationships[2:]},
+	}
+}
+
+func TestValidateRelationTargets(t *testing.T) {
+	inputs := tables.BuildGenerateModelInputs(newRelationTargetTables(), nil)
+	assert.Equal(t, 2, len(inputs))
+
+	items, orders := inputs[0], inputs[1]
+	assert.Equal(t, 3, len(orders.Relations))
+
+	err := tables.ValidateRelationTargets(inputs, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(orders.Relations))
+	assert.Equal(t, "items", orders.Relations[0].Table)
+	assert.Equal(t, raiden.RelationTypeHasMany, orders.Relations[0].RelationType)
+
+	assert.Equal(t, 1, len(items.Relations))
+	assert.Equal(t, "orders", items.Relations[0].Table)
+}
+
+func TestValidateRelationTargets_Strict(t *testing.T) {
+	inputs := tables.BuildGenerateModelInputs(newRelationTargetTables(), nil)
+
+	err := tables.ValidateRelationTargets(inputs, true)
+	assert.EqualError(t, err, "relation target table is not imported : public.orders -> public.users, public.orders -> audit.logs")
+}
@@ -0,0 +1,67 @@
+package tables
+
+import (
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// RelationResolver infer model relation from foreign key of imported table, custom resolver
+// can be used to infer relation from naming convention or external mapping file.
+// DefaultRelationResolver can be embedded for only replace part of the inference
+type RelationResolver interface {
+	// ScanRelations return relation of table and belongs to relation
+	// that is candidate of many to many relation
+	ScanRelations(mapTable MapTable, table *objects.Table) (relations []*state.Relation, manyToManyCandidates []*ManyToManyTable)
+
+	// IsManyToManyPivot check if table is pivot table of many to many candidate
+	IsManyToManyPivot(table *objects.Table, candidates []*ManyToManyTable) bool
+
+	// MergeManyToMany add many to many relation between every candidate of pivot table
+	MergeManyToMany(candidates []*ManyToManyTable, mapRelations MapRelations, overrides ...RelationOverride)
+
+	// ChildRelation return has one or has many relation from parent table to child table,
+	// relationships is every row of single foreign key constraint owned by child table
+	ChildRelation(child *objects.Table, relationships []objects.TablesRelationship) state.Relation
+}
+
+// DefaultRelationResolver infer relation with foreign key, unique constraint and pivot rule,
+// DefaultPivotRule is used when pivot rule is not set
+type DefaultRelationResolver struct {
+	PivotRule *PivotRule
+}
+
+func (DefaultRelationResolver) ScanRelations(mapTable MapTable, table *objects.Table) ([]*state.Relation, []*ManyToManyTable) {
+	return scanGenerateTableRelation(mapTable, table)
+}
+
+func (r DefaultRelationResolver) IsManyToManyPivot(table *objects.Table, candidates []*ManyToManyTable) bool {
+	return isManyToManyPivot(table, candidates, r.GetPivotRule())
+}
+
+// GetPivotRule return pivot rule of resolver
+func (r DefaultRelationResolver) GetPivotRule() PivotRule {
+	if r.PivotRule == nil {
+		return DefaultPivotRule
+	}
+	return *r.PivotRule
+}
+
+func (DefaultRelationResolver) MergeManyToMany(candidates []*ManyToManyTable, mapRelations MapRelations, overrides ...RelationOverride) {
+	mergeGenerateManyToManyCandidate(candidates, mapRelations, overrides...)
+}
+
+func (DefaultRelationResolver) ChildRelation(child *objects.Table, relationships []objects.TablesRelationship) state.Relation {
+	groups := groupTableRelationships(relationships)
+	if len(groups) == 0 {
+		return state.Relation{}
+	}
+	return buildGenerateChildRelation(child, groups[0])
+}
+
+// getRelationResolver return default resolver when resolver is not set
+func getRelationResolver(resolver RelationResolver) RelationResolver {
+	if resolver == nil {
+		return DefaultRelationResolver{}
+	}
+	return resolver
+}
@@ -0,0 +1,94 @@
+package tables_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+// oneToOneResolver treat every child relation as has one relation
+type oneToOneResolver struct {
+	tables.DefaultRelationResolver
+}
+
+func (oneToOneResolver) ChildRelation(child *objects.Table, relationships []objects.TablesRelationship) state.Relation {
+	r := relationships[0]
+	return state.Relation{
+		Table:        r.SourceTableName,
+		Schema:       r.SourceSchema,
+		Type:         "*Profile",
+		RelationType: raiden.RelationTypeHasOne,
+		PrimaryKey:   r.TargetColumnName,
+		ForeignKey:   r.SourceColumnName,
+	}
+}
+
+func TestBuildGenerateModelInputsWithResolver(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "profile_user_id_fkey", SourceSchema: "public", SourceTableName: "profile", SourceColumnName: "user_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id"},
+	}
+
+	// parent table doesn't report the relation, so relation is added as inverse relation
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "users"},
+		{ID: 2, Schema: "public", Name: "profile", Relationships: relationships},
+	}
+
+	// default resolver keep current behavior
+	rs := tables.BuildGenerateModelInputsWithResolver(sourceTables, nil, nil)
+	assert.Equal(t, rs, tables.BuildGenerateModelInputs(sourceTables, nil))
+	assert.Equal(t, raiden.RelationTypeHasMany, rs[1].Relations[0].RelationType)
+
+	rs = tables.BuildGenerateModelInputsWithResolver(sourceTables, nil, oneToOneResolver{})
+	assert.Equal(t, "users", rs[1].Table.Name)
+	assert.Equal(t, 1, len(rs[1].Relations))
+	assert.Equal(t, raiden.RelationTypeHasOne, rs[1].Relations[0].RelationType)
+	assert.Equal(t, "*Profile", rs[1].Relations[0].Type)
+
+	// belongs to relation is still inferred by embedded default resolver
+	assert.Equal(t, raiden.RelationTypeBelongsTo, rs[0].Relations[0].RelationType)
+}
+
+func TestDefaultRelationResolver_PivotRule(t *testing.T) {
+	relationships := []objects.TablesRelationship{
+		{ConstraintName: "enrollment_student_id_fkey", SourceSchema: "public", SourceTableName: "enrollment", SourceColumnName: "student_id", TargetTableSchema: "public", TargetTableName: "student", TargetColumnName: "id"},
+		{ConstraintName: "enrollment_course_id_fkey", SourceSchema: "public", SourceTableName: "enrollment", SourceColumnName: "course_id", TargetTableSchema: "public", TargetTableName: "course", TargetColumnName: "id"},
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "student", Columns: []objects.Column{{Name: "id", DataType: "bigint"}}, PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships[:1]},
+		{ID: 2, Schema: "public", Name: "course", Columns: []objects.Column{{Name: "id", DataType: "bigint"}}, PrimaryKeys: []objects.PrimaryKey{{Name: "id"}}, Relationships: relationships[1:]},
+		{
+			ID:     3,
+			Schema: "public",
+			Name:   "enrollment",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "student_id", DataType: "bigint"},
+				{Name: "course_id", DataType: "bigint"},
+				{Name: "grade", DataType: "text", IsNullable: true},
+			},
+			PrimaryKeys:   []objects.PrimaryKey{{Name: "id"}},
+			Relationships: relationships,
+		},
+	}
+
+	// enrollment have grade column, so it is not pivot table with default rule
+	rs := tables.BuildGenerateModelInputsWithResolver(sourceTables, nil, tables.DefaultRelationResolver{})
+	assert.Equal(t, 1, len(rs[0].Relations))
+
+	rule := tables.NewPivotRule(raiden.PivotRule{MaxExtraColumn: 1})
+	assert.Equal(t, tables.PivotRule{MinForeignKey: 2, MaxForeignKey: 2, MaxExtraColumn: 1}, rule)
+
+	resolver := tables.DefaultRelationResolver{PivotRule: &rule}
+	rs = tables.BuildGenerateModelInputsWithResolver(sourceTables, nil, resolver)
+	assert.Equal(t, 2, len(rs[0].Relations))
+	assert.Equal(t, raiden.RelationTypeManyToMany, rs[0].Relations[1].RelationType)
+
+	// relation cache is invalidated when pivot rule is changed
+	assert.NotEqual(t, tables.HashRelationInput(sourceTables, nil), tables.HashRelationInput(sourceTables, resolver))
+}
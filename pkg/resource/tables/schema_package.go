@@ -0,0 +1,82 @@
+package tables
+
+import (
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+)
+
+// RemoveCyclicSchemaRelations remove cross schema relation that cause import cycle
+// when model is generated into schema subpackage. Relation is processed by relation type,
+// belongs to relation (table that own foreign key) is kept first, then has one, has many and many to many.
+func RemoveCyclicSchemaRelations(inputs []*generator.GenerateModelInput) {
+	graph := make(map[string]map[string]bool)
+	mapRemoved := make(map[*generator.GenerateModelInput]map[int]bool)
+
+	relationTypes := []raiden.RelationType{
+		raiden.RelationTypeBelongsTo,
+		raiden.RelationTypeHasOne,
+		raiden.RelationTypeHasMany,
+		raiden.RelationTypeManyToMany,
+	}
+
+	for _, relationType := range relationTypes {
+		for _, input := range inputs {
+			source := input.Table.Schema
+			for i, r := range input.Relations {
+				if r.RelationType != relationType || r.Schema == "" || r.Schema == source {
+					continue
+				}
+
+				if isReachable(graph, r.Schema, source) {
+					Logger.Warn("skip cross schema relation, relation will cause import cycle", "table", input.Table.Name, "schema", source, "target", r.Table, "target-schema", r.Schema)
+					if _, exist := mapRemoved[input]; !exist {
+						mapRemoved[input] = make(map[int]bool)
+					}
+					mapRemoved[input][i] = true
+					continue
+				}
+
+				if _, exist := graph[source]; !exist {
+					graph[source] = make(map[string]bool)
+				}
+				graph[source][r.Schema] = true
+			}
+		}
+	}
+
+	for input, removed := range mapRemoved {
+		relations := make([]state.Relation, 0, len(input.Relations))
+		for i := range input.Relations {
+			if !removed[i] {
+				relations = append(relations, input.Relations[i])
+			}
+		}
+		input.Relations = relations
+	}
+}
+
+// isReachable check if there is path from node to another node in graph, used for check
+// if target schema package already import source schema package or target model embed source model
+func isReachable(graph map[string]map[string]bool, from, to string) bool {
+	visited := make(map[string]bool)
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == to {
+			return true
+		}
+
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		for next := range graph[current] {
+			queue = append(queue, next)
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package tables_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveCyclicSchemaRelations(t *testing.T) {
+	relation := objects.TablesRelationship{
+		ConstraintName: "profile_user_id_fkey", SourceSchema: "public", SourceTableName: "profile", SourceColumnName: "user_id",
+		TargetTableSchema: "auth", TargetTableName: "users", TargetColumnName: "id",
+	}
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "auth", Name: "users", Relationships: []objects.TablesRelationship{relation}},
+		{ID: 2, Schema: "public", Name: "profile", Relationships: []objects.TablesRelationship{relation}},
+	}
+
+	rs := tables.BuildGenerateModelInputs(sourceTables, nil)
+	assert.Equal(t, 2, len(rs))
+	assert.Equal(t, 1, len(rs[0].Relations))
+	assert.Equal(t, "public", rs[0].Relations[0].Schema)
+	assert.Equal(t, "auth", rs[1].Relations[0].Schema)
+
+	tables.RemoveCyclicSchemaRelations(rs)
+
+	// auth.users has many public.profile is removed, because public already import auth
+	assert.Equal(t, "users", rs[0].Table.Name)
+	assert.Equal(t, 0, len(rs[0].Relations))
+
+	assert.Equal(t, "profile", rs[1].Table.Name)
+	assert.Equal(t, 1, len(rs[1].Relations))
+	assert.Equal(t, raiden.RelationTypeBelongsTo, rs[1].Relations[0].RelationType)
+}
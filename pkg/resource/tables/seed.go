@@ -0,0 +1,77 @@
+package tables
+
+import (
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+// SortTablesByDependency sort table so parent table is placed before child table that
+// reference it, only relation between the given table is considered. Table in dependency
+// cycle can't be ordered and is placed last in schema and name order.
+func SortTablesByDependency(tables []objects.Table, mapRelations MapRelations) []objects.Table {
+	mapTable := tableToMap(tables)
+	keys := sortedMapTableKeys(mapTable)
+
+	// child table depend on the table targeted by its belongs to relation
+	dependencies := make(map[string]map[string]bool)
+	for _, k := range keys {
+		t := mapTable[k]
+		for _, r := range mapRelations[k] {
+			if r == nil || r.RelationType != raiden.RelationTypeBelongsTo {
+				continue
+			}
+
+			schema := r.Schema
+			if schema == "" {
+				schema = t.Schema
+			}
+
+			parent := getMapTableKey(schema, r.Table)
+			if _, exist := mapTable[parent]; !exist || parent == k {
+				continue
+			}
+
+			if _, exist := dependencies[k]; !exist {
+				dependencies[k] = make(map[string]bool)
+			}
+			dependencies[k][parent] = true
+		}
+	}
+
+	sorted := make([]objects.Table, 0, len(keys))
+	visited := make(map[string]bool)
+	for len(sorted) < len(keys) {
+		var added bool
+		for _, k := range keys {
+			if visited[k] || !isDependencySatisfied(dependencies[k], visited) {
+				continue
+			}
+			visited[k] = true
+			sorted = append(sorted, *mapTable[k])
+			added = true
+		}
+
+		if added {
+			continue
+		}
+
+		for _, k := range keys {
+			if visited[k] {
+				continue
+			}
+			Logger.Warn("table dependency cycle found, table is not ordered by relation", "schema", mapTable[k].Schema, "table", mapTable[k].Name)
+			visited[k] = true
+			sorted = append(sorted, *mapTable[k])
+		}
+	}
+	return sorted
+}
+
+func isDependencySatisfied(dependencies map[string]bool, visited map[string]bool) bool {
+	for d := range dependencies {
+		if !visited[d] {
+			return false
+		}
+	}
+	return true
+}
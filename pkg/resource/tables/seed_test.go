@@ -0,0 +1,35 @@
+package tables_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortTablesByDependency(t *testing.T) {
+	roleRelation := objects.TablesRelationship{
+		ConstraintName: "users_role_id_fkey", SourceSchema: "public", SourceTableName: "users", SourceColumnName: "role_id", TargetTableSchema: "auth", TargetTableName: "roles", TargetColumnName: "id",
+	}
+	userRelation := objects.TablesRelationship{
+		ConstraintName: "orders_user_id_fkey", SourceSchema: "public", SourceTableName: "orders", SourceColumnName: "user_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id",
+	}
+	selfRelation := objects.TablesRelationship{
+		ConstraintName: "users_referrer_id_fkey", SourceSchema: "public", SourceTableName: "users", SourceColumnName: "referrer_id", TargetTableSchema: "public", TargetTableName: "users", TargetColumnName: "id",
+	}
+
+	sourceTables := []objects.Table{
+		{ID: 1, Schema: "public", Name: "orders", Relationships: []objects.TablesRelationship{userRelation}},
+		{ID: 2, Schema: "public", Name: "users", Relationships: []objects.TablesRelationship{roleRelation, userRelation, selfRelation}},
+		{ID: 3, Schema: "auth", Name: "roles", Relationships: []objects.TablesRelationship{roleRelation}},
+	}
+
+	sorted := tables.SortTablesByDependency(sourceTables, tables.BuildGenerateMapRelations(sourceTables))
+
+	var names []string
+	for _, st := range sorted {
+		names = append(names, st.Schema+"."+st.Name)
+	}
+	assert.Equal(t, []string{"auth.roles", "public.users", "public.orders"}, names)
+}
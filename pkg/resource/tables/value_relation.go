@@ -0,0 +1,83 @@
+package tables
+
+import (
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// ApplyValueRelations generate relation as value instead of pointer :
+//   - has many and many to many relation is generated as slice of value (e.g []Orders)
+//   - belongs to and has one relation is generated as value when foreign key column is not nullable
+//
+// relation that refer to the same table or cause recursive struct type
+// (e.g a.b_id -> b and b.a_id -> a) is kept as pointer
+func ApplyValueRelations(inputs []*generator.GenerateModelInput) {
+	graph := make(map[string]map[string]bool)
+
+	for _, input := range inputs {
+		source := getMapTableKey(input.Table.Schema, input.Table.Name)
+		for i := range input.Relations {
+			r := &input.Relations[i]
+			typeName := strings.TrimLeft(r.Type, "[]*")
+
+			switch r.RelationType {
+			case raiden.RelationTypeHasMany, raiden.RelationTypeManyToMany:
+				r.Type = "[]" + typeName
+			case raiden.RelationTypeBelongsTo, raiden.RelationTypeHasOne:
+				schema := r.Schema
+				if schema == "" {
+					schema = input.Table.Schema
+				}
+
+				// self relation is named by foreign key instead of table name
+				target := getMapTableKey(schema, r.Table)
+				isSelfRelation := target == source || (schema == input.Table.Schema && typeName == utils.SnakeCaseToPascalCase(input.Table.Name))
+				if isSelfRelation || !isNotNullForeignKey(&input.Table, r.ForeignKey, r.ForeignKeys) {
+					continue
+				}
+
+				if isReachable(graph, target, source) {
+					Logger.Warn("keep relation as pointer, value relation will cause recursive type", "table", input.Table.Name, "target", r.Table)
+					continue
+				}
+
+				if _, exist := graph[source]; !exist {
+					graph[source] = make(map[string]bool)
+				}
+				graph[source][target] = true
+				r.Type = typeName
+			}
+		}
+	}
+}
+
+// isNotNullForeignKey check if all foreign key column is owned by table and not nullable,
+// has one relation that foreign key is owned by related table is always nullable
+func isNotNullForeignKey(table *objects.Table, foreignKey string, foreignKeys []string) bool {
+	columns := foreignKeys
+	if len(columns) == 0 {
+		columns = []string{foreignKey}
+	}
+
+	for _, fk := range columns {
+		found := false
+		for _, c := range table.Columns {
+			if c.Name == fk {
+				if c.IsNullable {
+					return false
+				}
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+	return true
+}
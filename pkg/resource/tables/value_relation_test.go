@@ -0,0 +1,60 @@
+package tables_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/resource/tables"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyValueRelations(t *testing.T) {
+	orders := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Schema: "public", Name: "orders",
+			Columns: []objects.Column{
+				{Name: "id"},
+				{Name: "user_id", IsNullable: false},
+				{Name: "coupon_id", IsNullable: true},
+				{Name: "parent_id", IsNullable: false},
+			},
+		},
+		Relations: []state.Relation{
+			{Table: "users", Schema: "public", Type: "*Users", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "user_id"},
+			{Table: "coupon", Schema: "public", Type: "*Coupon", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "coupon_id"},
+			{Table: "parent", Schema: "public", Type: "*Orders", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "parent_id"},
+			{Table: "order_items", Schema: "public", Type: "[]*OrderItems", RelationType: raiden.RelationTypeHasMany, PrimaryKey: "id", ForeignKey: "order_id"},
+		},
+	}
+
+	users := &generator.GenerateModelInput{
+		Table: objects.Table{
+			Schema: "public", Name: "users",
+			Columns: []objects.Column{
+				{Name: "id"},
+				{Name: "last_order_id", IsNullable: false},
+			},
+		},
+		Relations: []state.Relation{
+			{Table: "orders", Schema: "public", Type: "*Orders", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "last_order_id"},
+			{Table: "profile", Schema: "public", Type: "*Profile", RelationType: raiden.RelationTypeHasOne, PrimaryKey: "id", ForeignKey: "user_id"},
+			{Table: "roles", Schema: "public", Type: "[]*Roles", RelationType: raiden.RelationTypeManyToMany},
+		},
+	}
+
+	tables.ApplyValueRelations([]*generator.GenerateModelInput{orders, users})
+
+	assert.Equal(t, "Users", orders.Relations[0].Type)
+	assert.Equal(t, "*Coupon", orders.Relations[1].Type)
+	assert.Equal(t, "*Orders", orders.Relations[2].Type)
+	assert.Equal(t, "[]OrderItems", orders.Relations[3].Type)
+
+	// orders already embed users as value
+	assert.Equal(t, "*Orders", users.Relations[0].Type)
+	// foreign key is owned by profile
+	assert.Equal(t, "*Profile", users.Relations[1].Type)
+	assert.Equal(t, "[]Roles", users.Relations[2].Type)
+}
@@ -0,0 +1,73 @@
+package triggers
+
+import (
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+type CompareDiffResult struct {
+	Name           string
+	SourceResource objects.Trigger
+	TargetResource objects.Trigger
+	IsConflict     bool
+}
+
+func Compare(source []objects.Trigger, target []objects.Trigger) error {
+	diffResult, err := CompareList(source, target)
+	if err != nil {
+		return err
+	}
+	return PrintDiffResult(diffResult)
+}
+
+func CompareList(sourceTrigger []objects.Trigger, targetTrigger []objects.Trigger) (diffResult []CompareDiffResult, err error) {
+	mapTargetTrigger := make(map[string]objects.Trigger)
+	for i := range targetTrigger {
+		t := targetTrigger[i]
+		mapTargetTrigger[t.Key()] = t
+	}
+
+	for i := range sourceTrigger {
+		s := sourceTrigger[i]
+
+		t, isExist := mapTargetTrigger[s.Key()]
+		if !isExist {
+			continue
+		}
+
+		rs, e := CompareItem(s, t)
+		if e != nil {
+			return diffResult, e
+		}
+		diffResult = append(diffResult, rs)
+	}
+
+	return
+}
+
+// CompareItem compare create statement of both trigger, so trigger
+// with the same definition but different letter case is not a conflict
+func CompareItem(source, target objects.Trigger) (diffResult CompareDiffResult, err error) {
+	diffResult.SourceResource = source
+	diffResult.TargetResource = target
+	diffResult.Name = source.Name
+
+	sourceSql, err := query.BuildTriggerQuery(query.TriggerActionCreate, &source)
+	if err != nil {
+		return diffResult, err
+	}
+
+	targetSql, err := query.BuildTriggerQuery(query.TriggerActionCreate, &target)
+	if err != nil {
+		return diffResult, err
+	}
+
+	sourceCompare := strings.ReplaceAll(strings.ToLower(utils.CleanUpString(sourceSql)), " ", "")
+	targetCompare := strings.ReplaceAll(strings.ToLower(utils.CleanUpString(targetSql)), " ", "")
+
+	diffResult.IsConflict = sourceCompare != targetCompare
+	return
+}
@@ -0,0 +1,84 @@
+package triggers
+
+import (
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/resource/migrator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+type MigrateItem = migrator.MigrateItem[objects.Trigger, any]
+type MigrateActionFunc = migrator.MigrateActionFunc[objects.Trigger, any]
+
+var ActionFunc = MigrateActionFunc{
+	CreateFunc: supabase.CreateTrigger,
+	UpdateFunc: func(cfg *raiden.Config, param objects.Trigger, items any) (err error) {
+		return supabase.UpdateTrigger(cfg, param)
+	},
+	DeleteFunc: supabase.DeleteTrigger,
+}
+
+func BuildMigrateData(extractedLocalData state.ExtractTriggerResult, supabaseData []objects.Trigger) (migrateData []MigrateItem, err error) {
+	Logger.Info("start build migrate trigger data")
+	if rs, err := BuildMigrateItem(supabaseData, extractedLocalData.Existing); err != nil {
+		return migrateData, err
+	} else {
+		migrateData = append(migrateData, rs...)
+	}
+
+	Logger.Debug("filter new trigger data")
+	for i := range extractedLocalData.New {
+		migrateData = append(migrateData, MigrateItem{
+			Type:    migrator.MigrateTypeCreate,
+			NewData: extractedLocalData.New[i],
+		})
+	}
+
+	Logger.Debug("filter delete trigger data")
+	mapSupabaseTrigger := make(map[string]bool)
+	for i := range supabaseData {
+		mapSupabaseTrigger[supabaseData[i].Key()] = true
+	}
+
+	for i := range extractedLocalData.Delete {
+		t := extractedLocalData.Delete[i]
+		if mapSupabaseTrigger[t.Key()] {
+			migrateData = append(migrateData, MigrateItem{
+				Type:    migrator.MigrateTypeDelete,
+				OldData: t,
+			})
+		}
+	}
+	Logger.Info("finish build migrate trigger data")
+	return
+}
+
+func BuildMigrateItem(supabaseData []objects.Trigger, localData []objects.Trigger) (migratedData []MigrateItem, err error) {
+	Logger.Info("compare supabase and local resource for existing trigger data")
+	result, err := CompareList(localData, supabaseData)
+	if err != nil {
+		return
+	}
+
+	for i := range result {
+		r := result[i]
+
+		migrateType := migrator.MigrateTypeIgnore
+		if r.IsConflict {
+			migrateType = migrator.MigrateTypeUpdate
+		}
+
+		migratedData = append(migratedData, MigrateItem{
+			Type:    migrateType,
+			NewData: r.SourceResource,
+			OldData: r.TargetResource,
+		})
+	}
+
+	return
+}
+
+func Migrate(config *raiden.Config, triggers []MigrateItem, stateChan chan any, actions MigrateActionFunc) []error {
+	return migrator.MigrateResource(config, triggers, stateChan, actions, migrator.DefaultMigrator)
+}
@@ -0,0 +1,209 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/logger"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+var ValidateLogger hclog.Logger = logger.HcLog().Named("validate")
+
+// ValidateDriftReason is why generated file is different with local state
+type ValidateDriftReason string
+
+const (
+	// ValidateDriftMissing is generated file that recorded in local state but not exist
+	ValidateDriftMissing ValidateDriftReason = "missing"
+
+	// ValidateDriftEdited is generated file that modified after import
+	ValidateDriftEdited ValidateDriftReason = "edited"
+
+	// ValidateDriftStale is generated file that not modified after import, but
+	// different with file that generated from local state (e.g config is changed)
+	ValidateDriftStale ValidateDriftReason = "stale"
+)
+
+// ValidateDrift is generated file that drift from local state
+type ValidateDrift struct {
+	Path   string
+	Reason ValidateDriftReason
+}
+
+// Validate compare generated file with local state without load resource from supabase,
+// model is regenerated in memory from stored table and compared with model file in project
+func Validate(flags *Flags, config *raiden.Config, localState *state.State) ([]ValidateDrift, error) {
+	if localState == nil {
+		return nil, errors.New("local state is not found, run import before validate")
+	}
+
+	mapDrift := make(map[string]ValidateDriftReason)
+	for path, hash := range localState.FileHashes {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				mapDrift[path] = ValidateDriftMissing
+				continue
+			}
+			return nil, err
+		}
+
+		if utils.HashByte(content) != hash {
+			mapDrift[path] = ValidateDriftEdited
+		}
+	}
+
+	// model is not generated to memory when models folder is not exist, so validate doesn't create it
+	var models map[string][]byte
+	if utils.IsFolderExists(filepath.Join(flags.ProjectPath, generator.ModelDir)) {
+		var err error
+		if models, err = generateValidateModels(flags, config, localState); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, t := range localState.Tables {
+			if t.ModelPath != "" {
+				mapDrift[t.ModelPath] = ValidateDriftMissing
+			}
+		}
+	}
+
+	for path, generated := range models {
+		if _, exist := mapDrift[path]; exist {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				mapDrift[path] = ValidateDriftMissing
+				continue
+			}
+			return nil, err
+		}
+
+		if !bytes.Equal(content, generated) {
+			mapDrift[path] = ValidateDriftStale
+		}
+	}
+
+	drifts := make([]ValidateDrift, 0, len(mapDrift))
+	for path, reason := range mapDrift {
+		drifts = append(drifts, ValidateDrift{Path: importConflictPath(flags.ProjectPath, path), Reason: reason})
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Path < drifts[j].Path })
+	return drifts, nil
+}
+
+// generateValidateModels generate model of stored table to memory and return content by file path,
+// model stub is owned by user so it is not returned
+func generateValidateModels(flags *Flags, config *raiden.Config, localState *state.State) (map[string][]byte, error) {
+	resource := &Resource{RelationCache: &localState.RelationCache}
+	for _, t := range localState.Tables {
+		resource.Tables = append(resource.Tables, t.Table)
+		resource.Policies = append(resource.Policies, t.Policies...)
+	}
+
+	for _, t := range localState.Types {
+		switch {
+		case t.Type.IsComposite():
+			resource.Composites = append(resource.Composites, t.Type)
+		case t.Type.IsDomain():
+			resource.Domains = append(resource.Domains, t.Type)
+		default:
+			resource.Types = append(resource.Types, t.Type)
+		}
+	}
+
+	for _, r := range localState.Roles {
+		if !r.IsNative {
+			resource.Roles = append(resource.Roles, r.Role)
+		}
+	}
+
+	for _, r := range localState.Rpc {
+		resource.Functions = append(resource.Functions, r.Function)
+	}
+
+	for _, s := range localState.Storage {
+		resource.Storages = append(resource.Storages, s.Storage)
+	}
+
+	models, err := ResolveModels(config, flags, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	templateOverrides, err := generator.LoadProjectTemplateOverrides(flags.ProjectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// model layout is not stored in state, so it is detected from path of generated model
+	modelStub, singleFile := detectModelLayout(localState.Tables)
+	for _, m := range models {
+		m.WithStub = modelStub
+	}
+
+	generated := make(map[string][]byte)
+	captureFn := func(input generator.GenerateInput, writer io.Writer) error {
+		if input.TemplateName == "modelStubTemplate" {
+			return nil
+		}
+
+		var buff bytes.Buffer
+		if err := generator.Generate(input, &buff); err != nil {
+			return err
+		}
+		generated[input.OutputPath] = buff.Bytes()
+		return nil
+	}
+
+	ValidateLogger.Debug("generate model from local state", "total", len(models), "model-stub", modelStub, "single-file", singleFile)
+	if err := generator.GenerateModelsWithContext(context.Background(), flags.ProjectPath, models, generator.WithTemplateOverrides(templateOverrides, captureFn), singleFile); err != nil {
+		return nil, err
+	}
+	return generated, nil
+}
+
+// detectModelLayout check if model is generated with model stub or to single file
+func detectModelLayout(tables []state.TableState) (modelStub bool, singleFile bool) {
+	for _, t := range tables {
+		fileName := filepath.Base(t.ModelPath)
+		switch {
+		case fileName == generator.ModelsFileName:
+			singleFile = true
+		case strings.HasSuffix(fileName, generator.ModelGenFileSuffix+".go"):
+			modelStub = true
+		}
+	}
+	return
+}
+
+// ValidateBuild compile models package of project, so error of manually edited model is found
+func ValidateBuild(projectPath string) error {
+	modelsPath := "./" + filepath.ToSlash(generator.ModelDir) + "/..."
+	ValidateLogger.Debug("execute command", "cmd", "go build "+modelsPath)
+
+	cmd := exec.Command("go", "build", modelsPath)
+	cmd.Dir = projectPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed build models : %v\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
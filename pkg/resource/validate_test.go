@@ -0,0 +1,77 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/generator"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	flags := &Flags{ProjectPath: t.TempDir()}
+	config := &raiden.Config{ProjectName: "test"}
+	table := objects.Table{
+		ID:          1,
+		Name:        "orders",
+		Schema:      "public",
+		Columns:     []objects.Column{{Name: "id", DataType: "bigint"}, {Name: "total_amount", DataType: "numeric", IsNullable: true}},
+		PrimaryKeys: []objects.PrimaryKey{{Name: "id", Schema: "public", TableName: "orders"}},
+	}
+
+	require.NoError(t, generator.CreateInternalFolder(flags.ProjectPath))
+	models, err := ResolveModels(config, flags, &Resource{Tables: []objects.Table{table}})
+	require.NoError(t, err)
+	require.NoError(t, generator.GenerateModels(flags.ProjectPath, models, generator.Generate))
+
+	modelPath := filepath.Join(flags.ProjectPath, generator.ModelDir, "orders.go")
+	content, err := os.ReadFile(modelPath)
+	require.NoError(t, err)
+
+	localState := &state.State{
+		Tables:     []state.TableState{{Table: table, ModelPath: modelPath, ModelStruct: "Orders"}},
+		FileHashes: map[string]string{modelPath: utils.HashByte(content)},
+	}
+	modelFile := filepath.ToSlash(filepath.Join(generator.ModelDir, "orders.go"))
+
+	t.Run("match local state", func(t *testing.T) {
+		drifts, err := Validate(flags, config, localState)
+		assert.NoError(t, err)
+		assert.Empty(t, drifts)
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		drifts, err := Validate(flags, &raiden.Config{ProjectName: "test", JsonCase: "camel"}, localState)
+		assert.NoError(t, err)
+		assert.Equal(t, []ValidateDrift{{Path: modelFile, Reason: ValidateDriftStale}}, drifts)
+	})
+
+	t.Run("edited", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(modelPath, append(content, []byte("\n// edited\n")...), 0644))
+		t.Cleanup(func() { os.WriteFile(modelPath, content, 0644) })
+
+		drifts, err := Validate(flags, config, localState)
+		assert.NoError(t, err)
+		assert.Equal(t, []ValidateDrift{{Path: modelFile, Reason: ValidateDriftEdited}}, drifts)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		require.NoError(t, os.Remove(modelPath))
+		t.Cleanup(func() { os.WriteFile(modelPath, content, 0644) })
+
+		drifts, err := Validate(flags, config, localState)
+		assert.NoError(t, err)
+		assert.Equal(t, []ValidateDrift{{Path: modelFile, Reason: ValidateDriftMissing}}, drifts)
+	})
+
+	t.Run("without local state", func(t *testing.T) {
+		_, err := Validate(flags, config, nil)
+		assert.ErrorContains(t, err, "run import")
+	})
+}
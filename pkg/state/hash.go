@@ -0,0 +1,176 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/sev-2/raiden/pkg/postgres"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+// definition hash is content hash of canonical resource definition, used to detect
+// database drift without generate the resource. Object id and statistic is ignored and
+// list that order is not meaningful (columns, relations, policies) is sorted, list that
+// order is meaningful (enum values, function arguments, composite key columns) is kept.
+
+// HashTableDefinition create definition hash of table with its relations and policies
+func HashTableDefinition(table objects.Table, relations []Relation, policies []objects.Policy) string {
+	table.ID, table.PartitionOfID = 0, 0
+	table.Bytes, table.Size = 0, ""
+	table.LiveRowsEstimate, table.DeadRowsEstimate = 0, 0
+
+	columns := make([]objects.Column, len(table.Columns))
+	for i, c := range table.Columns {
+		c.TableID, c.ID, c.OrdinalPosition = 0, "", 0
+		c.Privileges = c.GetPrivileges()
+		columns[i] = c
+	}
+	sortByKey(columns, func(c objects.Column) string { return c.Name })
+	table.Columns = columns
+
+	primaryKeys := make([]objects.PrimaryKey, len(table.PrimaryKeys))
+	for i, pk := range table.PrimaryKeys {
+		pk.TableID = 0
+		primaryKeys[i] = pk
+	}
+	sortByKey(primaryKeys, func(pk objects.PrimaryKey) string { return pk.Name })
+	table.PrimaryKeys = primaryKeys
+
+	relationships := make([]objects.TablesRelationship, len(table.Relationships))
+	for i, r := range table.Relationships {
+		r.Id = 0
+		relationships[i] = r
+	}
+	sortByKey(relationships, func(r objects.TablesRelationship) string {
+		return fmt.Sprintf("%s.%s.%s.%s", r.SourceSchema, r.SourceTableName, r.ConstraintName, r.SourceColumnName)
+	})
+	table.Relationships = relationships
+
+	table.UniqueIndexes = append([]objects.TableUniqueIndex{}, table.UniqueIndexes...)
+	sortByKey(table.UniqueIndexes, func(i objects.TableUniqueIndex) string { return i.Name })
+
+	table.Indexes = append([]objects.TableIndex{}, table.Indexes...)
+	sortByKey(table.Indexes, func(i objects.TableIndex) string { return i.Name })
+
+	sortedRelations := append([]Relation{}, relations...)
+	sortByKey(sortedRelations, func(r Relation) string {
+		key := fmt.Sprintf("%s.%s.%s.%s.%s", r.Schema, r.Table, r.RelationType, r.ForeignKey, r.PrimaryKey)
+		if r.JoinRelation != nil {
+			key += "." + r.Through
+		}
+		return key
+	})
+
+	return hashDefinition(map[string]any{
+		"table":     table,
+		"relations": sortedRelations,
+		"policies":  canonicalPolicies(policies),
+	})
+}
+
+// HashRoleDefinition create definition hash of role, active connection is ignored
+func HashRoleDefinition(role objects.Role) string {
+	role.ID, role.ActiveConnections = 0, 0
+	role.MemberOf = sortedStrings(role.MemberOf)
+	return hashDefinition(role)
+}
+
+// HashFunctionDefinition create definition hash of function, argument order is kept
+func HashFunctionDefinition(fn objects.Function) string {
+	fn.ID, fn.ReturnTypeID, fn.ReturnTypeRelationID = 0, 0, 0
+
+	args := make([]objects.FunctionArg, len(fn.Args))
+	for i, a := range fn.Args {
+		a.TypeId = 0
+		args[i] = a
+	}
+	fn.Args = args
+	return hashDefinition(fn)
+}
+
+// HashStorageDefinition create definition hash of bucket with its policies
+func HashStorageDefinition(bucket objects.Bucket, policies []objects.Policy) string {
+	bucket.UpdatedAt = nil
+	bucket.AllowedMimeTypes = sortedStrings(bucket.AllowedMimeTypes)
+	return hashDefinition(map[string]any{
+		"bucket":   bucket,
+		"policies": canonicalPolicies(policies),
+	})
+}
+
+// HashTypeDefinition create definition hash of enum, composite or domain type,
+// enum value order is kept because it define sort order of the enum
+func HashTypeDefinition(t objects.Type) string {
+	t.ID = 0
+
+	attributes := make([]objects.TypeAttribute, len(t.Attributes))
+	for i, a := range t.Attributes {
+		// oid of built in type is the same in every database, so attribute type change is detected
+		if _, _, isBuiltin := postgres.GetBuiltinType(a.TypeID); !isBuiltin {
+			a.TypeID = 0
+		}
+		attributes[i] = a
+	}
+	t.Attributes = attributes
+	return hashDefinition(t)
+}
+
+// HashTriggerDefinition create definition hash of trigger
+func HashTriggerDefinition(trigger objects.Trigger) string {
+	trigger.ID, trigger.TableID = 0, 0
+	trigger.Events = sortedStrings(trigger.Events)
+	return hashDefinition(trigger)
+}
+
+// HashPublicationDefinition create definition hash of publication
+func HashPublicationDefinition(publication objects.Publication) string {
+	publication.ID = 0
+
+	if publication.Tables != nil {
+		publicationTables := make([]objects.PublicationTable, len(publication.Tables))
+		for i, t := range publication.Tables {
+			t.ID = 0
+			publicationTables[i] = t
+		}
+		sortByKey(publicationTables, func(t objects.PublicationTable) string { return t.Key() })
+		publication.Tables = publicationTables
+	}
+	return hashDefinition(publication)
+}
+
+func canonicalPolicies(policies []objects.Policy) []objects.Policy {
+	result := make([]objects.Policy, len(policies))
+	for i, p := range policies {
+		p.ID, p.TableID = 0, 0
+		p.Roles = sortedStrings(p.Roles)
+		result[i] = p
+	}
+	sortByKey(result, func(p objects.Policy) string {
+		return fmt.Sprintf("%s.%s.%s", p.Schema, p.Table, p.Name)
+	})
+	return result
+}
+
+func sortedStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+
+	result := append([]string{}, values...)
+	sort.Strings(result)
+	return result
+}
+
+func sortByKey[T any](items []T, keyFn func(T) string) {
+	sort.SliceStable(items, func(i, j int) bool { return keyFn(items[i]) < keyFn(items[j]) })
+}
+
+func hashDefinition(data any) string {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return utils.HashByte(content)
+}
@@ -0,0 +1,87 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashTableDefinition(t *testing.T) {
+	table := objects.Table{
+		ID: 10, Schema: "public", Name: "orders", LiveRowsEstimate: 5,
+		Columns: []objects.Column{
+			{TableID: 10, ID: "10.1", OrdinalPosition: 1, Name: "id", DataType: "bigint"},
+			{TableID: 10, ID: "10.2", OrdinalPosition: 2, Name: "user_id", DataType: "bigint"},
+			{
+				TableID: 10, ID: "10.3", OrdinalPosition: 3, Name: "note", DataType: "text",
+				Privileges: []objects.ColumnPrivilege{{Grantee: "support", PrivilegeType: "SELECT"}, {Grantee: "anon", PrivilegeType: "SELECT"}},
+			},
+		},
+		PrimaryKeys: []objects.PrimaryKey{{Name: "id", TableID: 10}},
+		Indexes:     []objects.TableIndex{{Name: "orders_user_id_idx", Columns: []string{"user_id"}}, {Name: "orders_note_idx", Columns: []string{"note"}}},
+	}
+	relations := []state.Relation{
+		{Table: "users", Type: "*Users", RelationType: raiden.RelationTypeBelongsTo, PrimaryKey: "id", ForeignKey: "user_id"},
+		{Table: "order_items", Type: "[]*OrderItems", RelationType: raiden.RelationTypeHasMany, PrimaryKey: "id", ForeignKey: "order_id"},
+	}
+	policies := []objects.Policy{
+		{ID: 1, Name: "read orders", Roles: []string{"anon", "authenticated"}, Command: objects.PolicyCommandSelect},
+		{ID: 2, Name: "insert orders", Roles: []string{"authenticated"}, Command: objects.PolicyCommandInsert},
+	}
+	hash := state.HashTableDefinition(table, relations, policies)
+
+	// reordered definition and different object id produce the same hash
+	reordered := table
+	reordered.ID, reordered.LiveRowsEstimate = 20, 100
+	reordered.Columns = []objects.Column{
+		{TableID: 20, ID: "20.1", OrdinalPosition: 1, Name: "note", DataType: "text", Privileges: []objects.ColumnPrivilege{{Grantee: "anon", PrivilegeType: "SELECT"}, {Grantee: "support", PrivilegeType: "SELECT"}}},
+		{TableID: 20, ID: "20.2", OrdinalPosition: 2, Name: "user_id", DataType: "bigint"},
+		{TableID: 20, ID: "20.3", OrdinalPosition: 3, Name: "id", DataType: "bigint"},
+	}
+	reordered.PrimaryKeys = []objects.PrimaryKey{{Name: "id", TableID: 20}}
+	reordered.Indexes = []objects.TableIndex{table.Indexes[1], table.Indexes[0]}
+	reorderedPolicies := []objects.Policy{
+		{ID: 4, Name: "insert orders", Roles: []string{"authenticated"}, Command: objects.PolicyCommandInsert},
+		{ID: 3, Name: "read orders", Roles: []string{"authenticated", "anon"}, Command: objects.PolicyCommandSelect},
+	}
+	assert.Equal(t, hash, state.HashTableDefinition(reordered, []state.Relation{relations[1], relations[0]}, reorderedPolicies))
+
+	// input is not sorted in place
+	assert.Equal(t, "id", table.Columns[0].Name)
+	assert.Equal(t, "support", table.Columns[2].Privileges[0].Grantee)
+
+	// changed definition produce different hash
+	changed := table
+	changed.Columns = append([]objects.Column{}, table.Columns...)
+	changed.Columns[2].IsNullable = true
+	assert.NotEqual(t, hash, state.HashTableDefinition(changed, relations, policies))
+	assert.NotEqual(t, hash, state.HashTableDefinition(table, relations[:1], policies))
+}
+
+func TestHashDefinition_Reordered(t *testing.T) {
+	role := objects.Role{ID: 1, Name: "editor", MemberOf: []string{"staff", "authenticated"}, ActiveConnections: 3}
+	assert.Equal(t, state.HashRoleDefinition(role), state.HashRoleDefinition(objects.Role{ID: 2, Name: "editor", MemberOf: []string{"authenticated", "staff"}}))
+
+	bucket := objects.Bucket{ID: "avatars", Name: "avatars", AllowedMimeTypes: []string{"image/png", "image/jpeg"}}
+	reorderedBucket := objects.Bucket{ID: "avatars", Name: "avatars", AllowedMimeTypes: []string{"image/jpeg", "image/png"}}
+	assert.Equal(t, state.HashStorageDefinition(bucket, nil), state.HashStorageDefinition(reorderedBucket, nil))
+
+	trigger := objects.Trigger{ID: 1, Name: "on_order", Table: "orders", Events: []string{"INSERT", "UPDATE"}}
+	assert.Equal(t, state.HashTriggerDefinition(trigger), state.HashTriggerDefinition(objects.Trigger{ID: 2, Name: "on_order", Table: "orders", Events: []string{"UPDATE", "INSERT"}}))
+
+	publication := objects.Publication{ID: 1, Name: "realtime", Tables: []objects.PublicationTable{{ID: 1, Name: "orders", Schema: "public"}, {ID: 2, Name: "users", Schema: "public"}}}
+	reorderedPublication := objects.Publication{ID: 3, Name: "realtime", Tables: []objects.PublicationTable{{ID: 5, Name: "users", Schema: "public"}, {ID: 4, Name: "orders", Schema: "public"}}}
+	assert.Equal(t, state.HashPublicationDefinition(publication), state.HashPublicationDefinition(reorderedPublication))
+
+	// enum value and function argument order is part of definition
+	enum := objects.Type{ID: 1, Name: "status", Enums: []string{"draft", "published"}}
+	assert.Equal(t, state.HashTypeDefinition(enum), state.HashTypeDefinition(objects.Type{ID: 2, Name: "status", Enums: []string{"draft", "published"}}))
+	assert.NotEqual(t, state.HashTypeDefinition(enum), state.HashTypeDefinition(objects.Type{ID: 1, Name: "status", Enums: []string{"published", "draft"}}))
+
+	fn := objects.Function{ID: 1, Name: "get_orders", Args: []objects.FunctionArg{{Name: "a", TypeId: 20}, {Name: "b", TypeId: 25}}}
+	assert.Equal(t, state.HashFunctionDefinition(fn), state.HashFunctionDefinition(objects.Function{ID: 2, Name: "get_orders", Args: []objects.FunctionArg{{Name: "a"}, {Name: "b"}}}))
+	assert.NotEqual(t, state.HashFunctionDefinition(fn), state.HashFunctionDefinition(objects.Function{ID: 1, Name: "get_orders", Args: []objects.FunctionArg{{Name: "b"}, {Name: "a"}}}))
+}
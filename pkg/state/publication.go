@@ -0,0 +1,99 @@
+package state
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+type ExtractPublicationResult struct {
+	Existing []objects.Publication
+	New      []objects.Publication
+	Delete   []objects.Publication
+}
+
+func ExtractPublication(publicationStates []PublicationState, appPublications []raiden.Publication) (result ExtractPublicationResult, err error) {
+	mapPublicationState := map[string]PublicationState{}
+	for i := range publicationStates {
+		p := publicationStates[i]
+		mapPublicationState[p.Publication.Key()] = p
+	}
+
+	for _, publication := range appPublications {
+		p := objects.Publication{}
+		BindToSupabasePublication(&p, publication)
+
+		state, isStateExist := mapPublicationState[p.Key()]
+		if !isStateExist {
+			result.New = append(result.New, p)
+			continue
+		}
+
+		sp := state.Publication
+		BindToSupabasePublication(&sp, publication)
+		result.Existing = append(result.Existing, sp)
+		delete(mapPublicationState, p.Key())
+	}
+
+	for _, state := range mapPublicationState {
+		result.Delete = append(result.Delete, state.Publication)
+	}
+
+	return
+}
+
+func BindToSupabasePublication(p *objects.Publication, publication raiden.Publication) {
+	name := publication.Name()
+	if name == "" {
+		rv := reflect.TypeOf(publication)
+		if rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		name = utils.ToSnakeCase(rv.Name())
+	}
+
+	p.Name = name
+	p.AllTables = publication.AllTables()
+
+	p.Tables = nil
+	if !p.AllTables {
+		p.Tables = make([]objects.PublicationTable, 0, len(publication.Tables()))
+		for _, t := range publication.Tables() {
+			schema, table, found := strings.Cut(t, ".")
+			if !found {
+				schema, table = raiden.DefaultPublicationTableSchema, t
+			}
+			p.Tables = append(p.Tables, objects.PublicationTable{Schema: schema, Name: table})
+		}
+	}
+
+	p.PublishInsert, p.PublishUpdate, p.PublishDelete, p.PublishTruncate = false, false, false, false
+	for _, a := range publication.Actions() {
+		switch a {
+		case raiden.PublicationActionInsert:
+			p.PublishInsert = true
+		case raiden.PublicationActionUpdate:
+			p.PublishUpdate = true
+		case raiden.PublicationActionDelete:
+			p.PublishDelete = true
+		case raiden.PublicationActionTruncate:
+			p.PublishTruncate = true
+		}
+	}
+}
+
+func (er ExtractPublicationResult) ToDeleteFlatMap() map[string]*objects.Publication {
+	mapData := make(map[string]*objects.Publication)
+
+	if len(er.Delete) > 0 {
+		for i := range er.Delete {
+			p := er.Delete[i]
+			mapData[p.Key()] = &p
+		}
+	}
+
+	return mapData
+}
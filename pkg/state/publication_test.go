@@ -0,0 +1,56 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+type SupabaseRealtime struct {
+	raiden.PublicationBase
+}
+
+func (p *SupabaseRealtime) Name() string {
+	return "supabase_realtime"
+}
+
+func (p *SupabaseRealtime) Tables() []string {
+	return []string{"orders", "billing.invoices"}
+}
+
+func (p *SupabaseRealtime) Actions() []raiden.PublicationAction {
+	return []raiden.PublicationAction{raiden.PublicationActionInsert, raiden.PublicationActionUpdate}
+}
+
+func TestExtractPublication(t *testing.T) {
+	appPublications := []raiden.Publication{&SupabaseRealtime{}}
+
+	rs, err := state.ExtractPublication(nil, appPublications)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	p := rs.New[0]
+	assert.Equal(t, "supabase_realtime", p.Key())
+	assert.False(t, p.AllTables)
+	assert.Equal(t, []string{"billing.invoices", "public.orders"}, p.TableKeys())
+	assert.True(t, p.PublishInsert)
+	assert.True(t, p.PublishUpdate)
+	assert.False(t, p.PublishDelete)
+	assert.False(t, p.PublishTruncate)
+
+	states := []state.PublicationState{
+		{Publication: objects.Publication{ID: 1, Name: "supabase_realtime"}},
+		{Publication: objects.Publication{ID: 2, Name: "audit_stream"}},
+	}
+	rs, err = state.ExtractPublication(states, appPublications)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(rs.New))
+	assert.Equal(t, 1, len(rs.Existing))
+	assert.Equal(t, 1, rs.Existing[0].ID)
+	assert.Equal(t, 2, len(rs.Existing[0].Tables))
+	assert.Equal(t, 1, len(rs.Delete))
+	assert.Equal(t, "audit_stream", rs.Delete[0].Name)
+}
@@ -67,6 +67,7 @@ func BindToSupabaseRole(r *objects.Role, role raiden.Role) {
 	r.CanLogin = role.CanLogin()
 	r.InheritRole = role.InheritRole()
 	r.ValidUntil = role.ValidUntil()
+	r.MemberOf = role.MemberOf()
 
 	// need role with superuser to create new superuser role and set replication
 	// r.IsReplicationRole = role.IsReplicationRole()
@@ -55,6 +55,8 @@ func BindRpcFunction(rpc raiden.Rpc, fn *objects.Function) (err error) {
 	fn.Name = rpc.GetName()
 	fn.Schema = rpc.GetSchema()
 	fn.CompleteStatement = rpc.GetCompleteStmt()
+	fn.Behavior = string(rpc.GetBehavior())
+	fn.SecurityDefiner = rpc.GetSecurity() == raiden.RpcSecurityTypeDefiner
 	return
 }
 
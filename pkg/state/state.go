@@ -19,10 +19,20 @@ var StateLogger = logger.HcLog().Named("supabase.state")
 
 type (
 	State struct {
-		Tables  []TableState
-		Roles   []RoleState
-		Rpc     []RpcState
-		Storage []StorageState
+		Tables       []TableState
+		Roles        []RoleState
+		Rpc          []RpcState
+		Storage      []StorageState
+		Types        []TypeState
+		Triggers     []TriggerState
+		Publications []PublicationState
+
+		// relation of imported table, reused when relation metadata is unchanged
+		RelationCache RelationCache
+
+		// content hash of generated file keyed by file path,
+		// used to detect file that modified after import
+		FileHashes map[string]string
 	}
 
 	TableState struct {
@@ -32,6 +42,10 @@ type (
 		ModelStruct string
 		LastUpdate  time.Time
 		Policies    []objects.Policy
+		ContentHash string
+
+		// hash of canonical definition for drift detection, see HashTableDefinition
+		DefinitionHash string
 	}
 
 	RoleState struct {
@@ -40,6 +54,8 @@ type (
 		RoleStruct string
 		IsNative   bool
 		LastUpdate time.Time
+
+		DefinitionHash string
 	}
 
 	RpcState struct {
@@ -47,6 +63,8 @@ type (
 		RpcPath    string
 		RpcStruct  string
 		LastUpdate time.Time
+
+		DefinitionHash string
 	}
 
 	StorageState struct {
@@ -55,15 +73,60 @@ type (
 		StorageStruct string
 		LastUpdate    time.Time
 		Policies      []objects.Policy
+
+		DefinitionHash string
+	}
+
+	TypeState struct {
+		Type       objects.Type
+		TypePath   string
+		TypeStruct string
+		LastUpdate time.Time
+
+		DefinitionHash string
+	}
+
+	TriggerState struct {
+		Trigger       objects.Trigger
+		TriggerPath   string
+		TriggerStruct string
+		LastUpdate    time.Time
+
+		DefinitionHash string
+	}
+
+	PublicationState struct {
+		Publication       objects.Publication
+		PublicationPath   string
+		PublicationStruct string
+		LastUpdate        time.Time
+
+		DefinitionHash string
+	}
+
+	RelationCache struct {
+		Hash      string
+		Relations map[string][]Relation
 	}
 
 	Relation struct {
 		Table        string
+		Schema       string
 		Type         string
 		RelationType raiden.RelationType
 		PrimaryKey   string
 		ForeignKey   string
 		Tag          string
+
+		// source constraint of inferred relation, rendered as comment of relation field
+		Comment string
+
+		// belongs to relation with nullable foreign key, related row may not exist
+		Nullable bool
+
+		// composite key, only set if relation have more than one column
+		PrimaryKeys []string
+		ForeignKeys []string
 		*JoinRelation
 	}
 
@@ -345,9 +408,145 @@ func (s *LocalState) DeleteStorage(storageId string) {
 	s.NeedUpdate = true
 }
 
+func (s *LocalState) AddType(t TypeState) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.State.Types = append(s.State.Types, t)
+	s.NeedUpdate = true
+}
+
+func (s *LocalState) AddTrigger(trigger TriggerState) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.State.Triggers = append(s.State.Triggers, trigger)
+	s.NeedUpdate = true
+}
+
+func (s *LocalState) FindTrigger(key string) (index int, triggerState TriggerState, found bool) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	found = false
+
+	for i := range s.State.Triggers {
+		t := s.State.Triggers[i]
+
+		if t.Trigger.Key() == key {
+			found = true
+			triggerState = t
+			index = i
+			return
+		}
+	}
+	return
+}
+
+func (s *LocalState) UpdateTrigger(index int, state TriggerState) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	s.State.Triggers[index] = state
+	s.NeedUpdate = true
+}
+
+func (s *LocalState) DeleteTrigger(key string) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	index := -1
+	for i := range s.State.Triggers {
+		t := s.State.Triggers[i]
+
+		if t.Trigger.Key() == key {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		return
+	}
+	s.State.Triggers = append(s.State.Triggers[:index], s.State.Triggers[index+1:]...)
+	s.NeedUpdate = true
+}
+
+func (s *LocalState) AddPublication(publication PublicationState) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.State.Publications = append(s.State.Publications, publication)
+	s.NeedUpdate = true
+}
+
+func (s *LocalState) FindPublication(key string) (index int, publicationState PublicationState, found bool) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	found = false
+
+	for i := range s.State.Publications {
+		p := s.State.Publications[i]
+
+		if p.Publication.Key() == key {
+			found = true
+			publicationState = p
+			index = i
+			return
+		}
+	}
+	return
+}
+
+func (s *LocalState) UpdatePublication(index int, state PublicationState) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	s.State.Publications[index] = state
+	s.NeedUpdate = true
+}
+
+func (s *LocalState) DeletePublication(key string) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	index := -1
+	for i := range s.State.Publications {
+		p := s.State.Publications[i]
+
+		if p.Publication.Key() == key {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		return
+	}
+	s.State.Publications = append(s.State.Publications[:index], s.State.Publications[index+1:]...)
+	s.NeedUpdate = true
+}
+
+func (s *LocalState) SetRelationCache(cache RelationCache) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.State.RelationCache = cache
+	s.NeedUpdate = true
+}
+
+func (s *LocalState) SetFileHash(path string, hash string) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	if s.State.FileHashes == nil {
+		s.State.FileHashes = make(map[string]string)
+	}
+	s.State.FileHashes[path] = hash
+	s.NeedUpdate = true
+}
+
+// Persist save state when there is change, need update flag is reset
+// after save so write lock is needed
 func (s *LocalState) Persist() error {
-	s.Mutex.RLock()
-	defer s.Mutex.RUnlock()
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
 	if s.NeedUpdate {
 		if err := Save(&s.State); err != nil {
 			return err
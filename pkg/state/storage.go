@@ -16,10 +16,12 @@ type ExtractStorageItem struct {
 	ExtractedPolicies ExtractedPolicies
 }
 
+type ExtractStorageItems []ExtractStorageItem
+
 type ExtractStorageResult struct {
-	Existing []ExtractStorageItem
-	New      []ExtractStorageItem
-	Delete   []ExtractStorageItem
+	Existing ExtractStorageItems
+	New      ExtractStorageItems
+	Delete   ExtractStorageItems
 }
 
 func ExtractStorage(storageStates []StorageState, appStorages []raiden.Bucket) (result ExtractStorageResult, err error) {
@@ -135,6 +137,14 @@ func BuildStorageFromState(ss StorageState, storage raiden.Bucket) (si ExtractSt
 	return
 }
 
+func (f ExtractStorageItems) ToFlatStorage() (storages []objects.Bucket) {
+	for i := range f {
+		s := f[i]
+		storages = append(storages, s.Storage)
+	}
+	return
+}
+
 func (er ExtractStorageResult) ToDeleteFlatMap() map[string]*objects.Bucket {
 	mapData := make(map[string]*objects.Bucket)
 
@@ -200,8 +210,8 @@ func getStoragePolicies(field *reflect.StructField, si *ExtractStorageItem) (pol
 			Action:     "PERMISSIVE",
 			Command:    objects.PolicyCommandUpdate,
 			Roles:      acl.Write.Roles,
-			Definition: acl.Write.Using,
-			Check:      acl.Write.Check,
+			Definition: acl.GetUpdateUsing(),
+			Check:      acl.GetUpdateCheck(),
 		}
 		if updatePolicy.Check == nil || (updatePolicy.Check != nil && *updatePolicy.Check == "") {
 			check := fmt.Sprintf("(%s)", defaultCheck)
@@ -224,7 +234,7 @@ func getStoragePolicies(field *reflect.StructField, si *ExtractStorageItem) (pol
 			Action:     "PERMISSIVE",
 			Command:    objects.PolicyCommandDelete,
 			Roles:      acl.Write.Roles,
-			Definition: acl.Write.Using,
+			Definition: acl.GetDeleteUsing(),
 		}
 		if deletePolicy.Definition == "" {
 			deletePolicy.Definition = fmt.Sprintf("(%s)", defaultDefinition)
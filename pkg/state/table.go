@@ -29,9 +29,11 @@ type ExtractTableResult struct {
 func ExtractTable(tableStates []TableState, appTable []any) (result ExtractTableResult, err error) {
 	var mapTableState = make(map[string]TableState)
 
+	// table is identified by schema and name, because table
+	// with same name can exist in different schema
 	for i := range tableStates {
 		t := tableStates[i]
-		mapTableState[t.Table.Name] = t
+		mapTableState[getTableStateKey(t.Table.Schema, t.Table.Name)] = t
 	}
 
 	for _, t := range appTable {
@@ -40,7 +42,7 @@ func ExtractTable(tableStates []TableState, appTable []any) (result ExtractTable
 			tableType = tableType.Elem()
 		}
 
-		tableName := utils.ToSnakeCase(tableType.Name())
+		tableName := getTableStateKey(getModelSchema(tableType), raiden.GetTableName(tableType))
 		ts, isExist := mapTableState[tableName]
 		if !isExist {
 			nt := buildTableFromModel(t)
@@ -79,7 +81,7 @@ func buildTableFromModel(model any) (ei ExtractTableItem) {
 		modelType = modelType.Elem()
 	}
 
-	ei.Table.Name = utils.ToSnakeCase(modelType.Name())
+	ei.Table.Name = raiden.GetTableName(modelType)
 
 	// add metadata
 	metadataField, isExist := modelType.FieldByName("Metadata")
@@ -91,8 +93,8 @@ func buildTableFromModel(model any) (ei ExtractTableItem) {
 		ei.Table.RLSForced = false
 	}
 
-	for i := 0; i < modelType.NumField(); i++ {
-		field := modelType.Field(i)
+	modelColumns := getModelColumnNames(modelType)
+	for _, field := range getModelFields(modelType) {
 		switch field.Name {
 		case "Metadata", "Acl":
 			continue
@@ -121,18 +123,32 @@ func buildTableFromModel(model any) (ei ExtractTableItem) {
 				}
 			}
 
+			if index := field.Tag.Get("index"); len(index) > 0 {
+				ei.Table.Indexes = append(ei.Table.Indexes, bindIndex(&field, index))
+			}
+
 			if join := field.Tag.Get("join"); len(join) > 0 {
 				jt := raiden.UnmarshalJoinTag(join)
-				if jt.JoinType == raiden.RelationTypeHasOne {
-					rel := objects.TablesRelationship{}
-					rel.SourceTableName = ei.Table.Name
-					rel.SourceColumnName = jt.ForeignKey
-					rel.SourceSchema = ei.Table.Schema
-					rel.TargetTableName = utils.ToSnakeCase(field.Name)
-					rel.TargetTableSchema = ei.Table.Schema
-					rel.TargetColumnName = jt.PrimaryKey
-
-					ei.Table.Relationships = append(ei.Table.Relationships, rel)
+				// has one relation that foreign key is not column of model
+				// is declared by parent table, foreign key belong to related table
+				isOwnForeignKey := jt.JoinType == raiden.RelationTypeBelongsTo || jt.JoinType == raiden.RelationTypeHasOne
+				foreignKeys, primaryKeys := getJoinKeys(jt)
+				if len(primaryKeys) == 0 {
+					primaryKeys = []string{"id"}
+				}
+
+				if isOwnForeignKey && len(foreignKeys) > 0 && len(foreignKeys) == len(primaryKeys) && isModelColumns(modelColumns, foreignKeys) {
+					for i := range foreignKeys {
+						rel := objects.TablesRelationship{}
+						rel.SourceTableName = ei.Table.Name
+						rel.SourceColumnName = foreignKeys[i]
+						rel.SourceSchema = ei.Table.Schema
+						rel.TargetTableName = getRelationTableName(&field)
+						rel.TargetTableSchema = ei.Table.Schema
+						rel.TargetColumnName = primaryKeys[i]
+
+						ei.Table.Relationships = append(ei.Table.Relationships, rel)
+					}
 				}
 			}
 		}
@@ -155,7 +171,7 @@ func buildTableFromState(model any, state TableState) (ei ExtractTableItem) {
 
 	// Get the reflect.Type of the struct
 	ei.Table = state.Table
-	ei.Table.Name = utils.ToSnakeCase(modelType.Name())
+	ei.Table.Name = raiden.GetTableName(modelType)
 
 	// map column for make check if column exist and reuse default
 	mapColumn := make(map[string]objects.Column)
@@ -164,11 +180,12 @@ func buildTableFromState(model any, state TableState) (ei ExtractTableItem) {
 		mapColumn[c.Name] = c
 	}
 
-	// map relation for make check if relation exist and reuse default
-	mapRelation := make(map[string]objects.TablesRelationship)
+	// map relation for make check if relation exist and reuse default,
+	// composite foreign key have one relationship row per column
+	mapRelation := make(map[string][]objects.TablesRelationship)
 	for i := range ei.Table.Relationships {
 		r := ei.Table.Relationships[i]
-		mapRelation[r.ConstraintName] = r
+		mapRelation[r.ConstraintName] = append(mapRelation[r.ConstraintName], r)
 	}
 
 	// map relation for make check if relation exist and reuse default
@@ -187,6 +204,9 @@ func buildTableFromState(model any, state TableState) (ei ExtractTableItem) {
 	var columns []objects.Column
 	var relations []objects.TablesRelationship
 	var primaryKeys []objects.PrimaryKey
+	var indexes []objects.TableIndex
+	mapAddedRelation := make(map[string]bool)
+	modelColumns := getModelColumnNames(modelType)
 
 	// update metadata
 	metadataField, isExist := modelType.FieldByName("Metadata")
@@ -199,11 +219,7 @@ func buildTableFromState(model any, state TableState) (ei ExtractTableItem) {
 	}
 
 	// Iterate over the fields of the struct
-	for i := 0; i < modelType.NumField(); i++ {
-		field := modelType.Field(i)
-		// Get field name and tag
-		fieldName := field.Name
-
+	for _, field := range getModelFields(modelType) {
 		switch field.Name {
 		case "Metadata", "Acl":
 			continue
@@ -242,10 +258,23 @@ func buildTableFromState(model any, state TableState) (ei ExtractTableItem) {
 				columns = append(columns, c)
 			}
 
+			if indexTag := field.Tag.Get("index"); len(indexTag) > 0 {
+				indexes = append(indexes, bindIndex(&field, indexTag))
+			}
+
 			if joinTag := field.Tag.Get("join"); len(joinTag) > 0 {
-				if r := buildTableRelation(ei.Table.Name, fieldName, ei.Table.Schema, mapRelation, joinTag); r.ConstraintName != "" {
-					relations = append(relations, r)
+				rs := buildTableRelation(ei.Table.Name, getRelationTableName(&field), ei.Table.Schema, mapRelation, modelColumns, joinTag)
+				if len(rs) == 0 {
+					continue
+				}
+
+				// self referencing relation is declared twice (has one and has many)
+				// with same constraint, make sure only registered once
+				if _, exist := mapAddedRelation[rs[0].ConstraintName]; exist {
+					continue
 				}
+				mapAddedRelation[rs[0].ConstraintName] = true
+				relations = append(relations, rs...)
 			}
 		}
 	}
@@ -277,17 +306,102 @@ func buildTableFromState(model any, state TableState) (ei ExtractTableItem) {
 		}
 	}
 
+	// column that excluded from model still exist in database, keep it from state
+	if excludeColumns := metadataField.Tag.Get("excludeColumns"); excludeColumns != "" {
+		for _, name := range strings.Split(excludeColumns, ",") {
+			if c, exist := mapColumn[name]; exist && !modelColumns[name] {
+				columns = append(columns, c)
+			}
+		}
+	}
+
 	ei.Table.Columns = columns
 	ei.Table.Relationships = relations
 	ei.Table.PrimaryKeys = primaryKeys
 
+	// model without index field keep index from state, so index is not
+	// dropped by model that generated before index declaration exist
+	if len(indexes) > 0 {
+		ei.Table.Indexes = indexes
+	}
+
 	return ei
 }
 
+// bindColumnTypeModifier set length of character type and precision / scale of numeric type
+func bindColumnTypeModifier(c *objects.Column, modifiers []int) {
+	if len(modifiers) == 0 {
+		return
+	}
+
+	switch postgres.DataType(c.DataType) {
+	case postgres.VarcharType, postgres.CharType, postgres.BpcharType:
+		c.Length = modifiers[0]
+	case postgres.NumericType, postgres.DecimalType:
+		c.Precision, c.Scale = modifiers[0], 0
+		if len(modifiers) > 1 {
+			c.Scale = modifiers[1]
+		}
+	}
+}
+
+// example tag `index:"name:posts_tags_idx;method:gin" indexColumns:"tags" indexWhere:"deleted_at IS NULL"`
+func bindIndex(field *reflect.StructField, indexTag string) objects.TableIndex {
+	it := raiden.UnmarshalIndexTag(indexTag)
+	idx := objects.TableIndex{
+		Name:      it.Name,
+		Columns:   raiden.SplitIndexColumns(field.Tag.Get("indexColumns")),
+		IsUnique:  it.Unique,
+		Method:    it.Method,
+		Predicate: field.Tag.Get("indexWhere"),
+	}
+
+	if idx.Name == "" {
+		idx.Name = utils.ToSnakeCase(field.Name)
+	}
+
+	if idx.Method == "" {
+		idx.Method = "btree"
+	}
+	return idx
+}
+
+// parseColumnGrantTag parse column privilege from grant tag,
+// example tag `grant:"select:anon,authenticated;update:authenticated"`
+func parseColumnGrantTag(tag string) (privileges []objects.ColumnPrivilege) {
+	for _, grant := range strings.Split(tag, ";") {
+		privilegeType, grantees, found := strings.Cut(grant, ":")
+		if !found {
+			continue
+		}
+
+		for _, g := range strings.Split(grantees, ",") {
+			if g = strings.TrimSpace(g); g != "" {
+				privileges = append(privileges, objects.ColumnPrivilege{
+					Grantee:       g,
+					PrivilegeType: strings.ToUpper(strings.TrimSpace(privilegeType)),
+				})
+			}
+		}
+	}
+	return
+}
+
 func bindColumn(field *reflect.StructField, ct *raiden.ColumnTag, c *objects.Column) {
 	c.IsNullable = ct.Nullable
 	c.IsUnique = ct.Unique
 
+	// model without check tag keep check constraint from state,
+	// so existing constraint is not dropped by model that generated before check tag exist
+	if check := field.Tag.Get("check"); len(check) > 0 {
+		c.Check = check
+	}
+
+	// model without grant tag keep column privilege from state, same as check tag
+	if grant := field.Tag.Get("grant"); len(grant) > 0 {
+		c.Privileges = parseColumnGrantTag(grant)
+	}
+
 	if ct.Name != "" {
 		c.Name = ct.Name
 	} else {
@@ -298,17 +412,56 @@ func bindColumn(field *reflect.StructField, ct *raiden.ColumnTag, c *objects.Col
 		c.IsIdentity = true
 	}
 
-	if ct.Type != "" {
-		pgType := postgres.GetPgDataTypeName(postgres.DataType(ct.Type), false)
+	isSerialColumn := false
+	if ct.Composite != "" {
+		// composite type is stored by type name, the same as loaded column
+		c.DataType, c.Format = string(postgres.UserDefinedType), ct.Composite
+		if _, name, found := strings.Cut(ct.Composite, "."); found {
+			c.Format = name
+		}
+	} else if postgres.IsArrayType(ct.Type) {
+		c.DataType = string(postgres.ArrayType)
+		c.Format = "_" + strings.TrimSuffix(ct.Type, "[]")
+	} else if ct.Type != "" {
+		// model without type modifier keep length and precision from state
+		dataType, modifiers := postgres.ParseTypeModifier(ct.Type)
+		pgType := postgres.GetPgDataTypeName(postgres.DataType(dataType), false)
+
+		// serial is stored as integer column that use sequence owned by the column
+		if baseType, isSerial := postgres.FromSerialType(pgType); isSerial {
+			pgType = baseType
+			isSerialColumn = true
+		}
 		c.DataType = string(pgType)
+		bindColumnTypeModifier(c, modifiers)
 	} else {
 		c.DataType = string(postgres.ToPostgresType(field.Type.Name()))
 	}
 
+	// column without domain tag is typed as the base type
+	c.Domain, c.DomainSchema = "", ""
+	if ct.Domain != "" {
+		c.DomainSchema, c.Domain = "public", ct.Domain
+		if schema, name, found := strings.Cut(ct.Domain, "."); found {
+			c.DomainSchema, c.Domain = schema, name
+		}
+	}
+
 	c.DefaultValue = ct.Default
 
+	if isSerialColumn {
+		c.Sequence = c.GetDefaultSequence()
+		if c.Sequence == "" {
+			c.Sequence = fmt.Sprintf("%s_%s_seq", c.Table, c.Name)
+			c.DefaultValue = fmt.Sprintf("nextval('%s'::regclass)", c.Sequence)
+		}
+	}
+
 	if ct.AutoIncrement {
 		c.IdentityGeneration = "BY DEFAULT"
+		if ct.ReadOnly {
+			c.IdentityGeneration = "ALWAYS"
+		}
 	}
 
 	if len(c.Enums) == 0 {
@@ -316,6 +469,22 @@ func bindColumn(field *reflect.StructField, ct *raiden.ColumnTag, c *objects.Col
 	}
 }
 
+func getTableStateKey(schema, name string) string {
+	if schema == "" {
+		schema = "public"
+	}
+	return fmt.Sprintf("%s.%s", schema, name)
+}
+
+func getModelSchema(modelType reflect.Type) string {
+	if metadataField, isExist := modelType.FieldByName("Metadata"); isExist {
+		if schema := metadataField.Tag.Get("schema"); len(schema) > 0 {
+			return schema
+		}
+	}
+	return "public"
+}
+
 func bindTableMetadata(field *reflect.StructField, table *objects.Table) {
 	if schema := field.Tag.Get("schema"); len(schema) > 0 {
 		table.Schema = schema
@@ -338,6 +507,30 @@ func bindTableMetadata(field *reflect.StructField, table *objects.Table) {
 	} else {
 		table.RLSForced = false
 	}
+
+	if view := field.Tag.Get("view"); len(view) > 0 {
+		if isView, err := strconv.ParseBool(view); err == nil {
+			table.IsView = isView
+		}
+	}
+
+	if partitioned := field.Tag.Get("partitioned"); len(partitioned) > 0 {
+		if isPartitioned, err := strconv.ParseBool(partitioned); err == nil {
+			table.IsPartitioned = isPartitioned
+		}
+	}
+
+	if foreign := field.Tag.Get("foreign"); len(foreign) > 0 {
+		if isForeign, err := strconv.ParseBool(foreign); err == nil {
+			table.IsForeign = isForeign
+		}
+	}
+
+	if materialized := field.Tag.Get("materialized"); len(materialized) > 0 {
+		if isMaterialized, err := strconv.ParseBool(materialized); err == nil {
+			table.IsMaterialized = isMaterialized
+		}
+	}
 }
 
 func getPolicies(field *reflect.StructField, ei *ExtractTableItem) (policies []objects.Policy) {
@@ -389,8 +582,8 @@ func getPolicies(field *reflect.StructField, ei *ExtractTableItem) (policies []o
 			Action:     "PERMISSIVE",
 			Command:    objects.PolicyCommandUpdate,
 			Roles:      acl.Write.Roles,
-			Definition: acl.Write.Using,
-			Check:      acl.Write.Check,
+			Definition: acl.GetUpdateUsing(),
+			Check:      acl.GetUpdateCheck(),
 		}
 		if updatePolicy.Check == nil || (updatePolicy.Check != nil && *updatePolicy.Check == "") {
 			updatePolicy.Check = &defaultCheck
@@ -412,7 +605,7 @@ func getPolicies(field *reflect.StructField, ei *ExtractTableItem) (policies []o
 			Action:     "PERMISSIVE",
 			Command:    objects.PolicyCommandDelete,
 			Roles:      acl.Write.Roles,
-			Definition: acl.Write.Using,
+			Definition: acl.GetDeleteUsing(),
 		}
 		if deletePolicy.Definition == "" {
 			deletePolicy.Definition = "true"
@@ -425,20 +618,30 @@ func getPolicies(field *reflect.StructField, ei *ExtractTableItem) (policies []o
 	return
 }
 
-func buildTableRelation(tableName, fieldName, schema string, mapRelations map[string]objects.TablesRelationship, joinTag string) (relation objects.TablesRelationship) {
+func buildTableRelation(tableName, relationTableName, schema string, mapRelations map[string][]objects.TablesRelationship, modelColumns map[string]bool, joinTag string) (relations []objects.TablesRelationship) {
 	jt := raiden.UnmarshalJoinTag(joinTag)
 
-	sourceTable, targetTable := utils.ToSnakeCase(fieldName), utils.ToSnakeCase(tableName)
+	sourceTable, targetTable := relationTableName, utils.ToSnakeCase(tableName)
 
-	var sourceTableName, targetTableName, primaryKey, foreignKey string
+	var sourceTableName, targetTableName string
+	var primaryKeys, foreignKeys []string
 
 	switch jt.JoinType {
 	case raiden.RelationTypeHasMany:
 		sourceTableName = sourceTable
 		targetTableName = targetTable
+	case raiden.RelationTypeBelongsTo:
+		sourceTableName = targetTable
+		targetTableName = sourceTable
 	case raiden.RelationTypeHasOne:
 		sourceTableName = targetTable
 		targetTableName = sourceTable
+
+		// parent has one child, foreign key is column of child table
+		if jt.ForeignKey != "" && !modelColumns[jt.ForeignKey] {
+			sourceTableName = sourceTable
+			targetTableName = targetTable
+		}
 	case raiden.RelationTypeManyToMany:
 		return
 	default:
@@ -446,36 +649,141 @@ func buildTableRelation(tableName, fieldName, schema string, mapRelations map[st
 	}
 
 	// setup primary and foreign key
-	if jt.ForeignKey != "" {
-		foreignKey = jt.ForeignKey
-	} else {
-		foreignKey = fmt.Sprintf("%s_id", utils.ToSnakeCase(targetTableName))
+	foreignKeys, primaryKeys = getJoinKeys(jt)
+	if len(foreignKeys) == 0 {
+		foreignKeys = []string{fmt.Sprintf("%s_id", utils.ToSnakeCase(targetTableName))}
 	}
 
-	if jt.PrimaryKey != "" {
-		primaryKey = jt.PrimaryKey
-	} else {
-		primaryKey = "id"
+	if len(primaryKeys) == 0 {
+		primaryKeys = []string{"id"}
+	}
+
+	if len(foreignKeys) != len(primaryKeys) {
+		return
 	}
 
 	// overwrite with default if relation is exist
-	relation.ConstraintName = getRelationConstrainName(schema, sourceTableName, foreignKey)
-	if r, ok := mapRelations[relation.ConstraintName]; ok {
-		relation = r
+	constraintName := getRelationConstrainName(schema, sourceTableName, strings.Join(foreignKeys, "_"))
+	existing, ok := mapRelations[constraintName]
+	if !ok {
+		existing = findRelationByColumns(mapRelations, sourceTableName, foreignKeys)
+	}
+
+	for i := range foreignKeys {
+		relation := objects.TablesRelationship{ConstraintName: constraintName}
+		for _, r := range existing {
+			if r.SourceColumnName == foreignKeys[i] {
+				relation = r
+				break
+			}
+		}
+
+		relation.SourceSchema = schema
+		relation.SourceTableName = sourceTableName
+		relation.SourceColumnName = foreignKeys[i]
+
+		relation.TargetTableSchema = schema
+		relation.TargetTableName = targetTableName
+		relation.TargetColumnName = primaryKeys[i]
+		relations = append(relations, relation)
+	}
+
+	return
+}
+
+// getJoinKeys return foreign and primary key columns of join tag,
+// composite keys is used when declared
+func getJoinKeys(jt raiden.JoinTag) (foreignKeys, primaryKeys []string) {
+	if len(jt.ForeignKeys) > 0 {
+		foreignKeys = jt.ForeignKeys
+	} else if jt.ForeignKey != "" {
+		foreignKeys = []string{jt.ForeignKey}
+	}
+
+	if len(jt.PrimaryKeys) > 0 {
+		primaryKeys = jt.PrimaryKeys
+	} else if jt.PrimaryKey != "" {
+		primaryKeys = []string{jt.PrimaryKey}
 	}
+	return
+}
 
-	relation.SourceSchema = schema
-	relation.SourceTableName = sourceTableName
-	relation.SourceColumnName = foreignKey
+func isModelColumns(modelColumns map[string]bool, columns []string) bool {
+	for _, c := range columns {
+		if !modelColumns[c] {
+			return false
+		}
+	}
+	return true
+}
 
-	relation.TargetTableSchema = schema
-	relation.TargetTableName = targetTableName
-	relation.TargetColumnName = primaryKey
+// findRelationByColumns return relationship rows of existing constraint that have
+// the same source table and columns, used when constraint name is not the default
+func findRelationByColumns(mapRelations map[string][]objects.TablesRelationship, sourceTableName string, foreignKeys []string) []objects.TablesRelationship {
+	for _, rs := range mapRelations {
+		if len(rs) != len(foreignKeys) || rs[0].SourceTableName != sourceTableName {
+			continue
+		}
+
+		isMatch := true
+		for _, r := range rs {
+			if !utils.Contains(foreignKeys, r.SourceColumnName) {
+				isMatch = false
+				break
+			}
+		}
+
+		if isMatch {
+			return rs
+		}
+	}
+	return nil
+}
 
+// getModelFields return field of model include field that promoted from
+// embedded struct (e.g generated model base), embedded field itself is skipped
+func getModelFields(modelType reflect.Type) (fields []reflect.StructField) {
+	for _, field := range reflect.VisibleFields(modelType) {
+		if field.Anonymous {
+			continue
+		}
+		fields = append(fields, field)
+	}
 	return
 }
 
+// getModelColumnNames return name of all column that declared in model
+func getModelColumnNames(modelType reflect.Type) map[string]bool {
+	columns := make(map[string]bool)
+	for _, field := range getModelFields(modelType) {
+		if columnTag := field.Tag.Get("column"); len(columnTag) > 0 {
+			name := raiden.UnmarshalColumnTag(columnTag).Name
+			if name == "" {
+				name = utils.ToSnakeCase(field.Name)
+			}
+			columns[name] = true
+		}
+	}
+	return columns
+}
+
 // get relation table name, base on struct type that defined in relation field
+// fallback to field name when type is not a struct, example :
+// - Parent *Categories will return categories
+// - Children []*Categories will return categories
+func getRelationTableName(field *reflect.StructField) string {
+	fieldType := field.Type
+	for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.Struct || fieldType.Name() == "" {
+		return utils.ToSnakeCase(field.Name)
+	}
+	return raiden.GetTableName(fieldType)
+}
+
+// get relation constraint name, example : public_submission_candidate_id_fkey
 func getRelationConstrainName(schema, table, foreignKey string) string {
 	return fmt.Sprintf("%s_%s_%s_fkey", schema, table, foreignKey)
 }
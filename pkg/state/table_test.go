@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sev-2/raiden"
 	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -108,3 +110,485 @@ func TestExtractTable_WithRelation(t *testing.T) {
 	assert.Equal(t, "note", rs.New[0].Table.Columns[4].Name)
 	assert.Equal(t, "created_at", rs.New[0].Table.Columns[5].Name)
 }
+
+type Article struct {
+	Id   int64    `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	Tags []string `json:"tags,omitempty" column:"name:tags;type:text[];nullable"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+
+	// Access control
+	Acl string `json:"-" read:"" write:""`
+}
+
+func TestExtractTable_ArrayColumn(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Article{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	for _, c := range rs.New[0].Table.Columns {
+		if c.Name == "tags" {
+			assert.Equal(t, "ARRAY", c.DataType)
+			assert.Equal(t, "_text", c.Format)
+			return
+		}
+	}
+	t.Error("tags column is not extracted")
+}
+
+type ArticleSummary struct {
+	Id    int64  `json:"id,omitempty" column:"name:id;type:bigint"`
+	Title string `json:"title,omitempty" column:"name:title;type:text;nullable"`
+
+	// View information
+	Metadata string `json:"-" schema:"public" view:"true"`
+}
+
+func TestExtractTable_View(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&ArticleSummary{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+	assert.True(t, rs.New[0].Table.IsView)
+	assert.False(t, rs.New[0].Table.IsMaterialized)
+}
+
+type ExchangeRates struct {
+	Currency string   `json:"currency,omitempty" column:"name:currency;type:text"`
+	Rate     *float64 `json:"rate,omitempty" column:"name:rate;type:numeric;nullable"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public" rlsEnable:"false" rlsForced:"false" foreign:"true" readOnly:"true"`
+}
+
+func TestExtractTable_ForeignTable(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&ExchangeRates{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+	assert.True(t, rs.New[0].Table.IsForeign)
+	assert.Equal(t, "exchange_rates", rs.New[0].Table.Name)
+}
+
+type Profile struct {
+	Id     int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	UserId string `json:"user_id,omitempty" column:"name:user_id;type:uuid"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+
+	// Access control
+	Acl string `json:"-" read:"authenticated" write:"authenticated" writeCheck:"user_id = auth.uid()" writeUsing:"user_id = auth.uid()" updateCheck:"true" deleteUsing:"false"`
+}
+
+func TestExtractTable_UpdateDeletePolicy(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Profile{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	policies := rs.New[0].ExtractedPolicies.New
+	assert.Equal(t, 4, len(policies))
+	for _, p := range policies {
+		switch p.Command {
+		case objects.PolicyCommandInsert:
+			assert.Equal(t, "(user_id = auth.uid())", *p.Check)
+		case objects.PolicyCommandUpdate:
+			assert.Equal(t, "(user_id = auth.uid())", p.Definition)
+			assert.Equal(t, "true", *p.Check)
+		case objects.PolicyCommandDelete:
+			assert.Equal(t, "(false)", p.Definition)
+		}
+	}
+}
+
+type Profiles struct {
+	Id        int64   `json:"col_id,omitempty" column:"name:col_id;type:bigint;primaryKey"`
+	FirstName *string `json:"col_first_name,omitempty" column:"name:col_first_name;type:text;nullable"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public" tableName:"tbl_profiles"`
+}
+
+func TestExtractTable_TableNameTag(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Profiles{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+	assert.Equal(t, "tbl_profiles", rs.New[0].Table.Name)
+	assert.Equal(t, "col_first_name", rs.New[0].Table.Columns[1].Name)
+}
+
+type Users struct {
+	Id int64 `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+
+	// Relations
+	UserProfile *UserProfile `json:"user_profile,omitempty" join:"joinType:hasOne;primaryKey:id;foreignKey:user_id"`
+}
+
+type UserProfile struct {
+	Id     int64 `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	UserId int64 `json:"user_id,omitempty" column:"name:user_id;type:bigint;unique"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+
+	// Relations
+	Users *Users `json:"users,omitempty" join:"joinType:hasOne;primaryKey:id;foreignKey:user_id"`
+}
+
+func TestExtractTable_HasOneChildRelation(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Users{}, &UserProfile{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rs.New))
+
+	for _, ei := range rs.New {
+		switch ei.Table.Name {
+		case "users":
+			assert.Equal(t, 0, len(ei.Table.Relationships))
+		case "user_profile":
+			assert.Equal(t, 1, len(ei.Table.Relationships))
+			assert.Equal(t, "user_id", ei.Table.Relationships[0].SourceColumnName)
+			assert.Equal(t, "users", ei.Table.Relationships[0].TargetTableName)
+		}
+	}
+}
+
+type UserAddress struct {
+	Id     int64 `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	UserId int64 `json:"user_id,omitempty" column:"name:user_id;type:bigint"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+
+	// Relations
+	Users *Users `json:"users,omitempty" join:"joinType:belongsTo;primaryKey:id;foreignKey:user_id"`
+}
+
+func TestExtractTable_BelongsToRelation(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&UserAddress{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+	assert.Equal(t, 1, len(rs.New[0].Table.Relationships))
+
+	r := rs.New[0].Table.Relationships[0]
+	assert.Equal(t, "user_address", r.SourceTableName)
+	assert.Equal(t, "user_id", r.SourceColumnName)
+	assert.Equal(t, "users", r.TargetTableName)
+	assert.Equal(t, "id", r.TargetColumnName)
+}
+
+type Orders struct {
+	Id     int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	Status string `json:"status,omitempty" column:"name:status;type:text;nullable:false" check:"(status = ANY (ARRAY['pending'::text, 'paid'::text]))"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+func TestExtractTable_CheckTag(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Orders{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+	assert.Equal(t, "", rs.New[0].Table.Columns[0].GetCheck())
+	assert.Equal(t, "(status = ANY (ARRAY['pending'::text, 'paid'::text]))", rs.New[0].Table.Columns[1].GetCheck())
+}
+
+type CustomerContacts struct {
+	Id    int64   `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	Email *string `json:"email,omitempty" column:"name:email;type:text;nullable" grant:"select:support;update:support,staff"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+func TestExtractTable_GrantTag(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&CustomerContacts{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+	assert.Equal(t, 0, len(rs.New[0].Table.Columns[0].Privileges))
+	assert.Equal(t, []objects.ColumnPrivilege{
+		{Grantee: "support", PrivilegeType: "SELECT"},
+		{Grantee: "staff", PrivilegeType: "UPDATE"},
+		{Grantee: "support", PrivilegeType: "UPDATE"},
+	}, rs.New[0].Table.Columns[1].GetPrivileges())
+}
+
+type Posts struct {
+	Id   int64    `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	Tags []string `json:"tags,omitempty" column:"name:tags;type:text[]"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+
+	// Indexes
+	PostsTagsIdx string `json:"-" index:"name:posts_tags_idx;method:gin" indexColumns:"tags" indexWhere:"deleted_at IS NULL"`
+}
+
+func TestExtractTable_IndexTag(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Posts{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+	assert.Equal(t, 2, len(rs.New[0].Table.Columns))
+	assert.Equal(t, []objects.TableIndex{
+		{Name: "posts_tags_idx", Columns: []string{"tags"}, Method: "gin", Predicate: "deleted_at IS NULL"},
+	}, rs.New[0].Table.Indexes)
+
+	// model without index field keep index from state
+	stateIndexes := []objects.TableIndex{{Name: "orders_status_idx", Columns: []string{"status"}, Method: "btree"}}
+	rs, err = state.ExtractTable([]state.TableState{
+		{Table: objects.Table{Name: "orders", Schema: "public", Indexes: stateIndexes}},
+	}, []any{&Orders{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.Existing))
+	assert.Equal(t, stateIndexes, rs.Existing[0].Table.Indexes)
+}
+
+type Products struct {
+	Id    int64    `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	Code  string   `json:"code,omitempty" column:"name:code;type:varchar(50);nullable:false"`
+	Price *float64 `json:"price,omitempty" column:"name:price;type:numeric(12,2);nullable"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+func TestExtractTable_TypeModifier(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Products{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	columns := rs.New[0].Table.Columns
+	assert.Equal(t, 3, len(columns))
+	assert.Equal(t, "character varying", columns[1].DataType)
+	assert.Equal(t, 50, columns[1].Length)
+	assert.Equal(t, "(50)", columns[1].GetTypeModifier())
+	assert.Equal(t, "numeric", columns[2].DataType)
+	assert.Equal(t, 12, columns[2].Precision)
+	assert.Equal(t, 2, columns[2].Scale)
+	assert.Equal(t, "(12,2)", columns[2].GetTypeModifier())
+}
+
+type AuditBase struct {
+	Id        int64      `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	CreatedAt *time.Time `json:"created_at,omitempty" column:"name:created_at;type:timestampz;nullable:false;default:now()"`
+}
+
+type Articles struct {
+	AuditBase
+	Title string `json:"title,omitempty" column:"name:title;type:text;nullable:false"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+func TestExtractTable_EmbeddedModelBase(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Articles{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	table := rs.New[0].Table
+	assert.Equal(t, "articles", table.Name)
+	assert.Equal(t, 3, len(table.Columns))
+	assert.Equal(t, "id", table.Columns[0].Name)
+	assert.Equal(t, "created_at", table.Columns[1].Name)
+	assert.Equal(t, "title", table.Columns[2].Name)
+	assert.Equal(t, 1, len(table.PrimaryKeys))
+}
+
+type Devices struct {
+	Id   *string `json:"id,omitempty" column:"name:id;type:uuid;primaryKey;nullable:false;default:gen_random_uuid();serverDefault"`
+	Name string  `json:"name,omitempty" column:"name:name;type:text;nullable:false"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+func TestExtractTable_UuidPrimaryKey(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Devices{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	table := rs.New[0].Table
+	assert.Equal(t, []objects.PrimaryKey{{Name: "id", TableName: "devices", Schema: "public"}}, table.PrimaryKeys)
+	assert.Equal(t, "uuid", table.Columns[0].DataType)
+	assert.Nil(t, table.Columns[0].IdentityGeneration)
+
+	defaultValue, isString := table.Columns[0].DefaultValue.(*string)
+	assert.True(t, isString)
+	assert.Equal(t, "gen_random_uuid()", *defaultValue)
+}
+
+type Email string
+
+type Customers struct {
+	Id    int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;nullable:false"`
+	Email Email  `json:"email,omitempty" column:"name:email;type:text;domain:public.email;nullable:false"`
+	Note  string `json:"note,omitempty" column:"name:note;type:text;nullable"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+func TestExtractTable_DomainColumn(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Customers{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	table := rs.New[0].Table
+	assert.Equal(t, "text", table.Columns[1].DataType)
+	assert.Equal(t, "public.email", table.Columns[1].GetDomain())
+	assert.Equal(t, "", table.Columns[2].GetDomain())
+}
+
+type Address struct {
+	Street *string `json:"street,omitempty"`
+}
+
+type Stores struct {
+	Id         int64                   `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;nullable:false"`
+	OpenPeriod raiden.Range[time.Time] `json:"open_period,omitempty" column:"name:open_period;type:tstzrange;nullable:false"`
+	Address    *Address                `json:"address,omitempty" column:"name:address;composite:public.address;nullable"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+func TestExtractTable_RangeAndCompositeColumn(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Stores{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	table := rs.New[0].Table
+	assert.Equal(t, "tstzrange", table.Columns[1].DataType)
+	assert.Equal(t, "USER-DEFINED", table.Columns[2].DataType)
+	assert.Equal(t, "address", table.Columns[2].Format)
+}
+
+type Ledgers struct {
+	Id    int64  `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;nullable:false"`
+	Total *int64 `json:"total,omitempty" column:"name:total;type:bigint;nullable:true"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public" excludeColumns:"_audit_created_by,_audit_removed"`
+}
+
+func TestExtractTable_ExcludeColumns(t *testing.T) {
+	tableState := state.TableState{
+		Table: objects.Table{
+			Name:   "ledgers",
+			Schema: "public",
+			Columns: []objects.Column{
+				{Name: "id", DataType: "bigint"},
+				{Name: "_audit_created_by", DataType: "uuid", IsNullable: true},
+				{Name: "total", DataType: "bigint", IsNullable: true},
+				{Name: "note", DataType: "text", IsNullable: true},
+			},
+		},
+	}
+
+	rs, err := state.ExtractTable([]state.TableState{tableState}, []any{&Ledgers{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.Existing))
+
+	// excluded column is kept from state, column that removed from model is not
+	var columns []string
+	for _, c := range rs.Existing[0].Table.Columns {
+		columns = append(columns, c.Name)
+	}
+	assert.Equal(t, []string{"id", "total", "_audit_created_by"}, columns)
+}
+
+type Invoices struct {
+	Id       int64  `json:"id,omitempty" column:"name:id;type:bigserial;primaryKey;nullable:false;default:nextval('invoices_id_seq'::regclass)"`
+	Number   *int64 `json:"number,omitempty" column:"name:number;type:bigint;nullable:false;default:nextval('document_number_seq'::regclass)"`
+	Sequence int32  `json:"sequence,omitempty" column:"name:sequence;type:serial;nullable:false"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+func TestExtractTable_SerialColumn(t *testing.T) {
+	rs, err := state.ExtractTable(make([]state.TableState, 0), []any{&Invoices{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	table := rs.New[0].Table
+	assert.Equal(t, "bigint", table.Columns[0].DataType)
+	assert.Equal(t, "invoices_id_seq", table.Columns[0].Sequence)
+	assert.Equal(t, "bigserial", table.Columns[0].GetSerialType())
+
+	// shared sequence is kept as default and not owned by column
+	assert.Equal(t, "", table.Columns[1].Sequence)
+	assert.Equal(t, "", table.Columns[1].GetSerialType())
+	assert.Equal(t, "document_number_seq", table.Columns[1].GetDefaultSequence())
+
+	// serial without default use implicit sequence name
+	assert.Equal(t, "integer", table.Columns[2].DataType)
+	assert.Equal(t, "nextval('invoices_sequence_seq'::regclass)", table.Columns[2].DefaultValue)
+	assert.Equal(t, "serial", table.Columns[2].GetSerialType())
+}
+
+type TenantOrders struct {
+	Id       int64 `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;nullable:false"`
+	TenantId int64 `json:"tenant_id,omitempty" column:"name:tenant_id;type:bigint;primaryKey;nullable:false"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+}
+
+type TenantOrderItems struct {
+	Id       int64 `json:"id,omitempty" column:"name:id;type:bigint;primaryKey;autoIncrement;nullable:false"`
+	OrderId  int64 `json:"order_id,omitempty" column:"name:order_id;type:bigint;nullable:false"`
+	TenantId int64 `json:"tenant_id,omitempty" column:"name:tenant_id;type:bigint;nullable:false"`
+
+	// Table information
+	Metadata string `json:"-" schema:"public"`
+
+	// Relations
+	TenantOrders *TenantOrders `json:"tenant_orders,omitempty" join:"joinType:hasOne;primaryKey:id;foreignKey:order_id;primaryKeys:id,tenant_id;foreignKeys:order_id,tenant_id"`
+}
+
+func TestExtractTable_CompositeForeignKey(t *testing.T) {
+	relations := []objects.TablesRelationship{
+		{
+			Id: 10, ConstraintName: "tenant_order_items_order_id_tenant_id_fkey",
+			SourceSchema: "public", SourceTableName: "tenant_order_items", SourceColumnName: "order_id",
+			TargetTableSchema: "public", TargetTableName: "tenant_orders", TargetColumnName: "id",
+		},
+		{
+			Id: 10, ConstraintName: "tenant_order_items_order_id_tenant_id_fkey",
+			SourceSchema: "public", SourceTableName: "tenant_order_items", SourceColumnName: "tenant_id",
+			TargetTableSchema: "public", TargetTableName: "tenant_orders", TargetColumnName: "tenant_id",
+		},
+	}
+
+	tableStates := []state.TableState{
+		{Table: objects.Table{Schema: "public", Name: "tenant_order_items", Relationships: relations}},
+	}
+
+	// relation from state is kept as is
+	rs, err := state.ExtractTable(tableStates, []any{&TenantOrderItems{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.Existing))
+	assert.Equal(t, relations, rs.Existing[0].Table.Relationships)
+
+	// new table have one relationship row per column
+	rs, err = state.ExtractTable(nil, []any{&TenantOrderItems{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+	assert.Equal(t, 2, len(rs.New[0].Table.Relationships))
+	assert.Equal(t, "order_id", rs.New[0].Table.Relationships[0].SourceColumnName)
+	assert.Equal(t, "id", rs.New[0].Table.Relationships[0].TargetColumnName)
+	assert.Equal(t, "tenant_id", rs.New[0].Table.Relationships[1].SourceColumnName)
+	assert.Equal(t, "tenant_id", rs.New[0].Table.Relationships[1].TargetColumnName)
+
+	// relation that not exist in state use constraint name of every column
+	tableStates[0].Table.Relationships = nil
+	rs, err = state.ExtractTable(tableStates, []any{&TenantOrderItems{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rs.Existing[0].Table.Relationships))
+	for _, r := range rs.Existing[0].Table.Relationships {
+		assert.Equal(t, "public_tenant_order_items_order_id_tenant_id_fkey", r.ConstraintName)
+	}
+}
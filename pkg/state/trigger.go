@@ -0,0 +1,120 @@
+package state
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/utils"
+)
+
+type ExtractTriggerResult struct {
+	Existing []objects.Trigger
+	New      []objects.Trigger
+	Delete   []objects.Trigger
+}
+
+func ExtractTrigger(triggerStates []TriggerState, appTriggers []raiden.Trigger) (result ExtractTriggerResult, err error) {
+	mapTriggerState := map[string]TriggerState{}
+	for i := range triggerStates {
+		t := triggerStates[i]
+		mapTriggerState[t.Trigger.Key()] = t
+	}
+
+	for _, trigger := range appTriggers {
+		t := objects.Trigger{}
+		if err := BindToSupabaseTrigger(&t, trigger); err != nil {
+			return result, err
+		}
+
+		state, isStateExist := mapTriggerState[t.Key()]
+		if !isStateExist {
+			result.New = append(result.New, t)
+			continue
+		}
+
+		st := state.Trigger
+		if err := BindToSupabaseTrigger(&st, trigger); err != nil {
+			return result, err
+		}
+		result.Existing = append(result.Existing, st)
+		delete(mapTriggerState, t.Key())
+	}
+
+	for _, state := range mapTriggerState {
+		result.Delete = append(result.Delete, state.Trigger)
+	}
+
+	return
+}
+
+func BindToSupabaseTrigger(t *objects.Trigger, trigger raiden.Trigger) error {
+	name := trigger.Name()
+	if name == "" {
+		rv := reflect.TypeOf(trigger)
+		if rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		name = utils.ToSnakeCase(rv.Name())
+	}
+
+	fn := trigger.Function()
+	if fn == nil {
+		return fmt.Errorf("trigger %s doesn't have function", name)
+	}
+
+	// rpc name is derived from struct name when it is not declared
+	fnName := fn.GetName()
+	if fnName == "" {
+		rv := reflect.TypeOf(fn)
+		if rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		fnName = utils.ToSnakeCase(rv.Name())
+	}
+
+	fnSchema := fn.GetSchema()
+	if fnSchema == "" {
+		fnSchema = raiden.DefaultRpcSchema
+	}
+
+	schema := trigger.Schema()
+	if schema == "" {
+		schema = raiden.DefaultTriggerSchema
+	}
+
+	events := make([]string, 0, len(trigger.Events()))
+	for _, e := range trigger.Events() {
+		events = append(events, string(e))
+	}
+
+	t.Name = name
+	t.Schema = schema
+	t.Table = trigger.Table()
+	t.Activation = string(trigger.Timing())
+	t.Orientation = string(trigger.Orientation())
+	t.Events = events
+	t.FunctionName = fnName
+	t.FunctionSchema = fnSchema
+	t.FunctionArgs = trigger.Arguments()
+
+	t.Condition = nil
+	if condition := trigger.Condition(); condition != "" {
+		t.Condition = &condition
+	}
+	return nil
+}
+
+func (er ExtractTriggerResult) ToDeleteFlatMap() map[string]*objects.Trigger {
+	mapData := make(map[string]*objects.Trigger)
+
+	if len(er.Delete) > 0 {
+		for i := range er.Delete {
+			t := er.Delete[i]
+			mapData[t.Key()] = &t
+		}
+	}
+
+	return mapData
+}
@@ -0,0 +1,67 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/state"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/stretchr/testify/assert"
+)
+
+type HandleNewOrder struct {
+	raiden.RpcBase
+}
+
+type OnOrderInserted struct {
+	raiden.TriggerBase
+}
+
+func (t *OnOrderInserted) Name() string {
+	return "on_order_inserted"
+}
+
+func (t *OnOrderInserted) Table() string {
+	return "orders"
+}
+
+func (t *OnOrderInserted) Events() []raiden.TriggerEvent {
+	return []raiden.TriggerEvent{raiden.TriggerEventInsert}
+}
+
+func (t *OnOrderInserted) Orientation() raiden.TriggerOrientation {
+	return raiden.TriggerOrientationRow
+}
+
+func (t *OnOrderInserted) Function() raiden.Rpc {
+	return &HandleNewOrder{}
+}
+
+func TestExtractTrigger(t *testing.T) {
+	appTriggers := []raiden.Trigger{&OnOrderInserted{}}
+
+	rs, err := state.ExtractTrigger(nil, appTriggers)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(rs.New))
+
+	tr := rs.New[0]
+	assert.Equal(t, "public.orders.on_order_inserted", tr.Key())
+	assert.Equal(t, "AFTER", tr.Activation)
+	assert.Equal(t, "ROW", tr.Orientation)
+	assert.Equal(t, []string{"INSERT"}, tr.Events)
+	assert.Equal(t, "handle_new_order", tr.FunctionName)
+	assert.Equal(t, "public", tr.FunctionSchema)
+	assert.Nil(t, tr.Condition)
+
+	states := []state.TriggerState{
+		{Trigger: objects.Trigger{ID: 1, Name: "on_order_inserted", Schema: "public", Table: "orders"}},
+		{Trigger: objects.Trigger{ID: 2, Name: "on_order_deleted", Schema: "public", Table: "orders"}},
+	}
+	rs, err = state.ExtractTrigger(states, appTriggers)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(rs.New))
+	assert.Equal(t, 1, len(rs.Existing))
+	assert.Equal(t, 1, rs.Existing[0].ID)
+	assert.Equal(t, 1, len(rs.Delete))
+	assert.Equal(t, "on_order_deleted", rs.Delete[0].Name)
+}
@@ -2,6 +2,7 @@ package net
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,8 +26,9 @@ type (
 )
 
 type ReqError struct {
-	Message string
-	Body    []byte
+	Message    string
+	StatusCode int
+	Body       []byte
 }
 
 func (s ReqError) Error() string {
@@ -49,13 +51,42 @@ var GetClient func() Client = func() Client {
 	}
 }
 
+// SendRequest send request and retry request that failed with transient error
+// when retry policy is set, retry is stopped when retry policy context is done
 func SendRequest(method string, url string, body []byte, timeout time.Duration, reqInterceptor RequestInterceptor, resInterceptor ResponseInterceptor) (rawBody []byte, err error) {
+	return SendRequestWithContext(context.Background(), method, url, body, timeout, reqInterceptor, resInterceptor)
+}
+
+// SendRequestWithContext is SendRequest that cancelled when ctx or retry policy context is done
+func SendRequestWithContext(ctx context.Context, method string, url string, body []byte, timeout time.Duration, reqInterceptor RequestInterceptor, resInterceptor ResponseInterceptor) (rawBody []byte, err error) {
+	policy := getRetryPolicy()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(policy.Context, cancel)
+	defer stop()
+
+	for attempt := 0; ; attempt++ {
+		rawBody, err = sendRequest(ctx, method, url, body, timeout, reqInterceptor, resInterceptor)
+		if err == nil || attempt >= policy.MaxRetries || ctx.Err() != nil || !isTransientError(err) {
+			return rawBody, err
+		}
+
+		delay := policy.backoffDelay(attempt)
+		Logger.Debug("retry request", "method", method, "url", url, "attempt", attempt+1, "max-retries", policy.MaxRetries, "delay", delay.String(), "reason", err.Error())
+		if sleepErr := sleepWithContext(ctx, delay); sleepErr != nil {
+			return nil, err
+		}
+	}
+}
+
+func sendRequest(ctx context.Context, method string, url string, body []byte, timeout time.Duration, reqInterceptor RequestInterceptor, resInterceptor ResponseInterceptor) (rawBody []byte, err error) {
 	reqTimeout := DefaultTimeout
 	if timeout != 0 {
 		reqTimeout = timeout
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, err
 	}
@@ -93,8 +124,9 @@ func SendRequest(method string, url string, body []byte, timeout time.Duration,
 			body, _ := io.ReadAll(resp.Body)
 			Logger.Error(string(body))
 			sendErr := ReqError{
-				Message: err.Error(),
-				Body:    body,
+				Message:    err.Error(),
+				StatusCode: statusCode,
+				Body:       body,
 			}
 			return nil, sendErr
 		}
@@ -118,7 +150,11 @@ func SendRequest(method string, url string, body []byte, timeout time.Duration,
 }
 
 func Post[T any](url string, rawBody []byte, timeout time.Duration, reqInterceptor RequestInterceptor, resInterceptor ResponseInterceptor) (res T, err error) {
-	byteData, err := SendRequest(http.MethodPost, url, rawBody, timeout, reqInterceptor, resInterceptor)
+	return PostWithContext[T](context.Background(), url, rawBody, timeout, reqInterceptor, resInterceptor)
+}
+
+func PostWithContext[T any](ctx context.Context, url string, rawBody []byte, timeout time.Duration, reqInterceptor RequestInterceptor, resInterceptor ResponseInterceptor) (res T, err error) {
+	byteData, err := SendRequestWithContext(ctx, http.MethodPost, url, rawBody, timeout, reqInterceptor, resInterceptor)
 	if err != nil {
 		return res, err
 	}
@@ -126,7 +162,11 @@ func Post[T any](url string, rawBody []byte, timeout time.Duration, reqIntercept
 }
 
 func Get[T any](url string, timeout time.Duration, reqInterceptor RequestInterceptor, resInterceptor ResponseInterceptor) (res T, err error) {
-	byteData, err := SendRequest(http.MethodGet, url, nil, timeout, reqInterceptor, resInterceptor)
+	return GetWithContext[T](context.Background(), url, timeout, reqInterceptor, resInterceptor)
+}
+
+func GetWithContext[T any](ctx context.Context, url string, timeout time.Duration, reqInterceptor RequestInterceptor, resInterceptor ResponseInterceptor) (res T, err error) {
+	byteData, err := SendRequestWithContext(ctx, http.MethodGet, url, nil, timeout, reqInterceptor, resInterceptor)
 	if err != nil {
 		return res, err
 	}
@@ -0,0 +1,98 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RetryPolicy is option for retry request that failed with transient error,
+// request is retried with exponential backoff and jitter until MaxRetries is reached
+type RetryPolicy struct {
+	Context    context.Context
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+var (
+	retryPolicy *RetryPolicy
+	retryMutex  sync.RWMutex
+
+	// status code that usually caused by overloaded or restarted server
+	transientStatusCodes = map[int]bool{
+		http.StatusRequestTimeout:     true,
+		http.StatusTooManyRequests:    true,
+		http.StatusBadGateway:         true,
+		http.StatusServiceUnavailable: true,
+		http.StatusGatewayTimeout:     true,
+	}
+)
+
+// SetRetryPolicy set retry policy for all request, set nil for disable retry
+func SetRetryPolicy(policy *RetryPolicy) {
+	retryMutex.Lock()
+	defer retryMutex.Unlock()
+	retryPolicy = policy
+}
+
+func getRetryPolicy() RetryPolicy {
+	retryMutex.RLock()
+	defer retryMutex.RUnlock()
+	if retryPolicy == nil {
+		return RetryPolicy{Context: context.Background()}
+	}
+
+	p := *retryPolicy
+	if p.Context == nil {
+		p.Context = context.Background()
+	}
+	return p
+}
+
+// isTransientError check if request can be retried, request that cancelled
+// from context and response with client error is not retried
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var reqErr ReqError
+	if errors.As(err, &reqErr) {
+		return transientStatusCodes[reqErr.StatusCode]
+	}
+	return true
+}
+
+// backoffDelay return delay before next attempt, delay is doubled every attempt
+// and randomized between half and full delay so client is not retry at the same time
+func (p RetryPolicy) backoffDelay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	if half := int64(delay / 2); half > 0 {
+		return time.Duration(half + rand.Int63n(half+1))
+	}
+	return delay
+}
+
+func sleepWithContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
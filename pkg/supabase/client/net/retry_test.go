@@ -0,0 +1,86 @@
+package net_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sev-2/raiden/pkg/supabase/client/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRequest_RetryTransientError(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"message":"ok"}`))
+	}))
+	defer server.Close()
+
+	net.SetRetryPolicy(&net.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+	defer net.SetRetryPolicy(nil)
+
+	rs, err := net.Get[net.DefaultResponse](server.URL, 0, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", rs.Message)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestSendRequest_NoRetryClientError(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	net.SetRetryPolicy(&net.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond})
+	defer net.SetRetryPolicy(nil)
+
+	_, err := net.Get[net.DefaultResponse](server.URL, 0, nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestSendRequest_RetryStopWhenCancelled(t *testing.T) {
+	var attempts int32
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	net.SetRetryPolicy(&net.RetryPolicy{Context: ctx, MaxRetries: 5, BaseDelay: time.Second})
+	defer net.SetRetryPolicy(nil)
+
+	_, err := net.Get[net.DefaultResponse](server.URL, 0, nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestSendRequestWithContext_Cancelled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := net.GetWithContext[net.DefaultResponse](ctx, server.URL, 0, nil, nil)
+	assert.Error(t, err)
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
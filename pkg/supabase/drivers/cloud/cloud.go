@@ -1,6 +1,7 @@
 package cloud
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -23,7 +24,7 @@ func DefaultAuthInterceptor(accessToken string) func(req *http.Request) error {
 func FindProject(cfg *raiden.Config) (objects.Project, error) {
 	CloudLogger.Trace("start find project from supabase")
 	url := fmt.Sprintf("%s/v1/projects", cfg.SupabaseApiUrl)
-	projects, err := net.Get[[]objects.Project](url, net.DefaultTimeout, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	projects, err := net.GetWithContext[[]objects.Project](cfg.Context(), url, net.DefaultTimeout, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return objects.Project{}, err
 	}
@@ -44,6 +45,10 @@ type ExecuteQueryParam struct {
 }
 
 func ExecuteQuery[T any](baseUrl, projectId, query string, reqInterceptor net.RequestInterceptor, resInterceptor net.ResponseInterceptor) (result T, err error) {
+	return ExecuteQueryWithContext[T](context.Background(), baseUrl, projectId, query, reqInterceptor, resInterceptor)
+}
+
+func ExecuteQueryWithContext[T any](ctx context.Context, baseUrl, projectId, query string, reqInterceptor net.RequestInterceptor, resInterceptor net.ResponseInterceptor) (result T, err error) {
 	url := fmt.Sprintf("%s/v1/projects/%s/database/query", baseUrl, projectId)
 	p := ExecuteQueryParam{Query: query}
 	pByte, err := json.Marshal(p)
@@ -52,5 +57,5 @@ func ExecuteQuery[T any](baseUrl, projectId, query string, reqInterceptor net.Re
 		return result, err
 	}
 
-	return net.Post[T](url, pByte, net.DefaultTimeout, reqInterceptor, resInterceptor)
+	return net.PostWithContext[T](ctx, url, pByte, net.DefaultTimeout, reqInterceptor, resInterceptor)
 }
@@ -0,0 +1,25 @@
+package cloud
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query/sql"
+)
+
+func GetForeignTables(cfg *raiden.Config, includedSchemas []string) ([]objects.Table, error) {
+	CloudLogger.Trace("start fetching foreign table from supabase")
+	q, err := sql.GenerateGetForeignTablesQuery(includedSchemas)
+	if err != nil {
+		err = fmt.Errorf("failed generate query get foreign table for project id %s : %v", cfg.ProjectId, err)
+		return []objects.Table{}, err
+	}
+
+	rs, err := ExecuteQueryWithContext[[]objects.Table](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	if err != nil {
+		err = fmt.Errorf("get foreign tables error : %s", err)
+	}
+	CloudLogger.Trace("finish fetching foreign table from supabase")
+	return rs, err
+}
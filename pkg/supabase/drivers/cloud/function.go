@@ -12,7 +12,7 @@ import (
 func GetFunctions(cfg *raiden.Config) ([]objects.Function, error) {
 	CloudLogger.Trace("start fetching function from supabase")
 	q := sql.GenerateFunctionsQuery([]string{"public"})
-	rs, err := ExecuteQuery[[]objects.Function](
+	rs, err := ExecuteQueryWithContext[[]objects.Function](cfg.Context(),
 		cfg.SupabaseApiUrl, cfg.ProjectId, q,
 		DefaultAuthInterceptor(cfg.AccessToken), nil,
 	)
@@ -23,10 +23,38 @@ func GetFunctions(cfg *raiden.Config) ([]objects.Function, error) {
 	return rs, err
 }
 
+func GetFunctionIds(cfg *raiden.Config, includedSchema []string) ([]objects.Function, error) {
+	CloudLogger.Trace("start fetching function id from supabase")
+	q := sql.GenerateFunctionIdsQuery(includedSchema)
+	rs, err := ExecuteQueryWithContext[[]objects.Function](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	if err != nil {
+		err = fmt.Errorf("get function ids error : %s", err)
+	}
+	CloudLogger.Trace("finish fetching function id from supabase")
+	return rs, err
+}
+
+func GetFunctionById(cfg *raiden.Config, id int) (result objects.Function, err error) {
+	CloudLogger.Trace("start fetching single function by id", "id", id)
+	q := sql.GenerateFunctionByIdQuery(id)
+	rs, err := ExecuteQueryWithContext[[]objects.Function](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	if err != nil {
+		err = fmt.Errorf("get function error : %s", err)
+		return
+	}
+
+	if len(rs) == 0 {
+		err = fmt.Errorf("get function %d is not found", id)
+		return
+	}
+	CloudLogger.Trace("finish fetching single function by id", "id", id)
+	return rs[0], nil
+}
+
 func GetFunctionByName(cfg *raiden.Config, schema, name string) (result objects.Function, err error) {
 	CloudLogger.Trace("start fetching single function by name")
 	sql := sql.GenerateFunctionByNameQuery(schema, name) + " limit 1"
-	rs, err := ExecuteQuery[[]objects.Function](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	rs, err := ExecuteQueryWithContext[[]objects.Function](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		err = fmt.Errorf("get function error : %s", err)
 		return
@@ -48,7 +76,7 @@ func CreateFunction(cfg *raiden.Config, fn objects.Function) (objects.Function,
 		return objects.Function{}, nil
 	}
 
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return objects.Function{}, fmt.Errorf("create new function %s error : %s", fn.Name, err)
 	}
@@ -64,7 +92,7 @@ func DeleteFunction(cfg *raiden.Config, fn objects.Function) error {
 		return err
 	}
 
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("delete Function %s error : %s", fn.Name, err)
 	}
@@ -78,7 +106,7 @@ func UpdateFunction(cfg *raiden.Config, fn objects.Function) error {
 	if err != nil {
 		return err
 	}
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, updateSql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, updateSql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("update function %s error : %s", fn.Name, err)
 	}
@@ -13,7 +13,7 @@ import (
 
 func GetPolicies(cfg *raiden.Config) ([]objects.Policy, error) {
 	CloudLogger.Trace("start fetching policies from supabase")
-	rs, err := ExecuteQuery[[]objects.Policy](cfg.SupabaseApiUrl, cfg.ProjectId, sql.GetPoliciesQuery, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	rs, err := ExecuteQueryWithContext[[]objects.Policy](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql.GetPoliciesQuery, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		err = fmt.Errorf("get policies error : %s", err)
 	}
@@ -27,7 +27,7 @@ func GetPolicyByName(cfg *raiden.Config, name string) (result objects.Policy, er
 	sql := fmt.Sprintf(qTemplate, pq.QuoteLiteral(strings.ToLower(name)))
 
 	// logger.Debug("Get Policy by name - execute : ", sql)
-	rs, err := ExecuteQuery[[]objects.Policy](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	rs, err := ExecuteQueryWithContext[[]objects.Policy](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		err = fmt.Errorf("get policies error : %s", err)
 		return
@@ -46,7 +46,7 @@ func CreatePolicy(cfg *raiden.Config, policy objects.Policy) (objects.Policy, er
 	sql := query.BuildCreatePolicyQuery(policy)
 
 	// Execute SQL Query
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return objects.Policy{}, fmt.Errorf("create new policy %s error : %s", policy.Name, err)
 	}
@@ -59,7 +59,7 @@ func UpdatePolicy(cfg *raiden.Config, policy objects.Policy, updatePolicyParams
 	CloudLogger.Trace("start update policy", "name", policy.Name)
 	sql := query.BuildUpdatePolicyQuery(policy, updatePolicyParams)
 	// Execute SQL Query
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("update policy %s error : %s", policy.Name, err)
 	}
@@ -71,7 +71,7 @@ func DeletePolicy(cfg *raiden.Config, policy objects.Policy) error {
 	CloudLogger.Trace("start delete policy", "name", policy.Name)
 	sql := query.BuildDeletePolicyQuery(policy)
 
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("delete role %s error : %s", policy.Name, err)
 	}
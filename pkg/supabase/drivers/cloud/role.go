@@ -80,7 +80,7 @@ var getRoleResponseInterceptor = func(resp *http.Response) error {
 
 func GetRoles(cfg *raiden.Config) ([]objects.Role, error) {
 	CloudLogger.Trace("start fetching role from supabase")
-	rs, err := ExecuteQuery[[]objects.Role](cfg.SupabaseApiUrl, cfg.ProjectId, sql.GetRolesQuery, DefaultAuthInterceptor(cfg.AccessToken), getRoleResponseInterceptor)
+	rs, err := ExecuteQueryWithContext[[]objects.Role](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql.GetRolesQuery, DefaultAuthInterceptor(cfg.AccessToken), getRoleResponseInterceptor)
 	if err != nil {
 		err = fmt.Errorf("get role error : %s", err)
 	}
@@ -93,7 +93,7 @@ func GetRoleByName(cfg *raiden.Config, name string) (result objects.Role, err er
 	qTemplate := sql.GetRolesQuery + " where rolname = %s limit 1"
 	q := fmt.Sprintf(qTemplate, pq.QuoteLiteral(name))
 
-	rs, err := ExecuteQuery[[]objects.Role](cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), getRoleResponseInterceptor)
+	rs, err := ExecuteQueryWithContext[[]objects.Role](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), getRoleResponseInterceptor)
 	if err != nil {
 		err = fmt.Errorf("get role error : %s", err)
 		return
@@ -111,7 +111,7 @@ func CreateRole(cfg *raiden.Config, role objects.Role) (objects.Role, error) {
 	CloudLogger.Trace("start create role", "name", role.Name)
 	sql := query.BuildCreateRoleQuery(role)
 	// Execute SQL Query
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return objects.Role{}, fmt.Errorf("create new role %s error : %s", role.Name, err)
 	}
@@ -122,7 +122,7 @@ func CreateRole(cfg *raiden.Config, role objects.Role) (objects.Role, error) {
 func UpdateRole(cfg *raiden.Config, newRole objects.Role, updateRoleParam objects.UpdateRoleParam) error {
 	CloudLogger.Trace("start update role", "name", newRole.Name)
 	sql := query.BuildUpdateRoleQuery(newRole, updateRoleParam)
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("update new role %s error : %s", updateRoleParam.OldData.Name, err)
 	}
@@ -135,7 +135,7 @@ func DeleteRole(cfg *raiden.Config, role objects.Role) error {
 	sql := query.BuildDeleteRoleQuery(role)
 
 	// execute delete
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("delete role %s error : %s", role.Name, err)
 	}
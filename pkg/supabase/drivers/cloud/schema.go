@@ -0,0 +1,22 @@
+package cloud
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+)
+
+func GetSchemaPrivileges(cfg *raiden.Config, schemas []string) ([]objects.SchemaPrivilege, error) {
+	CloudLogger.Trace("start fetching schema privileges from supabase", "schemas", schemas)
+	rs, err := ExecuteQueryWithContext[[]objects.SchemaPrivilege](cfg.Context(),
+		cfg.SupabaseApiUrl, cfg.ProjectId, query.BuildSchemaPrivilegesQuery(schemas),
+		DefaultAuthInterceptor(cfg.AccessToken), nil,
+	)
+	if err != nil {
+		err = fmt.Errorf("get schema privileges error : %s", err)
+	}
+	CloudLogger.Trace("finish fetching schema privileges from supabase")
+	return rs, err
+}
@@ -19,7 +19,7 @@ func GetTables(cfg *raiden.Config, includedSchemas []string, includeColumn bool)
 		return []objects.Table{}, err
 	}
 
-	rs, err := ExecuteQuery[[]objects.Table](cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	rs, err := ExecuteQueryWithContext[[]objects.Table](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		err = fmt.Errorf("get tables error : %s", err)
 	}
@@ -35,7 +35,7 @@ func GetTableByName(cfg *raiden.Config, name, schema string, includeColumn bool)
 		return result, err
 	}
 
-	rs, err := ExecuteQuery[[]objects.Table](cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	rs, err := ExecuteQueryWithContext[[]objects.Table](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, q, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		err = fmt.Errorf("get tables error : %s", err)
 		return
@@ -62,7 +62,7 @@ func CreateTable(cfg *raiden.Config, newTable objects.Table) (result objects.Tab
 	}
 
 	// execute update
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return result, fmt.Errorf("create new table %s error : %s", newTable.Name, err)
 	}
@@ -74,7 +74,7 @@ func CreateTable(cfg *raiden.Config, newTable objects.Table) (result objects.Tab
 func UpdateTable(cfg *raiden.Config, newTable objects.Table, updateItem objects.UpdateTableParam) error {
 	CloudLogger.Trace("start update table", "name", newTable.Name)
 	sql := query.BuildUpdateTableQuery(newTable, updateItem)
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("update tables error : %s", err)
 	}
@@ -102,6 +102,18 @@ func UpdateTable(cfg *raiden.Config, newTable objects.Table, updateItem objects.
 			return fmt.Errorf(strings.Join(errMsg, ";"))
 		}
 	}
+
+	// index is updated last because index may use new column
+	if len(updateItem.ChangeIndexItems) > 0 {
+		sql, err := query.BuildUpdateIndexesQuery(newTable.Schema, newTable.Name, updateItem.ChangeIndexItems)
+		if err != nil {
+			return err
+		}
+
+		if _, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil); err != nil {
+			return fmt.Errorf("update indexes of table %s error : %s", newTable.Name, err)
+		}
+	}
 	CloudLogger.Trace("finish update table", "name", newTable.Name)
 	return nil
 }
@@ -109,7 +121,7 @@ func UpdateTable(cfg *raiden.Config, newTable objects.Table, updateItem objects.
 func DeleteTable(cfg *raiden.Config, table objects.Table, cascade bool) error {
 	CloudLogger.Trace("start delete table", "name", table.Name)
 	sql := query.BuildDeleteTableQuery(table, true)
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("delete table %s error : %s", table.Name, err)
 	}
@@ -160,7 +172,7 @@ func updateColumnFromTable(
 					isCreate = true
 				case objects.UpdateColumnDelete:
 					isDelete = true
-				case objects.UpdateColumnName, objects.UpdateColumnDataType, objects.UpdateColumnUnique, objects.UpdateColumnNullable, objects.UpdateColumnDefaultValue, objects.UpdateColumnIdentity:
+				case objects.UpdateColumnName, objects.UpdateColumnDataType, objects.UpdateColumnUnique, objects.UpdateColumnNullable, objects.UpdateColumnDefaultValue, objects.UpdateColumnIdentity, objects.UpdateColumnCheck, objects.UpdateColumnPrivilege:
 					isUpdate = true
 				default:
 					continue
@@ -217,7 +229,7 @@ func CreateColumn(cfg *raiden.Config, column objects.Column, isPrimary bool) err
 		return err
 	}
 
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("create column %s.%s error : %s", column.Table, column.Name, err)
 	}
@@ -229,7 +241,7 @@ func UpdateColumn(cfg *raiden.Config, oldColumn, newColumn objects.Column, updat
 	CloudLogger.Trace("start update column", "table", oldColumn.Table, "name", newColumn.Name)
 
 	sql := query.BuildUpdateColumnQuery(oldColumn, newColumn, updateItem)
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("update column %s.%s error : %s", newColumn.Table, newColumn.Name, err)
 	}
@@ -241,7 +253,7 @@ func UpdateColumn(cfg *raiden.Config, oldColumn, newColumn objects.Column, updat
 func DeleteColumn(cfg *raiden.Config, column objects.Column) error {
 	CloudLogger.Trace("start delete column", "table", column.Table, "name", column.Name)
 	sql := query.BuildDeleteColumnQuery(column)
-	_, err := ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("delete column %s.%s error : %s", column.Table, column.Name, err)
 	}
@@ -345,7 +357,7 @@ func createForeignKey(cfg *raiden.Config, relation *objects.TablesRelationship)
 		return err
 	}
 
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("create foreign key %s.%s error : %s", relation.SourceTableName, relation.SourceColumnName, err)
 	}
@@ -368,7 +380,7 @@ func updateForeignKey(cfg *raiden.Config, relation *objects.TablesRelationship)
 	}
 
 	sql := deleteSql + createSql
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("update foreign key %s.%s error : %s", relation.SourceTableName, relation.SourceColumnName, err)
 	}
@@ -385,7 +397,7 @@ func deleteForeignKey(cfg *raiden.Config, relation *objects.TablesRelationship)
 		return err
 	}
 
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql, DefaultAuthInterceptor(cfg.AccessToken), nil)
 	if err != nil {
 		return fmt.Errorf("delete foreign key %s.%s error : %s", relation.SourceTableName, relation.SourceColumnName, err)
 	}
@@ -0,0 +1,19 @@
+package cloud
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query/sql"
+)
+
+func GetTypes(cfg *raiden.Config) ([]objects.Type, error) {
+	CloudLogger.Trace("start fetching types from supabase")
+	rs, err := ExecuteQueryWithContext[[]objects.Type](cfg.Context(), cfg.SupabaseApiUrl, cfg.ProjectId, sql.GetTypesQuery, DefaultAuthInterceptor(cfg.AccessToken), nil)
+	if err != nil {
+		err = fmt.Errorf("get types error : %s", err)
+	}
+	CloudLogger.Trace("finish fetching types from supabase")
+	return rs, err
+}
@@ -0,0 +1,25 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query/sql"
+)
+
+func GetForeignTables(cfg *raiden.Config, includedSchemas []string) ([]objects.Table, error) {
+	MetaLogger.Trace("start fetching foreign tables from meta")
+	q, err := sql.GenerateGetForeignTablesQuery(includedSchemas)
+	if err != nil {
+		err = fmt.Errorf("failed generate query get foreign table : %v", err)
+		return []objects.Table{}, err
+	}
+
+	rs, err := ExecuteQueryWithContext[[]objects.Table](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get foreign tables error : %s", err)
+	}
+	MetaLogger.Trace("finish fetching foreign tables from meta")
+	return rs, err
+}
@@ -13,7 +13,7 @@ import (
 func GetFunctions(cfg *raiden.Config) ([]objects.Function, error) {
 	MetaLogger.Trace("start fetching functions from meta")
 	url := fmt.Sprintf("%s%s/functions", cfg.SupabaseApiUrl, cfg.SupabaseApiBasePath)
-	rs, err := net.Get[[]objects.Function](url, net.DefaultTimeout, nil, nil)
+	rs, err := net.GetWithContext[[]objects.Function](cfg.Context(), url, net.DefaultTimeout, nil, nil)
 	if err != nil {
 		err = fmt.Errorf("get roles error : %s", err)
 	}
@@ -21,10 +21,38 @@ func GetFunctions(cfg *raiden.Config) ([]objects.Function, error) {
 	return rs, err
 }
 
+func GetFunctionIds(cfg *raiden.Config, includedSchema []string) ([]objects.Function, error) {
+	MetaLogger.Trace("start fetching function id from meta")
+	q := sql.GenerateFunctionIdsQuery(includedSchema)
+	rs, err := ExecuteQueryWithContext[[]objects.Function](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get function ids error : %s", err)
+	}
+	MetaLogger.Trace("finish fetching function id from meta")
+	return rs, err
+}
+
+func GetFunctionById(cfg *raiden.Config, id int) (result objects.Function, err error) {
+	MetaLogger.Trace("start fetching single function by id", "id", id)
+	q := sql.GenerateFunctionByIdQuery(id)
+	rs, err := ExecuteQueryWithContext[[]objects.Function](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get function error : %s", err)
+		return
+	}
+
+	if len(rs) == 0 {
+		err = fmt.Errorf("get function %d is not found", id)
+		return
+	}
+	MetaLogger.Trace("finish fetching single function by id", "id", id)
+	return rs[0], nil
+}
+
 func GetFunctionByName(cfg *raiden.Config, schema, name string) (result objects.Function, err error) {
 	MetaLogger.Trace("start fetching function by name from meta")
 	sql := sql.GenerateFunctionByNameQuery(schema, name) + " limit 1"
-	rs, err := ExecuteQuery[[]objects.Function](cfg.SupabaseApiUrl, sql, nil, nil, nil)
+	rs, err := ExecuteQueryWithContext[[]objects.Function](cfg.Context(), cfg.SupabaseApiUrl, sql, nil, nil, nil)
 	if err != nil {
 		err = fmt.Errorf("get function error : %s", err)
 		return
@@ -46,7 +74,7 @@ func CreateFunction(cfg *raiden.Config, fn objects.Function) (objects.Function,
 		return objects.Function{}, nil
 	}
 
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, sql, nil, nil, nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, sql, nil, nil, nil)
 	if err != nil {
 		return objects.Function{}, fmt.Errorf("create new function %s error : %s", fn.Name, err)
 	}
@@ -62,7 +90,7 @@ func DeleteFunction(cfg *raiden.Config, fn objects.Function) error {
 		return err
 	}
 
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, sql, nil, nil, nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("delete Function %s error : %s", fn.Name, err)
 	}
@@ -77,7 +105,7 @@ func UpdateFunction(cfg *raiden.Config, fn objects.Function) error {
 	if err != nil {
 		return err
 	}
-	_, err = ExecuteQuery[any](cfg.SupabaseApiUrl, updateSql, nil, nil, nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), cfg.SupabaseApiUrl, updateSql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("update function %s error : %s", fn.Name, err)
 	}
@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -18,6 +19,10 @@ type ExecuteQueryParam struct {
 }
 
 func ExecuteQuery[T any](baseUrl, query string, variables any, reqInterceptor net.RequestInterceptor, resInterceptor net.ResponseInterceptor) (result T, err error) {
+	return ExecuteQueryWithContext[T](context.Background(), baseUrl, query, variables, reqInterceptor, resInterceptor)
+}
+
+func ExecuteQueryWithContext[T any](ctx context.Context, baseUrl, query string, variables any, reqInterceptor net.RequestInterceptor, resInterceptor net.ResponseInterceptor) (result T, err error) {
 	url := fmt.Sprintf("%s/query", baseUrl)
 	p := ExecuteQueryParam{Query: query, Variables: variables}
 	pByte, err := json.Marshal(p)
@@ -25,7 +30,7 @@ func ExecuteQuery[T any](baseUrl, query string, variables any, reqInterceptor ne
 		MetaLogger.Error("error execute query", "query", query)
 		return result, err
 	}
-	return net.Post[T](url, pByte, net.DefaultTimeout, reqInterceptor, resInterceptor)
+	return net.PostWithContext[T](ctx, url, pByte, net.DefaultTimeout, reqInterceptor, resInterceptor)
 }
 
 func getBaseUrl(cfg *raiden.Config) string {
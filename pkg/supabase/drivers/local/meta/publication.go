@@ -0,0 +1,72 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+	"github.com/sev-2/raiden/pkg/supabase/query/sql"
+)
+
+func GetPublications(cfg *raiden.Config) ([]objects.Publication, error) {
+	MetaLogger.Trace("start fetching publication from meta")
+	q := sql.GeneratePublicationsQuery()
+	rs, err := ExecuteQueryWithContext[[]objects.Publication](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get publications error : %s", err)
+	}
+	MetaLogger.Trace("finish fetching publication from meta")
+	return rs, err
+}
+
+func GetPublicationByName(cfg *raiden.Config, name string) (result objects.Publication, err error) {
+	MetaLogger.Trace("start fetching single publication by name")
+	q := sql.GeneratePublicationByNameQuery(name) + " limit 1"
+	rs, err := ExecuteQueryWithContext[[]objects.Publication](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get publication error : %s", err)
+		return
+	}
+
+	if len(rs) == 0 {
+		err = fmt.Errorf("get publication %s is not found", name)
+		return
+	}
+	MetaLogger.Trace("finish fetching single publication by name")
+	return rs[0], nil
+}
+
+func CreatePublication(cfg *raiden.Config, p objects.Publication) (objects.Publication, error) {
+	MetaLogger.Trace("start create publication", "publication", p.Name)
+	sql := query.BuildCreatePublicationQuery(p)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
+	if err != nil {
+		return objects.Publication{}, fmt.Errorf("create new publication %s error : %s", p.Name, err)
+	}
+
+	MetaLogger.Trace("finish create publication", "publication", p.Name)
+	return GetPublicationByName(cfg, p.Name)
+}
+
+func UpdatePublication(cfg *raiden.Config, p objects.Publication, param objects.UpdatePublicationParam) error {
+	MetaLogger.Trace("start update publication", "publication", p.Name)
+	sql := query.BuildUpdatePublicationQuery(p, param)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("update publication %s error : %s", p.Name, err)
+	}
+	MetaLogger.Trace("finish update publication", "publication", p.Name)
+	return nil
+}
+
+func DeletePublication(cfg *raiden.Config, p objects.Publication) error {
+	MetaLogger.Trace("start delete publication", "publication", p.Name)
+	sql := query.BuildDeletePublicationQuery(p)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete publication %s error : %s", p.Name, err)
+	}
+	MetaLogger.Trace("finish delete publication", "publication", p.Name)
+	return nil
+}
@@ -0,0 +1,35 @@
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+)
+
+func GetTableRows(cfg *raiden.Config, schema, table string, orderBy []string, limit int) ([]map[string]json.RawMessage, error) {
+	MetaLogger.Trace("start fetching table rows from meta", "schema", schema, "table", table)
+	q := query.BuildSelectRowsQuery(schema, table, orderBy, limit)
+	rs, err := ExecuteQueryWithContext[[]map[string]json.RawMessage](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get rows of table %s error : %s", table, err)
+	}
+	MetaLogger.Trace("finish fetching table rows from meta", "schema", schema, "table", table)
+	return rs, err
+}
+
+func InsertTableRows(cfg *raiden.Config, schema, table string, columns []string, rowsJson string) error {
+	MetaLogger.Trace("start insert table rows", "schema", schema, "table", table)
+	sql, err := query.BuildInsertRowsQuery(schema, table, columns, rowsJson)
+	if err != nil {
+		return err
+	}
+
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("insert rows of table %s error : %s", table, err)
+	}
+	MetaLogger.Trace("finish insert table rows", "schema", schema, "table", table)
+	return nil
+}
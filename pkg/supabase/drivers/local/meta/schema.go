@@ -0,0 +1,19 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+)
+
+func GetSchemaPrivileges(cfg *raiden.Config, schemas []string) ([]objects.SchemaPrivilege, error) {
+	MetaLogger.Trace("start fetching schema privileges from meta", "schemas", schemas)
+	rs, err := ExecuteQueryWithContext[[]objects.SchemaPrivilege](cfg.Context(), getBaseUrl(cfg), query.BuildSchemaPrivilegesQuery(schemas), nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get schema privileges error : %s", err)
+	}
+	MetaLogger.Trace("finish fetching schema privileges from meta")
+	return rs, err
+}
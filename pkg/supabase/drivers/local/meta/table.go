@@ -29,12 +29,33 @@ func GetTables(cfg *raiden.Config, includedSchemas []string, includeColumns bool
 		return nil
 	}
 
-	rs, err := net.Get[[]objects.Table](url, net.DefaultTimeout, reqInterceptor, nil)
+	rs, err := net.GetWithContext[[]objects.Table](cfg.Context(), url, net.DefaultTimeout, reqInterceptor, nil)
 	if err != nil {
 		err = fmt.Errorf("get tables error : %s", err)
+		return rs, err
+	}
+
+	// pg-meta tables endpoint is not include partition information,
+	// fetch it separately and bind to table data
+	partitions, err := ExecuteQueryWithContext[[]objects.Table](cfg.Context(), getBaseUrl(cfg), sql.GetTablePartitionsQuery, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get tables partition error : %s", err)
+		return rs, err
+	}
+
+	mapPartition := make(map[int]objects.Table)
+	for _, p := range partitions {
+		mapPartition[p.ID] = p
+	}
+
+	for i := range rs {
+		if p, exist := mapPartition[rs[i].ID]; exist {
+			rs[i].IsPartitioned = p.IsPartitioned
+			rs[i].PartitionOfID = p.PartitionOfID
+		}
 	}
 	MetaLogger.Trace("finish fetching tables from meta")
-	return rs, err
+	return rs, nil
 }
 
 func GetTableByName(cfg *raiden.Config, name, schema string, includeColumn bool) (result objects.Table, err error) {
@@ -45,7 +66,7 @@ func GetTableByName(cfg *raiden.Config, name, schema string, includeColumn bool)
 		return result, err
 	}
 
-	rs, err := ExecuteQuery[[]objects.Table](getBaseUrl(cfg), q, nil, nil, nil)
+	rs, err := ExecuteQueryWithContext[[]objects.Table](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
 	if err != nil {
 		err = fmt.Errorf("get tables error : %s", err)
 		return
@@ -72,7 +93,7 @@ func CreateTable(cfg *raiden.Config, newTable objects.Table) (result objects.Tab
 	}
 
 	// execute update
-	_, err = ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return result, fmt.Errorf("create new table %s error : %s", newTable.Name, err)
 	}
@@ -84,7 +105,7 @@ func UpdateTable(cfg *raiden.Config, newTable objects.Table, updateItem objects.
 	MetaLogger.Trace("start update table", "name", newTable.Name)
 	sql := query.BuildUpdateTableQuery(newTable, updateItem)
 	// execute update
-	_, err := ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("update tables error : %s", err)
 	}
@@ -112,6 +133,18 @@ func UpdateTable(cfg *raiden.Config, newTable objects.Table, updateItem objects.
 			return fmt.Errorf(strings.Join(errMsg, ";"))
 		}
 	}
+
+	// index is updated last because index may use new column
+	if len(updateItem.ChangeIndexItems) > 0 {
+		sql, err := query.BuildUpdateIndexesQuery(newTable.Schema, newTable.Name, updateItem.ChangeIndexItems)
+		if err != nil {
+			return err
+		}
+
+		if _, err := ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil); err != nil {
+			return fmt.Errorf("update indexes of table %s error : %s", newTable.Name, err)
+		}
+	}
 	MetaLogger.Trace("finish update table", "name", newTable.Name)
 	return nil
 }
@@ -120,7 +153,7 @@ func DeleteTable(cfg *raiden.Config, table objects.Table, cascade bool) error {
 	MetaLogger.Trace("start delete table", "name", table.Name)
 	sql := query.BuildDeleteTableQuery(table, true)
 	// execute delete
-	_, err := ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("delete table %s error : %s", table.Name, err)
 	}
@@ -171,7 +204,7 @@ func updateColumnFromTable(
 					isCreate = true
 				case objects.UpdateColumnDelete:
 					isDelete = true
-				case objects.UpdateColumnName, objects.UpdateColumnDataType, objects.UpdateColumnUnique, objects.UpdateColumnNullable, objects.UpdateColumnDefaultValue, objects.UpdateColumnIdentity:
+				case objects.UpdateColumnName, objects.UpdateColumnDataType, objects.UpdateColumnUnique, objects.UpdateColumnNullable, objects.UpdateColumnDefaultValue, objects.UpdateColumnIdentity, objects.UpdateColumnCheck, objects.UpdateColumnPrivilege:
 					isUpdate = true
 				default:
 					continue
@@ -229,7 +262,7 @@ func CreateColumn(cfg *raiden.Config, column objects.Column, isPrimary bool) err
 	}
 
 	// Execute SQL Query
-	_, err = ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("create column %s.%s error : %s", column.Table, column.Name, err)
 	}
@@ -243,7 +276,7 @@ func UpdateColumn(cfg *raiden.Config, oldColumn, newColumn objects.Column, updat
 	sql := query.BuildUpdateColumnQuery(oldColumn, newColumn, updateItem)
 
 	// Execute SQL Query
-	_, err := ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("update column %s.%s error : %s", newColumn.Table, newColumn.Name, err)
 	}
@@ -254,7 +287,7 @@ func UpdateColumn(cfg *raiden.Config, oldColumn, newColumn objects.Column, updat
 func DeleteColumn(cfg *raiden.Config, column objects.Column) error {
 	MetaLogger.Trace("start delete column", "table", column.Table, "name", column.Name)
 	sql := query.BuildDeleteColumnQuery(column)
-	_, err := ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err := ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("delete column %s.%s error : %s", column.Table, column.Name, err)
 	}
@@ -356,7 +389,7 @@ func createForeignKey(cfg *raiden.Config, relation *objects.TablesRelationship)
 		return err
 	}
 
-	_, err = ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("create foreign key %s.%s error : %s", relation.SourceTableName, relation.SourceColumnName, err)
 	}
@@ -377,7 +410,7 @@ func updateForeignKey(cfg *raiden.Config, relation *objects.TablesRelationship)
 	}
 
 	sql := deleteSql + createSql
-	_, err = ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("update foreign key %s.%s error : %s", relation.SourceTableName, relation.SourceColumnName, err)
 	}
@@ -393,7 +426,7 @@ func deleteForeignKey(cfg *raiden.Config, relation *objects.TablesRelationship)
 		return err
 	}
 
-	_, err = ExecuteQuery[any](getBaseUrl(cfg), sql, nil, nil, nil)
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
 	if err != nil {
 		return fmt.Errorf("delete foreign key %s.%s error : %s", relation.SourceTableName, relation.SourceColumnName, err)
 	}
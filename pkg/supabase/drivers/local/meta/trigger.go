@@ -0,0 +1,84 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+	"github.com/sev-2/raiden/pkg/supabase/query"
+	"github.com/sev-2/raiden/pkg/supabase/query/sql"
+)
+
+func GetTriggers(cfg *raiden.Config, includedSchema []string) ([]objects.Trigger, error) {
+	MetaLogger.Trace("start fetching trigger from meta")
+	q := sql.GenerateTriggersQuery(includedSchema)
+	rs, err := ExecuteQueryWithContext[[]objects.Trigger](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get triggers error : %s", err)
+	}
+	MetaLogger.Trace("finish fetching trigger from meta")
+	return rs, err
+}
+
+func GetTriggerByName(cfg *raiden.Config, schema, table, name string) (result objects.Trigger, err error) {
+	MetaLogger.Trace("start fetching single trigger by name")
+	q := sql.GenerateTriggerByNameQuery(schema, table, name) + " limit 1"
+	rs, err := ExecuteQueryWithContext[[]objects.Trigger](cfg.Context(), getBaseUrl(cfg), q, nil, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get trigger error : %s", err)
+		return
+	}
+
+	if len(rs) == 0 {
+		err = fmt.Errorf("get trigger %s on table %s is not found", name, table)
+		return
+	}
+	MetaLogger.Trace("finish fetching single trigger by name")
+	return rs[0], nil
+}
+
+func CreateTrigger(cfg *raiden.Config, t objects.Trigger) (objects.Trigger, error) {
+	MetaLogger.Trace("start create trigger", "trigger", t.Name)
+	sql, err := query.BuildTriggerQuery(query.TriggerActionCreate, &t)
+	if err != nil {
+		return objects.Trigger{}, err
+	}
+
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
+	if err != nil {
+		return objects.Trigger{}, fmt.Errorf("create new trigger %s error : %s", t.Name, err)
+	}
+
+	MetaLogger.Trace("finish create trigger", "trigger", t.Name)
+	return GetTriggerByName(cfg, t.Schema, t.Table, t.Name)
+}
+
+func UpdateTrigger(cfg *raiden.Config, t objects.Trigger) error {
+	MetaLogger.Trace("start update trigger", "trigger", t.Name)
+	sql, err := query.BuildTriggerQuery(query.TriggerActionUpdate, &t)
+	if err != nil {
+		return err
+	}
+
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("update trigger %s error : %s", t.Name, err)
+	}
+	MetaLogger.Trace("finish update trigger", "trigger", t.Name)
+	return nil
+}
+
+func DeleteTrigger(cfg *raiden.Config, t objects.Trigger) error {
+	MetaLogger.Trace("start delete trigger", "trigger", t.Name)
+	sql, err := query.BuildTriggerQuery(query.TriggerActionDelete, &t)
+	if err != nil {
+		return err
+	}
+
+	_, err = ExecuteQueryWithContext[any](cfg.Context(), getBaseUrl(cfg), sql, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("delete trigger %s error : %s", t.Name, err)
+	}
+	MetaLogger.Trace("finish delete trigger", "trigger", t.Name)
+	return nil
+}
@@ -0,0 +1,20 @@
+package meta
+
+import (
+	"fmt"
+
+	"github.com/sev-2/raiden"
+	"github.com/sev-2/raiden/pkg/supabase/client/net"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+func GetTypes(cfg *raiden.Config) ([]objects.Type, error) {
+	MetaLogger.Trace("start fetching types from meta")
+	url := fmt.Sprintf("%s%s/types", cfg.SupabaseApiUrl, cfg.SupabaseApiBasePath)
+	rs, err := net.GetWithContext[[]objects.Type](cfg.Context(), url, net.DefaultTimeout, nil, nil)
+	if err != nil {
+		err = fmt.Errorf("get types error : %s", err)
+	}
+	MetaLogger.Trace("finish fetching types from meta")
+	return rs, err
+}
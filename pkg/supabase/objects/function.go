@@ -21,7 +21,7 @@ type Function struct {
 	ReturnType             string        `json:"return_type"`
 	ReturnTypeRelationID   int           `json:"return_type_relation_id"`
 	IsSetReturningFunction bool          `json:"is_set_returning_function"`
-	Behavior               string        `json:"behavior"`
+	Behavior               string        `json:"behavior"` // volatility : IMMUTABLE, STABLE or VOLATILE
 	SecurityDefiner        bool          `json:"security_definer"`
 	ConfigParams           any           `json:"config_params"`
 }
@@ -1,6 +1,9 @@
 package objects
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 type PolicyCommand string
 
@@ -13,8 +16,8 @@ type Policy struct {
 	Action     string        `json:"action"`
 	Roles      []string      `json:"roles"`
 	Command    PolicyCommand `json:"command"`
-	Definition string        `json:"definition"`
-	Check      *string       `json:"check"`
+	Definition string        `json:"definition"` // USING expression
+	Check      *string       `json:"check"`      // WITH CHECK expression
 }
 
 const (
@@ -41,18 +44,22 @@ func (p *Policies) FilterByTable(table string) Policies {
 	return filteredData
 }
 
+// FilterByBucket return storage.objects policies that refer to the bucket,
+// bucket name is matched with the closing quote so bucket "avatar"
+// doesn't match policy of bucket "avatar-private"
 func (p *Policies) FilterByBucket(bucket Bucket) Policies {
 	var filteredData Policies
 	if p == nil {
 		return filteredData
 	}
 
+	bucketExpr := fmt.Sprintf("bucket_id = '%s'", bucket.Name)
 	for _, v := range *p {
-		if v.Schema != "storage" {
+		if v.Schema != "storage" || v.Table != "objects" {
 			continue
 		}
 
-		if strings.Contains(v.Definition, bucket.Name) || (v.Check != nil && strings.Contains(*v.Check, bucket.Name)) {
+		if strings.Contains(v.Definition, bucketExpr) || (v.Check != nil && strings.Contains(*v.Check, bucketExpr)) {
 			filteredData = append(filteredData, v)
 		}
 	}
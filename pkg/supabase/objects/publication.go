@@ -0,0 +1,51 @@
+package objects
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Publication struct {
+	ID              int                `json:"id"`
+	Name            string             `json:"name"`
+	Owner           string             `json:"owner"`
+	AllTables       bool               `json:"all_tables"`
+	PublishInsert   bool               `json:"publish_insert"`
+	PublishUpdate   bool               `json:"publish_update"`
+	PublishDelete   bool               `json:"publish_delete"`
+	PublishTruncate bool               `json:"publish_truncate"`
+	Tables          []PublicationTable `json:"tables"` // nil when publication is for all tables
+}
+
+type PublicationTable struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// Key return publication identity, publication name is unique in database
+func (p Publication) Key() string {
+	return p.Name
+}
+
+func (t PublicationTable) Key() string {
+	schema := t.Schema
+	if schema == "" {
+		schema = "public"
+	}
+	return fmt.Sprintf("%s.%s", schema, t.Name)
+}
+
+// TableKeys return sorted schema qualified name of published table
+func (p Publication) TableKeys() []string {
+	keys := make([]string, 0, len(p.Tables))
+	for _, t := range p.Tables {
+		keys = append(keys, t.Key())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type UpdatePublicationParam struct {
+	OldData Publication
+}
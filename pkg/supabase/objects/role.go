@@ -12,6 +12,7 @@ type Role struct {
 	InheritRole       bool           `json:"inherit_role"`
 	IsReplicationRole bool           `json:"is_replication_role"`
 	IsSuperuser       bool           `json:"is_superuser"`
+	MemberOf          []string       `json:"member_of"`
 	Name              string         `json:"name"`
 	Password          string         `json:"password"`
 	ValidUntil        *SupabaseTime  `json:"valid_until"`
@@ -31,6 +32,7 @@ const (
 	UpdateRoleCanBypassRls  UpdateRoleType = "can_bypass_rls"
 	UpdateRoleConfig        UpdateRoleType = "config"
 	UpdateRoleValidUntil    UpdateRoleType = "valid_until"
+	UpdateRoleMemberOf      UpdateRoleType = "member_of"
 )
 
 type UpdateRoleParam struct {
@@ -0,0 +1,9 @@
+package objects
+
+// SchemaPrivilege is privilege of current database user on single schema,
+// usage is false when schema doesn't exist
+type SchemaPrivilege struct {
+	Name  string `json:"name"`
+	Exist bool   `json:"exist"`
+	Usage bool   `json:"usage"`
+}
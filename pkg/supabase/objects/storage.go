@@ -1,5 +1,7 @@
 package objects
 
+import "time"
+
 type Bucket struct {
 	ID                string   `json:"id,omitempty"`
 	Name              string   `json:"name,omitempty"`
@@ -9,6 +11,9 @@ type Bucket struct {
 	FileSizeLimit     *int     `json:"file_size_limit"`
 	AllowedMimeTypes  []string `json:"allowed_mime_types"`
 	OwnerID           *string  `json:"owner_id,omitempty"`
+
+	// last modified time of bucket, only set when bucket is loaded from storage api
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
 type UpdateBucketType string
@@ -1,5 +1,13 @@
 package objects
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sev-2/raiden/pkg/postgres"
+)
+
 // ----- table structure definitions -----
 
 type ReplicaIdentity string
@@ -38,11 +46,33 @@ type Column struct {
 	IsUnique           bool     `json:"is_unique"`
 	Enums              []string `json:"enums"`
 
+	// type modifier, length of character type and precision / scale of numeric type
+	Length    int `json:"length"`
+	Precision int `json:"precision"`
+	Scale     int `json:"scale"`
+
+	// domain type of column, data type contain the base type of domain
+	Domain       string `json:"domain"`
+	DomainSchema string `json:"domain_schema"`
+
+	// sequence owned by column, example : public.orders_id_seq for serial column
+	Sequence string `json:"sequence"`
+
+	// column level grant, table level grant is not included
+	Privileges []ColumnPrivilege `json:"privileges"`
+
 	// TODO : implement check and comment in models
 	Check   any `json:"check"`
 	Comment any `json:"comment"`
 }
 
+// ColumnPrivilege is privilege that granted to role on single column,
+// example : GRANT SELECT (email) ON public.users TO anon
+type ColumnPrivilege struct {
+	Grantee       string `json:"grantee"`
+	PrivilegeType string `json:"privilege_type"`
+}
+
 type PrimaryKey struct {
 	Name      string `json:"name"`
 	Schema    string `json:"schema"`
@@ -50,6 +80,130 @@ type PrimaryKey struct {
 	TableName string `json:"table_name"`
 }
 
+// TableUniqueIndex is unique index or unique constraint of table,
+// primary key is reported as unique index too
+type TableUniqueIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+}
+
+// TableIndex is index that not backed by primary key, unique or exclusion constraint,
+// column is key column or expression and contain operator class when it is not default
+type TableIndex struct {
+	Name      string   `json:"name"`
+	Columns   []string `json:"columns"`
+	IsUnique  bool     `json:"is_unique"`
+	Method    string   `json:"method"`
+	Predicate string   `json:"predicate"`
+}
+
+// GetMethod return index access method, btree is used when method is not set
+func (i TableIndex) GetMethod() string {
+	if i.Method == "" {
+		return "btree"
+	}
+	return i.Method
+}
+
+// GetCheck return check constraint expression of column,
+// empty string is returned when column doesn't have check constraint
+func (c Column) GetCheck() string {
+	switch v := c.Check.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case *string:
+		if v != nil {
+			return strings.TrimSpace(*v)
+		}
+	}
+	return ""
+}
+
+// GetPrivileges return unique column privilege sorted by privilege type and grantee,
+// privilege type is returned in upper case
+func (c Column) GetPrivileges() []ColumnPrivilege {
+	mapPrivilege := make(map[ColumnPrivilege]bool)
+	privileges := make([]ColumnPrivilege, 0, len(c.Privileges))
+	for _, p := range c.Privileges {
+		p.PrivilegeType = strings.ToUpper(strings.TrimSpace(p.PrivilegeType))
+		p.Grantee = strings.TrimSpace(p.Grantee)
+		if p.PrivilegeType == "" || p.Grantee == "" || mapPrivilege[p] {
+			continue
+		}
+		mapPrivilege[p] = true
+		privileges = append(privileges, p)
+	}
+
+	sort.Slice(privileges, func(i, j int) bool {
+		if privileges[i].PrivilegeType != privileges[j].PrivilegeType {
+			return privileges[i].PrivilegeType < privileges[j].PrivilegeType
+		}
+		return privileges[i].Grantee < privileges[j].Grantee
+	})
+	return privileges
+}
+
+// GetTypeModifier return type modifier of column data type, example : (50) for varchar(50)
+// and (12,2) for numeric(12,2), empty string is returned for unbounded type
+func (c Column) GetTypeModifier() string {
+	switch strings.ToLower(c.DataType) {
+	case "character varying", "varchar", "character", "char", "bpchar":
+		if c.Length > 0 {
+			return fmt.Sprintf("(%d)", c.Length)
+		}
+	case "numeric", "decimal":
+		if c.Precision > 0 {
+			return fmt.Sprintf("(%d,%d)", c.Precision, c.Scale)
+		}
+	}
+	return ""
+}
+
+// GetDomain return domain of column in format schema.name,
+// empty string is returned when column is not typed as domain
+func (c Column) GetDomain() string {
+	if c.Domain == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s", c.DomainSchema, c.Domain)
+}
+
+// GetDefaultSequence return sequence used by column default,
+// example : orders_id_seq for nextval('orders_id_seq'::regclass)
+func (c Column) GetDefaultSequence() string {
+	switch v := c.DefaultValue.(type) {
+	case string:
+		return postgres.GetNextvalSequence(v)
+	case *string:
+		if v != nil {
+			return postgres.GetNextvalSequence(*v)
+		}
+	}
+	return ""
+}
+
+// GetSerialType return serial type of column that declared as serial, column is serial when
+// default use implicit sequence owned by column, shared or explicitly declared sequence is not serial
+func (c Column) GetSerialType() string {
+	sequence := c.GetDefaultSequence()
+	if sequence == "" || c.Sequence == "" {
+		return ""
+	}
+
+	unqualified := func(name string) string {
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+		return strings.Trim(name, "\"")
+	}
+
+	owned := unqualified(c.Sequence)
+	if owned != unqualified(sequence) || owned != fmt.Sprintf("%s_%s_seq", c.Table, c.Name) {
+		return ""
+	}
+	return string(postgres.ToSerialType(postgres.DataType(c.DataType)))
+}
+
 type Table struct {
 	Bytes            int                  `json:"bytes"`
 	Columns          []Column             `json:"columns"`
@@ -65,12 +219,20 @@ type Table struct {
 	RLSForced        bool                 `json:"rls_forced"`
 	Schema           string               `json:"schema"`
 	Size             string               `json:"size"`
+	UniqueIndexes    []TableUniqueIndex   `json:"unique_indexes"`
+	Indexes          []TableIndex         `json:"indexes"`
+	IsView           bool                 `json:"is_view"`
+	IsMaterialized   bool                 `json:"is_materialized"`
+	IsPartitioned    bool                 `json:"is_partitioned"`
+	IsForeign        bool                 `json:"is_foreign"`
+	PartitionOfID    int                  `json:"partition_of_id"`
 }
 
 // ---- update table struct definitions ----
 type UpdateTableType string
 type UpdateColumnType string
 type UpdateRelationType string
+type UpdateIndexType string
 
 const (
 	UpdateTableSchema          UpdateTableType = "schema"
@@ -90,6 +252,8 @@ const (
 	UpdateColumnUnique       UpdateColumnType = "unique"
 	UpdateColumnNullable     UpdateColumnType = "nullable"
 	UpdateColumnIdentity     UpdateColumnType = "identity"
+	UpdateColumnCheck        UpdateColumnType = "check"
+	UpdateColumnPrivilege    UpdateColumnType = "privilege"
 )
 
 const (
@@ -98,6 +262,12 @@ const (
 	UpdateRelationDelete UpdateRelationType = "delete"
 )
 
+const (
+	UpdateIndexCreate UpdateIndexType = "create"
+	UpdateIndexUpdate UpdateIndexType = "update"
+	UpdateIndexDelete UpdateIndexType = "delete"
+)
+
 type UpdateColumnItem struct {
 	Name        string
 	UpdateItems []UpdateColumnType
@@ -108,9 +278,15 @@ type UpdateRelationItem struct {
 	Type UpdateRelationType
 }
 
+type UpdateIndexItem struct {
+	Data TableIndex
+	Type UpdateIndexType
+}
+
 type UpdateTableParam struct {
 	OldData             Table
 	ChangeRelationItems []UpdateRelationItem
+	ChangeIndexItems    []UpdateIndexItem
 	ChangeColumnItems   []UpdateColumnItem
 	ChangeItems         []UpdateTableType
 	ForceCreateRelation bool
@@ -0,0 +1,35 @@
+package objects
+
+import "fmt"
+
+type Trigger struct {
+	ID             int      `json:"id"`
+	TableID        int      `json:"table_id"`
+	EnabledMode    string   `json:"enabled_mode"` // ORIGIN, REPLICA, ALWAYS or DISABLED
+	FunctionArgs   []string `json:"function_args"`
+	Name           string   `json:"name"`
+	Table          string   `json:"table"`
+	Schema         string   `json:"schema"`
+	Condition      *string  `json:"condition"`
+	Orientation    string   `json:"orientation"` // ROW or STATEMENT
+	Activation     string   `json:"activation"`  // BEFORE, AFTER or INSTEAD OF
+	Events         []string `json:"events"`      // INSERT, UPDATE, DELETE or TRUNCATE
+	FunctionName   string   `json:"function_name"`
+	FunctionSchema string   `json:"function_schema"`
+}
+
+// Key return trigger identity, trigger name is only unique in the same table
+func (t Trigger) Key() string {
+	schema := t.Schema
+	if schema == "" {
+		schema = "public"
+	}
+	return fmt.Sprintf("%s.%s.%s", schema, t.Table, t.Name)
+}
+
+func (t Trigger) GetCondition() string {
+	if t.Condition == nil {
+		return ""
+	}
+	return *t.Condition
+}
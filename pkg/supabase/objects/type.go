@@ -0,0 +1,32 @@
+package objects
+
+type TypeAttribute struct {
+	Name   string `json:"name"`
+	TypeID int    `json:"type_id"`
+}
+
+type Type struct {
+	ID         int             `json:"id"`
+	Name       string          `json:"name"`
+	Schema     string          `json:"schema"`
+	Format     string          `json:"format"`
+	Enums      []string        `json:"enums"`
+	Attributes []TypeAttribute `json:"attributes"`
+	Comment    *string         `json:"comment"`
+
+	// base type and check constraint of domain type
+	BaseType string `json:"base_type"`
+	Check    string `json:"check"`
+}
+
+func (t Type) IsEnum() bool {
+	return len(t.Enums) > 0
+}
+
+func (t Type) IsDomain() bool {
+	return t.BaseType != ""
+}
+
+func (t Type) IsComposite() bool {
+	return len(t.Attributes) > 0
+}
@@ -2,6 +2,7 @@ package query
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 )
@@ -21,6 +22,11 @@ func BuildFunctionQuery(action FunctionAction, fn *objects.Function) (string, er
 	case FunctionActionDelete:
 		return fmt.Sprintf("DROP FUNCTION %s.%s;", fn.Schema, fn.Name), nil
 	case FunctionActionUpdate:
+		// trigger function can't be dropped while trigger still use it,
+		// trigger function doesn't have param so replace is enough
+		if strings.EqualFold(fn.ReturnType, "trigger") {
+			return fn.CompleteStatement + ";", nil
+		}
 		return fmt.Sprintf(`
 			BEGIN; 
 				%s 
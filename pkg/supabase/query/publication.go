@@ -0,0 +1,83 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+func BuildCreatePublicationQuery(p objects.Publication) string {
+	var forSql string
+	if p.AllTables {
+		forSql = " FOR ALL TABLES"
+	} else if len(p.Tables) > 0 {
+		forSql = fmt.Sprintf(" FOR TABLE %s", buildPublicationTableList(p.Tables))
+	}
+
+	return fmt.Sprintf(
+		"CREATE PUBLICATION %s%s WITH (publish = %s);",
+		pq.QuoteIdentifier(p.Name), forSql, pq.QuoteLiteral(buildPublishAction(p)),
+	)
+}
+
+// BuildUpdatePublicationQuery set publish action and published table, publication
+// can't be altered from or to all tables so it is recreated in one transaction
+func BuildUpdatePublicationQuery(newPublication objects.Publication, param objects.UpdatePublicationParam) string {
+	if newPublication.AllTables != param.OldData.AllTables {
+		return fmt.Sprintf(
+			"BEGIN; %s %s COMMIT;",
+			BuildDeletePublicationQuery(param.OldData), BuildCreatePublicationQuery(newPublication),
+		)
+	}
+
+	name := pq.QuoteIdentifier(newPublication.Name)
+	statements := []string{
+		fmt.Sprintf("ALTER PUBLICATION %s SET (publish = %s);", name, pq.QuoteLiteral(buildPublishAction(newPublication))),
+	}
+
+	if !newPublication.AllTables {
+		if len(newPublication.Tables) > 0 {
+			statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s SET TABLE %s;", name, buildPublicationTableList(newPublication.Tables)))
+		} else if len(param.OldData.Tables) > 0 {
+			// set table need at least one table, drop all old table instead
+			statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s DROP TABLE %s;", name, buildPublicationTableList(param.OldData.Tables)))
+		}
+	}
+
+	return strings.Join(statements, " ")
+}
+
+func BuildDeletePublicationQuery(p objects.Publication) string {
+	return fmt.Sprintf("DROP PUBLICATION IF EXISTS %s;", pq.QuoteIdentifier(p.Name))
+}
+
+func buildPublicationTableList(tables []objects.PublicationTable) string {
+	list := make([]string, 0, len(tables))
+	for _, t := range tables {
+		schema := t.Schema
+		if schema == "" {
+			schema = "public"
+		}
+		list = append(list, fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(t.Name)))
+	}
+	return strings.Join(list, ", ")
+}
+
+func buildPublishAction(p objects.Publication) string {
+	var actions []string
+	if p.PublishInsert {
+		actions = append(actions, "insert")
+	}
+	if p.PublishUpdate {
+		actions = append(actions, "update")
+	}
+	if p.PublishDelete {
+		actions = append(actions, "delete")
+	}
+	if p.PublishTruncate {
+		actions = append(actions, "truncate")
+	}
+	return strings.Join(actions, ", ")
+}
@@ -77,6 +77,8 @@ func BuildCreateRoleQuery(role objects.Role) string {
 		configClause = strings.Join(configStrings, " ")
 	}
 
+	memberOfClause := buildGrantRoleClause(role.Name, role.MemberOf)
+
 	return fmt.Sprintf(`
 	BEGIN;
 	do $$
@@ -87,16 +89,17 @@ func BuildCreateRoleQuery(role objects.Role) string {
 	END $$;
 	%s
 	GRANT %s TO authenticator;
+	%s
 	COMMIT;`,
 		role.Name, role.Name, strings.Join(createRolClauses, "\n"),
-		configClause, role.Name,
+		configClause, role.Name, memberOfClause,
 	)
 }
 
 func BuildUpdateRoleQuery(newRole objects.Role, updateRoleParam objects.UpdateRoleParam) string {
 	alter := fmt.Sprintf("ALTER ROLE %s ", updateRoleParam.OldData.Name)
 
-	var updateRoleClause, nameClause, configClause string
+	var updateRoleClause, nameClause, configClause, memberOfClause string
 	var updateRoleClauses []string
 
 	for _, item := range updateRoleParam.ChangeItems {
@@ -160,6 +163,8 @@ func BuildUpdateRoleQuery(newRole objects.Role, updateRoleParam objects.UpdateRo
 				}
 			}
 			configClause = strings.Join(configStrings, "\n")
+		case objects.UpdateRoleMemberOf:
+			memberOfClause = buildUpdateMemberOfClause(updateRoleParam.OldData.Name, updateRoleParam.OldData.MemberOf, newRole.MemberOf)
 		}
 	}
 
@@ -168,8 +173,57 @@ func BuildUpdateRoleQuery(newRole objects.Role, updateRoleParam objects.UpdateRo
 	}
 
 	return fmt.Sprintf(`
-		BEGIN; %s %s %s COMMIT;
-	`, updateRoleClause, configClause, nameClause)
+		BEGIN; %s %s %s %s COMMIT;
+	`, updateRoleClause, configClause, memberOfClause, nameClause)
+}
+
+func buildGrantRoleClause(roleName string, memberOf []string) string {
+	var grantClauses []string
+	for _, parent := range memberOf {
+		if parent == "" {
+			continue
+		}
+		grantClauses = append(grantClauses, fmt.Sprintf("GRANT %s TO %s;", parent, roleName))
+	}
+	return strings.Join(grantClauses, "\n")
+}
+
+func buildRevokeRoleClause(roleName string, memberOf []string) string {
+	var revokeClauses []string
+	for _, parent := range memberOf {
+		if parent == "" {
+			continue
+		}
+		revokeClauses = append(revokeClauses, fmt.Sprintf("REVOKE %s FROM %s;", parent, roleName))
+	}
+	return strings.Join(revokeClauses, "\n")
+}
+
+func buildUpdateMemberOfClause(roleName string, oldMemberOf, newMemberOf []string) string {
+	mapOld := make(map[string]bool)
+	for _, r := range oldMemberOf {
+		mapOld[r] = true
+	}
+
+	mapNew := make(map[string]bool)
+	for _, r := range newMemberOf {
+		mapNew[r] = true
+	}
+
+	var grantRoles, revokeRoles []string
+	for _, r := range newMemberOf {
+		if !mapOld[r] {
+			grantRoles = append(grantRoles, r)
+		}
+	}
+
+	for _, r := range oldMemberOf {
+		if !mapNew[r] {
+			revokeRoles = append(revokeRoles, r)
+		}
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s\n%s", buildRevokeRoleClause(roleName, revokeRoles), buildGrantRoleClause(roleName, grantRoles)))
 }
 
 func BuildDeleteRoleQuery(role objects.Role) string {
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// BuildSelectRowsQuery select table rows ordered by orderBy column,
+// ordering make selected rows stable between import
+func BuildSelectRowsQuery(schema, table string, orderBy []string, limit int) string {
+	q := fmt.Sprintf("SELECT * FROM %s", quoteRowTable(schema, table))
+	if len(orderBy) > 0 {
+		q += " ORDER BY " + quoteRowColumns(orderBy)
+	}
+
+	if limit > 0 {
+		q += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	return q + ";"
+}
+
+// BuildInsertRowsQuery insert json array of rows, json key must be column name.
+// identity column value is kept and existing rows is not inserted again
+func BuildInsertRowsQuery(schema, table string, columns []string, rowsJson string) (string, error) {
+	if len(columns) == 0 {
+		return "", fmt.Errorf("insert rows to %s.%s doesn't have column", schema, table)
+	}
+
+	cols := quoteRowColumns(columns)
+	tableName := quoteRowTable(schema, table)
+	return fmt.Sprintf(
+		"INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM json_populate_recordset(null::%s, %s) ON CONFLICT DO NOTHING;",
+		tableName, cols, cols, tableName, pq.QuoteLiteral(rowsJson),
+	), nil
+}
+
+func quoteRowTable(schema, table string) string {
+	if schema == "" {
+		schema = "public"
+	}
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(table))
+}
+
+func quoteRowColumns(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, c := range columns {
+		quoted = append(quoted, pq.QuoteIdentifier(c))
+	}
+	return strings.Join(quoted, ", ")
+}
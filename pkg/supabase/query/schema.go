@@ -0,0 +1,20 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// BuildSchemaPrivilegesQuery check if schema exist and current user have usage privilege on it,
+// query without schema return no row and only check that query can be executed
+func BuildSchemaPrivilegesQuery(schemas []string) string {
+	literals := make([]string, 0, len(schemas))
+	for _, s := range schemas {
+		literals = append(literals, pq.QuoteLiteral(s))
+	}
+
+	return fmt.Sprintf(`SELECT s.name, n.oid IS NOT NULL AS exist, coalesce(has_schema_privilege(n.oid, 'USAGE'), false) AS usage `+
+		`FROM unnest(ARRAY[%s]::text[]) AS s(name) LEFT JOIN pg_namespace n ON n.nspname = s.name;`, strings.Join(literals, ", "))
+}
@@ -27,6 +27,30 @@ SELECT
     END
   END AS data_type,
   COALESCE(bt.typname, t.typname) AS format,
+  CASE
+    WHEN COALESCE(bt.typname, t.typname) IN ('varchar', 'bpchar') AND a.atttypmod > 4 THEN a.atttypmod - 4
+    ELSE 0
+  END AS length,
+  CASE
+    WHEN COALESCE(bt.typname, t.typname) = 'numeric' AND a.atttypmod >= 4 THEN ((a.atttypmod - 4) >> 16) & 65535
+    ELSE 0
+  END AS precision,
+  CASE
+    WHEN COALESCE(bt.typname, t.typname) = 'numeric' AND a.atttypmod >= 4 THEN (a.atttypmod - 4) & 65535
+    ELSE 0
+  END AS scale,
+  CASE
+    WHEN t.typtype = 'd' AND nbt.nspname = 'pg_catalog' AND NOT (bt.typelem <> 0 :: oid AND bt.typlen = -1) THEN t.typname
+    ELSE NULL
+  END AS domain,
+  CASE
+    WHEN t.typtype = 'd' AND nbt.nspname = 'pg_catalog' AND NOT (bt.typelem <> 0 :: oid AND bt.typlen = -1) THEN nt.nspname
+    ELSE NULL
+  END AS domain_schema,
+  CASE
+    WHEN c.relkind IN ('r', 'p') THEN pg_get_serial_sequence(format('%I.%I', nc.nspname, c.relname), a.attname)
+    ELSE NULL
+  END AS sequence,
   a.attidentity IN ('a', 'd') AS is_identity,
   CASE
     a.attidentity
@@ -45,6 +69,21 @@ SELECT
   ) AS is_updatable,
   uniques.table_id IS NOT NULL AS is_unique,
   check_constraints.definition AS "check",
+  coalesce(
+    (
+      SELECT
+        jsonb_agg(
+          jsonb_build_object(
+            'grantee', CASE WHEN acl.grantee = 0 THEN 'public' ELSE pg_get_userbyid(acl.grantee) END,
+            'privilege_type', acl.privilege_type
+          )
+          ORDER BY acl.privilege_type, acl.grantee
+        )
+      FROM
+        aclexplode(a.attacl) acl
+    ),
+    '[]'
+  ) AS privileges,
   array_to_json(
     array(
       SELECT
@@ -1,5 +1,10 @@
 package sql
 
+import (
+	"strings"
+	"text/template"
+)
+
 var GetForeignKeysQuery = `
 SELECT
   c.oid :: int8 AS id,
@@ -12,3 +17,53 @@ FROM
 WHERE
   c.relkind = 'f'
 `
+
+const foreignTablesQueryTemplate = `
+WITH foreign_tables AS (
+  SELECT
+    c.oid :: int8 AS id,
+    n.nspname AS schema,
+    c.relname AS name,
+    obj_description(c.oid) AS comment,
+    true AS is_foreign
+  FROM
+    pg_class c
+    JOIN pg_namespace n ON n.oid = c.relnamespace
+  WHERE
+    c.relkind = 'f'
+), columns AS ({{.ColumnsSQL}})
+SELECT
+  *,
+  {{coalesceRowsToArray "columns" "columns.table_id = foreign_tables.id"}}
+FROM foreign_tables
+{{if .IncludeSchemas }}
+where schema {{.FilterSQL}}
+{{end}}
+`
+
+// GenerateGetForeignTablesQuery generate query for get foreign table with the columns,
+// the result is compatible with table object
+func GenerateGetForeignTablesQuery(includeSchemas []string) (string, error) {
+	tmpl, err := template.New("enrichedForeignTablesSQL").
+		Funcs(template.FuncMap{
+			"coalesceRowsToArray": coalesceRowsToArray,
+		}).
+		Parse(foreignTablesQueryTemplate)
+
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	err = tmpl.Execute(&result, map[string]interface{}{
+		"ColumnsSQL":     GetColumnsQuery,
+		"IncludeSchemas": len(includeSchemas) > 0,
+		"FilterSQL":      filterByList(includeSchemas, nil, nil),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
@@ -141,3 +141,32 @@ func GenerateFunctionByNameQuery(schema, name string) string {
 
 	return fmt.Sprintf(filteredSql, schemaFilter, nameFilter)
 }
+
+// GenerateFunctionIdsQuery only select id, schema and name of function,
+// definition is not read so listing function doesn`t fail because of one function
+func GenerateFunctionIdsQuery(includedSchema []string) string {
+	if len(includedSchema) == 0 {
+		includedSchema = append(includedSchema, "public")
+	}
+
+	var filterArg []string
+	for _, v := range includedSchema {
+		filterArg = append(filterArg, fmt.Sprintf("'%s'", v))
+	}
+
+	return fmt.Sprintf(`
+select
+  f.oid::int8 as id,
+  n.nspname as schema,
+  f.proname as name
+from
+  pg_proc as f
+  left join pg_namespace n on f.pronamespace = n.oid
+where
+  f.prokind = 'f' and n.nspname IN (%s)
+`, strings.Join(filterArg, ","))
+}
+
+func GenerateFunctionByIdQuery(id int) string {
+	return fmt.Sprintf(GetFunctionsQuery+" where f.oid = %d", id)
+}
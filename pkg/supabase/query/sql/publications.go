@@ -1,10 +1,13 @@
 package sql
 
+import "fmt"
+
 var GetPublicationsQuery = `
 SELECT
   p.oid :: int8 AS id,
   p.pubname AS name,
   p.pubowner::regrole::text AS owner,
+  p.puballtables AS all_tables,
   p.pubinsert AS publish_insert,
   p.pubupdate AS publish_update,
   p.pubdelete AS publish_delete,
@@ -38,3 +41,11 @@ FROM
       pr.prpubid = p.oid
   ) AS pr ON 1 = 1
 `
+
+func GeneratePublicationsQuery() string {
+	return fmt.Sprintf("SELECT * FROM (%s) AS p ORDER BY p.name", GetPublicationsQuery)
+}
+
+func GeneratePublicationByNameQuery(name string) string {
+	return fmt.Sprintf("SELECT * FROM (%s) AS p WHERE p.name = '%s'", GetPublicationsQuery, name)
+}
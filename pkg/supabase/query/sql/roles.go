@@ -25,7 +25,36 @@ SELECT
   END AS connection_limit,
   rolpassword AS password,
   rolvaliduntil AS valid_until,
-  rolconfig AS config
+  rolconfig AS config,
+  ARRAY(
+    SELECT
+      b.rolname
+    FROM
+      pg_auth_members m
+      JOIN pg_roles b ON m.roleid = b.oid
+    WHERE
+      m.member = pg_roles.oid
+    ORDER BY
+      b.rolname
+  ) AS member_of
 FROM
   pg_roles
 `
+
+var GetRoleMembershipsQuery = `
+SELECT
+  r.rolname AS name,
+  ARRAY(
+    SELECT
+      b.rolname
+    FROM
+      pg_auth_members m
+      JOIN pg_roles b ON m.roleid = b.oid
+    WHERE
+      m.member = r.oid
+    ORDER BY
+      b.rolname
+  ) AS member_of
+FROM
+  pg_roles r
+`
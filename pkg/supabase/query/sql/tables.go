@@ -25,7 +25,16 @@ SELECT
   pg_stat_get_live_tuples(c.oid) AS live_rows_estimate,
   pg_stat_get_dead_tuples(c.oid) AS dead_rows_estimate,
   obj_description(c.oid) AS comment,
+  c.relkind = 'p' AS is_partitioned,
+  coalesce(
+    (
+      SELECT i.inhparent :: int8 FROM pg_inherits i WHERE i.inhrelid = c.oid AND c.relispartition LIMIT 1
+    ),
+    0
+  ) AS partition_of_id,
   coalesce(pk.primary_keys, '[]') as primary_keys,
+  coalesce(uq.unique_indexes, '[]') as unique_indexes,
+  coalesce(ix.indexes, '[]') as indexes,
   coalesce(
     jsonb_agg(relationships) filter (where relationships is not null),
     '[]'
@@ -58,6 +67,64 @@ FROM
     group by table_id
   ) as pk
   on pk.table_id = c.oid
+  left join (
+    select
+      i.indrelid :: int8 as table_id,
+      jsonb_agg(
+        jsonb_build_object(
+          'name', ic.relname,
+          'columns', (
+            select jsonb_agg(a.attname order by k.ordinality)
+            from unnest(i.indkey :: int2[]) with ordinality as k(attnum, ordinality)
+            join pg_attribute a on a.attrelid = i.indrelid and a.attnum = k.attnum
+          )
+        )
+      ) as unique_indexes
+    from
+      pg_index i
+      join pg_class ic on ic.oid = i.indexrelid
+    where
+      i.indisunique
+      and i.indpred is null
+      and not (0 = any (i.indkey :: int2[]))
+    group by i.indrelid
+  ) as uq
+  on uq.table_id = c.oid
+  left join (
+    select
+      i.indrelid :: int8 as table_id,
+      jsonb_agg(
+        jsonb_build_object(
+          'name', ic.relname,
+          'columns', (
+            select jsonb_agg(
+              pg_get_indexdef(i.indexrelid, k.n, true)
+              || case when opc.opcdefault then '' else ' ' || quote_ident(opn.nspname) || '.' || quote_ident(opc.opcname) end
+              || case when (i.indoption[k.n - 1] & 1) = 1 then ' DESC' else '' end
+              order by k.n
+            )
+            from generate_series(1, i.indnkeyatts) as k(n)
+            join pg_opclass opc on opc.oid = i.indclass[k.n - 1]
+            join pg_namespace opn on opn.oid = opc.opcnamespace
+          ),
+          'is_unique', i.indisunique,
+          'method', am.amname,
+          'predicate', coalesce(pg_get_expr(i.indpred, i.indrelid, true), '')
+        )
+        order by ic.relname
+      ) as indexes
+    from
+      pg_index i
+      join pg_class ic on ic.oid = i.indexrelid
+      join pg_am am on am.oid = ic.relam
+    where
+      not exists (
+        select 1 from pg_constraint con
+        where con.conindid = i.indexrelid and con.contype in ('p', 'u', 'x')
+      )
+    group by i.indrelid
+  ) as ix
+  on ix.table_id = c.oid
   left join (
     select
       c.oid :: int8 as id,
@@ -102,8 +169,24 @@ group by
   c.relrowsecurity,
   c.relforcerowsecurity,
   c.relreplident,
+  c.relkind,
+  c.relispartition,
   nc.nspname,
-  pk.primary_keys
+  pk.primary_keys,
+  uq.unique_indexes,
+  ix.indexes
+`
+
+var GetTablePartitionsQuery = `
+SELECT
+  c.oid :: int8 AS id,
+  c.relkind = 'p' AS is_partitioned,
+  coalesce(i.inhparent :: int8, 0) AS partition_of_id
+FROM
+  pg_class c
+  LEFT JOIN pg_inherits i ON i.inhrelid = c.oid AND c.relispartition
+WHERE
+  c.relkind = 'p' OR c.relispartition
 `
 
 const tablesQueryTemplate = `
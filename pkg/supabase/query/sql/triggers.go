@@ -1,5 +1,10 @@
 package sql
 
+import (
+	"fmt"
+	"strings"
+)
+
 var GetTriggersQuery = `
 SELECT
   pg_t.oid AS id,
@@ -51,3 +56,27 @@ GROUP BY
   pg_p.proname,
   pg_n.nspname
 `
+
+func GenerateTriggersQuery(includedSchema []string) string {
+	if len(includedSchema) == 0 {
+		includedSchema = append(includedSchema, "public")
+	}
+
+	var filterArg []string
+	for _, v := range includedSchema {
+		filterArg = append(filterArg, fmt.Sprintf("'%s'", v))
+	}
+
+	return fmt.Sprintf("SELECT * FROM (%s) AS t WHERE t.schema IN (%s)", GetTriggersQuery, strings.Join(filterArg, ","))
+}
+
+func GenerateTriggerByNameQuery(schema, table, name string) string {
+	if len(schema) == 0 {
+		schema = "public"
+	}
+
+	return fmt.Sprintf(
+		"SELECT * FROM (%s) AS t WHERE t.schema = '%s' AND t.table = '%s' AND t.name = '%s'",
+		GetTriggersQuery, schema, table, name,
+	)
+}
@@ -8,6 +8,11 @@ select
   format_type (t.oid, null) as format,
   coalesce(t_enums.enums, '[]') as enums,
   coalesce(t_attributes.attributes, '[]') as attributes,
+  case
+    when t.typtype = 'd' then format_type(t.typbasetype, null)
+    else ''
+  end as base_type,
+  coalesce(t_checks.check, '') as check,
   obj_description (t.oid, 'pg_type') as comment
 from
   pg_type t
@@ -36,6 +41,25 @@ from
     group by
       c.oid
   ) as t_attributes on t_attributes.oid = t.typrelid
+  left join (
+    select
+      contypid,
+      string_agg(
+        substring(
+          pg_get_constraintdef(oid, true),
+          8,
+          length(pg_get_constraintdef(oid, true)) - 8
+        ),
+        ' AND '
+        order by oid asc
+      ) as check
+    from
+      pg_constraint
+    where
+      contype = 'c' and contypid <> 0
+    group by
+      contypid
+  ) as t_checks on t_checks.contypid = t.oid
 where
   (
     t.typrelid = 0
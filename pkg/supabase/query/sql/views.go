@@ -1,5 +1,10 @@
 package sql
 
+import (
+	"strings"
+	"text/template"
+)
+
 var GetViewsQuery = `
 SELECT
   c.oid :: int8 AS id,
@@ -14,3 +19,54 @@ FROM
 WHERE
   c.relkind = 'v'
 `
+
+const viewsQueryTemplate = `
+WITH views AS (
+  SELECT
+    c.oid :: int8 AS id,
+    n.nspname AS schema,
+    c.relname AS name,
+    obj_description(c.oid) AS comment,
+    true AS is_view,
+    c.relkind = 'm' AS is_materialized
+  FROM
+    pg_class c
+    JOIN pg_namespace n ON n.oid = c.relnamespace
+  WHERE
+    c.relkind IN ('v', 'm')
+), columns AS ({{.ColumnsSQL}})
+SELECT
+  *,
+  {{coalesceRowsToArray "columns" "columns.table_id = views.id"}}
+FROM views
+{{if .IncludeSchemas }}
+where schema {{.FilterSQL}}
+{{end}}
+`
+
+// GenerateGetViewsQuery generate query for get view and materialized view with the columns,
+// the result is compatible with table object
+func GenerateGetViewsQuery(includeSchemas []string) (string, error) {
+	tmpl, err := template.New("enrichedViewsSQL").
+		Funcs(template.FuncMap{
+			"coalesceRowsToArray": coalesceRowsToArray,
+		}).
+		Parse(viewsQueryTemplate)
+
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	err = tmpl.Execute(&result, map[string]interface{}{
+		"ColumnsSQL":     GetColumnsQuery,
+		"IncludeSchemas": len(includeSchemas) > 0,
+		"FilterSQL":      filterByList(includeSchemas, nil, nil),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/lib/pq"
+	"github.com/sev-2/raiden/pkg/postgres"
 	"github.com/sev-2/raiden/pkg/supabase/objects"
 )
 
@@ -25,13 +27,23 @@ func BuildCreateTableQuery(newTable objects.Table) (string, error) {
 		rlsForcedQuery = fmt.Sprintf("ALTER TABLE %s.%s FORCE ROW LEVEL SECURITY;", newTable.Schema, newTable.Name)
 	}
 
+	var indexQueries []string
+	for _, idx := range newTable.Indexes {
+		q, err := BuildIndexQuery(objects.UpdateIndexCreate, newTable.Schema, newTable.Name, idx)
+		if err != nil {
+			return "", err
+		}
+		indexQueries = append(indexQueries, q)
+	}
+
 	sql := fmt.Sprintf(`
 	BEGIN;
 	  %s
 	  %s
 	  %s
+	  %s
 	COMMIT;
-	`, createSql, rlsEnableQuery, rlsForcedQuery)
+	`, createSql, rlsEnableQuery, rlsForcedQuery, strings.Join(indexQueries, "\n"))
 	return sql, nil
 }
 
@@ -137,9 +149,61 @@ func buildCreateTableQuery(schema string, table objects.Table) (q string, err er
 	}
 
 	q = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (%s);", schema, table.Name, strings.Join(tableContains, ","))
+	if sequenceQuery := buildColumnSequenceQuery(table.Columns...); sequenceQuery != "" {
+		q = sequenceQuery + " " + q
+	}
+
+	for _, c := range table.Columns {
+		if grantQuery := buildColumnGrantQuery("GRANT", schema, table.Name, c.Name, c.GetPrivileges()); grantQuery != "" {
+			q += " " + grantQuery
+		}
+	}
+	return
+}
+
+// buildColumnGrantQuery build grant or revoke statement of column privileges,
+// example : GRANT SELECT (email) ON public.users TO anon;
+func buildColumnGrantQuery(action string, schema, table, column string, privileges []objects.ColumnPrivilege) string {
+	var queries []string
+	for _, p := range privileges {
+		target := "TO"
+		if action == "REVOKE" {
+			target = "FROM"
+		}
+		queries = append(queries, fmt.Sprintf("%s %s (%s) ON %s.%s %s %s;", action, p.PrivilegeType, column, schema, table, target, p.Grantee))
+	}
+	return strings.Join(queries, " ")
+}
+
+// diffColumnPrivileges return privilege that only exist in source privileges
+func diffColumnPrivileges(source, target []objects.ColumnPrivilege) (diff []objects.ColumnPrivilege) {
+	mapTarget := make(map[objects.ColumnPrivilege]bool)
+	for _, p := range target {
+		mapTarget[p] = true
+	}
+
+	for _, p := range source {
+		if !mapTarget[p] {
+			diff = append(diff, p)
+		}
+	}
 	return
 }
 
+// buildColumnSequenceQuery create sequence that used by column default and not created by serial column,
+// example : sequence that shared by several table or explicitly declared with custom name
+func buildColumnSequenceQuery(columns ...objects.Column) string {
+	var queries []string
+	for _, c := range columns {
+		sequence := c.GetDefaultSequence()
+		if sequence == "" || c.GetSerialType() != "" {
+			continue
+		}
+		queries = append(queries, fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s;", sequence))
+	}
+	return strings.Join(queries, " ")
+}
+
 // ----- Column ----
 func BuildCreateColumnQuery(column objects.Column, isPrimary bool) (q string, err error) {
 	colDef, err := buildColumnDef(column)
@@ -152,12 +216,6 @@ func BuildCreateColumnQuery(column objects.Column, isPrimary bool) (q string, er
 		isPrimaryKeyClause = "PRIMARY KEY"
 	}
 
-	// TODO : implement check setup
-	// checkSql := ""
-	// if column.Check != nil {
-	// 	checkSql = fmt.Sprintf("CHECK (%s)", *column.Check)
-	// }
-
 	// TODO : implement comment setup
 	// commentSql := ""
 	// if column.Comment != nil {
@@ -166,8 +224,11 @@ func BuildCreateColumnQuery(column objects.Column, isPrimary bool) (q string, er
 
 	q = fmt.Sprintf(`
 	BEGIN;
+	  %s
 	  ALTER TABLE %s.%s ADD COLUMN %s %s;
-	COMMIT;`, column.Schema, column.Table, colDef, isPrimaryKeyClause)
+	  %s
+	COMMIT;`, buildColumnSequenceQuery(column), column.Schema, column.Table, colDef, isPrimaryKeyClause,
+		buildColumnGrantQuery("GRANT", column.Schema, column.Table, column.Name, column.GetPrivileges()))
 	return
 }
 
@@ -190,7 +251,7 @@ func BuildUpdateColumnQuery(oldColumn, newColumn objects.Column, updateItem obje
 			sqlStatements = append(
 				sqlStatements,
 				fmt.Sprintf(
-					"%s ALTER COLUMN %s SET DATA TYPE %s USING %s::%s;", alter, oldColumn.Name, newColumn.DataType, oldColumn.Name, newColumn.DataType,
+					"%s ALTER COLUMN %s SET DATA TYPE %s USING %s::%s;", alter, oldColumn.Name, getColumnDataType(newColumn), oldColumn.Name, getColumnDataType(newColumn),
 				),
 			)
 		case objects.UpdateColumnUnique:
@@ -246,19 +307,11 @@ func BuildUpdateColumnQuery(oldColumn, newColumn objects.Column, updateItem obje
 				}
 			}
 
-			defaultValue := fmt.Sprintf("'%v'", value)
-			if _, e := strconv.ParseInt(value, 10, 64); e == nil {
-				defaultValue = value
-			} else if _, e := strconv.ParseUint(value, 10, 64); e == nil {
-				defaultValue = value
-			} else if _, e := strconv.ParseBool(value); e == nil {
-				defaultValue = value
-			} else if _, e := strconv.ParseFloat(value, 64); e == nil {
-				defaultValue = value
-			} else if strings.Contains(value, "()") {
-				defaultValue = value
+			if sequenceQuery := buildColumnSequenceQuery(newColumn); sequenceQuery != "" {
+				sqlStatements = append(sqlStatements, sequenceQuery)
 			}
 
+			defaultValue := buildDefaultValue(value)
 			sqlStatements = append(
 				sqlStatements,
 				fmt.Sprintf(
@@ -266,6 +319,32 @@ func BuildUpdateColumnQuery(oldColumn, newColumn objects.Column, updateItem obje
 				),
 			)
 
+		case objects.UpdateColumnCheck:
+			constraintName := fmt.Sprintf("%s_%s_check", newColumn.Table, newColumn.Name)
+			sqlStatements = append(
+				sqlStatements,
+				fmt.Sprintf(
+					"%s DROP CONSTRAINT IF EXISTS %s;", alter, constraintName,
+				),
+			)
+
+			if check := newColumn.GetCheck(); check != "" {
+				sqlStatements = append(
+					sqlStatements,
+					fmt.Sprintf(
+						"%s ADD CONSTRAINT %s CHECK (%s);", alter, constraintName, check,
+					),
+				)
+			}
+		case objects.UpdateColumnPrivilege:
+			oldPrivileges, newPrivileges := oldColumn.GetPrivileges(), newColumn.GetPrivileges()
+			if revokeQuery := buildColumnGrantQuery("REVOKE", newColumn.Schema, newColumn.Table, newColumn.Name, diffColumnPrivileges(oldPrivileges, newPrivileges)); revokeQuery != "" {
+				sqlStatements = append(sqlStatements, revokeQuery)
+			}
+
+			if grantQuery := buildColumnGrantQuery("GRANT", newColumn.Schema, newColumn.Table, newColumn.Name, diffColumnPrivileges(newPrivileges, oldPrivileges)); grantQuery != "" {
+				sqlStatements = append(sqlStatements, grantQuery)
+			}
 		case objects.UpdateColumnIdentity:
 			if newColumn.IsIdentity {
 				sqlStatements = append(
@@ -297,7 +376,10 @@ func BuildUpdateColumnQuery(oldColumn, newColumn objects.Column, updateItem obje
 
 func buildColumnDef(column objects.Column) (string, error) {
 	var defaultValueClause string
-	if column.IsIdentity {
+	if column.GetSerialType() != "" {
+		// serial column create the sequence and default by itself
+		defaultValueClause = ""
+	} else if column.IsIdentity {
 		if column.DefaultValue != nil {
 			return "", fmt.Errorf("columns %s.%s %s cannot both be identity and have a default value", column.Schema, column.Table, column.Name)
 		}
@@ -317,20 +399,7 @@ func buildColumnDef(column objects.Column) (string, error) {
 		}
 
 		if value != "" {
-			defaultValue := fmt.Sprintf("'%v'", value)
-			if _, e := strconv.ParseInt(value, 10, 64); e == nil {
-				defaultValue = value
-			} else if _, e := strconv.ParseUint(value, 10, 64); e == nil {
-				defaultValue = value
-			} else if _, e := strconv.ParseBool(value); e == nil {
-				defaultValue = value
-			} else if _, e := strconv.ParseFloat(value, 64); e == nil {
-				defaultValue = value
-			} else if strings.Contains(value, "()") {
-				defaultValue = value
-			}
-
-			defaultValueClause = fmt.Sprintf("DEFAULT %s", defaultValue)
+			defaultValueClause = fmt.Sprintf("DEFAULT %s", buildDefaultValue(value))
 		}
 
 	}
@@ -345,10 +414,48 @@ func buildColumnDef(column objects.Column) (string, error) {
 		isUniqueClause = "UNIQUE"
 	}
 
-	q := fmt.Sprintf("%s %s %s %s %s", column.Name, column.DataType, defaultValueClause, isNullableClause, isUniqueClause)
+	q := fmt.Sprintf("%s %s %s %s %s", column.Name, getColumnDataType(column), defaultValueClause, isNullableClause, isUniqueClause)
+	if check := column.GetCheck(); check != "" {
+		q = fmt.Sprintf("%s CHECK (%s)", q, check)
+	}
 	return q, nil
 }
 
+// buildDefaultValue write column default verbatim when it is number, boolean or sql expression
+// (e.g now() and CURRENT_TIMESTAMP), other value is written as quoted literal
+func buildDefaultValue(value string) string {
+	if _, e := strconv.ParseInt(value, 10, 64); e == nil {
+		return value
+	} else if _, e := strconv.ParseUint(value, 10, 64); e == nil {
+		return value
+	} else if _, e := strconv.ParseBool(value); e == nil {
+		return value
+	} else if _, e := strconv.ParseFloat(value, 64); e == nil {
+		return value
+	} else if postgres.IsDefaultExpression(value) {
+		return value
+	}
+	return fmt.Sprintf("'%v'", value)
+}
+
+// getColumnDataType return data type for column definition,
+// array column is defined with element type (e.g text[])
+func getColumnDataType(column objects.Column) string {
+	if serialType := column.GetSerialType(); serialType != "" {
+		return serialType
+	}
+	if postgres.DataType(column.DataType) == postgres.ArrayType {
+		return postgres.GetArrayElementType(column.Format) + "[]"
+	}
+	if domain := column.GetDomain(); domain != "" {
+		return domain
+	}
+	if postgres.DataType(column.DataType) == postgres.UserDefinedType && column.Format != "" {
+		return column.Format
+	}
+	return column.DataType + column.GetTypeModifier()
+}
+
 func BuildDeleteColumnQuery(column objects.Column) (q string) {
 	return fmt.Sprintf("ALTER TABLE %s.%s DROP COLUMN %s;", column.Schema, column.Table, column.Name)
 }
@@ -376,3 +483,58 @@ func BuildFkQuery(updateType objects.UpdateRelationType, relation *objects.Table
 		return "", fmt.Errorf("update relation with type '%s' is not available", updateType)
 	}
 }
+
+// ----- Index -----
+
+// BuildIndexQuery build query for create, recreate or drop index of table,
+// index column is written as is because it can be expression
+func BuildIndexQuery(updateType objects.UpdateIndexType, schema, table string, index objects.TableIndex) (string, error) {
+	if schema == "" {
+		schema = "public"
+	}
+	dropSql := fmt.Sprintf("DROP INDEX IF EXISTS %s.%s;", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(index.Name))
+
+	switch updateType {
+	case objects.UpdateIndexCreate, objects.UpdateIndexUpdate:
+		if len(index.Columns) == 0 {
+			return "", fmt.Errorf("index %s doesn't have column", index.Name)
+		}
+
+		var unique, where string
+		if index.IsUnique {
+			unique = "UNIQUE "
+		}
+
+		if index.Predicate != "" {
+			where = fmt.Sprintf(" WHERE %s", index.Predicate)
+		}
+
+		createSql := fmt.Sprintf(
+			"CREATE %sINDEX IF NOT EXISTS %s ON %s.%s USING %s (%s)%s;",
+			unique, pq.QuoteIdentifier(index.Name), pq.QuoteIdentifier(schema), pq.QuoteIdentifier(table),
+			index.GetMethod(), strings.Join(index.Columns, ", "), where,
+		)
+
+		if updateType == objects.UpdateIndexUpdate {
+			return dropSql + createSql, nil
+		}
+		return createSql, nil
+	case objects.UpdateIndexDelete:
+		return dropSql, nil
+	default:
+		return "", fmt.Errorf("update index with type '%s' is not available", updateType)
+	}
+}
+
+// BuildUpdateIndexesQuery build single query for all index change of table
+func BuildUpdateIndexesQuery(schema, table string, items []objects.UpdateIndexItem) (string, error) {
+	queries := make([]string, 0, len(items))
+	for _, i := range items {
+		q, err := BuildIndexQuery(i.Type, schema, table, i.Data)
+		if err != nil {
+			return "", err
+		}
+		queries = append(queries, q)
+	}
+	return strings.Join(queries, "\n"), nil
+}
@@ -0,0 +1,92 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/sev-2/raiden/pkg/supabase/objects"
+)
+
+type TriggerAction string
+
+const (
+	TriggerActionCreate TriggerAction = "create"
+	TriggerActionUpdate TriggerAction = "update"
+	TriggerActionDelete TriggerAction = "delete"
+)
+
+func BuildTriggerQuery(action TriggerAction, t *objects.Trigger) (string, error) {
+	switch action {
+	case TriggerActionCreate:
+		return buildCreateTriggerQuery(t)
+	case TriggerActionDelete:
+		return buildDropTriggerQuery(t), nil
+	case TriggerActionUpdate:
+		// trigger definition can't be altered, recreate it in one transaction
+		createSql, err := buildCreateTriggerQuery(t)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("BEGIN; %s %s COMMIT;", buildDropTriggerQuery(t), createSql), nil
+	default:
+		return "", fmt.Errorf("generate trigger sql with type '%s' is not available", action)
+	}
+}
+
+func buildCreateTriggerQuery(t *objects.Trigger) (string, error) {
+	if t.Name == "" || t.Table == "" {
+		return "", fmt.Errorf("trigger name and table is required")
+	}
+
+	if len(t.Events) == 0 {
+		return "", fmt.Errorf("trigger %s doesn't have event", t.Name)
+	}
+
+	if t.FunctionName == "" {
+		return "", fmt.Errorf("trigger %s doesn't have function", t.Name)
+	}
+
+	activation := strings.ToUpper(t.Activation)
+	if activation == "" {
+		activation = "AFTER"
+	}
+
+	orientation := strings.ToUpper(t.Orientation)
+	if orientation == "" {
+		orientation = "STATEMENT"
+	}
+
+	events := make([]string, 0, len(t.Events))
+	for _, e := range t.Events {
+		events = append(events, strings.ToUpper(e))
+	}
+
+	var whenSql string
+	if condition := t.GetCondition(); condition != "" {
+		whenSql = fmt.Sprintf(" WHEN (%s)", condition)
+	}
+
+	args := make([]string, 0, len(t.FunctionArgs))
+	for _, a := range t.FunctionArgs {
+		args = append(args, pq.QuoteLiteral(a))
+	}
+
+	return fmt.Sprintf(
+		"CREATE TRIGGER %s %s %s ON %s FOR EACH %s%s EXECUTE FUNCTION %s(%s);",
+		pq.QuoteIdentifier(t.Name), activation, strings.Join(events, " OR "),
+		quoteTriggerObject(t.Schema, t.Table), orientation, whenSql,
+		quoteTriggerObject(t.FunctionSchema, t.FunctionName), strings.Join(args, ", "),
+	), nil
+}
+
+func buildDropTriggerQuery(t *objects.Trigger) string {
+	return fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s;", pq.QuoteIdentifier(t.Name), quoteTriggerObject(t.Schema, t.Table))
+}
+
+func quoteTriggerObject(schema, name string) string {
+	if schema == "" {
+		schema = "public"
+	}
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(name))
+}
@@ -41,7 +41,7 @@ func getBucketUrl(cfg *raiden.Config) string {
 func GetBuckets(cfg *raiden.Config) (buckets []objects.Bucket, err error) {
 	return decorateActionWithDataErr("fetch", "storage", func() ([]objects.Bucket, error) {
 		StorageLogger.Debug("fetch all bucket")
-		return net.Get[[]objects.Bucket](getBucketUrl(cfg), net.DefaultTimeout, DefaultAuthInterceptor(cfg.ServiceKey, cfg.ServiceKey), nil)
+		return net.GetWithContext[[]objects.Bucket](cfg.Context(), getBucketUrl(cfg), net.DefaultTimeout, DefaultAuthInterceptor(cfg.ServiceKey, cfg.ServiceKey), nil)
 	})
 }
 
@@ -49,7 +49,7 @@ func GetBucket(cfg *raiden.Config, name string) (buckets objects.Bucket, err err
 	return decorateActionWithDataErr("fetch", "storage", func() (objects.Bucket, error) {
 		StorageLogger.Debug("fetch bucket")
 		url := fmt.Sprintf("%s/%s", getBucketUrl(cfg), name)
-		return net.Get[objects.Bucket](url, net.DefaultTimeout, DefaultAuthInterceptor(cfg.ServiceKey, cfg.ServiceKey), nil)
+		return net.GetWithContext[objects.Bucket](cfg.Context(), url, net.DefaultTimeout, DefaultAuthInterceptor(cfg.ServiceKey, cfg.ServiceKey), nil)
 	})
 
 }
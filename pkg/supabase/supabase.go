@@ -1,6 +1,7 @@
 package supabase
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -51,6 +52,34 @@ func GetTables(cfg *raiden.Config, includedSchemas []string) (tables []objects.T
 	})
 }
 
+func GetViews(cfg *raiden.Config, includedSchemas []string) (views []objects.Table, err error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get all view from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("Fetch", "view", func() ([]objects.Table, error) {
+			return cloud.GetViews(cfg, includedSchemas)
+		})
+	}
+
+	SupabaseLogger.Debug("Get all view from supabase pg-meta")
+	return decorateActionWithDataErr("Fetch", "view", func() ([]objects.Table, error) {
+		return meta.GetViews(cfg, includedSchemas)
+	})
+}
+
+func GetForeignTables(cfg *raiden.Config, includedSchemas []string) (foreignTables []objects.Table, err error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get all foreign table from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("Fetch", "foreign table", func() ([]objects.Table, error) {
+			return cloud.GetForeignTables(cfg, includedSchemas)
+		})
+	}
+
+	SupabaseLogger.Debug("Get all foreign table from supabase pg-meta")
+	return decorateActionWithDataErr("Fetch", "foreign table", func() ([]objects.Table, error) {
+		return meta.GetForeignTables(cfg, includedSchemas)
+	})
+}
+
 func CreateTable(cfg *raiden.Config, table objects.Table) (rs objects.Table, err error) {
 	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
 		SupabaseLogger.Debug("Create new table to supabase cloud", "table", table.Name, "project-id", cfg.ProjectId)
@@ -208,6 +237,46 @@ func GetFunctions(cfg *raiden.Config) ([]objects.Function, error) {
 	})
 }
 
+// GetFunctionIds only fetch id, schema and name of function
+func GetFunctionIds(cfg *raiden.Config, includedSchema []string) ([]objects.Function, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get all function id from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("fetch", "rpc id", func() ([]objects.Function, error) {
+			return cloud.GetFunctionIds(cfg, includedSchema)
+		})
+	}
+	SupabaseLogger.Debug("Get all function id from supabase pg-meta")
+	return decorateActionWithDataErr("fetch", "rpc id", func() ([]objects.Function, error) {
+		return meta.GetFunctionIds(cfg, includedSchema)
+	})
+}
+
+func GetFunctionById(cfg *raiden.Config, id int) (objects.Function, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get function from supabase cloud", "project-id", cfg.ProjectId, "id", id)
+		return decorateActionWithDataErr("fetch", "rpc", func() (objects.Function, error) {
+			return cloud.GetFunctionById(cfg, id)
+		})
+	}
+	SupabaseLogger.Debug("Get function from supabase pg-meta", "id", id)
+	return decorateActionWithDataErr("fetch", "rpc", func() (objects.Function, error) {
+		return meta.GetFunctionById(cfg, id)
+	})
+}
+
+func GetTypes(cfg *raiden.Config) ([]objects.Type, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get all types from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("fetch", "type", func() ([]objects.Type, error) {
+			return cloud.GetTypes(cfg)
+		})
+	}
+	SupabaseLogger.Debug("Get all types from supabase pg-meta")
+	return decorateActionWithDataErr("fetch", "type", func() ([]objects.Type, error) {
+		return meta.GetTypes(cfg)
+	})
+}
+
 func CreateFunction(cfg *raiden.Config, fn objects.Function) (objects.Function, error) {
 	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
 		SupabaseLogger.Debug("Create function from supabase cloud", "project-id", cfg.ProjectId)
@@ -247,6 +316,149 @@ func DeleteFunction(cfg *raiden.Config, fn objects.Function) (err error) {
 	})
 }
 
+func GetTriggers(cfg *raiden.Config, includedSchema []string) ([]objects.Trigger, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get all trigger from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("fetch", "trigger", func() ([]objects.Trigger, error) {
+			return cloud.GetTriggers(cfg, includedSchema)
+		})
+	}
+	SupabaseLogger.Debug("Get all trigger from supabase pg-meta")
+	return decorateActionWithDataErr("fetch", "trigger", func() ([]objects.Trigger, error) {
+		return meta.GetTriggers(cfg, includedSchema)
+	})
+}
+
+func CreateTrigger(cfg *raiden.Config, t objects.Trigger) (objects.Trigger, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Create trigger in supabase cloud", "name", t.Name, "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("create", "trigger", func() (objects.Trigger, error) {
+			return cloud.CreateTrigger(cfg, t)
+		})
+	}
+	SupabaseLogger.Debug("Create trigger in supabase pg-meta", "name", t.Name)
+	return decorateActionWithDataErr("create", "trigger", func() (objects.Trigger, error) {
+		return meta.CreateTrigger(cfg, t)
+	})
+}
+
+func UpdateTrigger(cfg *raiden.Config, t objects.Trigger) (err error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Update trigger in supabase cloud", "name", t.Name, "project-id", cfg.ProjectId)
+		return decorateActionErr("update", "trigger", func() error {
+			return cloud.UpdateTrigger(cfg, t)
+		})
+	}
+	SupabaseLogger.Debug("Update trigger in supabase pg-meta", "name", t.Name)
+	return decorateActionErr("update", "trigger", func() error {
+		return meta.UpdateTrigger(cfg, t)
+	})
+}
+
+func DeleteTrigger(cfg *raiden.Config, t objects.Trigger) (err error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Delete trigger in supabase cloud", "name", t.Name, "project-id", cfg.ProjectId)
+		return decorateActionErr("delete", "trigger", func() error {
+			return cloud.DeleteTrigger(cfg, t)
+		})
+	}
+	SupabaseLogger.Debug("Delete trigger in supabase pg-meta", "name", t.Name)
+	return decorateActionErr("delete", "trigger", func() error {
+		return meta.DeleteTrigger(cfg, t)
+	})
+}
+
+func GetSchemaPrivileges(cfg *raiden.Config, schemas []string) ([]objects.SchemaPrivilege, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get schema privileges from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("fetch", "schema privileges", func() ([]objects.SchemaPrivilege, error) {
+			return cloud.GetSchemaPrivileges(cfg, schemas)
+		})
+	}
+	SupabaseLogger.Debug("Get schema privileges from supabase pg-meta")
+	return decorateActionWithDataErr("fetch", "schema privileges", func() ([]objects.SchemaPrivilege, error) {
+		return meta.GetSchemaPrivileges(cfg, schemas)
+	})
+}
+
+func GetTableRows(cfg *raiden.Config, schema, table string, orderBy []string, limit int) ([]map[string]json.RawMessage, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get table rows from supabase cloud", "schema", schema, "name", table, "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("fetch", "table rows", func() ([]map[string]json.RawMessage, error) {
+			return cloud.GetTableRows(cfg, schema, table, orderBy, limit)
+		})
+	}
+	SupabaseLogger.Debug("Get table rows from supabase pg-meta", "schema", schema, "name", table)
+	return decorateActionWithDataErr("fetch", "table rows", func() ([]map[string]json.RawMessage, error) {
+		return meta.GetTableRows(cfg, schema, table, orderBy, limit)
+	})
+}
+
+func InsertTableRows(cfg *raiden.Config, schema, table string, columns []string, rowsJson string) (err error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Insert table rows in supabase cloud", "schema", schema, "name", table, "project-id", cfg.ProjectId)
+		return decorateActionErr("insert", "table rows", func() error {
+			return cloud.InsertTableRows(cfg, schema, table, columns, rowsJson)
+		})
+	}
+	SupabaseLogger.Debug("Insert table rows in supabase pg-meta", "schema", schema, "name", table)
+	return decorateActionErr("insert", "table rows", func() error {
+		return meta.InsertTableRows(cfg, schema, table, columns, rowsJson)
+	})
+}
+
+func GetPublications(cfg *raiden.Config) ([]objects.Publication, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Get all publication from supabase cloud", "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("fetch", "publication", func() ([]objects.Publication, error) {
+			return cloud.GetPublications(cfg)
+		})
+	}
+	SupabaseLogger.Debug("Get all publication from supabase pg-meta")
+	return decorateActionWithDataErr("fetch", "publication", func() ([]objects.Publication, error) {
+		return meta.GetPublications(cfg)
+	})
+}
+
+func CreatePublication(cfg *raiden.Config, p objects.Publication) (objects.Publication, error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Create publication in supabase cloud", "name", p.Name, "project-id", cfg.ProjectId)
+		return decorateActionWithDataErr("create", "publication", func() (objects.Publication, error) {
+			return cloud.CreatePublication(cfg, p)
+		})
+	}
+	SupabaseLogger.Debug("Create publication in supabase pg-meta", "name", p.Name)
+	return decorateActionWithDataErr("create", "publication", func() (objects.Publication, error) {
+		return meta.CreatePublication(cfg, p)
+	})
+}
+
+func UpdatePublication(cfg *raiden.Config, p objects.Publication, param objects.UpdatePublicationParam) (err error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Update publication in supabase cloud", "name", p.Name, "project-id", cfg.ProjectId)
+		return decorateActionErr("update", "publication", func() error {
+			return cloud.UpdatePublication(cfg, p, param)
+		})
+	}
+	SupabaseLogger.Debug("Update publication in supabase pg-meta", "name", p.Name)
+	return decorateActionErr("update", "publication", func() error {
+		return meta.UpdatePublication(cfg, p, param)
+	})
+}
+
+func DeletePublication(cfg *raiden.Config, p objects.Publication) (err error) {
+	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
+		SupabaseLogger.Debug("Delete publication in supabase cloud", "name", p.Name, "project-id", cfg.ProjectId)
+		return decorateActionErr("delete", "publication", func() error {
+			return cloud.DeletePublication(cfg, p)
+		})
+	}
+	SupabaseLogger.Debug("Delete publication in supabase pg-meta", "name", p.Name)
+	return decorateActionErr("delete", "publication", func() error {
+		return meta.DeletePublication(cfg, p)
+	})
+}
+
 func AdminUpdateUserData(cfg *raiden.Config, userId string, data objects.User) (objects.User, error) {
 	if cfg.DeploymentTarget == raiden.DeploymentTargetCloud {
 		SupabaseLogger.Debug("Update user data in supabase cloud", "user-id", userId, "project-id", cfg.ProjectId)
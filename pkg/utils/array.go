@@ -22,3 +22,13 @@ func RemoveByIndex[T any](source []T, index []int) []T {
 
 	return tmpArr
 }
+
+// Function to check if value exist in source
+func Contains[T comparable](source []T, value T) bool {
+	for i := range source {
+		if source[i] == value {
+			return true
+		}
+	}
+	return false
+}
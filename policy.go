@@ -1,7 +1,7 @@
 package raiden
 
 import (
-	"regexp"
+	"reflect"
 	"strings"
 )
 
@@ -15,25 +15,22 @@ type (
 	AclTag struct {
 		Read  Acl
 		Write Acl
+
+		// Update and Delete hold expression that override
+		// write expression for specific command
+		Update Acl
+		Delete Acl
 	}
 )
 
+// UnmarshalAclTag parse acl tag of model or storage, expression is unquoted
+// so expression that contain quote (e.g quoted identifier) is kept as is
 func UnmarshalAclTag(tag string) AclTag {
 	var aclTag AclTag
 
 	aclTagMap := make(map[string]string)
-
-	// Regular expression to match key-value pairs
-	re := regexp.MustCompile(`(\w+):"([^"]*)"`)
-
-	// Find all matches
-	matches := re.FindAllStringSubmatch(tag, -1)
-
-	// Loop through matches and add to result map
-	for _, match := range matches {
-		if len(match) == 3 {
-			key := match[1]
-			value := match[2]
+	for _, key := range []string{"read", "write", "readUsing", "writeCheck", "writeUsing", "updateCheck", "updateUsing", "deleteUsing"} {
+		if value, exist := reflect.StructTag(tag).Lookup(key); exist {
 			aclTagMap[key] = value
 		}
 	}
@@ -58,5 +55,44 @@ func UnmarshalAclTag(tag string) AclTag {
 		aclTag.Write.Using = writeTagUsing
 	}
 
+	if updateTagCheck, exist := aclTagMap["updateCheck"]; exist && len(updateTagCheck) > 0 {
+		aclTag.Update.Check = &updateTagCheck
+	}
+
+	if updateTagUsing, exist := aclTagMap["updateUsing"]; exist && len(updateTagUsing) > 0 {
+		aclTag.Update.Using = updateTagUsing
+	}
+
+	if deleteTagUsing, exist := aclTagMap["deleteUsing"]; exist && len(deleteTagUsing) > 0 {
+		aclTag.Delete.Using = deleteTagUsing
+	}
+
 	return aclTag
 }
+
+// GetUpdateCheck return update check expression,
+// fallback to write check when not defined
+func (a AclTag) GetUpdateCheck() *string {
+	if a.Update.Check != nil {
+		return a.Update.Check
+	}
+	return a.Write.Check
+}
+
+// GetUpdateUsing return update using expression,
+// fallback to write using when not defined
+func (a AclTag) GetUpdateUsing() string {
+	if a.Update.Using != "" {
+		return a.Update.Using
+	}
+	return a.Write.Using
+}
+
+// GetDeleteUsing return delete using expression,
+// fallback to write using when not defined
+func (a AclTag) GetDeleteUsing() string {
+	if a.Delete.Using != "" {
+		return a.Delete.Using
+	}
+	return a.Write.Using
+}
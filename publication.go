@@ -0,0 +1,48 @@
+package raiden
+
+type (
+	PublicationAction string
+
+	Publication interface {
+		// name
+		Name() string
+
+		// default false, publish change of all table including table that created later
+		AllTables() bool
+
+		// default nil, published table name, table outside public schema
+		// is declared with schema prefix (example : billing.invoices)
+		Tables() []string
+
+		// default all action
+		Actions() []PublicationAction
+	}
+
+	PublicationBase struct {
+	}
+)
+
+const (
+	PublicationActionInsert   PublicationAction = "insert"
+	PublicationActionUpdate   PublicationAction = "update"
+	PublicationActionDelete   PublicationAction = "delete"
+	PublicationActionTruncate PublicationAction = "truncate"
+
+	DefaultPublicationTableSchema = "public"
+)
+
+// ----- Base Publication Default Func -----
+func (p *PublicationBase) AllTables() bool {
+	return false
+}
+
+func (p *PublicationBase) Tables() []string {
+	return nil
+}
+
+func (p *PublicationBase) Actions() []PublicationAction {
+	return []PublicationAction{
+		PublicationActionInsert, PublicationActionUpdate,
+		PublicationActionDelete, PublicationActionTruncate,
+	}
+}
@@ -0,0 +1,217 @@
+package raiden
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Range is value of postgres range type (e.g int4range, tstzrange), bound is nil for
+// unbounded side. Value is serialized as range literal (e.g ["2024-01-01T00:00:00Z",)) the
+// same as postgrest return it, so range can be read and written through generated model
+type Range[T any] struct {
+	Lower          *T
+	Upper          *T
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+var rangeTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+// String return range literal, canonical form of discrete range (e.g [1,5)) is created by database
+func (r Range[T]) String() string {
+	if r.Empty {
+		return "empty"
+	}
+
+	var literal strings.Builder
+	if r.LowerInclusive && r.Lower != nil {
+		literal.WriteString("[")
+	} else {
+		literal.WriteString("(")
+	}
+
+	if r.Lower != nil {
+		literal.WriteString(quoteRangeBound(formatRangeBound(*r.Lower)))
+	}
+	literal.WriteString(",")
+	if r.Upper != nil {
+		literal.WriteString(quoteRangeBound(formatRangeBound(*r.Upper)))
+	}
+
+	if r.UpperInclusive && r.Upper != nil {
+		literal.WriteString("]")
+	} else {
+		literal.WriteString(")")
+	}
+	return literal.String()
+}
+
+func (r Range[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	var literal string
+	if err := json.Unmarshal(data, &literal); err != nil {
+		return err
+	}
+	return r.parse(literal)
+}
+
+// Value implement driver.Valuer, so range can be used as query argument (e.g bulk insert)
+func (r Range[T]) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan implement sql.Scanner
+func (r *Range[T]) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return r.parse(v)
+	case []byte:
+		return r.parse(string(v))
+	}
+	return fmt.Errorf("can't scan %T into range", src)
+}
+
+func (r *Range[T]) parse(literal string) error {
+	*r = Range[T]{}
+
+	literal = strings.TrimSpace(literal)
+	if strings.EqualFold(literal, "empty") {
+		r.Empty = true
+		return nil
+	}
+
+	if len(literal) < 3 {
+		return fmt.Errorf("invalid range literal %q", literal)
+	}
+
+	open, close := literal[0], literal[len(literal)-1]
+	if (open != '[' && open != '(') || (close != ']' && close != ')') {
+		return fmt.Errorf("invalid range literal %q", literal)
+	}
+	r.LowerInclusive, r.UpperInclusive = open == '[', close == ']'
+
+	lower, upper, err := splitRangeBounds(literal[1 : len(literal)-1])
+	if err != nil {
+		return fmt.Errorf("invalid range literal %q : %w", literal, err)
+	}
+
+	if r.Lower, err = parseRangeBound[T](lower); err != nil {
+		return err
+	}
+	if r.Upper, err = parseRangeBound[T](upper); err != nil {
+		return err
+	}
+	return nil
+}
+
+// splitRangeBounds split bound of range literal, bound may be quoted and contain comma,
+// nil is returned for empty bound because unquoted empty bound is unbounded
+func splitRangeBounds(value string) (lower, upper *string, err error) {
+	var bounds []*string
+	var current strings.Builder
+	var quoted, hasValue bool
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\' && i+1 < len(value):
+			i++
+			current.WriteByte(value[i])
+			hasValue = true
+		case c == '"':
+			if quoted && i+1 < len(value) && value[i+1] == '"' {
+				i++
+				current.WriteByte('"')
+				continue
+			}
+			quoted = !quoted
+			hasValue = true
+		case c == ',' && !quoted:
+			bounds = append(bounds, toRangeBound(current.String(), hasValue))
+			current.Reset()
+			hasValue = false
+		default:
+			current.WriteByte(c)
+			hasValue = true
+		}
+	}
+	bounds = append(bounds, toRangeBound(current.String(), hasValue))
+
+	if quoted || len(bounds) != 2 {
+		return nil, nil, fmt.Errorf("range must have lower and upper bound")
+	}
+	return bounds[0], bounds[1], nil
+}
+
+func toRangeBound(value string, hasValue bool) *string {
+	if !hasValue {
+		return nil
+	}
+	return &value
+}
+
+func parseRangeBound[T any](value *string) (*T, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	var bound T
+	var err error
+	switch b := any(&bound).(type) {
+	case *int32:
+		var n int64
+		n, err = strconv.ParseInt(*value, 10, 32)
+		*b = int32(n)
+	case *int64:
+		*b, err = strconv.ParseInt(*value, 10, 64)
+	case *float64:
+		*b, err = strconv.ParseFloat(*value, 64)
+	case *string:
+		*b = *value
+	case *time.Time:
+		for _, layout := range rangeTimeLayouts {
+			if *b, err = time.Parse(layout, *value); err == nil {
+				break
+			}
+		}
+	default:
+		err = json.Unmarshal([]byte(*value), &bound)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid range bound %q : %w", *value, err)
+	}
+	return &bound, nil
+}
+
+func formatRangeBound(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// quoteRangeBound quote bound that contain character with special meaning in range literal
+func quoteRangeBound(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` ,()[]"\`) {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
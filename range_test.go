@@ -0,0 +1,45 @@
+package raiden_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRange_Json(t *testing.T) {
+	// postgrest return range as range literal
+	var period raiden.Range[time.Time]
+	err := json.Unmarshal([]byte(`"[\"2024-01-01 00:00:00+00\",\"2024-01-02 12:30:00+07\")"`), &period)
+	assert.NoError(t, err)
+	assert.True(t, period.LowerInclusive)
+	assert.False(t, period.UpperInclusive)
+	assert.True(t, period.Lower.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, period.Upper.Equal(time.Date(2024, 1, 2, 5, 30, 0, 0, time.UTC)))
+
+	data, err := json.Marshal(period)
+	assert.NoError(t, err)
+	assert.Equal(t, `"[2024-01-01T00:00:00Z,2024-01-02T12:30:00+07:00)"`, string(data))
+
+	// unbounded side is nil
+	var capacity raiden.Range[int32]
+	assert.NoError(t, json.Unmarshal([]byte(`"[10,)"`), &capacity))
+	assert.Equal(t, int32(10), *capacity.Lower)
+	assert.Nil(t, capacity.Upper)
+	assert.Equal(t, "[10,)", capacity.String())
+
+	var empty raiden.Range[int32]
+	assert.NoError(t, json.Unmarshal([]byte(`"empty"`), &empty))
+	assert.True(t, empty.Empty)
+	assert.Equal(t, "empty", empty.String())
+
+	var label raiden.Range[string]
+	assert.NoError(t, json.Unmarshal([]byte(`"[\"a,b\",z]"`), &label))
+	assert.Equal(t, "a,b", *label.Lower)
+	assert.Equal(t, `["a,b",z]`, label.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`"1,2"`), &capacity))
+	assert.Error(t, json.Unmarshal([]byte(`"[a,2)"`), &capacity))
+}
@@ -36,6 +36,9 @@ type (
 
 		// default nil
 		ValidUntil() *objects.SupabaseTime
+
+		// default nil, list of parent role name
+		MemberOf() []string
 	}
 
 	RoleBase struct {
@@ -83,3 +86,7 @@ func (r *RoleBase) CanLogin() bool {
 func (r *RoleBase) ValidUntil() *objects.SupabaseTime {
 	return nil
 }
+
+func (r *RoleBase) MemberOf() []string {
+	return nil
+}
@@ -63,6 +63,7 @@ const (
 	RpcReturnDataTypeTable            RpcReturnDataType = "TABLE"
 	RpcReturnDataTypeSetOf            RpcReturnDataType = "SETOF"
 	RpcReturnDataTypeVoid             RpcReturnDataType = "VOID"
+	RpcReturnDataTypeTrigger          RpcReturnDataType = "TRIGGER"
 )
 
 func RpcParamToGoType(dataType RpcParamDataType) string {
@@ -194,6 +195,8 @@ func GetValidRpcReturnType(pType string, returnAlias bool) (RpcReturnDataType, e
 		return RpcReturnDataTypeTable, nil
 	case RpcReturnDataTypeVoid:
 		return RpcReturnDataTypeVoid, nil
+	case RpcReturnDataTypeTrigger:
+		return RpcReturnDataTypeTrigger, nil
 	default:
 		return "", fmt.Errorf("unsupported rpc return type  : %s", pCheckType)
 	}
@@ -240,6 +243,8 @@ func GetValidRpcReturnNameDecl(pType RpcReturnDataType, returnAlias bool) (strin
 		return "RpcReturnDataTypeTable", nil
 	case RpcReturnDataTypeVoid:
 		return "RpcReturnDataTypeVoid", nil
+	case RpcReturnDataTypeTrigger:
+		return "RpcReturnDataTypeTrigger", nil
 	default:
 		return "", fmt.Errorf("unsupported rpc return name declaration  : %s", pType)
 	}
@@ -377,6 +382,15 @@ func UnmarshalRpcParamTag(tag string) (RpcParamTag, error) {
 	return paramTag, nil
 }
 
+// hasRpcParamDefault check if param field is tagged with default value of function param
+func hasRpcParamDefault(field reflect.StructField) bool {
+	paramTag, err := UnmarshalRpcParamTag(field.Tag.Get("column"))
+	if err != nil {
+		return false
+	}
+	return paramTag.DefaultValue != ""
+}
+
 // ----- Rpc base functionality -----
 func (r *RpcBase) initModel() {
 	if r.Models == nil {
@@ -400,7 +414,7 @@ func (r *RpcBase) BindModel(model any, alias string) Rpc {
 		reflectType = reflectType.Elem()
 	}
 
-	r.Models[utils.ToSnakeCase(reflectType.Name())] = RpcModel{
+	r.Models[GetTableName(reflectType)] = RpcModel{
 		Alias: alias,
 		Model: model,
 	}
@@ -458,7 +472,10 @@ func (r *RpcBase) SetBehavior(behavior RpcBehaviorType) {
 }
 
 func (r *RpcBase) GetBehavior() RpcBehaviorType {
-	return RpcBehaviorVolatile
+	if r.Behavior == "" {
+		return RpcBehaviorVolatile
+	}
+	return r.Behavior
 }
 
 func (r *RpcBase) SetReturnType(returnType RpcReturnDataType) {
@@ -658,7 +675,7 @@ func buildRpcReturnSetOf(returnReflectType reflect.Type) (q string, err error) {
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("setof %s", st.Name()), nil
+	return fmt.Sprintf("setof %s", GetTableName(st)), nil
 }
 
 func buildRpcReturnTable(returnReflectType reflect.Type, rpc Rpc) (q string, err error) {
@@ -773,6 +790,14 @@ func ExecuteRpc(ctx Context, rpc Rpc) (any, error) {
 	mapParams := map[string]any{}
 	for i := 0; i < paramsType.NumField(); i++ {
 		fieldType, fieldValue := paramsType.Field(i), paramValue.Field(i)
+
+		// optional param (param with default value) is declared as pointer,
+		// skip it when not set so default value in function is used,
+		// nil param without default value is still sent as null
+		if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() && hasRpcParamDefault(fieldType) {
+			continue
+		}
+
 		key := utils.SnakeCaseToPascalCase(fieldType.Name)
 		if rpc.UseParamPrefix() {
 			key = fmt.Sprintf("%s%s", DefaultRpcParamPrefix, key)
@@ -64,3 +64,60 @@ func TestCreateQuery(t *testing.T) {
 	expectedCompleteQuery := "create or replace function public.get_submissions(scouter_name character varying, candidate_name text) returns table(id integer, created_at timestamp without time zone, sc_name character varying, c_name character varying) language plpgsql as $function$ begin return query select s.id, s.created_at, sc.name as sc_name, c.name as c_name from submission s inner join scouter sc on s.scouter_id = sc.scouter_id inner join candidate c on s.candidate_id = c.candidate_id where sc.name = scouter_name and c.name = candidate_name ; end; $function$"
 	assert.Equal(t, expectedCompleteQuery, rpc.GetCompleteStmt())
 }
+
+type GetCandidateCountParams struct {
+	CandidateName string `json:"candidate_name" column:"name:candidate_name;type:text"`
+}
+
+type GetCandidateCountResult int64
+
+type GetCandidateCount struct {
+	raiden.RpcBase
+	Params *GetCandidateCountParams `json:"-"`
+	Return GetCandidateCountResult  `json:"-"`
+}
+
+func (r *GetCandidateCount) GetName() string {
+	return "get_candidate_count"
+}
+
+func (r *GetCandidateCount) UseParamPrefix() bool {
+	return false
+}
+
+func (r *GetCandidateCount) GetSecurity() raiden.RpcSecurityType {
+	return raiden.RpcSecurityTypeDefiner
+}
+
+func (r *GetCandidateCount) GetBehavior() raiden.RpcBehaviorType {
+	return raiden.RpcBehaviorStable
+}
+
+func (r *GetCandidateCount) GetReturnType() raiden.RpcReturnDataType {
+	return raiden.RpcReturnDataTypeBigInt
+}
+
+func (r *GetCandidateCount) BindModels() {
+	r.BindModel(Candidate{}, "c")
+}
+
+func (r *GetCandidateCount) GetRawDefinition() string {
+	return `BEGIN RETURN (SELECT count(*) FROM :c c WHERE c.name = :candidate_name ); END;`
+}
+
+func TestCreateQuery_StableSecurityDefiner(t *testing.T) {
+	rpc := &GetCandidateCount{}
+	e := raiden.BuildRpc(rpc)
+	assert.NoError(t, e)
+
+	expectedCompleteQuery := "create or replace function public.get_candidate_count(candidate_name text) returns bigint language plpgsql stable security definer as $function$ begin return (select count(*) from candidate c where c.name = candidate_name ) ; end; $function$"
+	assert.Equal(t, expectedCompleteQuery, rpc.GetCompleteStmt())
+}
+
+func TestRpcBase_GetBehavior(t *testing.T) {
+	rpc := &raiden.RpcBase{}
+	assert.Equal(t, raiden.RpcBehaviorVolatile, rpc.GetBehavior())
+
+	rpc.SetBehavior(raiden.RpcBehaviorImmutable)
+	assert.Equal(t, raiden.RpcBehaviorImmutable, rpc.GetBehavior())
+}
@@ -1,5 +1,11 @@
 package raiden
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 type (
 	Bucket interface {
 		Name() string
@@ -12,6 +18,25 @@ type (
 	BucketBase struct{}
 )
 
+// file size unit, used for declare bucket file size limit
+// example : return 5 * raiden.MB
+const (
+	B  = 1
+	KB = 1024 * B
+	MB = 1024 * KB
+	GB = 1024 * MB
+)
+
+var fileSizeUnits = []struct {
+	Name string
+	Size int
+}{
+	{Name: "GB", Size: GB},
+	{Name: "MB", Size: MB},
+	{Name: "KB", Size: KB},
+	{Name: "B", Size: B},
+}
+
 func (b *BucketBase) Public() bool {
 	return false
 }
@@ -27,3 +52,39 @@ func (b *BucketBase) FileSizeLimit() int {
 func (b *BucketBase) AvifAutoDetection() bool {
 	return false
 }
+
+// ParseFileSize convert human readable size like "5MB", "512 kb" or "1024"
+// to size in bytes, value without unit is treated as bytes
+func ParseFileSize(size string) (int, error) {
+	cleanSize := strings.ToUpper(strings.TrimSpace(size))
+	if cleanSize == "" {
+		return 0, fmt.Errorf("invalid file size : %q", size)
+	}
+
+	multiplier := B
+	for _, u := range fileSizeUnits {
+		if strings.HasSuffix(cleanSize, u.Name) {
+			multiplier = u.Size
+			cleanSize = strings.TrimSpace(strings.TrimSuffix(cleanSize, u.Name))
+			break
+		}
+	}
+
+	value, err := strconv.Atoi(cleanSize)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid file size : %q", size)
+	}
+
+	return value * multiplier, nil
+}
+
+// FormatFileSize convert size in bytes to the largest unit
+// that can represent it without fraction, e.g 5242880 become "5MB"
+func FormatFileSize(size int) string {
+	for _, u := range fileSizeUnits {
+		if size >= u.Size && size%u.Size == 0 {
+			return fmt.Sprintf("%d%s", size/u.Size, u.Name)
+		}
+	}
+	return fmt.Sprintf("%dB", size)
+}
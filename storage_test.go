@@ -0,0 +1,32 @@
+package raiden_test
+
+import (
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFileSize(t *testing.T) {
+	size, err := raiden.ParseFileSize("5MB")
+	assert.NoError(t, err)
+	assert.Equal(t, 5*raiden.MB, size)
+
+	size, err = raiden.ParseFileSize("512 kb")
+	assert.NoError(t, err)
+	assert.Equal(t, 512*raiden.KB, size)
+
+	size, err = raiden.ParseFileSize("1500")
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, size)
+
+	_, err = raiden.ParseFileSize("five MB")
+	assert.Error(t, err)
+}
+
+func TestFormatFileSize(t *testing.T) {
+	assert.Equal(t, "5MB", raiden.FormatFileSize(5*raiden.MB))
+	assert.Equal(t, "1GB", raiden.FormatFileSize(raiden.GB))
+	assert.Equal(t, "1536KB", raiden.FormatFileSize(1536*raiden.KB))
+	assert.Equal(t, "1500B", raiden.FormatFileSize(1500))
+}
@@ -0,0 +1,103 @@
+package raiden
+
+import (
+	"encoding/json"
+)
+
+// AuthAppMetadata is raw_app_meta_data of auth.users, provider is set by supabase auth
+// and the other key is custom claim (e.g role) that only can be set by service role
+type AuthAppMetadata struct {
+	Provider  string         `json:"provider,omitempty"`
+	Providers []string       `json:"providers,omitempty"`
+	Claims    map[string]any `json:"-"`
+}
+
+func (m AuthAppMetadata) MarshalJSON() ([]byte, error) {
+	type plain AuthAppMetadata
+	return marshalJsonExtra(plain(m), m.Claims)
+}
+
+func (m *AuthAppMetadata) UnmarshalJSON(data []byte) error {
+	type plain AuthAppMetadata
+	if err := json.Unmarshal(data, (*plain)(m)); err != nil {
+		return err
+	}
+	return unmarshalJsonExtra(data, &m.Claims, "provider", "providers")
+}
+
+// AuthUserMetadata is raw_user_meta_data of auth.users, key is filled by sign up data
+// and oauth provider, key that not declared is kept in Data
+type AuthUserMetadata struct {
+	Sub           string         `json:"sub,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	FullName      string         `json:"full_name,omitempty"`
+	AvatarUrl     string         `json:"avatar_url,omitempty"`
+	Email         string         `json:"email,omitempty"`
+	EmailVerified bool           `json:"email_verified,omitempty"`
+	PhoneVerified bool           `json:"phone_verified,omitempty"`
+	Data          map[string]any `json:"-"`
+}
+
+func (m AuthUserMetadata) MarshalJSON() ([]byte, error) {
+	type plain AuthUserMetadata
+	return marshalJsonExtra(plain(m), m.Data)
+}
+
+func (m *AuthUserMetadata) UnmarshalJSON(data []byte) error {
+	type plain AuthUserMetadata
+	if err := json.Unmarshal(data, (*plain)(m)); err != nil {
+		return err
+	}
+	return unmarshalJsonExtra(data, &m.Data, "sub", "name", "full_name", "avatar_url", "email", "email_verified", "phone_verified")
+}
+
+// StorageObjectMetadata is metadata of storage.objects that set by storage api on upload
+type StorageObjectMetadata struct {
+	ETag           string `json:"eTag,omitempty"`
+	Size           int64  `json:"size,omitempty"`
+	Mimetype       string `json:"mimetype,omitempty"`
+	CacheControl   string `json:"cacheControl,omitempty"`
+	LastModified   string `json:"lastModified,omitempty"`
+	ContentLength  int64  `json:"contentLength,omitempty"`
+	HttpStatusCode int    `json:"httpStatusCode,omitempty"`
+}
+
+// marshalJsonExtra marshal value and merge it with extra key, declared key is not overridden
+func marshalJsonExtra(value any, extra map[string]any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	merged := make(map[string]any, len(extra))
+	for k, v := range extra {
+		merged[k] = v
+	}
+
+	var declared map[string]any
+	if err := json.Unmarshal(data, &declared); err != nil {
+		return nil, err
+	}
+	for k, v := range declared {
+		merged[k] = v
+	}
+	return json.Marshal(merged)
+}
+
+// unmarshalJsonExtra collect key of json object that not declared, extra is nil when there is no other key
+func unmarshalJsonExtra(data []byte, extra *map[string]any, declaredKeys ...string) error {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	for _, k := range declaredKeys {
+		delete(values, k)
+	}
+
+	*extra = nil
+	if len(values) > 0 {
+		*extra = values
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package raiden_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthAppMetadata_Json(t *testing.T) {
+	data := []byte(`{"provider":"email","providers":["email","google"],"role":"admin","tenant_id":7}`)
+
+	var metadata raiden.AuthAppMetadata
+	assert.NoError(t, json.Unmarshal(data, &metadata))
+	assert.Equal(t, "email", metadata.Provider)
+	assert.Equal(t, []string{"email", "google"}, metadata.Providers)
+	assert.Equal(t, map[string]any{"role": "admin", "tenant_id": float64(7)}, metadata.Claims)
+
+	result, err := json.Marshal(metadata)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(result))
+
+	// declared field is not overridden by claim with the same key
+	result, err = json.Marshal(raiden.AuthAppMetadata{Provider: "email", Claims: map[string]any{"provider": "github"}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"provider":"email"}`, string(result))
+}
+
+func TestAuthUserMetadata_Json(t *testing.T) {
+	data := []byte(`{"sub":"1","email":"a@mail.com","email_verified":true,"plan":"pro"}`)
+
+	var metadata raiden.AuthUserMetadata
+	assert.NoError(t, json.Unmarshal(data, &metadata))
+	assert.Equal(t, "a@mail.com", metadata.Email)
+	assert.True(t, metadata.EmailVerified)
+	assert.Equal(t, map[string]any{"plan": "pro"}, metadata.Data)
+
+	result, err := json.Marshal(metadata)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(result))
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"sub":"2"}`), &metadata))
+	assert.Nil(t, metadata.Data)
+}
@@ -0,0 +1,76 @@
+package raiden
+
+type (
+	TriggerTiming      string
+	TriggerEvent       string
+	TriggerOrientation string
+
+	Trigger interface {
+		// name
+		Name() string
+
+		// default public
+		Schema() string
+
+		// table name that trigger is attached to
+		Table() string
+
+		// default AFTER
+		Timing() TriggerTiming
+
+		// list of event that fire trigger
+		Events() []TriggerEvent
+
+		// default STATEMENT
+		Orientation() TriggerOrientation
+
+		// default empty, trigger WHEN condition
+		Condition() string
+
+		// rpc that executed by trigger, rpc must return trigger
+		Function() Rpc
+
+		// default nil, argument that passed to function
+		Arguments() []string
+	}
+
+	TriggerBase struct {
+	}
+)
+
+const (
+	TriggerTimingBefore    TriggerTiming = "BEFORE"
+	TriggerTimingAfter     TriggerTiming = "AFTER"
+	TriggerTimingInsteadOf TriggerTiming = "INSTEAD OF"
+
+	TriggerEventInsert   TriggerEvent = "INSERT"
+	TriggerEventUpdate   TriggerEvent = "UPDATE"
+	TriggerEventDelete   TriggerEvent = "DELETE"
+	TriggerEventTruncate TriggerEvent = "TRUNCATE"
+
+	TriggerOrientationRow       TriggerOrientation = "ROW"
+	TriggerOrientationStatement TriggerOrientation = "STATEMENT"
+
+	DefaultTriggerSchema = "public"
+)
+
+// ----- Base Trigger Default Func -----
+func (t *TriggerBase) Schema() string {
+	return DefaultTriggerSchema
+}
+
+func (t *TriggerBase) Timing() TriggerTiming {
+	return TriggerTimingAfter
+}
+
+func (t *TriggerBase) Orientation() TriggerOrientation {
+	return TriggerOrientationStatement
+}
+
+func (t *TriggerBase) Condition() string {
+	return ""
+}
+
+func (t *TriggerBase) Arguments() []string {
+	return nil
+}
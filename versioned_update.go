@@ -0,0 +1,92 @@
+package raiden
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrVersionConflict is returned when versioned update doesn't update any row,
+// the row is updated (or deleted) by other process after it is read
+var ErrVersionConflict = errors.New("row is not updated, version is changed by other update")
+
+// VersionedUpdateStatement is single row update statement with positional parameter ($1, $2, ...)
+// that only applied when version column still have the version of updated row
+type VersionedUpdateStatement struct {
+	Query string
+	Args  []any
+}
+
+// BuildVersionedUpdate build update statement of single row by key, row is only updated when
+// version column equal to current version and the version is incremented by the statement.
+// Version column is not set from values, so it must not be listed in columns
+func BuildVersionedUpdate(schema, table string, columns []string, values []any, keys []string, keyValues []any, version string, currentVersion any) (VersionedUpdateStatement, error) {
+	if len(keys) == 0 {
+		return VersionedUpdateStatement{}, fmt.Errorf("versioned update to %s.%s doesn't have key column", schema, table)
+	}
+
+	if version == "" {
+		return VersionedUpdateStatement{}, fmt.Errorf("versioned update to %s.%s doesn't have version column", schema, table)
+	}
+
+	// nullable version is generated as pointer, null version never equal to stored version
+	if rv := reflect.ValueOf(currentVersion); currentVersion == nil || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return VersionedUpdateStatement{}, fmt.Errorf("versioned update to %s.%s doesn't have value of version column %s", schema, table, version)
+	}
+
+	if len(values) != len(columns) {
+		return VersionedUpdateStatement{}, fmt.Errorf("versioned update to %s.%s have %d value, expected %d", schema, table, len(values), len(columns))
+	}
+
+	if len(keyValues) != len(keys) {
+		return VersionedUpdateStatement{}, fmt.Errorf("versioned update to %s.%s have %d key value, expected %d", schema, table, len(keyValues), len(keys))
+	}
+
+	args := make([]any, 0, len(columns)+len(keys)+1)
+	set := make([]string, 0, len(columns)+1)
+	for i, c := range columns {
+		if c == version {
+			return VersionedUpdateStatement{}, fmt.Errorf("versioned update to %s.%s can't set version column %s", schema, table, version)
+		}
+		args = append(args, values[i])
+		set = append(set, fmt.Sprintf("%s = $%d", quoteIdentifier(c), len(args)))
+	}
+	set = append(set, fmt.Sprintf("%s = %s + 1", quoteIdentifier(version), quoteIdentifier(version)))
+
+	where := make([]string, 0, len(keys)+1)
+	for i, k := range keys {
+		args = append(args, keyValues[i])
+		where = append(where, fmt.Sprintf("%s = $%d", quoteIdentifier(k), len(args)))
+	}
+	args = append(args, currentVersion)
+	where = append(where, fmt.Sprintf("%s = $%d", quoteIdentifier(version), len(args)))
+
+	query := fmt.Sprintf("UPDATE %s.%s SET %s WHERE %s", quoteIdentifier(schema), quoteIdentifier(table), strings.Join(set, ", "), strings.Join(where, " AND "))
+	return VersionedUpdateStatement{Query: query, Args: args}, nil
+}
+
+// Exec execute statement with exec func (e.g db.Exec or tx.Exec),
+// ErrVersionConflict is returned when no row is updated
+func (s VersionedUpdateStatement) Exec(exec func(query string, args ...any) (sql.Result, error)) error {
+	result, err := exec(s.Query, s.Args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	return CheckVersionedUpdate(affected)
+}
+
+// CheckVersionedUpdate return ErrVersionConflict when versioned update doesn't affect any row,
+// used when statement is executed by driver that doesn't implement database/sql
+func CheckVersionedUpdate(rowsAffected int64) error {
+	if rowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
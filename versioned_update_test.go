@@ -0,0 +1,52 @@
+package raiden_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/sev-2/raiden"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildVersionedUpdate(t *testing.T) {
+	s, err := raiden.BuildVersionedUpdate("public", "orders", []string{"status", "total"}, []any{"paid", 100}, []string{"id"}, []any{int64(7)}, "version", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "public"."orders" SET "status" = $1, "total" = $2, "version" = "version" + 1 WHERE "id" = $3 AND "version" = $4`, s.Query)
+	assert.Equal(t, []any{"paid", 100, int64(7), 3}, s.Args)
+
+	_, err = raiden.BuildVersionedUpdate("public", "orders", []string{"status", "version"}, []any{"paid", 4}, []string{"id"}, []any{int64(7)}, "version", 3)
+	assert.Error(t, err)
+
+	_, err = raiden.BuildVersionedUpdate("public", "orders", []string{"status"}, []any{"paid"}, nil, nil, "version", 3)
+	assert.Error(t, err)
+
+	_, err = raiden.BuildVersionedUpdate("public", "orders", []string{"status"}, []any{"paid", 100}, []string{"id"}, []any{int64(7)}, "version", 3)
+	assert.Error(t, err)
+
+	// nullable version is generated as pointer
+	var version *int32
+	_, err = raiden.BuildVersionedUpdate("public", "orders", []string{"status"}, []any{"paid"}, []string{"id"}, []any{int64(7)}, "version", version)
+	assert.EqualError(t, err, "versioned update to public.orders doesn't have value of version column version")
+
+	current := int32(3)
+	s, err = raiden.BuildVersionedUpdate("public", "orders", []string{"status"}, []any{"paid"}, []string{"id"}, []any{int64(7)}, "version", &current)
+	assert.NoError(t, err)
+	assert.Equal(t, &current, s.Args[2])
+}
+
+func TestVersionedUpdateStatement_Exec(t *testing.T) {
+	s := raiden.VersionedUpdateStatement{Query: "UPDATE", Args: []any{1}}
+
+	err := s.Exec(func(query string, args ...any) (sql.Result, error) {
+		assert.Equal(t, s.Query, query)
+		assert.Equal(t, s.Args, args)
+		return driver.RowsAffected(1), nil
+	})
+	assert.NoError(t, err)
+
+	err = s.Exec(func(query string, args ...any) (sql.Result, error) {
+		return driver.RowsAffected(0), nil
+	})
+	assert.ErrorIs(t, err, raiden.ErrVersionConflict)
+}